@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/google/jsonschema-go/jsonschema"
 	internalKiali "github.com/kiali/kiali-mcp-server/pkg/kiali"
@@ -13,6 +14,9 @@ import (
 type ServerTool struct {
 	Tool    Tool
 	Handler ToolHandlerFunc
+	// Timeout is the default deadline applied to each call of the tool (zero means no tool-level deadline).
+	// It can be overridden per tool through the tool_timeouts configuration option.
+	Timeout time.Duration
 }
 
 type Toolset interface {
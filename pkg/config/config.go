@@ -1,7 +1,9 @@
 package config
 
 import (
+	"fmt"
 	"os"
+	"strings"
 
 	"github.com/BurntSushi/toml"
 )
@@ -23,6 +25,20 @@ type StaticConfig struct {
 	Toolsets           []string `toml:"toolsets,omitempty"`
 	EnabledTools       []string `toml:"enabled_tools,omitempty"`
 	DisabledTools      []string `toml:"disabled_tools,omitempty"`
+	// ToolTimeouts overrides the timeout applied to individual tool calls, keyed by tool name
+	// (e.g. workload_details = "10s"). Values are Go duration strings, "0" disabling the timeout of the tool.
+	// Tools without an entry use their built-in default, if any, or no tool-level timeout.
+	ToolTimeouts map[string]string `toml:"tool_timeouts,omitempty"`
+	// Redact masks sensitive values (Authorization headers, tokens, passwords...) in tool outputs as "***".
+	Redact bool `toml:"redact,omitempty"`
+	// RedactFields extends the built-in JSON field names whose string values are masked when Redact is enabled.
+	RedactFields []string `toml:"redact_fields,omitempty"`
+	// RedactPatterns extends the built-in regular expressions of values masked when Redact is enabled.
+	// When a pattern has a capture group, only the first group is masked.
+	RedactPatterns []string `toml:"redact_patterns,omitempty"`
+	// CompactOutput removes the indentation and other insignificant whitespace of JSON tool outputs to save tokens.
+	// When disabled, each tool keeps its own format (indented summaries, compact raw Kiali responses).
+	CompactOutput bool `toml:"compact_output,omitempty"`
 
 	// Authorization-related fields
 	// RequireOAuth indicates whether the server requires OAuth for authentication.
@@ -35,6 +51,64 @@ type StaticConfig struct {
 	KialiServerURL string `toml:"kiali_server_url,omitempty"`
 	// KialiInsecure indicates whether the server should use insecure TLS for the Kiali server.
 	KialiInsecure bool `toml:"kiali_insecure,omitempty"`
+	// KialiDisableRedirects disables following redirects for Kiali requests, so a redirect (e.g. from an
+	// authentication proxy to its login page) is reported as an error instead of returning the page it points to.
+	KialiDisableRedirects bool `toml:"kiali_disable_redirects,omitempty"`
+	// KialiInCluster builds the Kiali URL from the in-cluster service DNS name
+	// (http://<kiali_service_name>.<kiali_namespace>:<kiali_service_port><kiali_web_root>) when KialiServerURL is not set.
+	KialiInCluster bool `toml:"kiali_in_cluster,omitempty"`
+	// KialiNamespace is the namespace where Kiali is installed. Defaults to "istio-system".
+	KialiNamespace string `toml:"kiali_namespace,omitempty"`
+	// KialiServiceName is the name of the Kiali service. Defaults to "kiali".
+	KialiServiceName string `toml:"kiali_service_name,omitempty"`
+	// KialiServicePort is the port of the Kiali service. Defaults to 20001.
+	KialiServicePort int `toml:"kiali_service_port,omitempty"`
+	// KialiWebRoot is the web root Kiali is served under. Defaults to "/kiali".
+	KialiWebRoot string `toml:"kiali_web_root,omitempty"`
+	// KialiNamespacesCacheTTL is how long (Go duration) the Kiali namespaces list is cached per identity.
+	// Caching is disabled when empty or zero.
+	KialiNamespacesCacheTTL string `toml:"kiali_namespaces_cache_ttl,omitempty"`
+	// KialiNamespacesBackgroundRefresh enables refreshing the cached namespaces list of the server identity in the
+	// background, while no Kiali request is in flight, so lookups never hit an expired cache. Requires KialiNamespacesCacheTTL.
+	KialiNamespacesBackgroundRefresh bool `toml:"kiali_namespaces_background_refresh,omitempty"`
+	// KialiNamespacesRefreshInterval is the interval (Go duration) between background refreshes.
+	// Defaults to half of KialiNamespacesCacheTTL.
+	KialiNamespacesRefreshInterval string `toml:"kiali_namespaces_refresh_interval,omitempty"`
+	// KialiQueryParams overrides the names of the rate, interval and array query parameters sent to Kiali,
+	// keyed by logical name (e.g. rateInterval = "rate_interval"), for Kiali versions that use different names.
+	KialiQueryParams map[string]string `toml:"kiali_query_params,omitempty"`
+	// KialiLogsPreferredContainer is a regular expression selecting the container to read logs from when none is
+	// requested and a pod has several application containers. "{workload}" stands for the workload name.
+	KialiLogsPreferredContainer string `toml:"kiali_logs_preferred_container,omitempty"`
+	// KialiExcludedNamespaces are never surfaced by any tool: they are filtered out of the namespace, health,
+	// list, graph and Istio config outputs, and calls targeting one of them fail.
+	KialiExcludedNamespaces []string `toml:"kiali_excluded_namespaces,omitempty"`
+	// KialiWritableNamespaces restricts the Istio objects created, patched or deleted by the write tools to these
+	// namespaces. Operations on other namespaces are rejected before contacting Kiali. All namespaces are writable
+	// when empty.
+	KialiWritableNamespaces []string `toml:"kiali_writable_namespaces,omitempty"`
+	// KialiTracesLimit is the maximum number of traces requested by the trace tools when the call sets no limit.
+	KialiTracesLimit int `toml:"kiali_traces_limit,omitempty"`
+	// KialiTracesLookback is how far back (Go duration) the trace tools search when the call sets no startMicros,
+	// counted from endMicros, or from now.
+	KialiTracesLookback string `toml:"kiali_traces_lookback,omitempty"`
+	// KialiCorrelationIDHeader is the header the correlation ID of each tool call is sent to Kiali in, so the
+	// Kiali requests of a call can be found in the Kiali logs. Defaults to "X-Request-Id".
+	KialiCorrelationIDHeader string `toml:"kiali_correlation_id_header,omitempty"`
+	// KialiHealthConcurrency is the maximum number of Kiali health requests in flight, shared by all the health
+	// fan-out (e.g. the health types of the mesh health summary, the per-namespace retries). Defaults to 3.
+	KialiHealthConcurrency int `toml:"kiali_health_concurrency,omitempty"`
+	// KialiRequestTimeout is the timeout (Go duration) of a Kiali request when the tool call has no deadline of its
+	// own. Defaults to 30s.
+	KialiRequestTimeout string `toml:"kiali_request_timeout,omitempty"`
+	// KialiMaxRetries is the number of times a Kiali request with an idempotent method (e.g. GET) is retried, with
+	// an exponential backoff, when it fails with a transient error (429, 502, 503, 504 or a network error).
+	// Defaults to 0, no retry.
+	KialiMaxRetries int `toml:"kiali_max_retries,omitempty"`
+	// KialiClusterContexts maps the name of a Kiali cluster to the kubeconfig context whose credentials are used for
+	// the calls targeting that cluster (clusterName), when the call carries no token of its own. Calls to the other
+	// clusters use the current kubeconfig context.
+	KialiClusterContexts map[string]string `toml:"kiali_cluster_contexts,omitempty"`
 	// AuthorizationURL is the URL of the OIDC authorization server.
 	// It is used for token validation and for STS token exchange.
 	AuthorizationURL string `toml:"authorization_url,omitempty"`
@@ -55,6 +129,13 @@ type StaticConfig struct {
 	ServerURL            string   `toml:"server_url,omitempty"`
 }
 
+const (
+	DefaultKialiNamespace   = "istio-system"
+	DefaultKialiServiceName = "kiali"
+	DefaultKialiServicePort = 20001
+	DefaultKialiWebRoot     = "/kiali"
+)
+
 func Default() *StaticConfig {
 	return &StaticConfig{
 		ListOutput: "table",
@@ -68,6 +149,35 @@ type GroupVersionKind struct {
 	Kind    string `toml:"kind,omitempty"`
 }
 
+// KialiService returns the namespace and name of the Kiali service, applying the defaults.
+func (c *StaticConfig) KialiService() (string, string) {
+	namespace, name := c.KialiNamespace, c.KialiServiceName
+	if namespace == "" {
+		namespace = DefaultKialiNamespace
+	}
+	if name == "" {
+		name = DefaultKialiServiceName
+	}
+	return namespace, name
+}
+
+// InClusterKialiURL returns the in-cluster URL of the Kiali service, applying the defaults.
+func (c *StaticConfig) InClusterKialiURL() string {
+	namespace, name := c.KialiService()
+	port := c.KialiServicePort
+	if port == 0 {
+		port = DefaultKialiServicePort
+	}
+	webRoot := c.KialiWebRoot
+	if webRoot == "" {
+		webRoot = DefaultKialiWebRoot
+	}
+	if webRoot != "/" && !strings.HasPrefix(webRoot, "/") {
+		webRoot = "/" + webRoot
+	}
+	return fmt.Sprintf("http://%s.%s:%d%s", name, namespace, port, strings.TrimRight(webRoot, "/"))
+}
+
 // Read reads the toml file and returns the StaticConfig.
 func Read(configPath string) (*StaticConfig, error) {
 	configData, err := os.ReadFile(configPath)
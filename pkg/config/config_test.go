@@ -65,12 +65,14 @@ func (s *ConfigSuite) TestReadConfigValid() {
 		
 		enabled_tools = ["configuration_view", "events_list", "namespaces_list", "pods_list", "resources_list", "resources_get", "resources_create_or_update", "resources_delete"]
 		disabled_tools = ["pods_delete", "pods_top", "pods_log", "pods_run", "pods_exec"]
-
 		denied_resources = [
 			{group = "apps", version = "v1", kind = "Deployment"},
 			{group = "rbac.authorization.k8s.io", version = "v1", kind = "Role"}
 		]
-		
+
+		[tool_timeouts]
+		workload_details = "10s"
+		graph = "2m"
 	`)
 
 	config, err := Read(validConfigPath)
@@ -118,6 +120,11 @@ func (s *ConfigSuite) TestReadConfigValid() {
 			s.Containsf(config.DisabledTools, tool, "Expected disabled tools to contain %s", tool)
 		}
 	})
+	s.Run("tool_timeouts", func() {
+		s.Require().Lenf(config.ToolTimeouts, 2, "Expected 2 tool timeouts, got %d", len(config.ToolTimeouts))
+		s.Equalf("10s", config.ToolTimeouts["workload_details"], "Expected workload_details timeout to be 10s, got %s", config.ToolTimeouts["workload_details"])
+		s.Equalf("2m", config.ToolTimeouts["graph"], "Expected graph timeout to be 2m, got %s", config.ToolTimeouts["graph"])
+	})
 	s.Run("denied_resources", func() {
 		s.Require().Lenf(config.DeniedResources, 2, "Expected 2 denied resources, got %d", len(config.DeniedResources))
 		s.Run("contains apps/v1/Deployment", func() {
@@ -159,6 +166,29 @@ func (s *ConfigSuite) TestReadConfigValidPreservesDefaultsForMissingFields() {
 	})
 }
 
+func (s *ConfigSuite) TestInClusterKialiURL() {
+	s.Run("uses defaults", func() {
+		config, err := ReadToml([]byte(`kiali_in_cluster = true`))
+		s.Require().NoError(err)
+		s.True(config.KialiInCluster)
+		s.Equal("http://kiali.istio-system:20001/kiali", config.InClusterKialiURL())
+	})
+	s.Run("uses configured service", func() {
+		config, err := ReadToml([]byte(`
+kiali_in_cluster = true
+kiali_namespace = "observability"
+kiali_service_name = "kiali-server"
+kiali_service_port = 8080
+kiali_web_root = "/"
+`))
+		s.Require().NoError(err)
+		s.Equal("http://kiali-server.observability:8080", config.InClusterKialiURL())
+		namespace, name := config.KialiService()
+		s.Equal("observability", namespace)
+		s.Equal("kiali-server", name)
+	})
+}
+
 func (s *ConfigSuite) writeConfig(content string) string {
 	s.T().Helper()
 	tempDir := s.T().TempDir()
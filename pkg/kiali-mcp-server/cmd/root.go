@@ -25,6 +25,7 @@ import (
 
 	"github.com/kiali/kiali-mcp-server/pkg/config"
 	internalhttp "github.com/kiali/kiali-mcp-server/pkg/http"
+	"github.com/kiali/kiali-mcp-server/pkg/kiali"
 	internalk8s "github.com/kiali/kiali-mcp-server/pkg/kubernetes"
 	"github.com/kiali/kiali-mcp-server/pkg/mcp"
 	"github.com/kiali/kiali-mcp-server/pkg/output"
@@ -234,6 +235,32 @@ func (m *MCPServerOptions) initializeLogging() {
 	klog.SetLoggerWithOptions(logger)
 }
 
+// validateNamespacesCache checks the Kiali namespaces cache and background refresh settings.
+func validateNamespacesCache(cfg *config.StaticConfig) error {
+	var ttl, interval time.Duration
+	var err error
+	if cfg.KialiNamespacesCacheTTL != "" {
+		if ttl, err = time.ParseDuration(cfg.KialiNamespacesCacheTTL); err != nil || ttl < 0 {
+			return fmt.Errorf("invalid kiali_namespaces_cache_ttl: %q must be a positive duration (e.g. 5m)", cfg.KialiNamespacesCacheTTL)
+		}
+	}
+	if cfg.KialiNamespacesRefreshInterval != "" {
+		if interval, err = time.ParseDuration(cfg.KialiNamespacesRefreshInterval); err != nil || interval <= 0 {
+			return fmt.Errorf("invalid kiali_namespaces_refresh_interval: %q must be a positive duration (e.g. 1m)", cfg.KialiNamespacesRefreshInterval)
+		}
+	}
+	if !cfg.KialiNamespacesBackgroundRefresh {
+		return nil
+	}
+	if ttl == 0 {
+		return fmt.Errorf("kiali_namespaces_background_refresh requires kiali_namespaces_cache_ttl to be set")
+	}
+	if interval >= ttl {
+		return fmt.Errorf("kiali_namespaces_refresh_interval (%s) must be shorter than kiali_namespaces_cache_ttl (%s)", interval, ttl)
+	}
+	return nil
+}
+
 func (m *MCPServerOptions) Validate() error {
 	if m.Port != "" && (m.SSEPort > 0 || m.HttpPort > 0) {
 		return fmt.Errorf("--port is mutually exclusive with deprecated --http-port and --sse-port flags")
@@ -244,6 +271,48 @@ func (m *MCPServerOptions) Validate() error {
 	if err := toolsets.Validate(m.StaticConfig.Toolsets); err != nil {
 		return err
 	}
+	for tool, timeout := range m.StaticConfig.ToolTimeouts {
+		if d, err := time.ParseDuration(timeout); err != nil || d < 0 {
+			return fmt.Errorf("invalid tool_timeouts value for %s: %q must be a non-negative duration (e.g. 30s, 2m, or 0 for no timeout)", tool, timeout)
+		}
+	}
+	if _, err := output.NewRedactor(m.StaticConfig.RedactFields, m.StaticConfig.RedactPatterns); err != nil {
+		return fmt.Errorf("invalid redaction configuration: %v", err)
+	}
+	if err := validateNamespacesCache(m.StaticConfig); err != nil {
+		return err
+	}
+	if err := kiali.ValidateQueryParams(m.StaticConfig.KialiQueryParams); err != nil {
+		return fmt.Errorf("invalid kiali_query_params: %v", err)
+	}
+	if err := kiali.ValidatePreferredContainer(m.StaticConfig.KialiLogsPreferredContainer); err != nil {
+		return fmt.Errorf("invalid kiali_logs_preferred_container: %v", err)
+	}
+	if err := kiali.ValidateTracesDefaults(m.StaticConfig.KialiTracesLimit, m.StaticConfig.KialiTracesLookback); err != nil {
+		return err
+	}
+	if err := kiali.ValidateHealthConcurrency(m.StaticConfig.KialiHealthConcurrency); err != nil {
+		return err
+	}
+	if err := kiali.ValidateRequestTimeout(m.StaticConfig.KialiRequestTimeout); err != nil {
+		return err
+	}
+	if err := kiali.ValidateMaxRetries(m.StaticConfig.KialiMaxRetries); err != nil {
+		return err
+	}
+	if err := kiali.ValidateClusterContexts(m.StaticConfig.KialiClusterContexts); err != nil {
+		return err
+	}
+	if err := kiali.ValidateCorrelationIDHeader(m.StaticConfig.KialiCorrelationIDHeader); err != nil {
+		return fmt.Errorf("invalid kiali_correlation_id_header: %v", err)
+	}
+	if m.StaticConfig.KialiServicePort < 0 || m.StaticConfig.KialiServicePort > 65535 {
+		return fmt.Errorf("invalid kiali_service_port: %d", m.StaticConfig.KialiServicePort)
+	}
+	if m.StaticConfig.KialiInCluster && strings.TrimSpace(m.StaticConfig.KialiServerURL) == "" {
+		m.StaticConfig.KialiServerURL = m.StaticConfig.InClusterKialiURL()
+		klog.V(1).Infof("using in-cluster Kiali URL: %s", m.StaticConfig.KialiServerURL)
+	}
 	if !m.StaticConfig.RequireOAuth && (m.StaticConfig.ValidateToken || m.StaticConfig.OAuthAudience != "" || m.StaticConfig.AuthorizationURL != "" || m.StaticConfig.ServerURL != "" || m.StaticConfig.CertificateAuthority != "") {
 		return fmt.Errorf("validate-token, oauth-audience, authorization-url, server-url and certificate-authority are only valid if require-oauth is enabled. Missing --port may implicitly set require-oauth to false")
 	}
@@ -264,8 +333,8 @@ func (m *MCPServerOptions) Validate() error {
 	if m.StaticConfig.Port != "" {
 		// If kiali toolset is enabled, require kiali_server_url to be set
 		hasKiali := false
-		for _, ts := range m.StaticConfig.Toolsets {
-			if strings.TrimSpace(ts) == "kiali" {
+		for _, ts := range toolsets.Enabled(m.StaticConfig.Toolsets) {
+			if ts.GetName() == "kiali" {
 				hasKiali = true
 				break
 			}
@@ -275,7 +344,8 @@ func (m *MCPServerOptions) Validate() error {
 			// Build a temporary Kubernetes manager from current static config
 			k8sMgr, err := internalk8s.NewManager(m.StaticConfig)
 			if err == nil && k8sMgr.IsOpenShift(context.Background()) {
-				if url, dErr := k8sMgr.DiscoverRouteURLForService(context.Background(), "istio-system", "kiali"); dErr == nil && strings.TrimSpace(url) != "" {
+				kialiNamespace, kialiService := m.StaticConfig.KialiService()
+				if url, dErr := k8sMgr.DiscoverRouteURLForService(context.Background(), kialiNamespace, kialiService); dErr == nil && strings.TrimSpace(url) != "" {
 					klog.V(0).Infof("auto-discovered Kiali URL: %s", url)
 					m.StaticConfig.KialiServerURL = url
 				} else if dErr != nil {
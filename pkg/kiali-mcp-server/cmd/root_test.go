@@ -260,6 +260,235 @@ func TestAuthorizationURL(t *testing.T) {
 	})
 }
 
+func TestKialiNamespacesCache(t *testing.T) {
+	writeConfig := func(t *testing.T, content string) string {
+		path := filepath.Join(t.TempDir(), "config.toml")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+	tests := []struct {
+		name          string
+		config        string
+		expectedError string
+	}{
+		{
+			name:   "cache with background refresh",
+			config: "kiali_namespaces_cache_ttl = \"5m\"\nkiali_namespaces_background_refresh = true\nkiali_namespaces_refresh_interval = \"1m\"\n",
+		},
+		{
+			name:          "invalid cache ttl",
+			config:        "kiali_namespaces_cache_ttl = \"soon\"\n",
+			expectedError: "invalid kiali_namespaces_cache_ttl",
+		},
+		{
+			name:          "background refresh without cache",
+			config:        "kiali_namespaces_background_refresh = true\n",
+			expectedError: "kiali_namespaces_background_refresh requires kiali_namespaces_cache_ttl",
+		},
+		{
+			name:          "refresh interval not shorter than ttl",
+			config:        "kiali_namespaces_cache_ttl = \"1m\"\nkiali_namespaces_background_refresh = true\nkiali_namespaces_refresh_interval = \"2m\"\n",
+			expectedError: "must be shorter than kiali_namespaces_cache_ttl",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ioStreams, _ := testStream()
+			rootCmd := NewMCPServer(ioStreams)
+			rootCmd.SetArgs([]string{"--version", "--config", writeConfig(t, tt.config), "--toolsets", "core,config,helm"})
+			err := rootCmd.Execute()
+			if tt.expectedError == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedError)
+		})
+	}
+}
+
+func TestKialiInCluster(t *testing.T) {
+	t.Run("builds the in-cluster URL", func(t *testing.T) {
+		o := NewMCPServerOptions(genericiooptions.IOStreams{In: &bytes.Buffer{}, Out: io.Discard, ErrOut: io.Discard})
+		o.StaticConfig.KialiInCluster = true
+		o.StaticConfig.KialiNamespace = "kiali"
+		require.NoError(t, o.Validate())
+		assert.Equal(t, "http://kiali.kiali:20001/kiali", o.StaticConfig.KialiServerURL)
+	})
+	t.Run("kiali server URL takes precedence", func(t *testing.T) {
+		o := NewMCPServerOptions(genericiooptions.IOStreams{In: &bytes.Buffer{}, Out: io.Discard, ErrOut: io.Discard})
+		o.StaticConfig.KialiInCluster = true
+		o.StaticConfig.KialiServerURL = "https://kiali.example.com"
+		require.NoError(t, o.Validate())
+		assert.Equal(t, "https://kiali.example.com", o.StaticConfig.KialiServerURL)
+	})
+	t.Run("invalid service port", func(t *testing.T) {
+		o := NewMCPServerOptions(genericiooptions.IOStreams{In: &bytes.Buffer{}, Out: io.Discard, ErrOut: io.Discard})
+		o.StaticConfig.KialiServicePort = 70000
+		require.Error(t, o.Validate())
+	})
+}
+
+func TestKialiQueryParams(t *testing.T) {
+	t.Run("known parameter overrides are accepted", func(t *testing.T) {
+		o := NewMCPServerOptions(genericiooptions.IOStreams{In: &bytes.Buffer{}, Out: io.Discard, ErrOut: io.Discard})
+		o.StaticConfig.KialiQueryParams = map[string]string{"rateInterval": "rate_interval", "quantiles": "quantiles"}
+		require.NoError(t, o.Validate())
+	})
+	t.Run("unknown parameter", func(t *testing.T) {
+		o := NewMCPServerOptions(genericiooptions.IOStreams{In: &bytes.Buffer{}, Out: io.Discard, ErrOut: io.Discard})
+		o.StaticConfig.KialiQueryParams = map[string]string{"rate": "rate_interval"}
+		err := o.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown query parameter \"rate\"")
+	})
+	t.Run("empty parameter name", func(t *testing.T) {
+		o := NewMCPServerOptions(genericiooptions.IOStreams{In: &bytes.Buffer{}, Out: io.Discard, ErrOut: io.Discard})
+		o.StaticConfig.KialiQueryParams = map[string]string{"step": " "}
+		require.Error(t, o.Validate())
+	})
+}
+
+func TestKialiLogsPreferredContainer(t *testing.T) {
+	t.Run("valid pattern with workload placeholder", func(t *testing.T) {
+		o := NewMCPServerOptions(genericiooptions.IOStreams{In: &bytes.Buffer{}, Out: io.Discard, ErrOut: io.Discard})
+		o.StaticConfig.KialiLogsPreferredContainer = "^{workload}(-app)?$"
+		require.NoError(t, o.Validate())
+	})
+	t.Run("invalid pattern", func(t *testing.T) {
+		o := NewMCPServerOptions(genericiooptions.IOStreams{In: &bytes.Buffer{}, Out: io.Discard, ErrOut: io.Discard})
+		o.StaticConfig.KialiLogsPreferredContainer = "app("
+		err := o.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid kiali_logs_preferred_container")
+	})
+}
+
+func TestKialiTracesDefaults(t *testing.T) {
+	t.Run("valid defaults", func(t *testing.T) {
+		o := NewMCPServerOptions(genericiooptions.IOStreams{In: &bytes.Buffer{}, Out: io.Discard, ErrOut: io.Discard})
+		o.StaticConfig.KialiTracesLimit = 100
+		o.StaticConfig.KialiTracesLookback = "15m"
+		require.NoError(t, o.Validate())
+	})
+	t.Run("negative limit", func(t *testing.T) {
+		o := NewMCPServerOptions(genericiooptions.IOStreams{In: &bytes.Buffer{}, Out: io.Discard, ErrOut: io.Discard})
+		o.StaticConfig.KialiTracesLimit = -1
+		err := o.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid kiali_traces_limit")
+	})
+	t.Run("invalid lookback", func(t *testing.T) {
+		o := NewMCPServerOptions(genericiooptions.IOStreams{In: &bytes.Buffer{}, Out: io.Discard, ErrOut: io.Discard})
+		o.StaticConfig.KialiTracesLookback = "15 minutes"
+		err := o.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid kiali_traces_lookback")
+	})
+}
+
+func TestKialiCorrelationIDHeader(t *testing.T) {
+	t.Run("valid header", func(t *testing.T) {
+		o := NewMCPServerOptions(genericiooptions.IOStreams{In: &bytes.Buffer{}, Out: io.Discard, ErrOut: io.Discard})
+		o.StaticConfig.KialiCorrelationIDHeader = "X-Correlation-Id"
+		require.NoError(t, o.Validate())
+	})
+	t.Run("invalid header", func(t *testing.T) {
+		o := NewMCPServerOptions(genericiooptions.IOStreams{In: &bytes.Buffer{}, Out: io.Discard, ErrOut: io.Discard})
+		o.StaticConfig.KialiCorrelationIDHeader = "X Correlation Id"
+		err := o.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid kiali_correlation_id_header")
+	})
+}
+
+func TestKialiHealthConcurrency(t *testing.T) {
+	t.Run("valid concurrency", func(t *testing.T) {
+		o := NewMCPServerOptions(genericiooptions.IOStreams{In: &bytes.Buffer{}, Out: io.Discard, ErrOut: io.Discard})
+		o.StaticConfig.KialiHealthConcurrency = 5
+		require.NoError(t, o.Validate())
+	})
+	t.Run("smallest concurrency", func(t *testing.T) {
+		o := NewMCPServerOptions(genericiooptions.IOStreams{In: &bytes.Buffer{}, Out: io.Discard, ErrOut: io.Discard})
+		o.StaticConfig.KialiHealthConcurrency = 1
+		require.NoError(t, o.Validate())
+	})
+	t.Run("zero concurrency selects the default", func(t *testing.T) {
+		o := NewMCPServerOptions(genericiooptions.IOStreams{In: &bytes.Buffer{}, Out: io.Discard, ErrOut: io.Discard})
+		o.StaticConfig.KialiHealthConcurrency = 0
+		require.NoError(t, o.Validate())
+	})
+	t.Run("negative concurrency", func(t *testing.T) {
+		o := NewMCPServerOptions(genericiooptions.IOStreams{In: &bytes.Buffer{}, Out: io.Discard, ErrOut: io.Discard})
+		o.StaticConfig.KialiHealthConcurrency = -1
+		err := o.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid kiali_health_concurrency: -1 must be zero (default of 3) or a positive number")
+	})
+}
+
+func TestKialiRequestTimeout(t *testing.T) {
+	t.Run("valid timeout", func(t *testing.T) {
+		o := NewMCPServerOptions(genericiooptions.IOStreams{In: &bytes.Buffer{}, Out: io.Discard, ErrOut: io.Discard})
+		o.StaticConfig.KialiRequestTimeout = "1m"
+		require.NoError(t, o.Validate())
+	})
+	t.Run("invalid timeout", func(t *testing.T) {
+		o := NewMCPServerOptions(genericiooptions.IOStreams{In: &bytes.Buffer{}, Out: io.Discard, ErrOut: io.Discard})
+		o.StaticConfig.KialiRequestTimeout = "0s"
+		err := o.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid kiali_request_timeout")
+	})
+}
+
+func TestKialiMaxRetries(t *testing.T) {
+	t.Run("valid retries", func(t *testing.T) {
+		o := NewMCPServerOptions(genericiooptions.IOStreams{In: &bytes.Buffer{}, Out: io.Discard, ErrOut: io.Discard})
+		o.StaticConfig.KialiMaxRetries = 2
+		require.NoError(t, o.Validate())
+	})
+	t.Run("negative retries", func(t *testing.T) {
+		o := NewMCPServerOptions(genericiooptions.IOStreams{In: &bytes.Buffer{}, Out: io.Discard, ErrOut: io.Discard})
+		o.StaticConfig.KialiMaxRetries = -1
+		err := o.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid kiali_max_retries")
+	})
+}
+
+func TestKialiClusterContexts(t *testing.T) {
+	t.Run("valid contexts", func(t *testing.T) {
+		o := NewMCPServerOptions(genericiooptions.IOStreams{In: &bytes.Buffer{}, Out: io.Discard, ErrOut: io.Discard})
+		o.StaticConfig.KialiClusterContexts = map[string]string{"east": "east-admin", "west": "west-admin"}
+		require.NoError(t, o.Validate())
+	})
+	t.Run("empty context", func(t *testing.T) {
+		o := NewMCPServerOptions(genericiooptions.IOStreams{In: &bytes.Buffer{}, Out: io.Discard, ErrOut: io.Discard})
+		o.StaticConfig.KialiClusterContexts = map[string]string{"east": " "}
+		err := o.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid kiali_cluster_contexts")
+	})
+}
+
+func TestRedactPatterns(t *testing.T) {
+	t.Run("valid patterns and fields", func(t *testing.T) {
+		o := NewMCPServerOptions(genericiooptions.IOStreams{In: &bytes.Buffer{}, Out: io.Discard, ErrOut: io.Discard})
+		o.StaticConfig.Redact = true
+		o.StaticConfig.RedactFields = []string{"jwksUri"}
+		o.StaticConfig.RedactPatterns = []string{`x-api-key:\s*(\S+)`}
+		require.NoError(t, o.Validate())
+	})
+	t.Run("invalid pattern", func(t *testing.T) {
+		o := NewMCPServerOptions(genericiooptions.IOStreams{In: &bytes.Buffer{}, Out: io.Discard, ErrOut: io.Discard})
+		o.StaticConfig.RedactPatterns = []string{"key=("}
+		err := o.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid redaction configuration")
+	})
+}
+
 func TestStdioLogging(t *testing.T) {
 	t.Run("stdio disables klog", func(t *testing.T) {
 		ioStreams, out := testStream()
@@ -308,3 +537,18 @@ func TestKialiURLReachability_TLSFallbackToInsecure(t *testing.T) {
 	assert.Containsf(t, out.String(), "Proceeding with insecure TLS (kiali_insecure=true)", "Expected insecure notice, got %s", out.String())
 	assert.Regexpf(t, regexp.MustCompile(`Kiali URL reachable \(https, insecure\): HTTP 200`), out.String(), "Expected HTTPS insecure reachability log, got %s", out.String())
 }
+
+func TestToolTimeouts(t *testing.T) {
+	t.Run("zero timeout disables the tool timeout", func(t *testing.T) {
+		o := NewMCPServerOptions(genericiooptions.IOStreams{In: &bytes.Buffer{}, Out: io.Discard, ErrOut: io.Discard})
+		o.StaticConfig.ToolTimeouts = map[string]string{"graph": "0s"}
+		require.NoError(t, o.Validate())
+	})
+	t.Run("negative timeout", func(t *testing.T) {
+		o := NewMCPServerOptions(genericiooptions.IOStreams{In: &bytes.Buffer{}, Out: io.Discard, ErrOut: io.Discard})
+		o.StaticConfig.ToolTimeouts = map[string]string{"graph": "-1s"}
+		err := o.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "must be a non-negative duration")
+	})
+}
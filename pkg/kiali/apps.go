@@ -0,0 +1,53 @@
+package kiali
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// AppsList returns the list of apps, the workloads grouped by their app label, across specified namespaces.
+// Parameters:
+//   - namespaces: comma-separated list of namespaces (optional, if empty lists the apps of all accessible namespaces)
+func (k *Kiali) AppsList(ctx context.Context, namespaces string) (string, error) {
+	baseURL, err := k.validateAndGetBaseURL()
+	if err != nil {
+		return "", err
+	}
+	u, err := url.Parse(fmt.Sprintf("%s/api/clusters/apps?health=true&istioResources=true&%s=60s",
+		strings.TrimRight(baseURL, "/"), url.QueryEscape(k.queryParam("rateInterval"))))
+	if err != nil {
+		return "", err
+	}
+	k.addNamespacesQuery(u, namespaces)
+	endpoint := u.String()
+
+	return k.executeRequest(ctx, endpoint)
+}
+
+// AppDetails returns the details for a specific app in a namespace, including its versioned workloads and
+// services. A NotFoundError is returned when the app does not exist.
+// Parameters:
+//   - namespace: the namespace containing the app
+//   - app: the name of the app, the value of the app label of its workloads
+func (k *Kiali) AppDetails(ctx context.Context, namespace string, app string) (string, error) {
+	baseURL, err := k.validateAndGetBaseURL()
+	if err != nil {
+		return "", err
+	}
+	if namespace == "" {
+		return "", fmt.Errorf("namespace is required")
+	}
+	if app == "" {
+		return "", fmt.Errorf("app name is required")
+	}
+	endpoint := fmt.Sprintf("%s/api/namespaces/%s/apps/%s?health=true&%s=60s",
+		strings.TrimRight(baseURL, "/"), url.PathEscape(namespace), url.PathEscape(app), url.QueryEscape(k.queryParam("rateInterval")))
+
+	content, err := k.executeRequest(ctx, endpoint)
+	if err != nil {
+		return "", classifyNotFound(err, "app", namespace, app)
+	}
+	return content, nil
+}
@@ -0,0 +1,155 @@
+package kiali
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// AuthorizationPolicySummary is a condensed view of an AuthorizationPolicy.
+// Each rule lists its sources (From), operations (To) and conditions (When); within a list the entries are ORed,
+// while the fields of an entry and the three lists are ANDed, as in Istio.
+type AuthorizationPolicySummary struct {
+	Name      string                     `json:"name"`
+	Namespace string                     `json:"namespace"`
+	Action    string                     `json:"action"`
+	Provider  string                     `json:"provider,omitempty"`
+	Selector  map[string]string          `json:"selector,omitempty"`
+	Rules     []AuthorizationRuleSummary `json:"rules"`
+	Note      string                     `json:"note,omitempty"`
+}
+
+// AuthorizationRuleSummary summarizes an AuthorizationPolicy rule.
+type AuthorizationRuleSummary struct {
+	From []string `json:"from,omitempty"`
+	To   []string `json:"to,omitempty"`
+	When []string `json:"when,omitempty"`
+}
+
+// authzSourceFields and authzOperationFields are the fields of the rule sources and operations, in summary order.
+var (
+	authzSourceFields = []string{
+		"principals", "notPrincipals", "requestPrincipals", "notRequestPrincipals",
+		"namespaces", "notNamespaces", "ipBlocks", "notIpBlocks", "remoteIpBlocks", "notRemoteIpBlocks",
+	}
+	authzOperationFields = []string{
+		"hosts", "notHosts", "ports", "notPorts", "methods", "notMethods", "paths", "notPaths",
+	}
+)
+
+// AuthorizationPolicies returns, as JSON, a summary of the AuthorizationPolicies of the namespace,
+// sorted by name.
+// Parameters:
+//   - namespace: the namespace to get the AuthorizationPolicies for
+func (k *Kiali) AuthorizationPolicies(ctx context.Context, namespace string) (string, error) {
+	if namespace == "" {
+		return "", fmt.Errorf("namespace is required")
+	}
+	content, err := k.IstioConfig(ctx)
+	if err != nil {
+		return "", err
+	}
+	objects, err := parseIstioConfigObjects(content)
+	if err != nil {
+		return "", err
+	}
+	policies := istioObjectsOfKind(objects, "AuthorizationPolicy", namespace)
+	summaries := make([]AuthorizationPolicySummary, 0, len(policies))
+	for _, policy := range policies {
+		summaries = append(summaries, summarizeAuthorizationPolicy(policy))
+	}
+	result, err := json.MarshalIndent(summaries, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal authorization policies: %v", err)
+	}
+	return string(result), nil
+}
+
+// summarizeAuthorizationPolicy condenses the spec of an AuthorizationPolicy.
+func summarizeAuthorizationPolicy(policy IstioObject) AuthorizationPolicySummary {
+	summary := AuthorizationPolicySummary{
+		Name:      policy.Metadata.Name,
+		Namespace: policy.Metadata.Namespace,
+		Action:    specString(policy.Spec, "action"),
+		Provider:  specString(specMap(policy.Spec, "provider"), "name"),
+		Selector:  workloadSelector(policy.Spec),
+		Rules:     make([]AuthorizationRuleSummary, 0),
+	}
+	if summary.Action == "" {
+		summary.Action = "ALLOW"
+	}
+	if len(summary.Selector) == 0 {
+		summary.Selector = nil
+	}
+	rules, _ := policy.Spec["rules"].([]any)
+	matchesAll := false
+	for _, r := range rules {
+		rule, _ := r.(map[string]any)
+		var ruleSummary AuthorizationRuleSummary
+		for _, from := range specList(rule, "from") {
+			ruleSummary.From = append(ruleSummary.From, summarizeAuthzFields(specMap(from, "source"), authzSourceFields))
+		}
+		for _, to := range specList(rule, "to") {
+			ruleSummary.To = append(ruleSummary.To, summarizeAuthzFields(specMap(to, "operation"), authzOperationFields))
+		}
+		for _, when := range specList(rule, "when") {
+			ruleSummary.When = append(ruleSummary.When, summarizeAuthzCondition(when))
+		}
+		if len(ruleSummary.From) == 0 && len(ruleSummary.To) == 0 && len(ruleSummary.When) == 0 {
+			matchesAll = true
+		}
+		summary.Rules = append(summary.Rules, ruleSummary)
+	}
+	switch {
+	case len(rules) == 0 && summary.Action == "ALLOW":
+		summary.Note = "no rules: allows nothing, so all requests to the selected workloads are denied"
+	case len(rules) == 0:
+		summary.Note = "no rules: the policy never matches"
+	case matchesAll:
+		summary.Note = fmt.Sprintf("an empty rule matches all requests, so the %s action applies to every request", summary.Action)
+	}
+	return summary
+}
+
+// summarizeAuthzFields summarizes the list fields of a rule source or operation, e.g. "namespaces=[foo]; methods=[GET]".
+func summarizeAuthzFields(values map[string]any, fields []string) string {
+	parts := make([]string, 0)
+	for _, field := range fields {
+		if items := specStrings(values, field); len(items) > 0 {
+			parts = append(parts, fmt.Sprintf("%s=[%s]", field, strings.Join(items, ", ")))
+		}
+	}
+	if len(parts) == 0 {
+		return "any"
+	}
+	return strings.Join(parts, "; ")
+}
+
+// summarizeAuthzCondition summarizes a rule condition, e.g. "request.headers[version] in [v1, v2]".
+func summarizeAuthzCondition(condition map[string]any) string {
+	key := specString(condition, "key")
+	parts := make([]string, 0, 2)
+	if values := specStrings(condition, "values"); len(values) > 0 {
+		parts = append(parts, fmt.Sprintf("%s in [%s]", key, strings.Join(values, ", ")))
+	}
+	if notValues := specStrings(condition, "notValues"); len(notValues) > 0 {
+		parts = append(parts, fmt.Sprintf("%s not in [%s]", key, strings.Join(notValues, ", ")))
+	}
+	if len(parts) == 0 {
+		return key
+	}
+	return strings.Join(parts, " and ")
+}
+
+// specList returns the object values of the given spec list field.
+func specList(spec map[string]any, field string) []map[string]any {
+	values, _ := spec[field].([]any)
+	ret := make([]map[string]any, 0, len(values))
+	for _, v := range values {
+		if m, ok := v.(map[string]any); ok {
+			ret = append(ret, m)
+		}
+	}
+	return ret
+}
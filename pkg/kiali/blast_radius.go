@@ -0,0 +1,123 @@
+package kiali
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// DefaultBlastRadiusDuration is the default window of traffic the blast radius is computed from.
+const DefaultBlastRadiusDuration = "10m"
+
+// BlastRadius lists the transitive upstream dependents of a service, grouped by their distance to it.
+type BlastRadius struct {
+	Namespace  string             `json:"namespace"`
+	Service    string             `json:"service"`
+	Duration   string             `json:"duration"`
+	Total      int                `json:"total"`
+	Dependents []BlastRadiusLevel `json:"dependents"`
+}
+
+// BlastRadiusLevel are the dependents sending traffic to the service through Distance - 1 intermediate nodes.
+type BlastRadiusLevel struct {
+	Distance int      `json:"distance"`
+	Nodes    []string `json:"nodes"`
+}
+
+// BlastRadius returns, as JSON, the services and other nodes that depend, directly or transitively, on the given
+// service. The service graph of the recent traffic is traversed in reverse from the service, so only callers that
+// sent requests during the window are found.
+// Parameters:
+//   - namespace: the namespace of the service
+//   - service: the name of the failing service
+//   - namespaces: additional namespaces whose callers should be traversed, besides the namespace of the service
+//   - duration: the window of traffic to consider (default: DefaultBlastRadiusDuration)
+func (k *Kiali) BlastRadius(ctx context.Context, namespace string, service string, namespaces []string, duration string) (string, error) {
+	if namespace == "" {
+		return "", fmt.Errorf("namespace is required")
+	}
+	if service == "" {
+		return "", fmt.Errorf("service name is required")
+	}
+	if duration == "" {
+		duration = DefaultBlastRadiusDuration
+	}
+	content, err := k.graph(ctx, append([]string{namespace}, namespaces...), graphOptions{graphType: "service", duration: duration, appenders: []string{"deadNode"}})
+	if err != nil {
+		return "", err
+	}
+	levels, err := blastRadiusLevels(content, namespace, service)
+	if err != nil {
+		return "", err
+	}
+	if levels == nil {
+		return "", fmt.Errorf("service %s not found in the graph of namespace %s: it received no traffic in the last %s", service, namespace, duration)
+	}
+	result := &BlastRadius{Namespace: namespace, Service: service, Duration: duration, Dependents: levels}
+	for _, level := range levels {
+		result.Total += len(level.Nodes)
+	}
+	ret, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal blast radius: %v", err)
+	}
+	return string(ret), nil
+}
+
+// blastRadiusLevels traverses the graph edges in reverse, breadth first, from the nodes of the service.
+// It returns nil if the service is not part of the graph.
+func blastRadiusLevels(content string, namespace string, service string) ([]BlastRadiusLevel, error) {
+	graph, err := parseGraph(content)
+	if err != nil {
+		return nil, err
+	}
+	names := map[string]string{}
+	visited := map[string]struct{}{}
+	var current []string
+	for _, n := range graph.Elements.Nodes {
+		id := n.field("id")
+		if id == "" || n.isBox() {
+			continue
+		}
+		names[id] = graphNodeName(n.Data)
+		if n.field("nodeType") == "service" && n.field("namespace") == namespace && n.field("service") == service {
+			// The service may be listed once per cluster
+			visited[id] = struct{}{}
+			current = append(current, id)
+		}
+	}
+	if len(current) == 0 {
+		return nil, nil
+	}
+	callers := map[string][]string{}
+	for _, e := range graph.Elements.Edges {
+		callers[e.Data.Target] = append(callers[e.Data.Target], e.Data.Source)
+	}
+
+	levels := make([]BlastRadiusLevel, 0)
+	for distance := 1; len(current) > 0; distance++ {
+		var next []string
+		nodes := map[string]struct{}{}
+		for _, id := range current {
+			for _, caller := range callers[id] {
+				if _, ok := visited[caller]; ok {
+					continue
+				}
+				visited[caller] = struct{}{}
+				next = append(next, caller)
+				name := names[caller]
+				if name == "" {
+					name = caller
+				}
+				nodes[name] = struct{}{}
+			}
+		}
+		if len(nodes) > 0 {
+			levels = append(levels, BlastRadiusLevel{Distance: distance, Nodes: slices.Sorted(maps.Keys(nodes))})
+		}
+		current = next
+	}
+	return levels, nil
+}
@@ -1,6 +1,11 @@
 package kiali
 
 import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
@@ -22,5 +27,42 @@ func resolveKialiRequiredConfigurations(kiali *Manager) error {
 	if kiali.cfg != nil && kiali.cfg.UserAgent == "" {
 		kiali.cfg.UserAgent = rest.DefaultKubernetesUserAgent()
 	}
-	return err
+	if err != nil {
+		return err
+	}
+	return resolveClusterContexts(kiali, pathOptions.LoadingRules)
+}
+
+// ValidateClusterContexts checks that the configured cluster to kubeconfig context mapping has no empty entry.
+func ValidateClusterContexts(contexts map[string]string) error {
+	for _, cluster := range slices.Sorted(maps.Keys(contexts)) {
+		if strings.TrimSpace(cluster) == "" {
+			return fmt.Errorf("invalid kiali_cluster_contexts: empty cluster name")
+		}
+		if strings.TrimSpace(contexts[cluster]) == "" {
+			return fmt.Errorf("invalid kiali_cluster_contexts: empty kubeconfig context for cluster %q", cluster)
+		}
+	}
+	return nil
+}
+
+// resolveClusterContexts loads the credentials of the kubeconfig context mapped to each Kiali cluster.
+func resolveClusterContexts(kiali *Manager, loadingRules *clientcmd.ClientConfigLoadingRules) error {
+	if len(kiali.staticConfig.KialiClusterContexts) == 0 {
+		return nil
+	}
+	kiali.clusterConfigs = make(map[string]*rest.Config, len(kiali.staticConfig.KialiClusterContexts))
+	for cluster, kubeContext := range kiali.staticConfig.KialiClusterContexts {
+		cfg, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+			loadingRules,
+			&clientcmd.ConfigOverrides{CurrentContext: kubeContext}).ClientConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load kubeconfig context %q of cluster %q: %v", kubeContext, cluster, err)
+		}
+		if cfg.UserAgent == "" {
+			cfg.UserAgent = rest.DefaultKubernetesUserAgent()
+		}
+		kiali.clusterConfigs[cluster] = cfg
+	}
+	return nil
 }
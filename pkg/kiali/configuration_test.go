@@ -0,0 +1,71 @@
+package kiali
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali-mcp-server/pkg/config"
+	internalk8s "github.com/kiali/kiali-mcp-server/pkg/kubernetes"
+)
+
+const clusterContextsKubeconfig = `apiVersion: v1
+kind: Config
+current-context: default
+clusters:
+- name: default
+  cluster: {server: "https://default.example.com"}
+- name: east
+  cluster: {server: "https://east.example.com"}
+users:
+- name: default
+  user: {token: default-token}
+- name: east
+  user: {token: east-token}
+contexts:
+- name: default
+  context: {cluster: default, user: default}
+- name: east-admin
+  context: {cluster: east, user: east}
+`
+
+func TestClusterContexts(t *testing.T) {
+	kubeconfig := filepath.Join(t.TempDir(), "kubeconfig")
+	require.NoError(t, os.WriteFile(kubeconfig, []byte(clusterContextsKubeconfig), 0o600))
+	manager, err := NewManager(&config.StaticConfig{
+		KialiServerURL:       "https://kiali.example.com",
+		KubeConfig:           kubeconfig,
+		KialiClusterContexts: map[string]string{"east": "east-admin"},
+	})
+	require.NoError(t, err)
+
+	authorization := func(ctx context.Context, clusterName string) string {
+		k, err := manager.DerivedForCluster(ctx, clusterName)
+		require.NoError(t, err)
+		return k.CurrentAuthorizationHeader(ctx)
+	}
+	t.Run("mapped cluster", func(t *testing.T) {
+		assert.Equal(t, "Bearer east-token", authorization(context.Background(), "east"))
+	})
+	t.Run("other cluster", func(t *testing.T) {
+		assert.Equal(t, "Bearer default-token", authorization(context.Background(), "west"))
+		assert.Equal(t, "Bearer default-token", authorization(context.Background(), ""))
+	})
+	t.Run("call token", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), internalk8s.OAuthAuthorizationHeader, "Bearer user-token")
+		assert.Equal(t, "Bearer user-token", authorization(ctx, "east"))
+	})
+	t.Run("unknown context", func(t *testing.T) {
+		_, err := NewManager(&config.StaticConfig{
+			KialiServerURL:       "https://kiali.example.com",
+			KubeConfig:           kubeconfig,
+			KialiClusterContexts: map[string]string{"west": "west-admin"},
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `failed to load kubeconfig context "west-admin" of cluster "west"`)
+	})
+}
@@ -0,0 +1,60 @@
+package kiali
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"golang.org/x/net/http/httpguts"
+)
+
+// DefaultCorrelationIDHeader is the header the correlation ID of a tool call is sent to Kiali in when
+// kiali_correlation_id_header is not set.
+const DefaultCorrelationIDHeader = "X-Request-Id"
+
+type correlationIDKey struct{}
+
+// NewCorrelationID returns a random correlation ID identifying a tool call, as 32 hexadecimal characters.
+func NewCorrelationID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// WithCorrelationID returns a copy of the context carrying the correlation ID of the tool call.
+// The Kiali requests executed with the context send it in the correlation ID header and log it.
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, id)
+}
+
+// CorrelationID returns the correlation ID carried by the context, or an empty string.
+func CorrelationID(ctx context.Context) string {
+	id, _ := ctx.Value(correlationIDKey{}).(string)
+	return id
+}
+
+// ValidateCorrelationIDHeader checks that the configured correlation ID header is a valid HTTP header name.
+func ValidateCorrelationIDHeader(header string) error {
+	if header = strings.TrimSpace(header); header != "" && !httpguts.ValidHeaderFieldName(header) {
+		return fmt.Errorf("invalid header name '%s'", header)
+	}
+	return nil
+}
+
+// setCorrelationID sets the correlation ID header of a Kiali request from the correlation ID of the context.
+// It returns a suffix for the log lines of the request, empty when the context has no correlation ID.
+func (k *Kiali) setCorrelationID(ctx context.Context, req *http.Request) string {
+	id := CorrelationID(ctx)
+	if id == "" {
+		return ""
+	}
+	header := strings.TrimSpace(k.manager.staticConfig.KialiCorrelationIDHeader)
+	if header == "" {
+		header = DefaultCorrelationIDHeader
+	}
+	req.Header.Set(header, id)
+	return " (correlation ID: " + id + ")"
+}
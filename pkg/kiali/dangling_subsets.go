@@ -0,0 +1,137 @@
+package kiali
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// DanglingSubset is a VirtualService destination referencing a subset that no DestinationRule of its host
+// defines. Traffic routed to it fails with 503 errors (NR, no route).
+//   - Route is the path of the destination in the VirtualService spec, e.g. "http[0].route[1]"
+//   - AvailableSubsets are the subsets defined by the DestinationRules of the host, if any
+type DanglingSubset struct {
+	Namespace        string   `json:"namespace"`
+	VirtualService   string   `json:"virtualService"`
+	Route            string   `json:"route"`
+	Host             string   `json:"host"`
+	Subset           string   `json:"subset"`
+	Reason           string   `json:"reason"`
+	AvailableSubsets []string `json:"availableSubsets,omitempty"`
+}
+
+// subsetDestination is a destination with a subset found in a VirtualService spec.
+type subsetDestination struct {
+	route  string
+	host   string
+	subset string
+}
+
+// DanglingSubsets cross-checks the subsets referenced by the VirtualService destinations against the subsets
+// defined by the DestinationRules of their hosts and returns, as JSON, the dangling references sorted by
+// namespace and VirtualService name.
+// Parameters:
+//   - namespace: the namespace to restrict the VirtualServices to (optional, all namespaces when empty)
+func (k *Kiali) DanglingSubsets(ctx context.Context, namespace string) (string, error) {
+	content, err := k.IstioConfig(ctx)
+	if err != nil {
+		return "", err
+	}
+	objects, err := parseIstioConfigObjects(content)
+	if err != nil {
+		return "", err
+	}
+	result, err := json.MarshalIndent(findDanglingSubsets(objects, namespace), "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal dangling subsets: %v", err)
+	}
+	return string(result), nil
+}
+
+// findDanglingSubsets returns the VirtualService destinations of the namespace whose subset is not defined by
+// any DestinationRule of the destination host. DestinationRules of all namespaces are considered.
+func findDanglingSubsets(objects []IstioObject, namespace string) []DanglingSubset {
+	rules := istioObjectsOfKind(objects, "DestinationRule", "")
+	ret := make([]DanglingSubset, 0)
+	for _, vs := range istioObjectsOfKind(objects, "VirtualService", namespace) {
+		for _, destination := range subsetDestinations(vs.Spec) {
+			host := qualifyHost(destination.host, vs.Metadata.Namespace)
+			subsets := map[string]bool{}
+			matched := false
+			for _, dr := range rules {
+				if !hostsOverlap(qualifyHost(specString(dr.Spec, "host"), dr.Metadata.Namespace), host) {
+					continue
+				}
+				matched = true
+				for _, subset := range specList(dr.Spec, "subsets") {
+					if name := specString(subset, "name"); name != "" {
+						subsets[name] = true
+					}
+				}
+			}
+			if subsets[destination.subset] {
+				continue
+			}
+			dangling := DanglingSubset{
+				Namespace:        vs.Metadata.Namespace,
+				VirtualService:   vs.Metadata.Name,
+				Route:            destination.route,
+				Host:             destination.host,
+				Subset:           destination.subset,
+				AvailableSubsets: slices.Sorted(maps.Keys(subsets)),
+			}
+			switch {
+			case !matched:
+				dangling.Reason = fmt.Sprintf("no DestinationRule targets host %s", host)
+			case len(subsets) == 0:
+				dangling.Reason = "the DestinationRules of the host define no subset"
+			default:
+				dangling.Reason = fmt.Sprintf("subset %s is not defined by the DestinationRules of the host (available: %s)",
+					destination.subset, strings.Join(dangling.AvailableSubsets, ", "))
+			}
+			if len(dangling.AvailableSubsets) == 0 {
+				dangling.AvailableSubsets = nil
+			}
+			ret = append(ret, dangling)
+		}
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		if ret[i].Namespace != ret[j].Namespace {
+			return ret[i].Namespace < ret[j].Namespace
+		}
+		return ret[i].VirtualService < ret[j].VirtualService
+	})
+	return ret
+}
+
+// subsetDestinations returns the destinations with a subset of the http, tls and tcp routes of a VirtualService
+// spec, including the HTTP mirror destinations, in spec order.
+func subsetDestinations(spec map[string]any) []subsetDestination {
+	ret := make([]subsetDestination, 0)
+	add := func(route string, destination map[string]any) {
+		if subset := specString(destination, "subset"); subset != "" {
+			ret = append(ret, subsetDestination{route: route, host: specString(destination, "host"), subset: subset})
+		}
+	}
+	for _, protocol := range []string{"http", "tls", "tcp"} {
+		for i, rule := range specList(spec, protocol) {
+			for j, route := range specList(rule, "route") {
+				add(fmt.Sprintf("%s[%d].route[%d]", protocol, i, j), specMap(route, "destination"))
+			}
+			if protocol != "http" {
+				continue
+			}
+			if mirror := specMap(rule, "mirror"); mirror != nil {
+				add(fmt.Sprintf("http[%d].mirror", i), mirror)
+			}
+			for j, mirror := range specList(rule, "mirrors") {
+				add(fmt.Sprintf("http[%d].mirrors[%d]", i, j), specMap(mirror, "destination"))
+			}
+		}
+	}
+	return ret
+}
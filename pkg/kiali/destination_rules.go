@@ -0,0 +1,113 @@
+package kiali
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// DestinationRuleSummary is a focused view of a DestinationRule and its subsets.
+// Traffic policies are flattened to sorted "path=value" entries, e.g. "loadBalancer.simple=ROUND_ROBIN".
+type DestinationRuleSummary struct {
+	Namespace     string          `json:"namespace"`
+	Name          string          `json:"name"`
+	Host          string          `json:"host"`
+	Subsets       []SubsetSummary `json:"subsets"`
+	TrafficPolicy []string        `json:"trafficPolicy,omitempty"`
+}
+
+// SubsetSummary is a DestinationRule subset.
+type SubsetSummary struct {
+	Name          string            `json:"name"`
+	Labels        map[string]string `json:"labels,omitempty"`
+	TrafficPolicy []string          `json:"trafficPolicy,omitempty"`
+}
+
+// DestinationRules returns, as JSON, a summary of the DestinationRules and their subsets, sorted by namespace and name.
+// Parameters:
+//   - namespace: the namespace to restrict the DestinationRules to (optional, all namespaces when empty)
+func (k *Kiali) DestinationRules(ctx context.Context, namespace string) (string, error) {
+	content, err := k.IstioConfig(ctx)
+	if err != nil {
+		return "", err
+	}
+	objects, err := parseIstioConfigObjects(content)
+	if err != nil {
+		return "", err
+	}
+	rules := istioObjectsOfKind(objects, "DestinationRule", namespace)
+	summaries := make([]DestinationRuleSummary, 0, len(rules))
+	for _, rule := range rules {
+		summaries = append(summaries, summarizeDestinationRule(rule))
+	}
+	result, err := json.MarshalIndent(summaries, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal destination rules: %v", err)
+	}
+	return string(result), nil
+}
+
+// summarizeDestinationRule projects the host, subsets and traffic policies of a DestinationRule.
+func summarizeDestinationRule(rule IstioObject) DestinationRuleSummary {
+	summary := DestinationRuleSummary{
+		Namespace:     rule.Metadata.Namespace,
+		Name:          rule.Metadata.Name,
+		Host:          specString(rule.Spec, "host"),
+		Subsets:       make([]SubsetSummary, 0),
+		TrafficPolicy: flattenSpec(specMap(rule.Spec, "trafficPolicy")),
+	}
+	for _, subset := range specList(rule.Spec, "subsets") {
+		s := SubsetSummary{
+			Name:          specString(subset, "name"),
+			Labels:        specLabels(subset, "labels"),
+			TrafficPolicy: flattenSpec(specMap(subset, "trafficPolicy")),
+		}
+		if len(s.Labels) == 0 {
+			s.Labels = nil
+		}
+		summary.Subsets = append(summary.Subsets, s)
+	}
+	return summary
+}
+
+// flattenSpec flattens a spec object to sorted "path=value" entries, e.g. "connectionPool.tcp.maxConnections=100".
+// List items are addressed by index, e.g. "portLevelSettings[0].port.number=9080".
+func flattenSpec(spec map[string]any) []string {
+	if len(spec) == 0 {
+		return nil
+	}
+	values := flattenSpecValues(spec)
+	ret := make([]string, 0, len(values))
+	for path, value := range values {
+		ret = append(ret, path+"="+value)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// flattenSpecValues flattens a spec object to its leaf values keyed by path, as flattenSpec does.
+func flattenSpecValues(spec map[string]any) map[string]string {
+	ret := map[string]string{}
+	var flatten func(path string, value any)
+	flatten = func(path string, value any) {
+		switch v := value.(type) {
+		case map[string]any:
+			for key, child := range v {
+				if path == "" {
+					flatten(key, child)
+				} else {
+					flatten(path+"."+key, child)
+				}
+			}
+		case []any:
+			for i, child := range v {
+				flatten(fmt.Sprintf("%s[%d]", path, i), child)
+			}
+		default:
+			ret[path] = fmt.Sprintf("%v", v)
+		}
+	}
+	flatten("", spec)
+	return ret
+}
@@ -0,0 +1,115 @@
+package kiali
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// EntityHealthReport is the computed health of a single app, service or workload.
+//   - Issue describes what makes the entity not healthy, if anything
+//   - ErrorRate is the percentage of failed inbound HTTP/gRPC requests, only set when there is inbound traffic
+//   - Replicas is only set for apps and workloads, summed over the workloads of an app
+type EntityHealthReport struct {
+	Kind      string         `json:"kind"`
+	Namespace string         `json:"namespace"`
+	Name      string         `json:"name"`
+	Status    HealthStatus   `json:"status"`
+	Issue     string         `json:"issue,omitempty"`
+	ErrorRate *float64       `json:"errorRate,omitempty"`
+	Replicas  *ReplicaCounts `json:"replicas,omitempty"`
+}
+
+// ReplicaCounts are the desired, current and available replicas of a workload, or of the workloads of an app.
+type ReplicaCounts struct {
+	Desired   int32 `json:"desired"`
+	Current   int32 `json:"current"`
+	Available int32 `json:"available"`
+}
+
+// EntityHealthStatus fetches the health of the given type in the namespace and returns, as JSON, the computed
+// health of the entity. A NotFoundError is returned when Kiali reports no health for the entity.
+// Parameters:
+//   - kind: the entity type, one of HealthTypes
+//   - namespace: the namespace containing the entity
+//   - name: the name of the entity
+//   - rateInterval: rate interval for fetching error rate (optional, default: "10m")
+func (k *Kiali) EntityHealthStatus(ctx context.Context, kind string, namespace string, name string, rateInterval string) (string, error) {
+	if !slices.Contains(HealthTypes, kind) {
+		return "", fmt.Errorf("invalid kind '%s': must be one of %s", kind, strings.Join(HealthTypes, ", "))
+	}
+	if namespace == "" {
+		return "", fmt.Errorf("namespace is required")
+	}
+	if name == "" {
+		return "", fmt.Errorf("%s name is required", kind)
+	}
+	health, err := k.clustersHealth(ctx, namespace, kind, rateInterval)
+	if err != nil {
+		return "", err
+	}
+	report, ok := evaluateEntityHealth(health, kind, namespace, name)
+	if !ok {
+		return "", &NotFoundError{Kind: kind, Namespace: namespace, Name: name}
+	}
+	result, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal entity health: %v", err)
+	}
+	return string(result), nil
+}
+
+// evaluateEntityHealth computes the health of the entity out of the health of its type.
+// False is returned when the health has no entry for the entity.
+func evaluateEntityHealth(health *ClustersHealth, kind, namespace, name string) (*EntityHealthReport, bool) {
+	var entity EntityHealth
+	var statuses []*WorkloadStatus
+	switch kind {
+	case "app":
+		app, ok := health.AppHealth[namespace][name]
+		if !ok {
+			return nil, false
+		}
+		entity = evaluateAppHealth(namespace, name, app)
+		if app != nil {
+			statuses = app.WorkloadStatuses
+		}
+	case "workload":
+		workload, ok := health.WorkloadHealth[namespace][name]
+		if !ok {
+			return nil, false
+		}
+		entity = evaluateWorkloadHealth(namespace, name, workload)
+		if workload != nil && workload.WorkloadStatus != nil {
+			statuses = []*WorkloadStatus{workload.WorkloadStatus}
+		}
+	default:
+		service, ok := health.ServiceHealth[namespace][name]
+		if !ok {
+			return nil, false
+		}
+		entity = evaluateServiceHealth(namespace, name, service)
+	}
+	report := &EntityHealthReport{
+		Kind:      kind,
+		Namespace: namespace,
+		Name:      name,
+		Status:    entity.Status,
+		Issue:     strings.Join(entity.Issues, "; "),
+		ErrorRate: entity.ErrorRate,
+	}
+	for _, ws := range statuses {
+		if ws == nil {
+			continue
+		}
+		if report.Replicas == nil {
+			report.Replicas = &ReplicaCounts{}
+		}
+		report.Replicas.Desired += ws.DesiredReplicas
+		report.Replicas.Current += ws.CurrentReplicas
+		report.Replicas.Available += ws.AvailableReplicas
+	}
+	return report, true
+}
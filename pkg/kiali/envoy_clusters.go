@@ -0,0 +1,327 @@
+package kiali
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// envoyClustersConfigDump is the Envoy config dump section holding the clusters.
+const envoyClustersConfigDump = "type.googleapis.com/envoy.admin.v3.ClustersConfigDump"
+
+// EnvoyClustersHealth is the health of the workloads backing the upstream clusters of a pod's sidecar.
+// Kiali does not expose the endpoint health of a sidecar (its config dump has no EDS section), so the health of
+// a cluster is the replicas of the workloads backing its service: a sidecar unable to reach a healthy upstream
+// is not detected. Unhealthy lists the clusters with an unavailable replica or without any replica; they come
+// first in Clusters.
+type EnvoyClustersHealth struct {
+	Namespace string               `json:"namespace"`
+	Workload  string               `json:"workload"`
+	Pod       string               `json:"pod"`
+	Summary   string               `json:"summary"`
+	Unhealthy []string             `json:"unhealthy"`
+	Clusters  []EnvoyClusterHealth `json:"clusters"`
+	Note      string               `json:"note,omitempty"`
+}
+
+// EnvoyClusterHealth is the number of available (Healthy) and desired (Total) replicas of the workloads backing
+// an Envoy cluster, e.g. "outbound|9080|v1|reviews.bookinfo.svc.cluster.local".
+type EnvoyClusterHealth struct {
+	Name    string `json:"name"`
+	Healthy int    `json:"healthy"`
+	Total   int    `json:"total"`
+}
+
+// WorkloadEnvoyClusters fetches the Envoy config dump of a pod of the workload and returns, as JSON, the
+// available and desired replicas of the workloads backing the Kubernetes service of each outbound cluster of its
+// sidecar, the clusters with unavailable replicas first.
+// A NotFoundError is returned when the workload does not exist.
+// Parameters:
+//   - namespace: the namespace containing the workload
+//   - workload: the name of the workload
+//   - pod: the pod of the workload to inspect (optional, the first pod by name when empty)
+func (k *Kiali) WorkloadEnvoyClusters(ctx context.Context, namespace string, workload string, pod string) (string, error) {
+	pods, err := k.WorkloadPods(ctx, namespace, workload)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case len(pods) == 0:
+		return "", fmt.Errorf("no pods found for workload %s in namespace %s", workload, namespace)
+	case pod == "":
+		pod = pods[0]
+	case !slices.Contains(pods, pod):
+		return "", fmt.Errorf("pod %s is not a pod of workload %s in namespace %s (pods: %s)", pod, workload, namespace, strings.Join(pods, ", "))
+	}
+	content, err := k.PodConfigDump(ctx, namespace, pod)
+	if err != nil {
+		return "", err
+	}
+	dump, err := parseEnvoyConfigDump(content)
+	if err != nil {
+		return "", err
+	}
+	var health *EnvoyClustersHealth
+	if dump.hasClusters {
+		clusters, err := k.workloadsEndpointsHealth(ctx, dump.clusters)
+		if err != nil {
+			return "", err
+		}
+		health = newEnvoyClustersHealth(clusters, true)
+	} else {
+		health = newEnvoyClustersHealth(nil, false)
+	}
+	health.Namespace = namespace
+	health.Workload = workload
+	health.Pod = pod
+	result, err := json.MarshalIndent(health, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal envoy clusters health: %v", err)
+	}
+	return string(result), nil
+}
+
+// PodConfigDump returns the Envoy config dump of the sidecar of a pod.
+// Parameters:
+//   - namespace: the namespace containing the pod
+//   - pod: the name of the pod
+func (k *Kiali) PodConfigDump(ctx context.Context, namespace string, pod string) (string, error) {
+	baseURL, err := k.validateAndGetBaseURL()
+	if err != nil {
+		return "", err
+	}
+	if namespace == "" {
+		return "", fmt.Errorf("namespace is required")
+	}
+	if pod == "" {
+		return "", fmt.Errorf("pod name is required")
+	}
+	endpoint := fmt.Sprintf("%s/api/namespaces/%s/pods/%s/config_dump",
+		strings.TrimRight(baseURL, "/"), url.PathEscape(namespace), url.PathEscape(pod))
+	content, err := k.executeRequest(ctx, endpoint)
+	if err != nil {
+		return "", classifyNotFound(err, "pod", namespace, pod)
+	}
+	return content, nil
+}
+
+// envoyConfigDump holds the upstream clusters of an Envoy config dump.
+type envoyConfigDump struct {
+	// clusters are the names of the clusters of the clusters section
+	clusters    []string
+	hasClusters bool
+}
+
+// parseEnvoyConfigDump extracts the clusters of an Envoy config dump.
+func parseEnvoyConfigDump(content string) (*envoyConfigDump, error) {
+	type clusterConfig struct {
+		Cluster struct {
+			Name string `json:"name"`
+		} `json:"cluster"`
+	}
+	var dump struct {
+		Configs []json.RawMessage `json:"configs"`
+	}
+	if err := json.Unmarshal([]byte(content), &dump); err != nil {
+		return nil, fmt.Errorf("failed to parse config dump: %v", err)
+	}
+	ret := &envoyConfigDump{}
+	for _, raw := range dump.Configs {
+		var section struct {
+			Type                  string          `json:"@type"`
+			StaticClusters        []clusterConfig `json:"static_clusters"`
+			DynamicActiveClusters []clusterConfig `json:"dynamic_active_clusters"`
+		}
+		if err := json.Unmarshal(raw, &section); err != nil {
+			return nil, fmt.Errorf("failed to parse config dump: %v", err)
+		}
+		if section.Type != envoyClustersConfigDump {
+			continue
+		}
+		ret.hasClusters = true
+		for _, config := range append(section.StaticClusters, section.DynamicActiveClusters...) {
+			if config.Cluster.Name != "" {
+				ret.clusters = append(ret.clusters, config.Cluster.Name)
+			}
+		}
+	}
+	return ret, nil
+}
+
+// outboundCluster is an Envoy outbound cluster of a Kubernetes service, e.g.
+// "outbound|9080|v1|reviews.bookinfo.svc.cluster.local".
+type outboundCluster struct {
+	name      string
+	host      string
+	namespace string
+	service   string
+	subset    string
+}
+
+// parseOutboundCluster parses the name of an outbound cluster of a Kubernetes service. It returns false for
+// the other clusters (inbound, passthrough, ServiceEntry hosts...).
+func parseOutboundCluster(name string) (outboundCluster, bool) {
+	parts := strings.Split(name, "|")
+	if len(parts) != 4 || parts[0] != "outbound" {
+		return outboundCluster{}, false
+	}
+	labels := strings.Split(parts[3], ".")
+	if len(labels) < 3 || labels[2] != "svc" {
+		return outboundCluster{}, false
+	}
+	return outboundCluster{name: name, host: parts[3], namespace: labels[1], service: labels[0], subset: parts[2]}, true
+}
+
+// workloadsEndpointsHealth computes the health of the outbound clusters of Kubernetes services from the
+// workloads backing them: the available replicas of the workloads matching the service selector, and the subset
+// labels of its DestinationRule for a subset cluster, are the healthy ones, and their desired replicas the
+// total. Clusters of services without selector are not reported, as their endpoints are not managed by Kubernetes.
+func (k *Kiali) workloadsEndpointsHealth(ctx context.Context, names []string) ([]EnvoyClusterHealth, error) {
+	clusters := make([]outboundCluster, 0)
+	namespaces := map[string]struct{}{}
+	hasSubsets := false
+	for _, name := range names {
+		if cluster, ok := parseOutboundCluster(name); ok {
+			clusters = append(clusters, cluster)
+			namespaces[cluster.namespace] = struct{}{}
+			hasSubsets = hasSubsets || cluster.subset != ""
+		}
+	}
+	if len(clusters) == 0 {
+		return nil, nil
+	}
+
+	nsList := strings.Join(slices.Sorted(maps.Keys(namespaces)), ",")
+	var servicesContent, workloadsContent, config string
+	var health *ClustersHealth
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() (err error) {
+		servicesContent, err = k.ServicesList(gctx, nsList)
+		return err
+	})
+	g.Go(func() (err error) {
+		workloadsContent, err = k.WorkloadsList(gctx, nsList)
+		return err
+	})
+	g.Go(func() (err error) {
+		health, err = k.clustersHealth(gctx, nsList, "workload", "")
+		return err
+	})
+	if hasSubsets {
+		g.Go(func() (err error) {
+			config, err = k.IstioConfig(gctx)
+			return err
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	var services struct {
+		Services []serviceListItem `json:"services"`
+	}
+	if err := json.Unmarshal([]byte(servicesContent), &services); err != nil {
+		return nil, fmt.Errorf("failed to parse services: %v", err)
+	}
+	var workloads struct {
+		Workloads []workloadListItem `json:"workloads"`
+	}
+	if err := json.Unmarshal([]byte(workloadsContent), &workloads); err != nil {
+		return nil, fmt.Errorf("failed to parse workloads: %v", err)
+	}
+	var rules []IstioObject
+	if hasSubsets {
+		objects, err := parseIstioConfigObjects(config)
+		if err != nil {
+			return nil, err
+		}
+		rules = istioObjectsOfKind(objects, "DestinationRule", "")
+	}
+
+	ret := make([]EnvoyClusterHealth, 0, len(clusters))
+	for _, cluster := range clusters {
+		selector := map[string]string{}
+		for _, s := range services.Services {
+			if s.Namespace == cluster.namespace && s.Name == cluster.service {
+				maps.Copy(selector, s.Selector)
+			}
+		}
+		if len(selector) == 0 {
+			continue
+		}
+		if cluster.subset != "" {
+			maps.Copy(selector, destinationRuleSubsetLabels(rules, cluster.host, cluster.subset))
+		}
+		endpoints := EnvoyClusterHealth{Name: cluster.name}
+		for _, w := range workloads.Workloads {
+			if w.Namespace != cluster.namespace || !labelsMatch(selector, w.Labels) {
+				continue
+			}
+			if wh := health.WorkloadHealth[w.Namespace][w.Name]; wh != nil && wh.WorkloadStatus != nil {
+				endpoints.Healthy += int(wh.WorkloadStatus.AvailableReplicas)
+				endpoints.Total += int(max(wh.WorkloadStatus.DesiredReplicas, wh.WorkloadStatus.AvailableReplicas))
+			}
+		}
+		ret = append(ret, endpoints)
+	}
+	return ret, nil
+}
+
+// destinationRuleSubsetLabels returns the labels of the subset of the DestinationRule of the host, nil when
+// not found.
+func destinationRuleSubsetLabels(rules []IstioObject, host string, subset string) map[string]string {
+	for _, rule := range rules {
+		if qualifyHost(specString(rule.Spec, "host"), rule.Metadata.Namespace) != host {
+			continue
+		}
+		for _, s := range specList(rule.Spec, "subsets") {
+			if specString(s, "name") == subset {
+				return specLabels(s, "labels")
+			}
+		}
+	}
+	return nil
+}
+
+// newEnvoyClustersHealth reports the health of the clusters, the clusters with unavailable replicas or without
+// any replica first. hasClusters is false when the config dump has no clusters section.
+func newEnvoyClustersHealth(clusters []EnvoyClusterHealth, hasClusters bool) *EnvoyClustersHealth {
+	health := &EnvoyClustersHealth{Unhealthy: make([]string, 0), Clusters: make([]EnvoyClusterHealth, 0, len(clusters))}
+	unhealthy := func(c EnvoyClusterHealth) bool {
+		return c.Total == 0 || c.Healthy < c.Total
+	}
+	for _, cluster := range clusters {
+		if unhealthy(cluster) {
+			health.Unhealthy = append(health.Unhealthy, cluster.Name)
+		}
+		health.Clusters = append(health.Clusters, cluster)
+	}
+	sort.Strings(health.Unhealthy)
+	sort.Slice(health.Clusters, func(i, j int) bool {
+		ci, cj := health.Clusters[i], health.Clusters[j]
+		if ui, uj := unhealthy(ci), unhealthy(cj); ui != uj {
+			return ui
+		}
+		return ci.Name < cj.Name
+	})
+
+	switch {
+	case !hasClusters:
+		health.Summary = "no clusters in the config dump: the pod has no sidecar or its proxy is not reachable by Kiali"
+		return health
+	case len(health.Clusters) == 0:
+		health.Summary = "no upstream cluster of a Kubernetes service in the config dump"
+	case len(health.Unhealthy) > 0:
+		health.Summary = fmt.Sprintf("%d/%d upstream clusters are backed by workloads with unavailable replicas or no replica: %s", len(health.Unhealthy), len(health.Clusters), strings.Join(health.Unhealthy, ", "))
+	default:
+		health.Summary = fmt.Sprintf("all the replicas backing the %d upstream clusters are available", len(health.Clusters))
+	}
+	health.Note = "Kiali does not expose the endpoint health of the sidecar: the counts are the available and desired replicas of the workloads backing the service of each cluster, so a sidecar unable to reach an upstream whose workloads are healthy is not detected, and only the clusters of Kubernetes services with a selector are reported"
+	return health
+}
@@ -0,0 +1,349 @@
+package kiali
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// APIError is returned when the Kiali API responds with a non-2xx status code.
+type APIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	if e.Body != "" {
+		return fmt.Sprintf("kiali API error: %s", e.Body)
+	}
+	return fmt.Sprintf("kiali API error: status %d", e.StatusCode)
+}
+
+// RedirectError is returned when redirects are disabled and the Kiali API responds with a redirect,
+// which usually means an authentication proxy in front of Kiali is bouncing the request to a login page.
+type RedirectError struct {
+	StatusCode int
+	Location   string
+}
+
+func (e *RedirectError) Error() string {
+	if e.Location != "" {
+		return fmt.Sprintf("unexpected redirect (possible auth proxy): status %d to %s", e.StatusCode, e.Location)
+	}
+	return fmt.Sprintf("unexpected redirect (possible auth proxy): status %d", e.StatusCode)
+}
+
+// responseError returns the error for a non-2xx Kiali API response, or nil for a successful one.
+// Error bodies that cannot be decoded to text are left out of the error, which then only reports the status code.
+func responseError(resp *http.Response, body []byte) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	if resp.StatusCode >= 300 && resp.StatusCode < 400 {
+		return &RedirectError{StatusCode: resp.StatusCode, Location: resp.Header.Get("Location")}
+	}
+	body, err := decompressBody(resp, body)
+	if err != nil || !utf8.Valid(body) {
+		return &APIError{StatusCode: resp.StatusCode}
+	}
+	return &APIError{StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(body))}
+}
+
+// gzipMagic is the header of gzip streams.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// maxDecompressedBodySize bounds the size of a decompressed error body, so that a small gzip body cannot
+// expand to an unbounded amount of memory.
+const maxDecompressedBodySize = 1 << 20
+
+// decompressBody returns the gzip-decoded body when the response is gzip-encoded but was not transparently
+// decompressed by the HTTP transport, e.g. when a proxy compresses the body without a Content-Encoding header.
+// Other bodies are returned unchanged. An error is returned when the decoded body exceeds maxDecompressedBodySize.
+func decompressBody(resp *http.Response, body []byte) ([]byte, error) {
+	if resp.Uncompressed {
+		return body, nil
+	}
+	if !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") && !bytes.HasPrefix(body, gzipMagic) {
+		return body, nil
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	decompressed, err := io.ReadAll(io.LimitReader(reader, maxDecompressedBodySize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(decompressed) > maxDecompressedBodySize {
+		return nil, fmt.Errorf("decompressed body exceeds %d bytes", maxDecompressedBodySize)
+	}
+	return decompressed, nil
+}
+
+// NotFoundError is returned when a requested entity does not exist in the given namespace.
+type NotFoundError struct {
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("%s '%s' not found in namespace '%s'", e.Kind, e.Name, e.Namespace)
+}
+
+// IsNotFound reports whether the error is a NotFoundError, a NamespaceNotFoundError or a Kiali API 404 response.
+// A namespace that is not accessible is not reported as not found, even when Kiali answers with a 404.
+func IsNotFound(err error) bool {
+	var notFound *NotFoundError
+	if errors.As(err, &notFound) || IsNamespaceNotFound(err) {
+		return true
+	}
+	if IsNamespaceNotAccessible(err) {
+		return false
+	}
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// classifyNotFound converts a Kiali API 404 response into a NotFoundError for the given entity.
+// Namespace errors and any other error are returned unchanged.
+func classifyNotFound(err error, kind, namespace, name string) error {
+	if IsNamespaceNotFound(err) || IsNamespaceNotAccessible(err) {
+		return err
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return &NotFoundError{Kind: kind, Namespace: namespace, Name: name}
+	}
+	return err
+}
+
+// NamespaceExcludedError is returned when a call targets a namespace excluded by the kiali_excluded_namespaces
+// configuration.
+type NamespaceExcludedError struct {
+	Namespace string
+}
+
+func (e *NamespaceExcludedError) Error() string {
+	return fmt.Sprintf("namespace '%s' excluded by configuration", e.Namespace)
+}
+
+// IsNamespaceExcluded reports whether the error is a NamespaceExcludedError.
+func IsNamespaceExcluded(err error) bool {
+	var excluded *NamespaceExcludedError
+	return errors.As(err, &excluded)
+}
+
+// NamespaceNotWritableError is returned when a write operation targets a namespace outside of the
+// kiali_writable_namespaces configuration.
+type NamespaceNotWritableError struct {
+	Namespace string
+	Writable  []string
+}
+
+func (e *NamespaceNotWritableError) Error() string {
+	return fmt.Sprintf("namespace '%s' is not writable: write operations are restricted by configuration to the namespaces %s",
+		e.Namespace, strings.Join(e.Writable, ", "))
+}
+
+// IsNamespaceNotWritable reports whether the error is a NamespaceNotWritableError.
+func IsNamespaceNotWritable(err error) bool {
+	var notWritable *NamespaceNotWritableError
+	return errors.As(err, &notWritable)
+}
+
+// NamespaceNotFoundError is returned when Kiali reports that the namespace targeted by a call does not exist.
+type NamespaceNotFoundError struct {
+	Namespace string
+	Err       error
+}
+
+func (e *NamespaceNotFoundError) Error() string {
+	return fmt.Sprintf("namespace '%s' not found: it does not exist", e.Namespace)
+}
+
+func (e *NamespaceNotFoundError) Unwrap() error {
+	return e.Err
+}
+
+// IsNamespaceNotFound reports whether the error is a NamespaceNotFoundError.
+func IsNamespaceNotFound(err error) bool {
+	var notFound *NamespaceNotFoundError
+	return errors.As(err, &notFound)
+}
+
+// NamespaceNotAccessibleError is returned when Kiali reports that the credentials in use are not allowed to
+// access the namespace targeted by a call.
+type NamespaceNotAccessibleError struct {
+	Namespace string
+	Err       error
+}
+
+func (e *NamespaceNotAccessibleError) Error() string {
+	return fmt.Sprintf("namespace '%s' is not accessible: the credentials in use are not allowed to access it", e.Namespace)
+}
+
+func (e *NamespaceNotAccessibleError) Unwrap() error {
+	return e.Err
+}
+
+// IsNamespaceNotAccessible reports whether the error is a NamespaceNotAccessibleError.
+func IsNamespaceNotAccessible(err error) bool {
+	var notAccessible *NamespaceNotAccessibleError
+	return errors.As(err, &notAccessible)
+}
+
+var (
+	// namespaceNotAccessiblePattern matches the Kiali and Kubernetes messages for a namespace the credentials cannot
+	// access, e.g. `Namespace [bookinfo] is not accessible for cluster [east]` or `namespaces "bookinfo" is forbidden: ...`.
+	namespaceNotAccessiblePattern = regexp.MustCompile(`(?i)namespaces?(?:\.kiali)?\s*["\[]([^"\]]+)["\]]\s*(?:in cluster \[[^\]]*\]\s*)?is (?:not accessible|forbidden)`)
+	// namespaceNotFoundPattern matches the Kiali and Kubernetes messages for a namespace that does not exist,
+	// e.g. `namespaces "bookinfo" not found` or `Namespace [bookinfo] is not found`.
+	namespaceNotFoundPattern = regexp.MustCompile(`(?i)namespaces?(?:\.kiali)?\s*["\[]([^"\]]+)["\]]\s*(?:in cluster \[[^\]]*\]\s*)?(?:is )?not found`)
+)
+
+// classifyNamespaceError converts the Kiali API 403 and 404 responses about the namespace of a call into a
+// NamespaceNotAccessibleError or a NamespaceNotFoundError. The namespace is read from the Kiali message, or
+// from the endpoint path for a 403 to a GET on a namespace-scoped endpoint. A 403 to a write is about the
+// object rather than its namespace (e.g. RBAC not allowing to patch it), so unless its message names the
+// namespace it is returned unchanged, as is any other error.
+func classifyNamespaceError(err error, method, endpoint string) error {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || (apiErr.StatusCode != http.StatusForbidden && apiErr.StatusCode != http.StatusNotFound) {
+		return err
+	}
+	message := apiErrorMessage(apiErr.Body)
+	if m := namespaceNotAccessiblePattern.FindStringSubmatch(message); m != nil {
+		return &NamespaceNotAccessibleError{Namespace: m[1], Err: err}
+	}
+	if m := namespaceNotFoundPattern.FindStringSubmatch(message); m != nil {
+		return &NamespaceNotFoundError{Namespace: m[1], Err: err}
+	}
+	if apiErr.StatusCode == http.StatusForbidden && method == http.MethodGet {
+		if u, parseErr := url.Parse(endpoint); parseErr == nil {
+			if ns := endpointNamespace(u.Path); ns != "" {
+				return &NamespaceNotAccessibleError{Namespace: ns, Err: err}
+			}
+		}
+	}
+	return err
+}
+
+// apiErrorMessage returns the message of a Kiali API error body, either a JSON object with an "error" field
+// (and an optional "detail") or plain text.
+func apiErrorMessage(body string) string {
+	var response struct {
+		Error  string `json:"error"`
+		Detail string `json:"detail"`
+	}
+	if err := json.Unmarshal([]byte(body), &response); err != nil || response.Error == "" {
+		return body
+	}
+	if response.Detail != "" {
+		return response.Error + ": " + response.Detail
+	}
+	return response.Error
+}
+
+// CreateStatusUnknownError is returned when a create request timed out, so the object may or may not have been
+// created. Exists reports whether the object was found by a follow-up check, and is nil when the check was not
+// possible (no deterministic name) or failed (CheckErr).
+type CreateStatusUnknownError struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Exists    *bool
+	CheckErr  error
+	Err       error
+}
+
+func (e *CreateStatusUnknownError) Error() string {
+	msg := fmt.Sprintf("creation status unknown: %v", e.Err)
+	switch {
+	case e.Name == "":
+		return msg + fmt.Sprintf("; the %s has no metadata.name, so its existence cannot be checked: set a deterministic name before retrying to avoid duplicates", e.Kind)
+	case e.Exists == nil:
+		return msg + fmt.Sprintf("; %s %s/%s exists: unknown (check failed: %v), verify it exists before retrying", e.Kind, e.Namespace, e.Name, e.CheckErr)
+	case *e.Exists:
+		return msg + fmt.Sprintf("; %s %s/%s exists: yes, the creation succeeded, do not retry it", e.Kind, e.Namespace, e.Name)
+	default:
+		return msg + fmt.Sprintf("; %s %s/%s exists: no, the creation can be retried", e.Kind, e.Namespace, e.Name)
+	}
+}
+
+func (e *CreateStatusUnknownError) Unwrap() error {
+	return e.Err
+}
+
+// TimeoutError is returned when a Kiali request did not complete in time, either because of the client timeout
+// (kiali_request_timeout) or because the deadline of the tool call expired.
+type TimeoutError struct {
+	Endpoint string
+	After    time.Duration
+	Err      error
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("kiali request to %s timed out after %s: increase kiali_request_timeout (or the tool timeout) or narrow the query (e.g. fewer namespaces, a shorter duration)", e.Endpoint, e.After)
+}
+
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// IsTimeout reports whether the error is a TimeoutError.
+func IsTimeout(err error) bool {
+	var timeout *TimeoutError
+	return errors.As(err, &timeout)
+}
+
+// classifyTimeout converts the error of a Kiali request that timed out into a TimeoutError reporting the
+// endpoint, without its query string, and the time elapsed since the request started. Any other error is
+// returned unchanged.
+func classifyTimeout(err error, endpoint string, start time.Time) error {
+	if !isTimeout(err) {
+		return err
+	}
+	if u, parseErr := url.Parse(endpoint); parseErr == nil {
+		u.RawQuery = ""
+		endpoint = u.String()
+	}
+	return &TimeoutError{Endpoint: endpoint, After: time.Since(start).Round(100 * time.Millisecond), Err: err}
+}
+
+// isTimeout reports whether the request failed because it timed out.
+func isTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// PodDiscoveryError is returned by WorkloadLogs when the pods of the workload could not be discovered, so that
+// no log was requested, as opposed to a failure fetching the logs of the pods.
+type PodDiscoveryError struct {
+	Namespace string
+	Workload  string
+	Err       error
+}
+
+func (e *PodDiscoveryError) Error() string {
+	return fmt.Sprintf("could not discover the pods of workload '%s' in namespace '%s': %v", e.Workload, e.Namespace, e.Err)
+}
+
+func (e *PodDiscoveryError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,262 @@
+package kiali
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// excludedNamespaces returns the namespaces excluded by the kiali_excluded_namespaces configuration,
+// or nil when none is configured.
+func (k *Kiali) excludedNamespaces() map[string]struct{} {
+	if k == nil || k.manager == nil || k.manager.staticConfig == nil {
+		return nil
+	}
+	var excluded map[string]struct{}
+	for _, ns := range k.manager.staticConfig.KialiExcludedNamespaces {
+		if ns = strings.TrimSpace(ns); ns == "" {
+			continue
+		}
+		if excluded == nil {
+			excluded = map[string]struct{}{}
+		}
+		excluded[ns] = struct{}{}
+	}
+	return excluded
+}
+
+// checkWritableNamespace fails with a NamespaceNotWritableError when kiali_writable_namespaces is configured and
+// does not list the namespace.
+func (k *Kiali) checkWritableNamespace(namespace string) error {
+	if k == nil || k.manager == nil || k.manager.staticConfig == nil {
+		return nil
+	}
+	writable := make([]string, 0)
+	for _, ns := range k.manager.staticConfig.KialiWritableNamespaces {
+		if ns = strings.TrimSpace(ns); ns != "" {
+			writable = append(writable, ns)
+		}
+	}
+	if len(writable) == 0 || slices.Contains(writable, namespace) {
+		return nil
+	}
+	return &NamespaceNotWritableError{Namespace: namespace, Writable: writable}
+}
+
+// applyExcludedNamespaces checks a Kiali endpoint against the excluded namespaces before it is requested.
+// Endpoints of an entity in an excluded namespace (/api/namespaces/<namespace>/...) fail with a
+// NamespaceExcludedError, and excluded namespaces are removed from the namespaces query parameter.
+// When only excluded namespaces were requested the call fails as well, as Kiali would otherwise return
+// all the namespaces.
+func (k *Kiali) applyExcludedNamespaces(endpoint string) (string, error) {
+	excluded := k.excludedNamespaces()
+	if len(excluded) == 0 {
+		return endpoint, nil
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+	if ns := endpointNamespace(u.Path); ns != "" {
+		if _, ok := excluded[ns]; ok {
+			return "", &NamespaceExcludedError{Namespace: ns}
+		}
+	}
+	if u.RawQuery == "" {
+		return endpoint, nil
+	}
+	// The namespaces are escaped individually and joined with a literal comma, see addNamespacesQuery
+	key := url.QueryEscape(k.queryParam("namespaces"))
+	params := strings.Split(u.RawQuery, "&")
+	for i, param := range params {
+		name, value, found := strings.Cut(param, "=")
+		if !found || name != key || value == "" {
+			continue
+		}
+		kept := make([]string, 0)
+		var firstExcluded string
+		for _, escaped := range strings.Split(value, ",") {
+			ns, err := url.QueryUnescape(escaped)
+			if err != nil {
+				ns = escaped
+			}
+			if _, ok := excluded[ns]; ok {
+				if firstExcluded == "" {
+					firstExcluded = ns
+				}
+				continue
+			}
+			kept = append(kept, escaped)
+		}
+		if len(kept) == 0 && firstExcluded != "" {
+			return "", &NamespaceExcludedError{Namespace: firstExcluded}
+		}
+		params[i] = name + "=" + strings.Join(kept, ",")
+	}
+	u.RawQuery = strings.Join(params, "&")
+	return u.String(), nil
+}
+
+// endpointNamespace returns the namespace of an entity endpoint path, e.g. "bookinfo" for
+// "/api/namespaces/bookinfo/services/reviews", or "" when the path is not namespace-scoped.
+func endpointNamespace(path string) string {
+	_, rest, found := strings.Cut(path, "/api/namespaces/")
+	if !found {
+		return ""
+	}
+	ns, _, found := strings.Cut(rest, "/")
+	if !found {
+		// e.g. /api/namespaces/graph
+		return ""
+	}
+	return ns
+}
+
+// filterExcludedNamespaces removes the entities of the excluded namespaces from the Kiali responses spanning
+// several namespaces: the namespaces, health, services, workloads and apps lists, the graph and the Istio config
+// and its validations.
+// Other responses are returned unchanged.
+func (k *Kiali) filterExcludedNamespaces(endpoint, content string) (string, error) {
+	excluded := k.excludedNamespaces()
+	if len(excluded) == 0 {
+		return content, nil
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+	isExcluded := func(value any) bool {
+		ns, _ := value.(string)
+		_, ok := excluded[ns]
+		return ok
+	}
+	var filter func(any) any
+	switch path := strings.TrimRight(u.Path, "/"); {
+	case strings.HasSuffix(path, "/api/namespaces"):
+		filter = func(v any) any {
+			items, _ := v.([]any)
+			return filterItems(items, func(item map[string]any) bool { return isExcluded(item["name"]) })
+		}
+	case strings.HasSuffix(path, "/api/clusters/health"):
+		// e.g. {"namespaceAppHealth": {"bookinfo": {...}}}
+		filter = func(v any) any {
+			health, _ := v.(map[string]any)
+			for _, byNamespace := range health {
+				if m, ok := byNamespace.(map[string]any); ok {
+					for ns := range excluded {
+						delete(m, ns)
+					}
+				}
+			}
+			return health
+		}
+	case strings.HasSuffix(path, "/api/clusters/services"), strings.HasSuffix(path, "/api/clusters/workloads"),
+		strings.HasSuffix(path, "/api/clusters/apps"):
+		filter = func(v any) any {
+			list, _ := v.(map[string]any)
+			for field, value := range list {
+				if items, ok := value.([]any); ok {
+					list[field] = filterItems(items, func(item map[string]any) bool { return isExcluded(item["namespace"]) })
+				}
+			}
+			return list
+		}
+	case strings.HasSuffix(path, "/api/namespaces/graph"):
+		filter = func(v any) any {
+			graph, _ := v.(map[string]any)
+			filterGraphNamespaces(graph, isExcluded)
+			return graph
+		}
+	case strings.HasSuffix(path, "/api/istio/config"):
+		filter = func(v any) any {
+			config, _ := v.(map[string]any)
+			resources, _ := config["resources"].(map[string]any)
+			for kind, value := range resources {
+				items, _ := value.([]any)
+				resources[kind] = filterItems(items, func(item map[string]any) bool {
+					metadata, _ := item["metadata"].(map[string]any)
+					return isExcluded(metadata["namespace"])
+				})
+			}
+			// e.g. {"validations": {"networking.istio.io/v1, Kind=VirtualService": {"reviews.bookinfo": {...}}}}
+			validations, _ := config["validations"].(map[string]any)
+			for _, value := range validations {
+				objects, _ := value.(map[string]any)
+				for key, object := range objects {
+					validation, _ := object.(map[string]any)
+					namespace, _ := validation["namespace"].(string)
+					if i := strings.LastIndex(key, "."); namespace == "" && i > 0 {
+						namespace = key[i+1:]
+					}
+					if isExcluded(namespace) {
+						delete(objects, key)
+					}
+				}
+			}
+			return config
+		}
+	default:
+		return content, nil
+	}
+	var parsed any
+	if err := json.Unmarshal([]byte(content), &parsed); err != nil {
+		return "", fmt.Errorf("failed to filter excluded namespaces: %v", err)
+	}
+	result, err := json.Marshal(filter(parsed))
+	if err != nil {
+		return "", fmt.Errorf("failed to filter excluded namespaces: %v", err)
+	}
+	return string(result), nil
+}
+
+// filterItems returns the object items for which drop is false. Non-object items are kept.
+func filterItems(items []any, drop func(map[string]any) bool) []any {
+	kept := make([]any, 0, len(items))
+	for _, item := range items {
+		if m, ok := item.(map[string]any); ok && drop(m) {
+			continue
+		}
+		kept = append(kept, item)
+	}
+	return kept
+}
+
+// filterGraphNamespaces removes from a Kiali graph the nodes of the excluded namespaces, the nodes boxed in them
+// and the edges from or to any removed node.
+func filterGraphNamespaces(graph map[string]any, isExcluded func(any) bool) {
+	elements, _ := graph["elements"].(map[string]any)
+	if elements == nil {
+		return
+	}
+	nodeData := func(item map[string]any) map[string]any {
+		data, _ := item["data"].(map[string]any)
+		return data
+	}
+	nodes, _ := elements["nodes"].([]any)
+	removed := map[any]bool{}
+	for changed := true; changed; {
+		changed = false
+		for _, n := range nodes {
+			node, _ := n.(map[string]any)
+			data := nodeData(node)
+			if data == nil || removed[data["id"]] {
+				continue
+			}
+			if isExcluded(data["namespace"]) || (data["parent"] != nil && removed[data["parent"]]) {
+				removed[data["id"]] = true
+				changed = true
+			}
+		}
+	}
+	if len(removed) == 0 {
+		return
+	}
+	elements["nodes"] = filterItems(nodes, func(node map[string]any) bool { return removed[nodeData(node)["id"]] })
+	edges, _ := elements["edges"].([]any)
+	elements["edges"] = filterItems(edges, func(edge map[string]any) bool {
+		data := nodeData(edge)
+		return removed[data["source"]] || removed[data["target"]]
+	})
+}
@@ -0,0 +1,40 @@
+package kiali
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// fanOut calls fetch for each of the n items, at most limit at a time, and returns the error of each item, by
+// item index. Failures are reported per item rather than returned, so the other items are not cancelled.
+// When the context has a deadline, the items share it, less a tenth of the remaining time left to report the
+// items that did not complete before the deadline of the call expires. Otherwise each item is bounded by the
+// configured Kiali request timeout, so a slow item cannot hold up the result.
+func (k *Kiali) fanOut(ctx context.Context, n int, limit int, fetch func(ctx context.Context, i int) error) []error {
+	itemContext := func() (context.Context, context.CancelFunc) {
+		return context.WithTimeout(ctx, k.requestTimeout())
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		cutoff := time.Now().Add(time.Until(deadline) * 9 / 10)
+		itemContext = func() (context.Context, context.CancelFunc) {
+			return context.WithDeadline(ctx, cutoff)
+		}
+	}
+	errs := make([]error, n)
+	g := new(errgroup.Group)
+	g.SetLimit(limit)
+	for i := range n {
+		g.Go(func() error {
+			itemCtx, cancel := itemContext()
+			defer cancel()
+			if errs[i] = itemCtx.Err(); errs[i] == nil {
+				errs[i] = fetch(itemCtx, i)
+			}
+			return nil
+		})
+	}
+	_ = g.Wait()
+	return errs
+}
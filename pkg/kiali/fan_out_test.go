@@ -0,0 +1,74 @@
+package kiali
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali-mcp-server/pkg/config"
+)
+
+func TestFanOut(t *testing.T) {
+	k := NewFromConfig(&config.StaticConfig{KialiRequestTimeout: "20s"})
+	itemDeadline := func(ctx context.Context) time.Time {
+		var deadline time.Time
+		k.fanOut(ctx, 1, 1, func(ctx context.Context, _ int) error {
+			deadline, _ = ctx.Deadline()
+			return nil
+		})
+		return deadline
+	}
+
+	t.Run("items share the deadline of the call", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+		defer cancel()
+		parent, _ := ctx.Deadline()
+		deadline := itemDeadline(ctx)
+		assert.True(t, deadline.Before(parent), "the items leave time to report their failures")
+		assert.True(t, time.Until(deadline) > 2*time.Minute, "a long deadline of the call is not cut, got %s", time.Until(deadline))
+	})
+
+	t.Run("items bounded by the request timeout without deadline", func(t *testing.T) {
+		remaining := time.Until(itemDeadline(context.Background()))
+		assert.True(t, remaining > 19*time.Second && remaining <= 20*time.Second, "got %s", remaining)
+	})
+
+	t.Run("errors by item and concurrency limit", func(t *testing.T) {
+		var inFlight, maxInFlight atomic.Int32
+		errs := k.fanOut(context.Background(), 6, 2, func(ctx context.Context, i int) error {
+			n := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for {
+				m := maxInFlight.Load()
+				if n <= m || maxInFlight.CompareAndSwap(m, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			if i%2 == 1 {
+				return errors.New("boom")
+			}
+			return nil
+		})
+		assert.Equal(t, int32(2), maxInFlight.Load())
+		for i, err := range errs {
+			assert.Equal(t, i%2 == 1, err != nil, "error of item %d", i)
+		}
+	})
+
+	t.Run("items not started before the deadline fail", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		called := false
+		errs := k.fanOut(ctx, 1, 1, func(context.Context, int) error {
+			called = true
+			return nil
+		})
+		assert.False(t, called)
+		assert.ErrorIs(t, errs[0], context.Canceled)
+	})
+}
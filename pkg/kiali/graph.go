@@ -2,14 +2,81 @@ package kiali
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/url"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 )
 
+// DefaultGraphDuration is the default window of traffic the graph is built from.
+const DefaultGraphDuration = "60s"
+
+// LightweightGraphDuration is the default window of traffic of a lightweight graph.
+const LightweightGraphDuration = "30s"
+
+// graphOptions are the variable parameters of a graph request.
+type graphOptions struct {
+	// graphType is the Kiali graph type, e.g. "versionedApp" or "service"
+	graphType string
+	// duration is the window of traffic, ending at queryTime (default: DefaultGraphDuration)
+	duration string
+	// queryTime is the Unix timestamp, in seconds, of the end of the window (default: now)
+	queryTime string
+	appenders []string
+	// queryParams override the default graph parameters, e.g. "includeIdleEdges" or "boxBy". Parameters unknown to
+	// this client are passed through to Kiali.
+	queryParams map[string]string
+}
+
+// GraphOptions are the optional parameters of Graph. The zero value requests the full graph of the default window.
+type GraphOptions struct {
+	// IncludeHealth controls the health appender, which adds significant Prometheus load on large meshes;
+	// without it a pure topology graph is returned.
+	IncludeHealth bool
+	// Lightweight minimizes the Prometheus load for heavily loaded environments: only the deadNode appender is
+	// requested (plus health, if included) and the default duration is LightweightGraphDuration, so the graph
+	// lacks the Istio config, service entry, mesh check and workload entry information.
+	Lightweight bool
+	// ResponseTime adds the responseTime appender, which sets the response time of the HTTP and gRPC edges, in
+	// milliseconds (95th percentile), at the cost of additional Prometheus queries.
+	ResponseTime bool
+	// Duration (default: DefaultGraphDuration) and QueryTime (Unix timestamp in seconds, default: now) select the
+	// window of traffic the graph reflects, which allows looking at a past window. The traffic comes from
+	// Prometheus, so windows older than its retention are empty and the rates are averaged over the whole window.
+	Duration  string
+	QueryTime string
+	// QueryParams override the default graph parameters (e.g. "graphType", "injectServiceNodes",
+	// "includeIdleEdges", "boxBy" or "appenders") and are passed through to Kiali as is otherwise, so parameters
+	// of newer Kiali versions can be set. Nil keeps the defaults.
+	QueryParams map[string]string
+}
+
 // Graph calls the Kiali graph API using the provided Authorization header value.
 // `namespaces` may contain zero, one or many namespaces. If empty, the API may return an empty graph
 // or the server default, depending on Kiali configuration.
-func (k *Kiali) Graph(ctx context.Context, namespaces []string) (string, error) {
+func (k *Kiali) Graph(ctx context.Context, namespaces []string, opts GraphOptions) (string, error) {
+	appenders := []string{"deadNode", "istio", "serviceEntry", "meshCheck", "workloadEntry"}
+	duration := opts.Duration
+	if opts.Lightweight {
+		appenders = []string{"deadNode"}
+		if duration == "" {
+			duration = LightweightGraphDuration
+		}
+	}
+	if opts.IncludeHealth {
+		appenders = append(appenders, "health")
+	}
+	if opts.ResponseTime {
+		appenders = append(appenders, "responseTime")
+	}
+	return k.graph(ctx, namespaces, graphOptions{graphType: "versionedApp", duration: duration, queryTime: opts.QueryTime, appenders: appenders, queryParams: opts.QueryParams})
+}
+
+// graph calls the Kiali graph API with the given options.
+func (k *Kiali) graph(ctx context.Context, namespaces []string, opts graphOptions) (string, error) {
 	baseURL, err := k.validateAndGetBaseURL()
 	if err != nil {
 		return "", err
@@ -21,30 +88,313 @@ func (k *Kiali) Graph(ctx context.Context, namespaces []string) (string, error)
 		return "", err
 	}
 	q := u.Query()
-	// Static graph parameters per requirements
-	q.Set("duration", "60s")
-	q.Set("graphType", "versionedApp")
+	// Default graph parameters, which opts.queryParams may override
+	if opts.duration == "" {
+		opts.duration = DefaultGraphDuration
+	}
+	q.Set(k.queryParam("duration"), opts.duration)
+	if opts.queryTime != "" {
+		q.Set(k.queryParam("queryTime"), opts.queryTime)
+	}
+	q.Set("graphType", opts.graphType)
 	q.Set("includeIdleEdges", "false")
 	q.Set("injectServiceNodes", "true")
 	q.Set("boxBy", "cluster,namespace,app")
 	q.Set("ambientTraffic", "none")
-	q.Set("appenders", "deadNode,istio,serviceEntry,meshCheck,workloadEntry,health")
+	q.Set("appenders", strings.Join(opts.appenders, ","))
 	q.Set("rateGrpc", "requests")
 	q.Set("rateHttp", "requests")
 	q.Set("rateTcp", "sent")
+	k.setQueryParams(q, opts.queryParams)
+	u.RawQuery = q.Encode()
 	// Optional namespaces param
-	cleaned := make([]string, 0, len(namespaces))
-	for _, ns := range namespaces {
-		ns = strings.TrimSpace(ns)
-		if ns != "" {
-			cleaned = append(cleaned, ns)
+	k.addNamespacesQuery(u, namespaces...)
+	endpoint = u.String()
+
+	return k.executeRequest(ctx, endpoint)
+}
+
+// GraphProtocols lists the edge protocols supported by FilterGraphByProtocol.
+var GraphProtocols = []string{"http", "grpc", "tcp"}
+
+// Output formats of the graph: the raw cytoscape elements returned by Kiali, or the GraphAdjacency list.
+const (
+	GraphFormatCytoscape = "cytoscape"
+	GraphFormatAdjacency = "adjacency"
+)
+
+// GraphFormats lists the supported graph output formats.
+var GraphFormats = []string{GraphFormatCytoscape, GraphFormatAdjacency}
+
+// FilterGraphByProtocol keeps only the graph edges whose traffic protocol matches the given one
+// ("http", "grpc" or "tcp") and removes the nodes that are no longer connected to any remaining edge.
+// Box (group) nodes are kept only if they still contain a connected node.
+// The rest of the Kiali graph response is returned unchanged.
+func FilterGraphByProtocol(content string, protocol string) (string, error) {
+	protocol = strings.ToLower(strings.TrimSpace(protocol))
+	if !slices.Contains(GraphProtocols, protocol) {
+		return "", fmt.Errorf("invalid protocol %q: must be one of %s", protocol, strings.Join(GraphProtocols, ", "))
+	}
+	var graph map[string]any
+	if err := json.Unmarshal([]byte(content), &graph); err != nil {
+		return "", fmt.Errorf("failed to parse graph: %v", err)
+	}
+	elements, _ := graph["elements"].(map[string]any)
+	if elements == nil {
+		return content, nil
+	}
+	edges, _ := elements["edges"].([]any)
+	nodes, _ := elements["nodes"].([]any)
+
+	keptEdges := make([]any, 0, len(edges))
+	connected := map[string]struct{}{}
+	for _, e := range edges {
+		data := elementData(e)
+		traffic, _ := data["traffic"].(map[string]any)
+		if p, _ := traffic["protocol"].(string); strings.ToLower(p) != protocol {
+			continue
+		}
+		keptEdges = append(keptEdges, e)
+		if source, ok := data["source"].(string); ok {
+			connected[source] = struct{}{}
+		}
+		if target, ok := data["target"].(string); ok {
+			connected[target] = struct{}{}
 		}
 	}
-	if len(cleaned) > 0 {
-		q.Set("namespaces", strings.Join(cleaned, ","))
+
+	// Keep connected nodes and every box (parent) that contains one of them
+	parents := map[string]string{}
+	for _, n := range nodes {
+		data := elementData(n)
+		id, _ := data["id"].(string)
+		if parent, ok := data["parent"].(string); ok && parent != "" {
+			parents[id] = parent
+		}
+	}
+	keep := map[string]struct{}{}
+	for id := range connected {
+		for current := id; current != ""; current = parents[current] {
+			if _, seen := keep[current]; seen {
+				break
+			}
+			keep[current] = struct{}{}
+		}
+	}
+	keptNodes := make([]any, 0, len(keep))
+	for _, n := range nodes {
+		id, _ := elementData(n)["id"].(string)
+		if _, ok := keep[id]; ok {
+			keptNodes = append(keptNodes, n)
+		}
 	}
-	u.RawQuery = q.Encode()
-	endpoint = u.String()
 
-	return k.executeRequest(ctx, endpoint)
+	elements["nodes"] = keptNodes
+	elements["edges"] = keptEdges
+	filtered, err := json.Marshal(graph)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal filtered graph: %v", err)
+	}
+	return string(filtered), nil
+}
+
+// elementData returns the "data" object of a cytoscape graph element.
+func elementData(element any) map[string]any {
+	e, _ := element.(map[string]any)
+	data, _ := e["data"].(map[string]any)
+	return data
+}
+
+// errorGraphAppenders are the only appenders needed to color a graph by errors: dead nodes are removed
+// and the health of the nodes is computed. Edge error rates are always part of the graph traffic.
+var errorGraphAppenders = []string{"deadNode", "health"}
+
+// ErrorGraph is an error-focused view of the mesh graph.
+type ErrorGraph struct {
+	Namespaces []string         `json:"namespaces,omitempty"`
+	Duration   string           `json:"duration"`
+	Edges      []ErrorGraphEdge `json:"edges"`
+}
+
+// ErrorGraphEdge is a graph edge annotated with its request and error rates.
+// ErrorRate is the percentage of failed requests; it is not set for TCP edges, which have no notion of errors.
+type ErrorGraphEdge struct {
+	Source      string   `json:"source"`
+	Target      string   `json:"target"`
+	Protocol    string   `json:"protocol"`
+	RequestRate float64  `json:"requestRate"`
+	ErrorRate   *float64 `json:"errorRate,omitempty"`
+}
+
+// ErrorGraph requests the mesh graph with only the appenders needed for error coloring and returns, as JSON,
+// its edges annotated with their protocol, request rate and error rate, the edges with the most errors first.
+// Parameters:
+//   - namespaces: the namespaces to include in the graph
+//   - duration: the window the rates are computed over (e.g. "10m", default: DefaultGraphDuration)
+//   - queryTime: the Unix timestamp, in seconds, of the end of the window (default: now)
+func (k *Kiali) ErrorGraph(ctx context.Context, namespaces []string, duration string, queryTime string) (string, error) {
+	if duration == "" {
+		duration = DefaultGraphDuration
+	}
+	content, err := k.graph(ctx, namespaces, graphOptions{graphType: "versionedApp", duration: duration, queryTime: queryTime, appenders: errorGraphAppenders})
+	if err != nil {
+		return "", err
+	}
+	edges, err := parseErrorGraphEdges(content)
+	if err != nil {
+		return "", err
+	}
+	result, err := json.MarshalIndent(&ErrorGraph{Namespaces: namespaces, Duration: duration, Edges: edges}, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal error graph: %v", err)
+	}
+	return string(result), nil
+}
+
+// parseErrorGraphEdges extracts the edges of a Kiali graph response, naming their endpoints after the nodes
+// and sorting them by decreasing error rate.
+func parseErrorGraphEdges(content string) ([]ErrorGraphEdge, error) {
+	graph, err := parseGraph(content)
+	if err != nil {
+		return nil, err
+	}
+	names := graph.nodeNames()
+	edges := make([]ErrorGraphEdge, 0, len(graph.Elements.Edges))
+	for _, e := range graph.Elements.Edges {
+		rate, errorRate := e.rates()
+		edges = append(edges, ErrorGraphEdge{
+			Source:      names.name(e.Data.Source),
+			Target:      names.name(e.Data.Target),
+			Protocol:    e.protocol(),
+			RequestRate: rate,
+			ErrorRate:   errorRate,
+		})
+	}
+	sort.SliceStable(edges, func(i, j int) bool {
+		ei, ej := errorRateOrZero(edges[i]), errorRateOrZero(edges[j])
+		if ei != ej {
+			return ei > ej
+		}
+		if edges[i].Source != edges[j].Source {
+			return edges[i].Source < edges[j].Source
+		}
+		return edges[i].Target < edges[j].Target
+	})
+	return edges, nil
+}
+
+// graphNodeName returns a readable name for a graph node, e.g. "bookinfo/reviews-v1" for a workload
+// or "bookinfo/reviews:v1" for a versioned app.
+func graphNodeName(data map[string]any) string {
+	name := graphNodeLocalName(data)
+	if namespace, _ := data["namespace"].(string); namespace != "" && name != "unknown" {
+		return namespace + "/" + name
+	}
+	return name
+}
+
+// graphNodeLocalName returns the name of a graph node within its namespace, e.g. "reviews-v1" for a workload
+// or "reviews:v1" for a versioned app.
+func graphNodeLocalName(data map[string]any) string {
+	field := func(key string) string {
+		value, _ := data[key].(string)
+		return value
+	}
+	name := ""
+	switch field("nodeType") {
+	case "service":
+		name = field("service")
+	case "workload":
+		name = field("workload")
+	case "app":
+		name = field("app")
+		if version := field("version"); version != "" {
+			name += ":" + version
+		}
+	case "unknown":
+		return "unknown"
+	}
+	if name == "" {
+		name = field("id")
+		for _, key := range []string{"workload", "app", "service"} {
+			if value := field(key); value != "" {
+				name = value
+				break
+			}
+		}
+	}
+	return name
+}
+
+// parseRate parses a Kiali rate, which is encoded as a string; missing or invalid rates are 0.
+func parseRate(value string) float64 {
+	rate, _ := strconv.ParseFloat(value, 64)
+	return rate
+}
+
+func errorRateOrZero(edge ErrorGraphEdge) float64 {
+	if edge.ErrorRate == nil {
+		return 0
+	}
+	return *edge.ErrorRate
+}
+
+// GraphAdjacency is a flattened representation of the mesh graph, easier to process than the cytoscape elements.
+type GraphAdjacency struct {
+	Nodes []AdjacencyNode `json:"nodes"`
+	Edges []AdjacencyEdge `json:"edges"`
+}
+
+// AdjacencyNode is a graph node; Kind is the Kiali node type (app, service, workload, unknown...).
+type AdjacencyNode struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	Kind      string `json:"kind"`
+}
+
+// AdjacencyEdge is a graph edge between two node IDs. RPS is the request rate of HTTP and gRPC edges, or the
+// sent bytes rate of TCP edges. ErrorRate is the percentage of failed requests; it is not set for TCP edges.
+// ResponseTimeMs is the response time of the requests, in milliseconds; it is only set when the graph was
+// requested with the responseTime appender.
+type AdjacencyEdge struct {
+	From           string   `json:"from"`
+	To             string   `json:"to"`
+	Protocol       string   `json:"protocol"`
+	RPS            float64  `json:"rps"`
+	ErrorRate      *float64 `json:"errorRate,omitempty"`
+	ResponseTimeMs *float64 `json:"responseTimeMs,omitempty"`
+}
+
+// ToGraphAdjacency converts a Kiali graph response into its adjacency list representation, as JSON.
+// Box (group) nodes are dropped, as they are not part of the call graph.
+func ToGraphAdjacency(content string) (string, error) {
+	graph, err := parseGraph(content)
+	if err != nil {
+		return "", err
+	}
+	adjacency := GraphAdjacency{
+		Nodes: make([]AdjacencyNode, 0, len(graph.Elements.Nodes)),
+		Edges: make([]AdjacencyEdge, 0, len(graph.Elements.Edges)),
+	}
+	for _, n := range graph.Elements.Nodes {
+		if n.isBox() {
+			continue
+		}
+		adjacency.Nodes = append(adjacency.Nodes, AdjacencyNode{ID: n.field("id"), Name: graphNodeLocalName(n.Data), Namespace: n.field("namespace"), Kind: n.field("nodeType")})
+	}
+	for _, e := range graph.Elements.Edges {
+		rate, errorRate := e.rates()
+		edge := AdjacencyEdge{From: e.Data.Source, To: e.Data.Target, Protocol: e.protocol(), RPS: rate, ErrorRate: errorRate}
+		if e.Data.ResponseTime != "" {
+			responseTime := parseRate(e.Data.ResponseTime)
+			edge.ResponseTimeMs = &responseTime
+		}
+		adjacency.Edges = append(adjacency.Edges, edge)
+	}
+	result, err := json.MarshalIndent(adjacency, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal graph adjacency: %v", err)
+	}
+	return string(result), nil
 }
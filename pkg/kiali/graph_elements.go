@@ -0,0 +1,94 @@
+package kiali
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// kialiGraph is the decoded cytoscape elements of a Kiali graph response.
+type kialiGraph struct {
+	Elements struct {
+		Nodes []graphNode `json:"nodes"`
+		Edges []graphEdge `json:"edges"`
+	} `json:"elements"`
+}
+
+// graphNode is a node of a Kiali graph. Its data is kept as a map, as its fields depend on the node type.
+type graphNode struct {
+	Data map[string]any `json:"data"`
+}
+
+// graphEdge is an edge of a Kiali graph. IsMTLS is the percentage of mTLS traffic, and ResponseTime the response
+// time in milliseconds, set only when the graph was requested with the responseTime appender.
+type graphEdge struct {
+	Data struct {
+		Source       string `json:"source"`
+		Target       string `json:"target"`
+		IsMTLS       string `json:"isMTLS"`
+		ResponseTime string `json:"responseTime"`
+		Traffic      struct {
+			Protocol string            `json:"protocol"`
+			Rates    map[string]string `json:"rates"`
+		} `json:"traffic"`
+	} `json:"data"`
+}
+
+// parseGraph decodes the elements of a Kiali graph response.
+func parseGraph(content string) (*kialiGraph, error) {
+	var graph kialiGraph
+	if err := json.Unmarshal([]byte(content), &graph); err != nil {
+		return nil, fmt.Errorf("failed to parse graph: %v", err)
+	}
+	return &graph, nil
+}
+
+// nodeNames returns the readable names of the nodes of the graph, by node id.
+func (g *kialiGraph) nodeNames() graphNodeNames {
+	names := graphNodeNames{}
+	for _, n := range g.Elements.Nodes {
+		if id := n.field("id"); id != "" {
+			names[id] = graphNodeName(n.Data)
+		}
+	}
+	return names
+}
+
+// graphNodeNames are the readable names of graph nodes, by node id.
+type graphNodeNames map[string]string
+
+// name returns the readable name of the node, its id when the node is not part of the graph.
+func (names graphNodeNames) name(id string) string {
+	if name, ok := names[id]; ok {
+		return name
+	}
+	return id
+}
+
+// field returns the string field of the node data, empty when not set.
+func (n graphNode) field(key string) string {
+	value, _ := n.Data[key].(string)
+	return value
+}
+
+// isBox reports whether the node is a box (group) node, which is not part of the call graph.
+func (n graphNode) isBox() bool {
+	return n.field("isBox") != ""
+}
+
+// protocol returns the lower-cased protocol of the traffic of the edge.
+func (e graphEdge) protocol() string {
+	return strings.ToLower(e.Data.Traffic.Protocol)
+}
+
+// rates returns the rate of the edge, in requests per second for HTTP and gRPC and bytes per second for TCP, and
+// for HTTP and gRPC the percentage of failed requests, nil for the other protocols.
+func (e graphEdge) rates() (float64, *float64) {
+	protocol := e.protocol()
+	rate := parseRate(e.Data.Traffic.Rates[protocol])
+	if protocol != "http" && protocol != "grpc" {
+		return rate, nil
+	}
+	errorRate := parseRate(e.Data.Traffic.Rates[protocol+"PercentErr"])
+	return rate, &errorRate
+}
@@ -0,0 +1,86 @@
+package kiali
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GraphTypes lists the graph types of the Kiali UI.
+var GraphTypes = []string{"app", "versionedApp", "workload", "service"}
+
+// GraphLink is a link to a graph view of the Kiali UI. Note is set when the link is unlikely to open in a
+// browser, e.g. when the Kiali URL is an in-cluster address.
+type GraphLink struct {
+	URL        string   `json:"url"`
+	Namespaces []string `json:"namespaces"`
+	GraphType  string   `json:"graphType"`
+	Duration   string   `json:"duration"`
+	Note       string   `json:"note,omitempty"`
+}
+
+// GraphLink returns, as JSON, the URL of the Kiali UI graph view of the namespaces, so the graph analyzed through
+// the API can be opened in the UI. No request is made to Kiali.
+// Parameters:
+//   - namespaces: the namespaces shown in the graph (optional, the UI keeps its current selection when empty)
+//   - graphType: one of GraphTypes (optional, default: "versionedApp")
+//   - duration: the window of traffic the graph reflects, as a Go duration (optional, default: DefaultGraphDuration)
+func (k *Kiali) GraphLink(namespaces []string, graphType string, duration string) (string, error) {
+	baseURL, err := k.validateAndGetBaseURL()
+	if err != nil {
+		return "", err
+	}
+	if graphType == "" {
+		graphType = "versionedApp"
+	}
+	if !slices.Contains(GraphTypes, graphType) {
+		return "", fmt.Errorf("invalid graph type '%s': must be one of %s", graphType, strings.Join(GraphTypes, ", "))
+	}
+	if duration == "" {
+		duration = DefaultGraphDuration
+	}
+	d, err := time.ParseDuration(duration)
+	if err != nil || d < time.Second {
+		return "", fmt.Errorf("invalid duration '%s': must be a duration of at least 1s (e.g. 5m, 1h)", duration)
+	}
+
+	u, err := url.Parse(strings.TrimRight(baseURL, "/") + "/console/graph/namespaces/")
+	if err != nil {
+		return "", err
+	}
+	q := u.Query()
+	// The Kiali UI takes the duration in seconds
+	q.Set("duration", strconv.Itoa(int(d.Seconds())))
+	q.Set("graphType", graphType)
+	if len(namespaces) > 0 {
+		q.Set("namespaces", strings.Join(namespaces, ","))
+	}
+	u.RawQuery = q.Encode()
+
+	link := &GraphLink{URL: u.String(), Namespaces: namespaces, GraphType: graphType, Duration: d.String()}
+	if link.Namespaces == nil {
+		link.Namespaces = make([]string, 0)
+	}
+	if host := u.Hostname(); k.isInClusterHost(host) {
+		link.Note = fmt.Sprintf("the Kiali URL host '%s' looks like an in-cluster address: the link may need the external Kiali route or a port-forward to open in a browser", host)
+	}
+	result, err := json.MarshalIndent(link, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal graph link: %v", err)
+	}
+	return string(result), nil
+}
+
+// isInClusterHost reports whether the host is a Kubernetes service address, only resolvable inside the cluster,
+// such as the <kiali_service_name>.<kiali_namespace> address of kiali_in_cluster.
+func (k *Kiali) isInClusterHost(host string) bool {
+	if host == "localhost" {
+		return false
+	}
+	namespace, name := k.manager.staticConfig.KialiService()
+	return !strings.Contains(host, ".") || host == name+"."+namespace || strings.HasSuffix(host, ".svc") || strings.HasSuffix(host, ".svc.cluster.local")
+}
@@ -2,8 +2,12 @@ package kiali
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/url"
 	"strings"
+
+	"golang.org/x/sync/errgroup"
 )
 
 // Health returns health status for apps, workloads, and services across namespaces.
@@ -13,6 +17,8 @@ import (
 //   - type: health type - "app", "service", or "workload" (default: "app")
 //   - rateInterval: rate interval for fetching error rate (default: "10m")
 //   - queryTime: Unix timestamp for the prometheus query (optional)
+//   - clusterName: cluster of the namespaces in multi-cluster meshes, so Kiali queries the Prometheus of that
+//     cluster (optional, default: the Kiali home cluster). An unknown cluster is rejected before querying the health
 func (k *Kiali) Health(ctx context.Context, namespaces string, queryParams map[string]string) (string, error) {
 	baseURL, err := k.validateAndGetBaseURL()
 	if err != nil {
@@ -28,20 +34,113 @@ func (k *Kiali) Health(ctx context.Context, namespaces string, queryParams map[s
 	}
 	q := u.Query()
 
-	// Add namespaces if provided
-	if namespaces != "" {
-		q.Set("namespaces", namespaces)
-	}
-
 	// Add optional query parameters
-	if len(queryParams) > 0 {
-		for key, value := range queryParams {
-			q.Set(key, value)
-		}
-	}
+	k.setQueryParams(q, queryParams)
+	// Namespaces are only taken from the dedicated argument
+	q.Del(k.queryParam("namespaces"))
+	// The cluster is set once validated, below
+	q.Del(k.queryParam("clusterName"))
 
 	u.RawQuery = q.Encode()
+	// Add namespaces if provided
+	k.addNamespacesQuery(u, namespaces)
 	endpoint = u.String()
+	if endpoint, err = k.withCluster(ctx, endpoint, queryParams["clusterName"]); err != nil {
+		return "", err
+	}
 
+	// Health requests are expensive for Kiali, the parallel ones are bounded by kiali_health_concurrency
+	release, err := k.acquireHealthSlot(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer release()
 	return k.executeRequest(ctx, endpoint)
 }
+
+// HealthPartial returns health like Health, but when Kiali rejects a multi-namespace request with a 404 or a
+// NamespaceNotAccessibleError (e.g. because one of the namespaces does not exist or cannot be accessed), it
+// retries each namespace on its own and merges the successful responses. The namespaces that still failed are
+// reported in a "failedNamespaces" field, mapped to their error. The original error is returned when every namespace fails.
+// Parameters:
+//   - namespaces: comma-separated list of namespaces (optional, if empty returns health for all accessible namespaces)
+//   - queryParams: optional query parameters map for filtering health data (e.g., "type", "rateInterval", "queryTime")
+func (k *Kiali) HealthPartial(ctx context.Context, namespaces string, queryParams map[string]string) (string, error) {
+	content, err := k.Health(ctx, namespaces, queryParams)
+	list := splitNamespaces(namespaces)
+	if err == nil || !(IsNotFound(err) || IsNamespaceNotAccessible(err)) || len(list) < 2 {
+		return content, err
+	}
+
+	contents := make([]string, len(list))
+	errs := make([]error, len(list))
+	g, gctx := errgroup.WithContext(ctx)
+	for i, ns := range list {
+		g.Go(func() error {
+			contents[i], errs[i] = k.Health(gctx, ns, queryParams)
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	merged := map[string]map[string]json.RawMessage{}
+	failed := map[string]string{}
+	for i, ns := range list {
+		if errs[i] != nil {
+			failed[ns] = errs[i].Error()
+			continue
+		}
+		var health map[string]map[string]json.RawMessage
+		if err := json.Unmarshal([]byte(contents[i]), &health); err != nil {
+			failed[ns] = fmt.Sprintf("failed to parse health: %v", err)
+			continue
+		}
+		for field, byNamespace := range health {
+			if merged[field] == nil {
+				merged[field] = map[string]json.RawMessage{}
+			}
+			for key, value := range byNamespace {
+				merged[field][key] = value
+			}
+		}
+	}
+	if len(failed) == len(list) {
+		return "", err
+	}
+	result := make(map[string]any, len(merged)+1)
+	for field, byNamespace := range merged {
+		result[field] = byNamespace
+	}
+	if len(failed) > 0 {
+		result["failedNamespaces"] = failed
+	}
+	ret, err := json.Marshal(result)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal health: %v", err)
+	}
+	return string(ret), nil
+}
+
+// UnhealthyApps returns, as JSON, the apps whose computed health is DEGRADED or UNHEALTHY, along with
+// the issues that caused it (unavailable replicas, unsynced proxies, request error ratios).
+// Apps are sorted by decreasing severity, then by namespace and name.
+// Parameters:
+//   - namespaces: comma-separated list of namespaces (optional, if empty checks all accessible namespaces)
+//   - rateInterval: rate interval for fetching error rate (optional, default: "10m")
+func (k *Kiali) UnhealthyApps(ctx context.Context, namespaces string, rateInterval string) (string, error) {
+	apps, err := k.evaluateHealth(ctx, namespaces, "app", rateInterval)
+	if err != nil {
+		return "", err
+	}
+	unhealthy := make([]EntityHealth, 0)
+	for _, app := range apps {
+		if isFailingStatus(app.Status) {
+			unhealthy = append(unhealthy, app)
+		}
+	}
+	result, err := json.MarshalIndent(unhealthy, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal unhealthy apps: %v", err)
+	}
+	return string(result), nil
+}
@@ -0,0 +1,342 @@
+package kiali
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"regexp"
+	"slices"
+	"sort"
+
+	"k8s.io/utils/ptr"
+)
+
+// HealthStatus is the health status computed client-side from the raw Kiali health data.
+type HealthStatus string
+
+const (
+	HealthStatusHealthy   HealthStatus = "HEALTHY"
+	HealthStatusDegraded  HealthStatus = "DEGRADED"
+	HealthStatusUnhealthy HealthStatus = "UNHEALTHY"
+	HealthStatusNotReady  HealthStatus = "NOT_READY"
+	HealthStatusNA        HealthStatus = "NA"
+)
+
+// healthStatusPriority orders the statuses from the least to the most severe.
+var healthStatusPriority = map[HealthStatus]int{
+	HealthStatusNA:        0,
+	HealthStatusHealthy:   1,
+	HealthStatusNotReady:  2,
+	HealthStatusDegraded:  3,
+	HealthStatusUnhealthy: 4,
+}
+
+// WorkloadStatus is the replica and proxy status of a workload as reported by Kiali.
+// SyncedProxies is negative (or missing) when the proxy status is not tracked.
+type WorkloadStatus struct {
+	Name              string `json:"name"`
+	DesiredReplicas   int32  `json:"desiredReplicas"`
+	CurrentReplicas   int32  `json:"currentReplicas"`
+	AvailableReplicas int32  `json:"availableReplicas"`
+	SyncedProxies     *int32 `json:"syncedProxies,omitempty"`
+}
+
+// RequestHealth holds the inbound and outbound request rates, keyed by protocol and response code, and the
+// health annotations of the entity.
+type RequestHealth struct {
+	Inbound           map[string]map[string]float64 `json:"inbound"`
+	Outbound          map[string]map[string]float64 `json:"outbound"`
+	HealthAnnotations map[string]string             `json:"healthAnnotations,omitempty"`
+
+	// tolerances configured in Kiali for the entity, nil to use the defaults
+	tolerances []errorTolerance
+}
+
+// AppHealth is the raw health data of an app.
+type AppHealth struct {
+	WorkloadStatuses []*WorkloadStatus `json:"workloadStatuses"`
+	Requests         RequestHealth     `json:"requests"`
+}
+
+// WorkloadHealth is the raw health data of a workload.
+type WorkloadHealth struct {
+	WorkloadStatus *WorkloadStatus `json:"workloadStatus"`
+	Requests       RequestHealth   `json:"requests"`
+}
+
+// ServiceHealth is the raw health data of a service.
+type ServiceHealth struct {
+	Requests RequestHealth `json:"requests"`
+}
+
+// ClustersHealth is the response of the Kiali clusters health API, keyed by namespace and then by entity name.
+type ClustersHealth struct {
+	AppHealth      map[string]map[string]*AppHealth      `json:"namespaceAppHealth,omitempty"`
+	WorkloadHealth map[string]map[string]*WorkloadHealth `json:"namespaceWorkloadHealth,omitempty"`
+	ServiceHealth  map[string]map[string]*ServiceHealth  `json:"namespaceServiceHealth,omitempty"`
+}
+
+// EntityHealth is the computed health of an app, workload or service.
+// ErrorRate is the percentage of failed inbound HTTP/gRPC requests, only set when there is inbound traffic.
+type EntityHealth struct {
+	Namespace string       `json:"namespace"`
+	Name      string       `json:"name"`
+	Type      string       `json:"type"`
+	Status    HealthStatus `json:"status"`
+	ErrorRate *float64     `json:"errorRate,omitempty"`
+	Issues    []string     `json:"issues,omitempty"`
+
+	// inbound request and error rates (requests/s), used to aggregate the mesh error rate
+	inboundRequests float64
+	inboundErrors   float64
+	// available and desired replicas of the workloads, zero for services
+	availableReplicas int32
+	desiredReplicas   int32
+}
+
+// errorTolerance is the error ratio (in percent) above which requests with matching codes degrade the health.
+// A nil direction matches both directions.
+type errorTolerance struct {
+	protocol  string
+	code      *regexp.Regexp
+	direction *regexp.Regexp
+	label     string
+	degraded  float64
+	failure   float64
+}
+
+// defaultErrorTolerances mirrors the default Kiali health configuration for request errors.
+var defaultErrorTolerances = []errorTolerance{
+	{protocol: "http", code: regexp.MustCompile(`^(5\d\d|-)$`), label: "HTTP 5xx", degraded: 0, failure: 10},
+	{protocol: "http", code: regexp.MustCompile(`^4\d\d$`), label: "HTTP 4xx", degraded: 10, failure: 20},
+	{protocol: "grpc", code: regexp.MustCompile(`^([1-9]|1[0-6]|-)$`), label: "gRPC", degraded: 0, failure: 10},
+}
+
+// parseClustersHealth parses the response of the Kiali clusters health API.
+// The short `appHealth`, `workloadHealth` and `serviceHealth` keys are accepted as well.
+func parseClustersHealth(content string) (*ClustersHealth, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(content), &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse health: %v", err)
+	}
+	health := &ClustersHealth{}
+	targets := map[string]any{
+		"namespaceAppHealth":      &health.AppHealth,
+		"appHealth":               &health.AppHealth,
+		"namespaceWorkloadHealth": &health.WorkloadHealth,
+		"workloadHealth":          &health.WorkloadHealth,
+		"namespaceServiceHealth":  &health.ServiceHealth,
+		"serviceHealth":           &health.ServiceHealth,
+	}
+	for _, key := range slices.Sorted(maps.Keys(targets)) {
+		value, ok := raw[key]
+		if !ok || string(value) == "null" {
+			continue
+		}
+		if err := json.Unmarshal(value, targets[key]); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %v", key, err)
+		}
+	}
+	return health, nil
+}
+
+// evaluateAppHealth computes the health of an app from its workloads and requests.
+func evaluateAppHealth(namespace, name string, health *AppHealth) EntityHealth {
+	ret := EntityHealth{Namespace: namespace, Name: name, Type: "app", Status: HealthStatusNA}
+	if health == nil {
+		return ret
+	}
+	for _, ws := range health.WorkloadStatuses {
+		ret.addReplicas(ws)
+		status, issue := evaluateWorkloadStatus(ws)
+		ret.Status = mergeHealthStatus(ret.Status, status)
+		if issue != "" {
+			ret.Issues = append(ret.Issues, issue)
+		}
+	}
+	applyRequestHealth(&ret, health.Requests)
+	return ret
+}
+
+// evaluateWorkloadHealth computes the health of a workload from its status and requests.
+func evaluateWorkloadHealth(namespace, name string, health *WorkloadHealth) EntityHealth {
+	ret := EntityHealth{Namespace: namespace, Name: name, Type: "workload", Status: HealthStatusNA}
+	if health == nil {
+		return ret
+	}
+	ret.addReplicas(health.WorkloadStatus)
+	status, issue := evaluateWorkloadStatus(health.WorkloadStatus)
+	ret.Status = mergeHealthStatus(ret.Status, status)
+	if issue != "" {
+		ret.Issues = append(ret.Issues, issue)
+	}
+	applyRequestHealth(&ret, health.Requests)
+	return ret
+}
+
+// evaluateServiceHealth computes the health of a service from its requests.
+func evaluateServiceHealth(namespace, name string, health *ServiceHealth) EntityHealth {
+	ret := EntityHealth{Namespace: namespace, Name: name, Type: "service", Status: HealthStatusNA}
+	if health == nil {
+		return ret
+	}
+	applyRequestHealth(&ret, health.Requests)
+	return ret
+}
+
+// applyRequestHealth merges the request health into the entity status and records its inbound error rate.
+func applyRequestHealth(entity *EntityHealth, requests RequestHealth) {
+	status, issues := evaluateRequestHealth(requests)
+	entity.Status = mergeHealthStatus(entity.Status, status)
+	entity.Issues = append(entity.Issues, issues...)
+	entity.inboundRequests, entity.inboundErrors = inboundTraffic(requests)
+	if entity.inboundRequests > 0 {
+		entity.ErrorRate = ptr.To(roundPercentage(entity.inboundErrors / entity.inboundRequests * 100))
+	}
+}
+
+// addReplicas adds the replicas of a workload to the entity.
+func (e *EntityHealth) addReplicas(ws *WorkloadStatus) {
+	if ws != nil {
+		e.availableReplicas += ws.AvailableReplicas
+		e.desiredReplicas += ws.DesiredReplicas
+	}
+}
+
+// evaluateWorkloadStatus computes the health of a workload from its replicas and synced proxies.
+// An issue description is returned for any status other than healthy.
+func evaluateWorkloadStatus(ws *WorkloadStatus) (HealthStatus, string) {
+	if ws == nil {
+		return HealthStatusNA, ""
+	}
+	switch {
+	case ws.DesiredReplicas == 0 && ws.CurrentReplicas == 0:
+		return HealthStatusNotReady, fmt.Sprintf("workload %s is scaled down to 0 replicas", ws.Name)
+	case ws.AvailableReplicas == 0:
+		return HealthStatusUnhealthy, fmt.Sprintf("workload %s has no available replicas (0/%d)", ws.Name, ws.DesiredReplicas)
+	case ws.AvailableReplicas < ws.DesiredReplicas:
+		return HealthStatusDegraded, fmt.Sprintf("workload %s has %d/%d replicas available", ws.Name, ws.AvailableReplicas, ws.DesiredReplicas)
+	case ws.SyncedProxies != nil && *ws.SyncedProxies >= 0 && *ws.SyncedProxies < ws.AvailableReplicas:
+		return HealthStatusDegraded, fmt.Sprintf("workload %s has %d/%d proxies synced", ws.Name, *ws.SyncedProxies, ws.AvailableReplicas)
+	}
+	return HealthStatusHealthy, ""
+}
+
+// evaluateRequestHealth computes the health from the inbound and outbound request error ratios, using the
+// tolerances of the entity (see RequestHealth.errorTolerances). Directions without traffic do not contribute
+// to the status.
+func evaluateRequestHealth(requests RequestHealth) (HealthStatus, []string) {
+	status := HealthStatusNA
+	issues := make([]string, 0)
+	tolerances := requests.errorTolerances()
+	for _, direction := range []struct {
+		name  string
+		rates map[string]map[string]float64
+	}{{"inbound", requests.Inbound}, {"outbound", requests.Outbound}} {
+		for _, tolerance := range tolerances {
+			if tolerance.direction != nil && !tolerance.direction.MatchString(direction.name) {
+				continue
+			}
+			codes := direction.rates[tolerance.protocol]
+			ratio, ok := errorRatio(codes, tolerance.code)
+			if !ok {
+				continue
+			}
+			current := getStatusForCodeRatio(ratio, tolerance)
+			status = mergeHealthStatus(status, current)
+			if current != HealthStatusHealthy {
+				issues = append(issues, fmt.Sprintf("%.2f%% %s errors on %s requests", ratio, tolerance.label, direction.name))
+			}
+		}
+	}
+	return status, issues
+}
+
+// inboundTraffic returns the total and failed inbound HTTP/gRPC request rates.
+func inboundTraffic(requests RequestHealth) (float64, float64) {
+	var total, failed float64
+	tolerances := requests.errorTolerances()
+	for protocol, codes := range requests.Inbound {
+		for code, rate := range codes {
+			if protocol != "http" && protocol != "grpc" {
+				continue
+			}
+			total += rate
+			for _, tolerance := range tolerances {
+				if tolerance.direction != nil && !tolerance.direction.MatchString("inbound") {
+					continue
+				}
+				if tolerance.protocol == protocol && tolerance.code.MatchString(code) {
+					failed += rate
+					break
+				}
+			}
+		}
+	}
+	return total, failed
+}
+
+// roundPercentage rounds a percentage to two decimals.
+func roundPercentage(value float64) float64 {
+	return roundTwoDecimals(value)
+}
+
+// roundTwoDecimals rounds a value to two decimals.
+func roundTwoDecimals(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+// errorRatio returns the percentage of requests whose response code matches the given pattern.
+// The second value is false when there is no traffic.
+func errorRatio(codes map[string]float64, pattern *regexp.Regexp) (float64, bool) {
+	var total, failed float64
+	for code, rate := range codes {
+		total += rate
+		if pattern.MatchString(code) {
+			failed += rate
+		}
+	}
+	if total <= 0 {
+		return 0, false
+	}
+	return failed / total * 100, true
+}
+
+// getStatusForCodeRatio maps an error ratio (in percent) to a health status using the given tolerance, as the
+// function of the same name of the Kiali frontend.
+func getStatusForCodeRatio(ratio float64, tolerance errorTolerance) HealthStatus {
+	switch {
+	case ratio >= tolerance.failure:
+		return HealthStatusUnhealthy
+	case ratio > tolerance.degraded:
+		return HealthStatusDegraded
+	}
+	return HealthStatusHealthy
+}
+
+// mergeHealthStatus returns the most severe of the two statuses.
+func mergeHealthStatus(a, b HealthStatus) HealthStatus {
+	if healthStatusPriority[b] > healthStatusPriority[a] {
+		return b
+	}
+	return a
+}
+
+// isFailingStatus reports whether the status is degraded or unhealthy.
+func isFailingStatus(status HealthStatus) bool {
+	return status == HealthStatusDegraded || status == HealthStatusUnhealthy
+}
+
+// sortEntityHealth sorts entities by decreasing severity, then by namespace and name.
+func sortEntityHealth(entities []EntityHealth) {
+	sort.SliceStable(entities, func(i, j int) bool {
+		if pi, pj := healthStatusPriority[entities[i].Status], healthStatusPriority[entities[j].Status]; pi != pj {
+			return pi > pj
+		}
+		if entities[i].Namespace != entities[j].Namespace {
+			return entities[i].Namespace < entities[j].Namespace
+		}
+		return entities[i].Name < entities[j].Name
+	})
+}
@@ -0,0 +1,237 @@
+package kiali
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+)
+
+func httpRequests(codes map[string]float64) map[string]map[string]float64 {
+	return map[string]map[string]float64{"http": codes}
+}
+
+func TestGetStatusForCodeRatio(t *testing.T) {
+	http5xx, http4xx, grpc := defaultErrorTolerances[0], defaultErrorTolerances[1], defaultErrorTolerances[2]
+	tests := []struct {
+		name      string
+		ratio     float64
+		tolerance errorTolerance
+		expected  HealthStatus
+	}{
+		{name: "5xx no errors", ratio: 0, tolerance: http5xx, expected: HealthStatusHealthy},
+		{name: "5xx any error degrades", ratio: 0.01, tolerance: http5xx, expected: HealthStatusDegraded},
+		{name: "5xx just below failure", ratio: 9.99, tolerance: http5xx, expected: HealthStatusDegraded},
+		{name: "5xx exactly 10% fails", ratio: 10, tolerance: http5xx, expected: HealthStatusUnhealthy},
+		{name: "4xx exactly 10% is tolerated", ratio: 10, tolerance: http4xx, expected: HealthStatusHealthy},
+		{name: "4xx just above 10% degrades", ratio: 10.01, tolerance: http4xx, expected: HealthStatusDegraded},
+		{name: "4xx just below 20%", ratio: 19.99, tolerance: http4xx, expected: HealthStatusDegraded},
+		{name: "4xx exactly 20% fails", ratio: 20, tolerance: http4xx, expected: HealthStatusUnhealthy},
+		{name: "gRPC exactly 10% fails", ratio: 10, tolerance: grpc, expected: HealthStatusUnhealthy},
+		{name: "100% errors", ratio: 100, tolerance: http5xx, expected: HealthStatusUnhealthy},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, getStatusForCodeRatio(tt.ratio, tt.tolerance))
+		})
+	}
+}
+
+func TestMergeHealthStatus(t *testing.T) {
+	tests := []struct {
+		a, b     HealthStatus
+		expected HealthStatus
+	}{
+		{a: HealthStatusNA, b: HealthStatusHealthy, expected: HealthStatusHealthy},
+		{a: HealthStatusHealthy, b: HealthStatusNA, expected: HealthStatusHealthy},
+		{a: HealthStatusHealthy, b: HealthStatusNotReady, expected: HealthStatusNotReady},
+		{a: HealthStatusNotReady, b: HealthStatusDegraded, expected: HealthStatusDegraded},
+		{a: HealthStatusUnhealthy, b: HealthStatusDegraded, expected: HealthStatusUnhealthy},
+		{a: HealthStatusDegraded, b: HealthStatusUnhealthy, expected: HealthStatusUnhealthy},
+		{a: HealthStatusNA, b: HealthStatusNA, expected: HealthStatusNA},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.a)+"+"+string(tt.b), func(t *testing.T) {
+			assert.Equal(t, tt.expected, mergeHealthStatus(tt.a, tt.b))
+		})
+	}
+}
+
+func TestEvaluateRequestHealth(t *testing.T) {
+	tests := []struct {
+		name           string
+		requests       RequestHealth
+		expected       HealthStatus
+		expectedIssues []string
+	}{
+		{
+			name:     "no traffic",
+			requests: RequestHealth{},
+			expected: HealthStatusNA,
+		},
+		{
+			name:           "exactly 10% inbound 5xx",
+			requests:       RequestHealth{Inbound: httpRequests(map[string]float64{"200": 9, "500": 1})},
+			expected:       HealthStatusUnhealthy,
+			expectedIssues: []string{"10.00% HTTP 5xx errors on inbound requests"},
+		},
+		{
+			name:           "exactly 20% inbound 4xx",
+			requests:       RequestHealth{Inbound: httpRequests(map[string]float64{"200": 8, "404": 2})},
+			expected:       HealthStatusUnhealthy,
+			expectedIssues: []string{"20.00% HTTP 4xx errors on inbound requests"},
+		},
+		{
+			name:     "exactly 10% inbound 4xx",
+			requests: RequestHealth{Inbound: httpRequests(map[string]float64{"200": 9, "404": 1})},
+			expected: HealthStatusHealthy,
+		},
+		{
+			name: "healthy inbound, degraded outbound",
+			requests: RequestHealth{
+				Inbound:  httpRequests(map[string]float64{"200": 10}),
+				Outbound: httpRequests(map[string]float64{"200": 19, "503": 1}),
+			},
+			expected:       HealthStatusDegraded,
+			expectedIssues: []string{"5.00% HTTP 5xx errors on outbound requests"},
+		},
+		{
+			name: "degraded inbound, unhealthy outbound gRPC",
+			requests: RequestHealth{
+				Inbound:  httpRequests(map[string]float64{"200": 19, "-": 1}),
+				Outbound: map[string]map[string]float64{"grpc": {"0": 1, "14": 1}},
+			},
+			expected: HealthStatusUnhealthy,
+			expectedIssues: []string{
+				"5.00% HTTP 5xx errors on inbound requests",
+				"50.00% gRPC errors on outbound requests",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, issues := evaluateRequestHealth(tt.requests)
+			assert.Equal(t, tt.expected, status)
+			if tt.expectedIssues == nil {
+				assert.Empty(t, issues)
+			} else {
+				assert.Equal(t, tt.expectedIssues, issues)
+			}
+		})
+	}
+}
+
+func TestEvaluateAppHealth(t *testing.T) {
+	tests := []struct {
+		name              string
+		health            *AppHealth
+		expected          HealthStatus
+		expectedErrorRate *float64
+	}{
+		{
+			name:     "no health data",
+			health:   nil,
+			expected: HealthStatusNA,
+		},
+		{
+			name: "scaled to zero",
+			health: &AppHealth{WorkloadStatuses: []*WorkloadStatus{
+				{Name: "reviews-v1", DesiredReplicas: 0, CurrentReplicas: 0, AvailableReplicas: 0},
+			}},
+			expected: HealthStatusNotReady,
+		},
+		{
+			name: "scaled to zero workload does not hide an unavailable one",
+			health: &AppHealth{WorkloadStatuses: []*WorkloadStatus{
+				{Name: "reviews-v1", DesiredReplicas: 0, CurrentReplicas: 0, AvailableReplicas: 0},
+				{Name: "reviews-v2", DesiredReplicas: 1, CurrentReplicas: 1, AvailableReplicas: 0},
+			}},
+			expected: HealthStatusUnhealthy,
+		},
+		{
+			name: "missing synced proxies degrade",
+			health: &AppHealth{WorkloadStatuses: []*WorkloadStatus{
+				{Name: "reviews-v1", DesiredReplicas: 2, CurrentReplicas: 2, AvailableReplicas: 2, SyncedProxies: ptr.To(int32(1))},
+			}},
+			expected: HealthStatusDegraded,
+		},
+		{
+			name: "untracked proxy status is ignored",
+			health: &AppHealth{WorkloadStatuses: []*WorkloadStatus{
+				{Name: "reviews-v1", DesiredReplicas: 2, CurrentReplicas: 2, AvailableReplicas: 2, SyncedProxies: ptr.To(int32(-1))},
+			}},
+			expected: HealthStatusHealthy,
+		},
+		{
+			name: "healthy replicas with exactly 10% inbound errors",
+			health: &AppHealth{
+				WorkloadStatuses: []*WorkloadStatus{{Name: "reviews-v1", DesiredReplicas: 1, CurrentReplicas: 1, AvailableReplicas: 1}},
+				Requests:         RequestHealth{Inbound: httpRequests(map[string]float64{"200": 9, "500": 1})},
+			},
+			expected:          HealthStatusUnhealthy,
+			expectedErrorRate: ptr.To(10.0),
+		},
+		{
+			name: "outbound errors do not count towards the error rate",
+			health: &AppHealth{
+				WorkloadStatuses: []*WorkloadStatus{{Name: "reviews-v1", DesiredReplicas: 1, CurrentReplicas: 1, AvailableReplicas: 1}},
+				Requests: RequestHealth{
+					Inbound:  httpRequests(map[string]float64{"200": 10}),
+					Outbound: httpRequests(map[string]float64{"503": 10}),
+				},
+			},
+			expected:          HealthStatusUnhealthy,
+			expectedErrorRate: ptr.To(0.0),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			health := evaluateAppHealth("bookinfo", "reviews", tt.health)
+			assert.Equal(t, "app", health.Type)
+			assert.Equal(t, tt.expected, health.Status)
+			assert.Equal(t, tt.expectedErrorRate, health.ErrorRate)
+		})
+	}
+}
+
+func TestRequestErrorTolerances(t *testing.T) {
+	configured, err := newErrorTolerances("4XX", 30, 40, "http", "inbound")
+	assert.NoError(t, err)
+
+	t.Run("defaults without annotation nor configuration", func(t *testing.T) {
+		assert.Equal(t, defaultErrorTolerances, RequestHealth{}.errorTolerances())
+	})
+
+	t.Run("configured tolerances", func(t *testing.T) {
+		requests := RequestHealth{Inbound: httpRequests(map[string]float64{"200": 7, "404": 3}), tolerances: configured}
+		status, issues := evaluateRequestHealth(requests)
+		assert.Equal(t, HealthStatusHealthy, status)
+		assert.Empty(t, issues)
+	})
+
+	t.Run("annotation takes precedence", func(t *testing.T) {
+		requests := RequestHealth{
+			Inbound:           httpRequests(map[string]float64{"200": 7, "404": 3}),
+			HealthAnnotations: map[string]string{healthRateAnnotation: "4XX,10,20,http,inbound;5XX,0,10,http,.*"},
+			tolerances:        configured,
+		}
+		status, issues := evaluateRequestHealth(requests)
+		assert.Equal(t, HealthStatusUnhealthy, status)
+		assert.Equal(t, []string{"30.00% HTTP 4xx errors on inbound requests"}, issues)
+	})
+
+	t.Run("tolerances only apply to their direction", func(t *testing.T) {
+		requests := RequestHealth{
+			Outbound:          httpRequests(map[string]float64{"200": 7, "404": 3}),
+			HealthAnnotations: map[string]string{healthRateAnnotation: "4XX,10,20,http,inbound"},
+		}
+		status, issues := evaluateRequestHealth(requests)
+		assert.Equal(t, HealthStatusNA, status)
+		assert.Empty(t, issues)
+	})
+
+	t.Run("invalid annotation is ignored", func(t *testing.T) {
+		requests := RequestHealth{HealthAnnotations: map[string]string{healthRateAnnotation: "4XX,ten,20,http,inbound"}, tolerances: configured}
+		assert.Equal(t, configured, requests.errorTolerances())
+	})
+}
@@ -0,0 +1,270 @@
+package kiali
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// healthRateAnnotation is the annotation overriding the request error tolerances of an entity, as a ";"
+// separated list of "<CODE>,<DEGRADED>,<FAILURE>,<PROTOCOL>,<DIRECTION>" tolerances (e.g. "4XX,10,20,http,inbound").
+const healthRateAnnotation = "health.kiali.io/rate"
+
+// healthRateRule is an entry of the Kiali health configuration: the tolerances apply to the entities whose
+// namespace, kind and name match the patterns.
+type healthRateRule struct {
+	namespace  *regexp.Regexp
+	kind       *regexp.Regexp
+	name       *regexp.Regexp
+	tolerances []errorTolerance
+}
+
+// matches reports whether the rule applies to the entity.
+func (r healthRateRule) matches(namespace, kind, name string) bool {
+	return r.namespace.MatchString(namespace) && r.kind.MatchString(kind) && r.name.MatchString(name)
+}
+
+// healthRatesCacheTTL is how long the health configuration fetched from Kiali is reused. The configuration is the
+// same for every caller and rarely changes, while every health computation needs it.
+const healthRatesCacheTTL = time.Minute
+
+// healthRatesCache keeps the request error tolerances of the Kiali health configuration.
+type healthRatesCache struct {
+	mu      sync.Mutex
+	rules   []healthRateRule
+	expires time.Time
+}
+
+// healthRates returns the request error tolerances configured in Kiali (healthConfig.rate of the Kiali
+// configuration), fetched at most once per healthRatesCacheTTL. Nil is returned when the configuration is
+// unavailable or has no rate, the default tolerances then apply.
+func (k *Kiali) healthRates(ctx context.Context) []healthRateRule {
+	cache := &k.manager.healthRates
+	// The lock is held while fetching, so that concurrent health computations share a single request
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if time.Now().Before(cache.expires) {
+		return cache.rules
+	}
+	rules, cacheable := k.fetchHealthRates(ctx)
+	if cacheable {
+		cache.rules, cache.expires = rules, time.Now().Add(healthRatesCacheTTL)
+	}
+	return rules
+}
+
+// fetchHealthRates fetches the request error tolerances configured in Kiali. It also reports whether the result
+// may be cached: transient failures are not, so that the configuration is fetched again by the next call.
+func (k *Kiali) fetchHealthRates(ctx context.Context) ([]healthRateRule, bool) {
+	baseURL, err := k.validateAndGetBaseURL()
+	if err != nil {
+		return nil, false
+	}
+	content, err := k.executeRequest(ctx, strings.TrimRight(baseURL, "/")+"/api/config")
+	if err != nil {
+		klog.V(1).Infof("failed to get the Kiali health configuration, using the default tolerances: %v", err)
+		var apiErr *APIError
+		return nil, errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+	}
+	rules, err := parseHealthRates(content)
+	if err != nil {
+		klog.V(1).Infof("failed to parse the Kiali health configuration, using the default tolerances: %v", err)
+	}
+	return rules, true
+}
+
+// parseHealthRates parses the healthConfig.rate entries of the Kiali configuration. Empty patterns match
+// everything; tolerance codes use "X" as a digit wildcard, as in Kiali (e.g. "5XX").
+func parseHealthRates(content string) ([]healthRateRule, error) {
+	var cfg struct {
+		HealthConfig struct {
+			Rate []struct {
+				Namespace string `json:"namespace"`
+				Kind      string `json:"kind"`
+				Name      string `json:"name"`
+				Tolerance []struct {
+					Code      string  `json:"code"`
+					Degraded  float64 `json:"degraded"`
+					Failure   float64 `json:"failure"`
+					Protocol  string  `json:"protocol"`
+					Direction string  `json:"direction"`
+				} `json:"tolerance"`
+			} `json:"rate"`
+		} `json:"healthConfig"`
+	}
+	if err := json.Unmarshal([]byte(content), &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse Kiali configuration: %v", err)
+	}
+	var rules []healthRateRule
+	for i, rate := range cfg.HealthConfig.Rate {
+		rule := healthRateRule{}
+		var err error
+		if rule.namespace, err = compileHealthPattern(rate.Namespace); err != nil {
+			return nil, fmt.Errorf("invalid namespace of health rate %d: %v", i, err)
+		}
+		if rule.kind, err = compileHealthPattern(rate.Kind); err != nil {
+			return nil, fmt.Errorf("invalid kind of health rate %d: %v", i, err)
+		}
+		if rule.name, err = compileHealthPattern(rate.Name); err != nil {
+			return nil, fmt.Errorf("invalid name of health rate %d: %v", i, err)
+		}
+		for _, t := range rate.Tolerance {
+			tolerances, err := newErrorTolerances(t.Code, t.Degraded, t.Failure, t.Protocol, t.Direction)
+			if err != nil {
+				return nil, fmt.Errorf("invalid tolerance of health rate %d: %v", i, err)
+			}
+			rule.tolerances = append(rule.tolerances, tolerances...)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// parseHealthRateAnnotation parses the tolerances of a health.kiali.io/rate annotation.
+func parseHealthRateAnnotation(annotation string) ([]errorTolerance, error) {
+	var ret []errorTolerance
+	for _, entry := range strings.Split(annotation, ";") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		fields := strings.Split(entry, ",")
+		if len(fields) != 5 {
+			return nil, fmt.Errorf("invalid tolerance '%s': expected <CODE>,<DEGRADED>,<FAILURE>,<PROTOCOL>,<DIRECTION>", entry)
+		}
+		degraded, err := strconv.ParseFloat(strings.TrimSpace(fields[1]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid degraded threshold in '%s': %v", entry, err)
+		}
+		failure, err := strconv.ParseFloat(strings.TrimSpace(fields[2]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid failure threshold in '%s': %v", entry, err)
+		}
+		tolerances, err := newErrorTolerances(strings.TrimSpace(fields[0]), degraded, failure, strings.TrimSpace(fields[3]), strings.TrimSpace(fields[4]))
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, tolerances...)
+	}
+	if len(ret) == 0 {
+		return nil, fmt.Errorf("no tolerance")
+	}
+	return ret, nil
+}
+
+// newErrorTolerances builds the tolerances of a configured code pattern, one per supported protocol (http,
+// grpc) matching the protocol pattern.
+func newErrorTolerances(code string, degraded, failure float64, protocol, direction string) ([]errorTolerance, error) {
+	if code == "" {
+		return nil, fmt.Errorf("code is required")
+	}
+	codeRe, err := regexp.Compile("^(?:" + strings.NewReplacer("X", `\d`, "x", `\d`).Replace(code) + ")$")
+	if err != nil {
+		return nil, fmt.Errorf("invalid code '%s': %v", code, err)
+	}
+	protocolRe, err := compileHealthPattern(protocol)
+	if err != nil {
+		return nil, fmt.Errorf("invalid protocol '%s': %v", protocol, err)
+	}
+	directionRe, err := compileHealthPattern(direction)
+	if err != nil {
+		return nil, fmt.Errorf("invalid direction '%s': %v", direction, err)
+	}
+	var ret []errorTolerance
+	for _, p := range []string{"http", "grpc"} {
+		if !protocolRe.MatchString(p) {
+			continue
+		}
+		ret = append(ret, errorTolerance{
+			protocol:  p,
+			code:      codeRe,
+			direction: directionRe,
+			label:     toleranceLabel(p, code),
+			degraded:  degraded,
+			failure:   failure,
+		})
+	}
+	return ret, nil
+}
+
+// compileHealthPattern compiles a pattern matching whole values, an empty pattern matches everything.
+func compileHealthPattern(pattern string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		pattern = ".*"
+	}
+	return regexp.Compile("^(?:" + pattern + ")$")
+}
+
+// simpleCodePattern matches the code patterns that read well in an issue, e.g. "5XX" or "-".
+var simpleCodePattern = regexp.MustCompile(`^[0-9xX-]+$`)
+
+// toleranceLabel describes the errors of a tolerance in issues, e.g. "HTTP 5xx".
+func toleranceLabel(protocol, code string) string {
+	label := "HTTP"
+	if protocol == "grpc" {
+		label = "gRPC"
+	}
+	if simpleCodePattern.MatchString(code) {
+		label += " " + strings.ToLower(code)
+	}
+	return label
+}
+
+// applyHealthRates assigns to the request health of every entity the tolerances of the first configured rule
+// it matches. Entities matching no rule keep the default tolerances.
+func (h *ClustersHealth) applyHealthRates(rules []healthRateRule) {
+	if len(rules) == 0 {
+		return
+	}
+	tolerancesOf := func(namespace, kind, name string) []errorTolerance {
+		for _, rule := range rules {
+			if rule.matches(namespace, kind, name) {
+				return rule.tolerances
+			}
+		}
+		return nil
+	}
+	for namespace, apps := range h.AppHealth {
+		for name, app := range apps {
+			if app != nil {
+				app.Requests.tolerances = tolerancesOf(namespace, "app", name)
+			}
+		}
+	}
+	for namespace, services := range h.ServiceHealth {
+		for name, service := range services {
+			if service != nil {
+				service.Requests.tolerances = tolerancesOf(namespace, "service", name)
+			}
+		}
+	}
+	for namespace, workloads := range h.WorkloadHealth {
+		for name, workload := range workloads {
+			if workload != nil {
+				workload.Requests.tolerances = tolerancesOf(namespace, "workload", name)
+			}
+		}
+	}
+}
+
+// errorTolerances returns the tolerances the requests are evaluated with: those of the health.kiali.io/rate
+// annotation of the entity, then those configured in Kiali, then the defaults. An invalid annotation is ignored.
+func (r RequestHealth) errorTolerances() []errorTolerance {
+	if annotation := r.HealthAnnotations[healthRateAnnotation]; annotation != "" {
+		if tolerances, err := parseHealthRateAnnotation(annotation); err == nil {
+			return tolerances
+		}
+	}
+	if len(r.tolerances) > 0 {
+		return r.tolerances
+	}
+	return defaultErrorTolerances
+}
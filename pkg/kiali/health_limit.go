@@ -0,0 +1,44 @@
+package kiali
+
+import (
+	"context"
+	"fmt"
+)
+
+// DefaultHealthConcurrency is the maximum number of Kiali health requests in flight when
+// kiali_health_concurrency is not set.
+const DefaultHealthConcurrency = 3
+
+// ValidateHealthConcurrency checks the configured maximum number of Kiali health requests in flight.
+// Zero, the value when kiali_health_concurrency is not set, selects DefaultHealthConcurrency.
+func ValidateHealthConcurrency(concurrency int) error {
+	if concurrency < 0 {
+		return fmt.Errorf("invalid kiali_health_concurrency: %d must be zero (default of %d) or a positive number", concurrency, DefaultHealthConcurrency)
+	}
+	return nil
+}
+
+// healthSlots returns the semaphore bounding the Kiali health requests in flight. It is shared by every
+// client of the manager, so that the parallel health fetches of concurrent tool calls are bounded together.
+func (m *Manager) healthSlots() chan struct{} {
+	m.healthSlotsOnce.Do(func() {
+		concurrency := DefaultHealthConcurrency
+		if m.staticConfig != nil && m.staticConfig.KialiHealthConcurrency > 0 {
+			concurrency = m.staticConfig.KialiHealthConcurrency
+		}
+		m.healthSem = make(chan struct{}, concurrency)
+	})
+	return m.healthSem
+}
+
+// acquireHealthSlot waits until fewer than the configured maximum of health requests are in flight and returns
+// the function releasing the slot. An error is returned when the context is done before a slot is free.
+func (k *Kiali) acquireHealthSlot(ctx context.Context) (func(), error) {
+	slots := k.manager.healthSlots()
+	select {
+	case slots <- struct{}{}:
+		return func() { <-slots }, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("timed out waiting for a free health request slot: %v", ctx.Err())
+	}
+}
@@ -0,0 +1,394 @@
+package kiali
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// HealthTypes are the entity types whose health is aggregated by MeshHealthSummary.
+var HealthTypes = []string{"app", "service", "workload"}
+
+// Shares (in percent) of the inbound traffic served by failing entities above which the traffic-weighted
+// overall status is UNHEALTHY (UNHEALTHY entities) or DEGRADED (DEGRADED or UNHEALTHY entities).
+const (
+	weightedUnhealthyShare = 20.0
+	weightedDegradedShare  = 5.0
+)
+
+// EntityCounts is the number of entities per computed health status.
+type EntityCounts struct {
+	Total     int `json:"total"`
+	Healthy   int `json:"healthy"`
+	Degraded  int `json:"degraded"`
+	Unhealthy int `json:"unhealthy"`
+	NotReady  int `json:"notReady"`
+	NA        int `json:"na"`
+}
+
+// add counts an entity with the given status.
+func (c *EntityCounts) add(status HealthStatus) {
+	c.Total++
+	switch status {
+	case HealthStatusHealthy:
+		c.Healthy++
+	case HealthStatusDegraded:
+		c.Degraded++
+	case HealthStatusUnhealthy:
+		c.Unhealthy++
+	case HealthStatusNotReady:
+		c.NotReady++
+	default:
+		c.NA++
+	}
+}
+
+// NamespaceHealthSummary is the aggregated health of the entities of a namespace.
+type NamespaceHealthSummary struct {
+	Status   HealthStatus             `json:"status"`
+	Entities map[string]*EntityCounts `json:"entities"`
+}
+
+// MeshHealthSummary is the health of the mesh computed from the app, service and workload health, or from the
+// health of the subset of these Types that was requested.
+//   - OverallStatus is the most severe status of any entity (NOT_READY entities, i.e. scaled down, are ignored).
+//     When traffic-weighted, it is instead based on the share of the inbound traffic served by failing entities,
+//     reported as ImpactedTraffic, so that failing entities without traffic do not degrade it
+//   - Availability is the percentage of entities with health data that are HEALTHY
+//   - ErrorRate is the percentage of failed inbound HTTP/gRPC requests, from the service health
+//     (or, if services are not included, the app or workload health)
+//   - Raw are the Kiali health responses the summary was computed from, keyed by type, only set when requested
+type MeshHealthSummary struct {
+	Types           []string                           `json:"types"`
+	OverallStatus   HealthStatus                       `json:"overallStatus"`
+	ImpactedTraffic *float64                           `json:"impactedTraffic,omitempty"`
+	Availability    float64                            `json:"availability"`
+	ErrorRate       float64                            `json:"errorRate"`
+	Entities        map[string]*EntityCounts           `json:"entities"`
+	Namespaces      map[string]*NamespaceHealthSummary `json:"namespaces"`
+	Unhealthy       []EntityHealth                     `json:"unhealthy"`
+	Raw             map[string]json.RawMessage         `json:"raw,omitempty"`
+}
+
+// MeshHealthSummary fetches the app, service and workload health in parallel and returns, as JSON,
+// the mesh health summary computed from them.
+// Parameters:
+//   - namespaces: comma-separated list of namespaces (optional, if empty summarizes all accessible namespaces)
+//   - rateInterval: rate interval for fetching error rate (optional, default: "10m")
+//   - trafficWeighted: weight the entities by their inbound request volume to compute the overall status
+//   - types: the health types to fetch and aggregate, a subset of HealthTypes (optional, all of them when empty)
+//   - includeRaw: attach the raw Kiali health responses to the summary, which makes it much larger
+func (k *Kiali) MeshHealthSummary(ctx context.Context, namespaces string, rateInterval string, trafficWeighted bool, types []string, includeRaw bool) (string, error) {
+	if err := validateHealthTypes(types); err != nil {
+		return "", err
+	}
+	byType, raw, err := k.evaluateHealthTypes(ctx, namespaces, rateInterval, types...)
+	if err != nil {
+		return "", err
+	}
+	summary := computeMeshHealthSummary(byType, trafficWeighted)
+	if includeRaw {
+		summary.Raw = raw
+	}
+	result, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal mesh health summary: %v", err)
+	}
+	return string(result), nil
+}
+
+// validateHealthTypes checks that the types are HealthTypes.
+func validateHealthTypes(types []string) error {
+	for _, healthType := range types {
+		if !slices.Contains(HealthTypes, healthType) {
+			return fmt.Errorf("invalid health type '%s': must be one of %s", healthType, strings.Join(HealthTypes, ", "))
+		}
+	}
+	return nil
+}
+
+// meshHealthSummary computes the summary of the health of the given types, or of every type when none is given.
+func (k *Kiali) meshHealthSummary(ctx context.Context, namespaces string, rateInterval string, trafficWeighted bool, types ...string) (*MeshHealthSummary, error) {
+	byType, _, err := k.evaluateHealthTypes(ctx, namespaces, rateInterval, types...)
+	if err != nil {
+		return nil, err
+	}
+	return computeMeshHealthSummary(byType, trafficWeighted), nil
+}
+
+// evaluateHealthTypes fetches the health of the given types, or of every type when none is given, in parallel
+// and returns the computed health of the entities and the raw Kiali health responses, keyed by type.
+func (k *Kiali) evaluateHealthTypes(ctx context.Context, namespaces string, rateInterval string, types ...string) (map[string][]EntityHealth, map[string]json.RawMessage, error) {
+	healthTypes := HealthTypes
+	if len(types) > 0 {
+		healthTypes = slices.Compact(slices.Sorted(slices.Values(types)))
+	}
+	entities := make([][]EntityHealth, len(healthTypes))
+	contents := make([]string, len(healthTypes))
+	// one goroutine per type, the Kiali health requests themselves are bounded by the shared health slots
+	g, gCtx := errgroup.WithContext(ctx)
+	g.SetLimit(len(HealthTypes))
+	for i, healthType := range healthTypes {
+		g.Go(func() error {
+			health, content, err := k.fetchClustersHealth(gCtx, namespaces, healthType, rateInterval)
+			if err != nil {
+				return fmt.Errorf("failed to get %s health: %v", healthType, err)
+			}
+			entities[i] = evaluateClustersHealth(health, healthType)
+			contents[i] = content
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, nil, err
+	}
+	byType := make(map[string][]EntityHealth, len(healthTypes))
+	raw := make(map[string]json.RawMessage, len(healthTypes))
+	for i, healthType := range healthTypes {
+		byType[healthType] = entities[i]
+		raw[healthType] = json.RawMessage(contents[i])
+	}
+	return byType, raw, nil
+}
+
+// evaluateHealth fetches the health of the given type and computes the health of every entity.
+func (k *Kiali) evaluateHealth(ctx context.Context, namespaces string, healthType string, rateInterval string) ([]EntityHealth, error) {
+	health, err := k.clustersHealth(ctx, namespaces, healthType, rateInterval)
+	if err != nil {
+		return nil, err
+	}
+	return evaluateClustersHealth(health, healthType), nil
+}
+
+// clustersHealth fetches and parses the raw health of the given type, along with the request error
+// tolerances configured in Kiali for the entities.
+func (k *Kiali) clustersHealth(ctx context.Context, namespaces string, healthType string, rateInterval string) (*ClustersHealth, error) {
+	health, _, err := k.fetchClustersHealth(ctx, namespaces, healthType, rateInterval)
+	return health, err
+}
+
+// fetchClustersHealth is clustersHealth, also returning the raw Kiali health response.
+func (k *Kiali) fetchClustersHealth(ctx context.Context, namespaces string, healthType string, rateInterval string) (*ClustersHealth, string, error) {
+	queryParams := map[string]string{"type": healthType}
+	if rateInterval != "" {
+		queryParams["rateInterval"] = rateInterval
+	}
+	rules := k.healthRates(ctx)
+	content, err := k.Health(ctx, namespaces, queryParams)
+	if err != nil {
+		return nil, "", err
+	}
+	health, err := parseClustersHealth(content)
+	if err != nil {
+		return nil, "", err
+	}
+	health.applyHealthRates(rules)
+	return health, content, nil
+}
+
+// evaluateClustersHealth computes the health of every entity of the given type.
+func evaluateClustersHealth(health *ClustersHealth, healthType string) []EntityHealth {
+	ret := make([]EntityHealth, 0)
+	switch healthType {
+	case "app":
+		for namespace, apps := range health.AppHealth {
+			for name, app := range apps {
+				ret = append(ret, evaluateAppHealth(namespace, name, app))
+			}
+		}
+	case "service":
+		for namespace, services := range health.ServiceHealth {
+			for name, service := range services {
+				ret = append(ret, evaluateServiceHealth(namespace, name, service))
+			}
+		}
+	case "workload":
+		for namespace, workloads := range health.WorkloadHealth {
+			for name, workload := range workloads {
+				ret = append(ret, evaluateWorkloadHealth(namespace, name, workload))
+			}
+		}
+	}
+	sortEntityHealth(ret)
+	return ret
+}
+
+// computeMeshHealthSummary aggregates the computed entity health, keyed by type, into the mesh summary.
+// When trafficWeighted is set, the overall status is weighted by the inbound traffic of the entities.
+func computeMeshHealthSummary(byType map[string][]EntityHealth, trafficWeighted bool) *MeshHealthSummary {
+	summary := &MeshHealthSummary{
+		Types:      slices.Sorted(maps.Keys(byType)),
+		Entities:   map[string]*EntityCounts{},
+		Namespaces: map[string]*NamespaceHealthSummary{},
+		Unhealthy:  make([]EntityHealth, 0),
+	}
+	all := make([]EntityHealth, 0)
+	for _, healthType := range slices.Sorted(maps.Keys(byType)) {
+		counts := &EntityCounts{}
+		summary.Entities[healthType] = counts
+		for _, entity := range byType[healthType] {
+			counts.add(entity.Status)
+			ns, ok := summary.Namespaces[entity.Namespace]
+			if !ok {
+				ns = &NamespaceHealthSummary{Status: HealthStatusNA, Entities: map[string]*EntityCounts{}}
+				summary.Namespaces[entity.Namespace] = ns
+			}
+			if ns.Entities[healthType] == nil {
+				ns.Entities[healthType] = &EntityCounts{}
+			}
+			ns.Entities[healthType].add(entity.Status)
+			ns.Status = mergeOverallStatus(ns.Status, entity.Status)
+			if isFailingStatus(entity.Status) {
+				summary.Unhealthy = append(summary.Unhealthy, entity)
+			}
+			all = append(all, entity)
+		}
+	}
+	if trafficWeighted {
+		var impacted float64
+		summary.OverallStatus, impacted = computeWeightedOverallStatus(all)
+		summary.ImpactedTraffic = &impacted
+	} else {
+		summary.OverallStatus = computeOverallStatus(all)
+	}
+	summary.Availability = computeAvailability(summary.Entities)
+	for _, healthType := range []string{"service", "app", "workload"} {
+		if entities, ok := byType[healthType]; ok {
+			summary.ErrorRate = computeErrorRate(entities)
+			break
+		}
+	}
+	sortEntityHealth(summary.Unhealthy)
+	return summary
+}
+
+// mergeOverallStatus merges an entity status into an aggregated status, ignoring NOT_READY entities.
+func mergeOverallStatus(overall, status HealthStatus) HealthStatus {
+	if status == HealthStatusNotReady {
+		return overall
+	}
+	return mergeHealthStatus(overall, status)
+}
+
+// computeOverallStatus returns the most severe status of the entities, ignoring NOT_READY entities.
+func computeOverallStatus(entities []EntityHealth) HealthStatus {
+	overall := HealthStatusNA
+	for _, entity := range entities {
+		overall = mergeOverallStatus(overall, entity.Status)
+	}
+	return overall
+}
+
+// computeWeightedOverallStatus returns the overall status based on the share of the inbound traffic served by
+// failing entities, and that share (in percent). Entities without inbound traffic and NOT_READY entities are
+// ignored; if no entity has inbound traffic, the unweighted overall status is returned.
+func computeWeightedOverallStatus(entities []EntityHealth) (HealthStatus, float64) {
+	var total, unhealthy, failing float64
+	for _, entity := range entities {
+		if entity.Status == HealthStatusNotReady || entity.inboundRequests <= 0 {
+			continue
+		}
+		total += entity.inboundRequests
+		switch entity.Status {
+		case HealthStatusUnhealthy:
+			unhealthy += entity.inboundRequests
+			failing += entity.inboundRequests
+		case HealthStatusDegraded:
+			failing += entity.inboundRequests
+		}
+	}
+	if total <= 0 {
+		return computeOverallStatus(entities), 0
+	}
+	impacted := roundPercentage(failing / total * 100)
+	switch {
+	case unhealthy/total*100 >= weightedUnhealthyShare:
+		return HealthStatusUnhealthy, impacted
+	case failing/total*100 >= weightedDegradedShare:
+		return HealthStatusDegraded, impacted
+	default:
+		return HealthStatusHealthy, impacted
+	}
+}
+
+// computeAvailability returns the percentage of entities with health data that are healthy.
+func computeAvailability(counts map[string]*EntityCounts) float64 {
+	var healthy, evaluated int
+	for _, c := range counts {
+		healthy += c.Healthy
+		evaluated += c.Healthy + c.Degraded + c.Unhealthy
+	}
+	if evaluated == 0 {
+		return 100
+	}
+	return roundPercentage(float64(healthy) / float64(evaluated) * 100)
+}
+
+// computeErrorRate returns the percentage of failed inbound requests across the entities.
+func computeErrorRate(entities []EntityHealth) float64 {
+	var total, failed float64
+	for _, entity := range entities {
+		total += entity.inboundRequests
+		failed += entity.inboundErrors
+	}
+	if total <= 0 {
+		return 0
+	}
+	return roundPercentage(failed / total * 100)
+}
+
+// MeshHealthMetrics computes the mesh health summary and renders it in the Prometheus text exposition format:
+//   - mesh_entity_health{type,namespace,status}: number of entities per type, namespace and status
+//   - mesh_availability: percentage of entities with health data that are HEALTHY
+//   - mesh_error_rate: percentage of failed inbound HTTP/gRPC requests
+//
+// Parameters:
+//   - namespaces: comma-separated list of namespaces (optional, if empty summarizes all accessible namespaces)
+//   - rateInterval: rate interval for fetching error rate (optional, default: "10m")
+func (k *Kiali) MeshHealthMetrics(ctx context.Context, namespaces string, rateInterval string) (string, error) {
+	summary, err := k.meshHealthSummary(ctx, namespaces, rateInterval, false)
+	if err != nil {
+		return "", err
+	}
+	return formatMeshHealthMetrics(summary), nil
+}
+
+// formatMeshHealthMetrics renders the summary as Prometheus metrics. Every status is emitted for each
+// type and namespace, including zero counts, so the series stay stable between scrapes.
+func formatMeshHealthMetrics(summary *MeshHealthSummary) string {
+	var sb strings.Builder
+	sb.WriteString("# HELP mesh_entity_health Number of mesh entities per type, namespace and computed health status.\n")
+	sb.WriteString("# TYPE mesh_entity_health gauge\n")
+	for _, namespace := range slices.Sorted(maps.Keys(summary.Namespaces)) {
+		entities := summary.Namespaces[namespace].Entities
+		for _, healthType := range slices.Sorted(maps.Keys(entities)) {
+			counts := entities[healthType]
+			for _, sc := range []struct {
+				status HealthStatus
+				count  int
+			}{
+				{HealthStatusHealthy, counts.Healthy},
+				{HealthStatusDegraded, counts.Degraded},
+				{HealthStatusUnhealthy, counts.Unhealthy},
+				{HealthStatusNotReady, counts.NotReady},
+				{HealthStatusNA, counts.NA},
+			} {
+				fmt.Fprintf(&sb, "mesh_entity_health{type=%q,namespace=%q,status=%q} %d\n",
+					healthType, namespace, sc.status, sc.count)
+			}
+		}
+	}
+	sb.WriteString("# HELP mesh_availability Percentage of mesh entities with health data that are healthy.\n")
+	sb.WriteString("# TYPE mesh_availability gauge\n")
+	fmt.Fprintf(&sb, "mesh_availability %s\n", strconv.FormatFloat(summary.Availability, 'g', -1, 64))
+	sb.WriteString("# HELP mesh_error_rate Percentage of failed inbound HTTP and gRPC requests.\n")
+	sb.WriteString("# TYPE mesh_error_rate gauge\n")
+	fmt.Fprintf(&sb, "mesh_error_rate %s\n", strconv.FormatFloat(summary.ErrorRate, 'g', -1, 64))
+	return sb.String()
+}
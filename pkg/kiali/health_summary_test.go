@@ -0,0 +1,71 @@
+package kiali
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeWeightedOverallStatus(t *testing.T) {
+	entity := func(status HealthStatus, requests float64) EntityHealth {
+		return EntityHealth{Status: status, inboundRequests: requests}
+	}
+	tests := []struct {
+		name             string
+		entities         []EntityHealth
+		expected         HealthStatus
+		expectedImpacted float64
+	}{
+		{
+			name:     "no entities",
+			expected: HealthStatusNA,
+		},
+		{
+			name:     "no traffic falls back to the unweighted status",
+			entities: []EntityHealth{entity(HealthStatusHealthy, 0), entity(HealthStatusDegraded, 0)},
+			expected: HealthStatusDegraded,
+		},
+		{
+			name:             "unhealthy low-traffic entity",
+			entities:         []EntityHealth{entity(HealthStatusHealthy, 99), entity(HealthStatusUnhealthy, 1)},
+			expected:         HealthStatusHealthy,
+			expectedImpacted: 1,
+		},
+		{
+			name:     "unhealthy entity without traffic is ignored",
+			entities: []EntityHealth{entity(HealthStatusHealthy, 10), entity(HealthStatusUnhealthy, 0)},
+			expected: HealthStatusHealthy,
+		},
+		{
+			name:             "unhealthy entity with 5% of the traffic degrades",
+			entities:         []EntityHealth{entity(HealthStatusHealthy, 95), entity(HealthStatusUnhealthy, 5)},
+			expected:         HealthStatusDegraded,
+			expectedImpacted: 5,
+		},
+		{
+			name:             "unhealthy entity with 20% of the traffic fails",
+			entities:         []EntityHealth{entity(HealthStatusHealthy, 80), entity(HealthStatusUnhealthy, 20)},
+			expected:         HealthStatusUnhealthy,
+			expectedImpacted: 20,
+		},
+		{
+			name:             "degraded high-traffic entity never fails",
+			entities:         []EntityHealth{entity(HealthStatusHealthy, 10), entity(HealthStatusDegraded, 90)},
+			expected:         HealthStatusDegraded,
+			expectedImpacted: 90,
+		},
+		{
+			name:             "not ready entities are ignored",
+			entities:         []EntityHealth{entity(HealthStatusHealthy, 10), entity(HealthStatusNotReady, 90)},
+			expected:         HealthStatusHealthy,
+			expectedImpacted: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, impacted := computeWeightedOverallStatus(tt.entities)
+			assert.Equal(t, tt.expected, status)
+			assert.Equal(t, tt.expectedImpacted, impacted)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package kiali
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+
+	"k8s.io/utils/ptr"
+)
+
+// HealthTableRow is the computed health of an app, service or workload, as a row of a flat health table.
+//   - ErrorRate is the percentage of failed inbound HTTP/gRPC requests, null without inbound traffic
+//   - Availability is the percentage of the desired replicas of the workloads that are available, null for
+//     services and for entities without desired replicas
+type HealthTableRow struct {
+	Type         string       `json:"type"`
+	Namespace    string       `json:"namespace"`
+	Name         string       `json:"name"`
+	Status       HealthStatus `json:"status"`
+	ErrorRate    *float64     `json:"errorRate"`
+	Availability *float64     `json:"availability"`
+}
+
+// MeshHealthTable fetches the health of the given types in parallel and returns, as JSON, one row per entity,
+// healthy or not, sorted by decreasing severity, then by namespace and name, e.g. to render a sortable table.
+// Parameters:
+//   - namespaces: comma-separated list of namespaces (optional, if empty lists all accessible namespaces)
+//   - rateInterval: rate interval for fetching error rate (optional, default: "10m")
+//   - types: the health types to fetch, a subset of HealthTypes (optional, all of them when empty)
+func (k *Kiali) MeshHealthTable(ctx context.Context, namespaces string, rateInterval string, types []string) (string, error) {
+	if err := validateHealthTypes(types); err != nil {
+		return "", err
+	}
+	byType, _, err := k.evaluateHealthTypes(ctx, namespaces, rateInterval, types...)
+	if err != nil {
+		return "", err
+	}
+	result, err := json.MarshalIndent(computeHealthTable(byType), "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal mesh health table: %v", err)
+	}
+	return string(result), nil
+}
+
+// computeHealthTable flattens the computed entity health, keyed by type, into rows sorted by decreasing
+// severity. Entities of the same severity, namespace and name are ordered by type.
+func computeHealthTable(byType map[string][]EntityHealth) []HealthTableRow {
+	all := make([]EntityHealth, 0)
+	for _, healthType := range slices.Sorted(maps.Keys(byType)) {
+		all = append(all, byType[healthType]...)
+	}
+	sortEntityHealth(all)
+	rows := make([]HealthTableRow, 0, len(all))
+	for _, entity := range all {
+		row := HealthTableRow{
+			Type:      entity.Type,
+			Namespace: entity.Namespace,
+			Name:      entity.Name,
+			Status:    entity.Status,
+			ErrorRate: entity.ErrorRate,
+		}
+		if entity.desiredReplicas > 0 {
+			// Available replicas can exceed the desired ones during a rollout
+			row.Availability = ptr.To(roundPercentage(min(float64(entity.availableReplicas)/float64(entity.desiredReplicas)*100, 100)))
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
@@ -0,0 +1,192 @@
+package kiali
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// HostReachability tells whether a host is defined in the mesh configuration, by a ServiceEntry or a mesh
+// VirtualService, and the routing and policies applying to the calls to it.
+type HostReachability struct {
+	Host             string                   `json:"host"`
+	Namespace        string                   `json:"namespace,omitempty"`
+	Defined          bool                     `json:"defined"`
+	ServiceEntries   []ServiceEntrySummary    `json:"serviceEntries"`
+	Routes           []HostRoute              `json:"routes"`
+	DestinationRules []DestinationRuleSummary `json:"destinationRules"`
+	Notes            []string                 `json:"notes,omitempty"`
+}
+
+// ServiceEntrySummary is a ServiceEntry defining the host. Location defaults to MESH_EXTERNAL and Resolution to
+// NONE, as in Istio. Visible is false when the ServiceEntry is not exported to the namespace of the caller.
+type ServiceEntrySummary struct {
+	Namespace  string   `json:"namespace"`
+	Name       string   `json:"name"`
+	Hosts      []string `json:"hosts"`
+	Location   string   `json:"location"`
+	Resolution string   `json:"resolution"`
+	Ports      []string `json:"ports,omitempty"`
+	ExportTo   []string `json:"exportTo,omitempty"`
+	Visible    bool     `json:"visible"`
+}
+
+// HostRoute is a route of a mesh VirtualService routing the host, in route evaluation order. The match and
+// destination entries are flattened to "path=value" entries.
+type HostRoute struct {
+	VirtualService string   `json:"virtualService"`
+	Protocol       string   `json:"protocol"`
+	Name           string   `json:"name,omitempty"`
+	Match          []string `json:"match,omitempty"`
+	Destinations   []string `json:"destinations,omitempty"`
+	Visible        bool     `json:"visible"`
+}
+
+// HostReachability searches the Istio config for the ServiceEntries and mesh VirtualServices matching the host
+// and returns, as JSON, whether the host is defined, how it is resolved and routed and the DestinationRules
+// applying to it.
+// Parameters:
+//   - host: the host called, e.g. "api.example.com" or "reviews.bookinfo" (wildcards such as "*.example.com" are supported)
+//   - namespace: the namespace of the caller (optional), used to qualify short host names and to check that
+//     the objects are exported to it
+func (k *Kiali) HostReachability(ctx context.Context, host string, namespace string) (string, error) {
+	if strings.TrimSpace(host) == "" {
+		return "", fmt.Errorf("host is required")
+	}
+	content, err := k.IstioConfig(ctx)
+	if err != nil {
+		return "", err
+	}
+	objects, err := parseIstioConfigObjects(content)
+	if err != nil {
+		return "", err
+	}
+	result, err := json.MarshalIndent(computeHostReachability(objects, host, namespace), "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal host reachability: %v", err)
+	}
+	return string(result), nil
+}
+
+// computeHostReachability computes the reachability of the host out of the Istio config objects.
+func computeHostReachability(objects []IstioObject, host, namespace string) *HostReachability {
+	host = strings.ToLower(strings.TrimSpace(host))
+	ret := &HostReachability{
+		Host:             host,
+		Namespace:        namespace,
+		ServiceEntries:   make([]ServiceEntrySummary, 0),
+		Routes:           make([]HostRoute, 0),
+		DestinationRules: make([]DestinationRuleSummary, 0),
+	}
+	for _, se := range istioObjectsOfKind(objects, "ServiceEntry", "") {
+		if !objectMatchesHost(se, specStrings(se.Spec, "hosts"), host, namespace) {
+			continue
+		}
+		summary := ServiceEntrySummary{
+			Namespace:  se.Metadata.Namespace,
+			Name:       se.Metadata.Name,
+			Hosts:      specStrings(se.Spec, "hosts"),
+			Location:   specString(se.Spec, "location"),
+			Resolution: specString(se.Spec, "resolution"),
+			ExportTo:   specStrings(se.Spec, "exportTo"),
+			Visible:    exportedTo(se, namespace),
+		}
+		if summary.Location == "" {
+			summary.Location = "MESH_EXTERNAL"
+		}
+		if summary.Resolution == "" {
+			summary.Resolution = "NONE"
+		}
+		for _, port := range specList(se.Spec, "ports") {
+			summary.Ports = append(summary.Ports, fmt.Sprintf("%v/%s", port["number"], specString(port, "protocol")))
+		}
+		ret.ServiceEntries = append(ret.ServiceEntries, summary)
+	}
+	virtualServices := 0
+	for _, vs := range istioObjectsOfKind(objects, "VirtualService", "") {
+		if !appliesToMesh(vs) || !objectMatchesHost(vs, specStrings(vs.Spec, "hosts"), host, namespace) {
+			continue
+		}
+		virtualServices++
+		name := vs.Metadata.Namespace + "/" + vs.Metadata.Name
+		visible := exportedTo(vs, namespace)
+		for _, protocol := range []string{"http", "tls", "tcp"} {
+			for _, route := range specList(vs.Spec, protocol) {
+				r := HostRoute{VirtualService: name, Protocol: protocol, Name: specString(route, "name"), Visible: visible}
+				for _, match := range specList(route, "match") {
+					r.Match = append(r.Match, strings.Join(flattenSpec(match), ", "))
+				}
+				for _, destination := range specList(route, "route") {
+					r.Destinations = append(r.Destinations, strings.Join(flattenSpec(destination), ", "))
+				}
+				ret.Routes = append(ret.Routes, r)
+			}
+		}
+	}
+	for _, dr := range istioObjectsOfKind(objects, "DestinationRule", "") {
+		if objectMatchesHost(dr, []string{specString(dr.Spec, "host")}, host, namespace) {
+			ret.DestinationRules = append(ret.DestinationRules, summarizeDestinationRule(dr))
+		}
+	}
+	ret.Defined = len(ret.ServiceEntries) > 0 || virtualServices > 0
+	ret.Notes = hostReachabilityNotes(ret, virtualServices)
+	return ret
+}
+
+// hostReachabilityNotes explains the reachability of the host.
+func hostReachabilityNotes(r *HostReachability, virtualServices int) []string {
+	var notes []string
+	internal := !strings.Contains(r.Host, ".") || strings.HasSuffix(r.Host, ".svc.cluster.local")
+	switch {
+	case len(r.ServiceEntries) == 0 && internal:
+		notes = append(notes, "no ServiceEntry defines the host: as a cluster service host, it is reachable if the Kubernetes service exists")
+	case len(r.ServiceEntries) == 0:
+		notes = append(notes, "no ServiceEntry defines the host: it is only reachable when the mesh outboundTrafficPolicy is ALLOW_ANY (the Istio default), "+
+			"calls are blocked with REGISTRY_ONLY and are not observed as a mesh service")
+	case !slices.ContainsFunc(r.ServiceEntries, func(se ServiceEntrySummary) bool { return se.Visible }):
+		notes = append(notes, fmt.Sprintf("the ServiceEntries defining the host are not exported to namespace '%s'", r.Namespace))
+	}
+	for _, se := range r.ServiceEntries {
+		if se.Resolution == "NONE" && se.Location == "MESH_EXTERNAL" {
+			notes = append(notes, fmt.Sprintf("ServiceEntry %s/%s has resolution NONE: the connections are forwarded to the IP address the caller resolved", se.Namespace, se.Name))
+		}
+	}
+	if virtualServices == 0 {
+		notes = append(notes, "no mesh VirtualService routes the host, the calls go directly to its endpoints")
+	} else if virtualServices > 1 {
+		notes = append(notes, fmt.Sprintf("%d VirtualServices route the host; only one of them is applied to mesh traffic", virtualServices))
+	}
+	return notes
+}
+
+// objectMatchesHost reports whether one of the hosts of the object matches the host. Hosts are compared both as
+// written, for external hosts, and qualified with the namespaces, for short cluster service names.
+func objectMatchesHost(obj IstioObject, hosts []string, host, namespace string) bool {
+	for _, h := range hosts {
+		if h == "" {
+			continue
+		}
+		if hostsOverlap(strings.ToLower(h), host) ||
+			(namespace != "" && hostsOverlap(qualifyHost(h, obj.Metadata.Namespace), qualifyHost(host, namespace))) {
+			return true
+		}
+	}
+	return false
+}
+
+// exportedTo reports whether the object is visible from the namespace according to its exportTo field.
+// Objects are visible from every namespace when the namespace is not known or exportTo is not set.
+func exportedTo(obj IstioObject, namespace string) bool {
+	exportTo := specStrings(obj.Spec, "exportTo")
+	if namespace == "" || len(exportTo) == 0 {
+		return true
+	}
+	for _, e := range exportTo {
+		if e == "*" || e == namespace || (e == "." && obj.Metadata.Namespace == namespace) {
+			return true
+		}
+	}
+	return false
+}
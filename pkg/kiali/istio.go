@@ -2,10 +2,16 @@ package kiali
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"maps"
 	"net/http"
 	"net/url"
+	"slices"
 	"strings"
+	"time"
+
+	"k8s.io/utils/ptr"
 )
 
 // IstioConfig calls the Kiali Istio config API to get all Istio objects in the mesh.
@@ -27,7 +33,8 @@ func (k *Kiali) IstioConfig(ctx context.Context) (string, error) {
 //   - version: the API version (e.g., "v1", "v1beta1")
 //   - kind: the resource kind (e.g., "DestinationRule", "VirtualService", "HTTPRoute")
 //   - name: the name of the resource
-func (k *Kiali) IstioObjectDetails(ctx context.Context, namespace, group, version, kind, name string) (string, error) {
+//   - clusterName: the cluster of the resource in multi-cluster meshes (optional, default: the Kiali home cluster)
+func (k *Kiali) IstioObjectDetails(ctx context.Context, namespace, group, version, kind, name, clusterName string) (string, error) {
 	baseURL, err := k.validateAndGetBaseURL()
 	if err != nil {
 		return "", err
@@ -54,6 +61,9 @@ func (k *Kiali) IstioObjectDetails(ctx context.Context, namespace, group, versio
 		url.PathEscape(version),
 		url.PathEscape(kind),
 		url.PathEscape(name))
+	if endpoint, err = k.withCluster(ctx, endpoint, clusterName); err != nil {
+		return "", err
+	}
 
 	return k.executeRequest(ctx, endpoint)
 }
@@ -66,7 +76,8 @@ func (k *Kiali) IstioObjectDetails(ctx context.Context, namespace, group, versio
 //   - kind: the resource kind (e.g., "DestinationRule", "VirtualService", "HTTPRoute")
 //   - name: the name of the resource
 //   - jsonPatch: the JSON patch data to apply
-func (k *Kiali) IstioObjectPatch(ctx context.Context, namespace, group, version, kind, name, jsonPatch string) (string, error) {
+//   - clusterName: the cluster of the resource in multi-cluster meshes (optional, default: the Kiali home cluster)
+func (k *Kiali) IstioObjectPatch(ctx context.Context, namespace, group, version, kind, name, jsonPatch, clusterName string) (string, error) {
 	baseURL, err := k.validateAndGetBaseURL()
 	if err != nil {
 		return "", err
@@ -89,6 +100,9 @@ func (k *Kiali) IstioObjectPatch(ctx context.Context, namespace, group, version,
 	if jsonPatch == "" {
 		return "", fmt.Errorf("json patch data is required")
 	}
+	if err := k.checkWritableNamespace(namespace); err != nil {
+		return "", err
+	}
 	endpoint := fmt.Sprintf("%s/api/namespaces/%s/istio/%s/%s/%s/%s",
 		strings.TrimRight(baseURL, "/"),
 		url.PathEscape(namespace),
@@ -96,18 +110,28 @@ func (k *Kiali) IstioObjectPatch(ctx context.Context, namespace, group, version,
 		url.PathEscape(version),
 		url.PathEscape(kind),
 		url.PathEscape(name))
+	if endpoint, err = k.withCluster(ctx, endpoint, clusterName); err != nil {
+		return "", err
+	}
 
 	return k.executeRequestWithBody(ctx, http.MethodPatch, endpoint, "application/json", strings.NewReader(jsonPatch))
 }
 
+// createVerifyTimeout bounds the check of whether an object exists after its create request timed out.
+const createVerifyTimeout = 10 * time.Second
+
 // IstioObjectCreate creates a new Istio object using POST method.
+// When the request times out, the object may still have been created: if the object has a deterministic
+// metadata.name, it is then looked up and a CreateStatusUnknownError reports whether it exists, so the caller
+// knows whether retrying is safe.
 // Parameters:
 //   - namespace: the namespace where the Istio object will be created
 //   - group: the API group (e.g., "networking.istio.io", "gateway.networking.k8s.io")
 //   - version: the API version (e.g., "v1", "v1beta1")
 //   - kind: the resource kind (e.g., "DestinationRule", "VirtualService", "HTTPRoute")
 //   - jsonData: the JSON data for the new object
-func (k *Kiali) IstioObjectCreate(ctx context.Context, namespace, group, version, kind, jsonData string) (string, error) {
+//   - clusterName: the cluster of the resource in multi-cluster meshes (optional, default: the Kiali home cluster)
+func (k *Kiali) IstioObjectCreate(ctx context.Context, namespace, group, version, kind, jsonData, clusterName string) (string, error) {
 	baseURL, err := k.validateAndGetBaseURL()
 	if err != nil {
 		return "", err
@@ -127,14 +151,41 @@ func (k *Kiali) IstioObjectCreate(ctx context.Context, namespace, group, version
 	if jsonData == "" {
 		return "", fmt.Errorf("json data is required")
 	}
+	if err := k.checkWritableNamespace(namespace); err != nil {
+		return "", err
+	}
 	endpoint := fmt.Sprintf("%s/api/namespaces/%s/istio/%s/%s/%s",
 		strings.TrimRight(baseURL, "/"),
 		url.PathEscape(namespace),
 		url.PathEscape(group),
 		url.PathEscape(version),
 		url.PathEscape(kind))
+	if endpoint, err = k.withCluster(ctx, endpoint, clusterName); err != nil {
+		return "", err
+	}
 
-	return k.executeRequestWithBody(ctx, http.MethodPost, endpoint, "application/json", strings.NewReader(jsonData))
+	content, err := k.executeRequestWithBody(ctx, http.MethodPost, endpoint, "application/json", strings.NewReader(jsonData))
+	if err != nil && isTimeout(err) {
+		var object IstioObject
+		_ = json.Unmarshal([]byte(jsonData), &object)
+		statusErr := &CreateStatusUnknownError{Kind: kind, Namespace: namespace, Name: object.Metadata.Name, Err: err}
+		if statusErr.Name != "" {
+			// The request context has expired, check with a fresh deadline keeping the caller's credentials
+			verifyCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), createVerifyTimeout)
+			defer cancel()
+			_, checkErr := k.IstioObjectDetails(verifyCtx, namespace, group, version, kind, statusErr.Name, clusterName)
+			switch {
+			case checkErr == nil:
+				statusErr.Exists = ptr.To(true)
+			case IsNotFound(checkErr):
+				statusErr.Exists = ptr.To(false)
+			default:
+				statusErr.CheckErr = checkErr
+			}
+		}
+		return "", statusErr
+	}
+	return content, err
 }
 
 // IstioObjectDelete deletes an existing Istio object using DELETE method.
@@ -144,7 +195,8 @@ func (k *Kiali) IstioObjectCreate(ctx context.Context, namespace, group, version
 //   - version: the API version (e.g., "v1", "v1beta1")
 //   - kind: the resource kind (e.g., "DestinationRule", "VirtualService", "HTTPRoute", "Gateway")
 //   - name: the name of the resource
-func (k *Kiali) IstioObjectDelete(ctx context.Context, namespace, group, version, kind, name string) (string, error) {
+//   - clusterName: the cluster of the resource in multi-cluster meshes (optional, default: the Kiali home cluster)
+func (k *Kiali) IstioObjectDelete(ctx context.Context, namespace, group, version, kind, name, clusterName string) (string, error) {
 	baseURL, err := k.validateAndGetBaseURL()
 	if err != nil {
 		return "", err
@@ -164,6 +216,9 @@ func (k *Kiali) IstioObjectDelete(ctx context.Context, namespace, group, version
 	if name == "" {
 		return "", fmt.Errorf("name is required")
 	}
+	if err := k.checkWritableNamespace(namespace); err != nil {
+		return "", err
+	}
 	endpoint := fmt.Sprintf("%s/api/namespaces/%s/istio/%s/%s/%s/%s",
 		strings.TrimRight(baseURL, "/"),
 		url.PathEscape(namespace),
@@ -171,6 +226,45 @@ func (k *Kiali) IstioObjectDelete(ctx context.Context, namespace, group, version
 		url.PathEscape(version),
 		url.PathEscape(kind),
 		url.PathEscape(name))
+	if endpoint, err = k.withCluster(ctx, endpoint, clusterName); err != nil {
+		return "", err
+	}
 
 	return k.executeRequestWithBody(ctx, http.MethodDelete, endpoint, "", nil)
 }
+
+// withCluster adds the clusterName query parameter to the endpoint, after checking that the cluster is one of the
+// clusters known to Kiali. The endpoint is returned unchanged if clusterName is empty.
+func (k *Kiali) withCluster(ctx context.Context, endpoint string, clusterName string) (string, error) {
+	clusterName = strings.TrimSpace(clusterName)
+	if clusterName == "" {
+		return endpoint, nil
+	}
+	content, err := k.Clusters(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to validate cluster %q: %v", clusterName, err)
+	}
+	var clusters []struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal([]byte(content), &clusters); err != nil {
+		return "", fmt.Errorf("failed to validate cluster %q: failed to parse clusters: %v", clusterName, err)
+	}
+	known := map[string]bool{}
+	for _, cluster := range clusters {
+		if cluster.Name != "" {
+			known[cluster.Name] = true
+		}
+	}
+	if !known[clusterName] {
+		return "", fmt.Errorf("unknown cluster %q, known clusters are: %s", clusterName, strings.Join(slices.Sorted(maps.Keys(known)), ", "))
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+	q := u.Query()
+	q.Set("clusterName", clusterName)
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
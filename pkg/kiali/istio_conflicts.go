@@ -0,0 +1,213 @@
+package kiali
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// IstioConflict describes an existing Istio object that may conflict with a proposed one.
+type IstioConflict struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Reason    string `json:"reason"`
+}
+
+// IstioObjectConflicts checks a proposed Istio object against the existing Istio configuration and
+// returns the list of potential conflicts as JSON.
+// The following overlaps are detected:
+//   - an object of the same kind with the same name already exists in the namespace
+//   - VirtualService: another VirtualService claims an overlapping host for the same gateways (or the mesh)
+//   - DestinationRule: another DestinationRule targets the same host
+//   - Gateway: another Gateway with the same selector exposes an overlapping host on the same port
+//   - Sidecar, PeerAuthentication, RequestAuthentication, AuthorizationPolicy and EnvoyFilter:
+//     another object in the namespace uses the same workload selector (or none for namespace-wide objects)
+//
+// Parameters:
+//   - namespace: the namespace where the Istio object would be created
+//   - group: the API group (e.g., "networking.istio.io", "gateway.networking.k8s.io")
+//   - version: the API version (e.g., "v1", "v1beta1")
+//   - kind: the resource kind (e.g., "DestinationRule", "VirtualService", "Gateway")
+//   - jsonData: the JSON data for the proposed object
+func (k *Kiali) IstioObjectConflicts(ctx context.Context, namespace, group, version, kind, jsonData string) (string, error) {
+	if namespace == "" {
+		return "", fmt.Errorf("namespace is required")
+	}
+	if group == "" {
+		return "", fmt.Errorf("group is required")
+	}
+	if version == "" {
+		return "", fmt.Errorf("version is required")
+	}
+	if kind == "" {
+		return "", fmt.Errorf("kind is required")
+	}
+	if jsonData == "" {
+		return "", fmt.Errorf("json data is required")
+	}
+	var proposed IstioObject
+	if err := json.Unmarshal([]byte(jsonData), &proposed); err != nil {
+		return "", fmt.Errorf("failed to parse proposed object: %v", err)
+	}
+	proposed.Kind = kind
+	proposed.Metadata.Namespace = namespace
+
+	content, err := k.IstioConfig(ctx)
+	if err != nil {
+		return "", err
+	}
+	existing, err := parseIstioConfigObjects(content)
+	if err != nil {
+		return "", err
+	}
+	conflicts := findIstioConflicts(proposed, existing)
+	result, err := json.MarshalIndent(conflicts, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal conflicts: %v", err)
+	}
+	return string(result), nil
+}
+
+// findIstioConflicts returns the existing objects that may conflict with the proposed one.
+func findIstioConflicts(proposed IstioObject, existing []IstioObject) []IstioConflict {
+	conflicts := make([]IstioConflict, 0)
+	for _, obj := range istioObjectsOfKind(existing, proposed.Kind, "") {
+		conflict := IstioConflict{Kind: obj.Kind, Namespace: obj.Metadata.Namespace, Name: obj.Metadata.Name}
+		if proposed.Metadata.Name != "" && obj.Metadata.Name == proposed.Metadata.Name && obj.Metadata.Namespace == proposed.Metadata.Namespace {
+			conflict.Reason = fmt.Sprintf("a %s named '%s' already exists in namespace '%s'", obj.Kind, obj.Metadata.Name, obj.Metadata.Namespace)
+			conflicts = append(conflicts, conflict)
+			continue
+		}
+		if reason := conflictReason(proposed, obj); reason != "" {
+			conflict.Reason = reason
+			conflicts = append(conflicts, conflict)
+		}
+	}
+	return conflicts
+}
+
+// conflictReason returns a human-readable reason if the two objects of the same kind overlap, or an empty string.
+func conflictReason(proposed, obj IstioObject) string {
+	switch proposed.Kind {
+	case "VirtualService":
+		shared := overlappingHosts(proposed, obj, "hosts")
+		if len(shared) == 0 || !gatewaysOverlap(proposed, obj) {
+			return ""
+		}
+		return fmt.Sprintf("also routes host(s) %s; one VirtualService may silently override the other", strings.Join(shared, ", "))
+	case "DestinationRule":
+		pHost := qualifyHost(specString(proposed.Spec, "host"), proposed.Metadata.Namespace)
+		oHost := qualifyHost(specString(obj.Spec, "host"), obj.Metadata.Namespace)
+		if pHost == "" || pHost != oHost {
+			return ""
+		}
+		return fmt.Sprintf("also targets host %s; only one DestinationRule is applied per host", pHost)
+	case "Gateway":
+		if !maps.Equal(workloadSelector(proposed.Spec), workloadSelector(obj.Spec)) {
+			return ""
+		}
+		if shared := overlappingGatewayServers(proposed, obj); len(shared) > 0 {
+			return fmt.Sprintf("uses the same selector and exposes %s", strings.Join(shared, ", "))
+		}
+	case "Sidecar", "PeerAuthentication", "RequestAuthentication", "AuthorizationPolicy", "EnvoyFilter":
+		if proposed.Metadata.Namespace != obj.Metadata.Namespace {
+			return ""
+		}
+		pSelector, oSelector := workloadSelector(proposed.Spec), workloadSelector(obj.Spec)
+		if !maps.Equal(pSelector, oSelector) {
+			return ""
+		}
+		if len(pSelector) == 0 {
+			return fmt.Sprintf("is also a namespace-wide %s in namespace '%s'", proposed.Kind, obj.Metadata.Namespace)
+		}
+		return fmt.Sprintf("uses the same workload selector %s", formatLabels(pSelector))
+	}
+	return ""
+}
+
+// overlappingHosts returns the hosts of the given spec field that overlap between both objects.
+func overlappingHosts(a, b IstioObject, field string) []string {
+	shared := make([]string, 0)
+	for _, ah := range specStrings(a.Spec, field) {
+		qa := qualifyHost(ah, a.Metadata.Namespace)
+		for _, bh := range specStrings(b.Spec, field) {
+			if hostsOverlap(qa, qualifyHost(bh, b.Metadata.Namespace)) {
+				shared = append(shared, ah)
+				break
+			}
+		}
+	}
+	return shared
+}
+
+// gatewaysOverlap reports whether two VirtualServices are bound to at least one common gateway.
+// A VirtualService without gateways is bound to the "mesh" gateway.
+func gatewaysOverlap(a, b IstioObject) bool {
+	normalize := func(obj IstioObject) map[string]struct{} {
+		gateways := specStrings(obj.Spec, "gateways")
+		if len(gateways) == 0 {
+			gateways = []string{"mesh"}
+		}
+		ret := make(map[string]struct{}, len(gateways))
+		for _, gw := range gateways {
+			if gw != "mesh" && !strings.Contains(gw, "/") {
+				gw = obj.Metadata.Namespace + "/" + gw
+			}
+			ret[gw] = struct{}{}
+		}
+		return ret
+	}
+	bGateways := normalize(b)
+	for gw := range normalize(a) {
+		if _, ok := bGateways[gw]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// overlappingGatewayServers returns "host:port" descriptions of the servers exposed by both gateways.
+func overlappingGatewayServers(a, b IstioObject) []string {
+	shared := make([]string, 0)
+	aServers, _ := a.Spec["servers"].([]any)
+	bServers, _ := b.Spec["servers"].([]any)
+	for _, as := range aServers {
+		aServer, _ := as.(map[string]any)
+		aPort := fmt.Sprint(specMap(aServer, "port")["number"])
+		for _, bs := range bServers {
+			bServer, _ := bs.(map[string]any)
+			if aPort != fmt.Sprint(specMap(bServer, "port")["number"]) {
+				continue
+			}
+			for _, ah := range specStrings(aServer, "hosts") {
+				for _, bh := range specStrings(bServer, "hosts") {
+					if hostsOverlap(hostWithoutNamespace(ah), hostWithoutNamespace(bh)) {
+						shared = append(shared, fmt.Sprintf("%s:%s", ah, aPort))
+					}
+				}
+			}
+		}
+	}
+	return shared
+}
+
+// hostWithoutNamespace strips the optional "namespace/" prefix from a Gateway server host.
+func hostWithoutNamespace(host string) string {
+	if _, h, found := strings.Cut(host, "/"); found {
+		return strings.ToLower(h)
+	}
+	return strings.ToLower(host)
+}
+
+// formatLabels renders labels as a sorted, comma-separated key=value list.
+func formatLabels(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for _, key := range slices.Sorted(maps.Keys(labels)) {
+		pairs = append(pairs, key+"="+labels[key])
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
@@ -0,0 +1,58 @@
+package kiali
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// knownIstioGVKs are the supported Istio and Gateway API kinds, by group and version.
+var knownIstioGVKs = map[string]map[string][]string{
+	"networking.istio.io": {
+		"v1":       {"DestinationRule", "Gateway", "ServiceEntry", "Sidecar", "VirtualService", "WorkloadEntry", "WorkloadGroup"},
+		"v1beta1":  {"DestinationRule", "Gateway", "ProxyConfig", "ServiceEntry", "Sidecar", "VirtualService", "WorkloadEntry", "WorkloadGroup"},
+		"v1alpha3": {"DestinationRule", "EnvoyFilter", "Gateway", "ServiceEntry", "Sidecar", "VirtualService", "WorkloadEntry", "WorkloadGroup"},
+	},
+	"security.istio.io": {
+		"v1":      {"AuthorizationPolicy", "PeerAuthentication", "RequestAuthentication"},
+		"v1beta1": {"AuthorizationPolicy", "PeerAuthentication", "RequestAuthentication"},
+	},
+	"telemetry.istio.io": {
+		"v1":       {"Telemetry"},
+		"v1alpha1": {"Telemetry"},
+	},
+	"extensions.istio.io": {
+		"v1alpha1": {"WasmPlugin"},
+	},
+	"gateway.networking.k8s.io": {
+		"v1":       {"GRPCRoute", "Gateway", "GatewayClass", "HTTPRoute"},
+		"v1beta1":  {"Gateway", "GatewayClass", "HTTPRoute", "ReferenceGrant"},
+		"v1alpha2": {"GRPCRoute", "ReferenceGrant", "TCPRoute", "TLSRoute", "UDPRoute"},
+	},
+}
+
+// ValidateIstioGVK checks that the group, version and kind are a known Istio or Gateway API combination.
+// The error lists the valid combinations for the kind, or all of them when the kind is unknown.
+func ValidateIstioGVK(group, version, kind string) error {
+	if slices.Contains(knownIstioGVKs[group][version], kind) {
+		return nil
+	}
+	var valid []string
+	for _, g := range slices.Sorted(maps.Keys(knownIstioGVKs)) {
+		for _, v := range slices.Sorted(maps.Keys(knownIstioGVKs[g])) {
+			if slices.Contains(knownIstioGVKs[g][v], kind) {
+				valid = append(valid, g+"/"+v+"/"+kind)
+			}
+		}
+	}
+	if len(valid) > 0 {
+		return fmt.Errorf("unknown group/version/kind %s/%s/%s, valid combinations for %s are: %s", group, version, kind, kind, strings.Join(valid, ", "))
+	}
+	for _, g := range slices.Sorted(maps.Keys(knownIstioGVKs)) {
+		for _, v := range slices.Sorted(maps.Keys(knownIstioGVKs[g])) {
+			valid = append(valid, g+"/"+v+": "+strings.Join(knownIstioGVKs[g][v], ", "))
+		}
+	}
+	return fmt.Errorf("unknown group/version/kind %s/%s/%s, valid combinations are: %s", group, version, kind, strings.Join(valid, "; "))
+}
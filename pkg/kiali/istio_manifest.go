@@ -0,0 +1,320 @@
+package kiali
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/yaml"
+)
+
+// Severities of the issues found by ValidateIstioManifest.
+const (
+	ManifestIssueError   = "error"
+	ManifestIssueWarning = "warning"
+)
+
+// ManifestValidation is the result of the local validation of an Istio object manifest.
+// The manifest is valid when no issue is an error; warnings point at likely mistakes.
+type ManifestValidation struct {
+	APIVersion string          `json:"apiVersion,omitempty"`
+	Kind       string          `json:"kind,omitempty"`
+	Namespace  string          `json:"namespace,omitempty"`
+	Name       string          `json:"name,omitempty"`
+	Valid      bool            `json:"valid"`
+	Issues     []ManifestIssue `json:"issues"`
+}
+
+// ManifestIssue is a problem found in a manifest, at the given path of the object (e.g. "spec.http[0].route").
+type ManifestIssue struct {
+	Severity string `json:"severity"`
+	Path     string `json:"path"`
+	Message  string `json:"message"`
+}
+
+// workloadSelectorKinds are the Istio kinds applying to the workloads matched by spec.selector.matchLabels
+// (spec.workloadSelector.labels for Sidecar), or to the whole namespace without selector.
+var workloadSelectorKinds = []string{"AuthorizationPolicy", "EnvoyFilter", "PeerAuthentication", "RequestAuthentication", "Sidecar", "Telemetry", "WasmPlugin"}
+
+// ValidateIstioManifest structurally validates a YAML or JSON Istio object manifest, without contacting the
+// cluster, and returns the issues found as JSON. The following is checked:
+//   - apiVersion and kind are a known Istio or Gateway API combination (see ValidateIstioGVK)
+//   - metadata.name is set and is a valid DNS-1123 subdomain
+//   - the fields required by the kind are set, e.g. the hosts of a VirtualService or the host of a DestinationRule
+//   - the workload selector of selector-based kinds has labels, a warning is reported when there is no selector
+//
+// Parameters:
+//   - document: the YAML or JSON manifest of a single Istio object
+func ValidateIstioManifest(document string) (string, error) {
+	if strings.TrimSpace(document) == "" {
+		return "", fmt.Errorf("document is required")
+	}
+	data, err := yaml.YAMLToJSON([]byte(document))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse document: %v", err)
+	}
+	var obj map[string]any
+	if err := json.Unmarshal(data, &obj); err != nil || obj == nil {
+		return "", fmt.Errorf("failed to parse document: the document must be a YAML or JSON object")
+	}
+	result, err := json.MarshalIndent(validateIstioManifest(obj), "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal manifest validation: %v", err)
+	}
+	return string(result), nil
+}
+
+// manifestValidator accumulates the issues found in a manifest.
+type manifestValidator struct {
+	issues []ManifestIssue
+}
+
+func (v *manifestValidator) errorf(path, format string, args ...any) {
+	v.issues = append(v.issues, ManifestIssue{Severity: ManifestIssueError, Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *manifestValidator) warnf(path, format string, args ...any) {
+	v.issues = append(v.issues, ManifestIssue{Severity: ManifestIssueWarning, Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// requireString reports an error when the field of the object is not a non-empty string.
+func (v *manifestValidator) requireString(obj map[string]any, path, field string) string {
+	value, ok := obj[field].(string)
+	if !ok || value == "" {
+		v.errorf(path+"."+field, "%s is required", field)
+	}
+	return value
+}
+
+// requireList reports an error when the field of the object is not a non-empty list.
+func (v *manifestValidator) requireList(obj map[string]any, path, field string) []any {
+	value, ok := obj[field].([]any)
+	if !ok || len(value) == 0 {
+		v.errorf(path+"."+field, "%s must be a non-empty list", field)
+	}
+	return value
+}
+
+// validateIstioManifest runs the structural checks on a parsed manifest.
+func validateIstioManifest(obj map[string]any) *ManifestValidation {
+	v := &manifestValidator{}
+	result := &ManifestValidation{}
+	result.APIVersion, _ = obj["apiVersion"].(string)
+	result.Kind, _ = obj["kind"].(string)
+	metadata, _ := obj["metadata"].(map[string]any)
+	result.Name, _ = metadata["name"].(string)
+	result.Namespace, _ = metadata["namespace"].(string)
+
+	if result.APIVersion == "" {
+		v.errorf("apiVersion", "apiVersion is required")
+	}
+	if result.Kind == "" {
+		v.errorf("kind", "kind is required")
+	}
+	if result.APIVersion != "" && result.Kind != "" {
+		group, version, _ := strings.Cut(result.APIVersion, "/")
+		if err := ValidateIstioGVK(group, version, result.Kind); err != nil {
+			v.errorf("apiVersion", "%v", err)
+		}
+	}
+
+	if metadata == nil {
+		v.errorf("metadata", "metadata is required")
+	} else if result.Name == "" {
+		v.errorf("metadata.name", "name is required")
+	} else if errs := validation.IsDNS1123Subdomain(result.Name); len(errs) > 0 {
+		v.errorf("metadata.name", "invalid name '%s': %s", result.Name, strings.Join(errs, "; "))
+	}
+	if result.Namespace != "" {
+		if errs := validation.IsDNS1123Label(result.Namespace); len(errs) > 0 {
+			v.errorf("metadata.namespace", "invalid namespace '%s': %s", result.Namespace, strings.Join(errs, "; "))
+		}
+	}
+
+	spec, ok := obj["spec"].(map[string]any)
+	if !ok {
+		v.errorf("spec", "spec is required")
+	} else {
+		group, _, _ := strings.Cut(result.APIVersion, "/")
+		v.validateSpec(group, result.Kind, spec)
+	}
+
+	result.Issues = v.issues
+	if result.Issues == nil {
+		result.Issues = make([]ManifestIssue, 0)
+	}
+	result.Valid = true
+	for _, issue := range result.Issues {
+		if issue.Severity == ManifestIssueError {
+			result.Valid = false
+		}
+	}
+	return result
+}
+
+// validateSpec checks the fields required by the kind.
+func (v *manifestValidator) validateSpec(group, kind string, spec map[string]any) {
+	if group == "gateway.networking.k8s.io" {
+		switch kind {
+		case "Gateway":
+			v.requireString(spec, "spec", "gatewayClassName")
+			for i, listener := range v.requireList(spec, "spec", "listeners") {
+				path := fmt.Sprintf("spec.listeners[%d]", i)
+				l, _ := listener.(map[string]any)
+				v.requireString(l, path, "name")
+				v.requireString(l, path, "protocol")
+				if _, ok := l["port"].(float64); !ok {
+					v.errorf(path+".port", "port is required")
+				}
+			}
+		case "HTTPRoute", "GRPCRoute", "TCPRoute", "TLSRoute", "UDPRoute":
+			if refs, _ := spec["parentRefs"].([]any); len(refs) == 0 {
+				v.warnf("spec.parentRefs", "no parentRefs: the route is not attached to any Gateway")
+			}
+			rules, _ := spec["rules"].([]any)
+			for i, rule := range rules {
+				r, _ := rule.(map[string]any)
+				backends, _ := r["backendRefs"].([]any)
+				for j, backend := range backends {
+					b, _ := backend.(map[string]any)
+					v.requireString(b, fmt.Sprintf("spec.rules[%d].backendRefs[%d]", i, j), "name")
+				}
+			}
+		}
+		return
+	}
+
+	switch kind {
+	case "VirtualService":
+		v.requireList(spec, "spec", "hosts")
+		routes := 0
+		for _, protocol := range []string{"http", "tls", "tcp"} {
+			list, _ := spec[protocol].([]any)
+			routes += len(list)
+			for i, route := range list {
+				r, _ := route.(map[string]any)
+				v.validateRouteDestinations(r, fmt.Sprintf("spec.%s[%d]", protocol, i), protocol == "http")
+			}
+		}
+		if routes == 0 {
+			v.errorf("spec", "at least one http, tls or tcp route is required")
+		}
+	case "DestinationRule":
+		v.requireString(spec, "spec", "host")
+		subsets, _ := spec["subsets"].([]any)
+		names := map[string]bool{}
+		for i, subset := range subsets {
+			path := fmt.Sprintf("spec.subsets[%d]", i)
+			s, _ := subset.(map[string]any)
+			name := v.requireString(s, path, "name")
+			if name != "" && names[name] {
+				v.errorf(path+".name", "duplicate subset name '%s'", name)
+			}
+			names[name] = true
+			if labels, _ := s["labels"].(map[string]any); len(labels) == 0 {
+				v.warnf(path+".labels", "subset has no labels: it selects all the endpoints of the host")
+			}
+		}
+	case "Gateway":
+		if selector, _ := spec["selector"].(map[string]any); len(selector) == 0 {
+			v.warnf("spec.selector", "no selector: the Gateway is not bound to any gateway workload")
+		}
+		for i, server := range v.requireList(spec, "spec", "servers") {
+			path := fmt.Sprintf("spec.servers[%d]", i)
+			s, _ := server.(map[string]any)
+			port, _ := s["port"].(map[string]any)
+			if port == nil {
+				v.errorf(path+".port", "port is required")
+			} else {
+				if _, ok := port["number"].(float64); !ok {
+					v.errorf(path+".port.number", "number is required")
+				}
+				v.requireString(port, path+".port", "protocol")
+			}
+			v.requireList(s, path, "hosts")
+		}
+	case "ServiceEntry":
+		v.requireList(spec, "spec", "hosts")
+		ports, _ := spec["ports"].([]any)
+		for i, port := range ports {
+			path := fmt.Sprintf("spec.ports[%d]", i)
+			p, _ := port.(map[string]any)
+			if _, ok := p["number"].(float64); !ok {
+				v.errorf(path+".number", "number is required")
+			}
+			v.requireString(p, path, "name")
+		}
+	case "WorkloadEntry":
+		if address, _ := spec["address"].(string); address == "" {
+			if network, _ := spec["network"].(string); network == "" {
+				v.errorf("spec.address", "address is required when no network is set")
+			}
+		}
+	case "WorkloadGroup":
+		if template, _ := spec["template"].(map[string]any); template == nil {
+			v.errorf("spec.template", "template is required")
+		}
+	case "EnvoyFilter":
+		v.requireList(spec, "spec", "configPatches")
+	}
+
+	if slices.Contains(workloadSelectorKinds, kind) {
+		v.validateWorkloadSelector(kind, spec)
+	}
+}
+
+// validateRouteDestinations checks that each destination of the route has a host and that the weights of
+// several destinations add up to 100.
+func (v *manifestValidator) validateRouteDestinations(route map[string]any, path string, http bool) {
+	destinations, _ := route["route"].([]any)
+	if len(destinations) == 0 {
+		if _, redirect := route["redirect"]; http && redirect {
+			return
+		}
+		if _, direct := route["directResponse"]; http && direct {
+			return
+		}
+		v.errorf(path+".route", "route must be a non-empty list")
+		return
+	}
+	var weights float64
+	for i, destination := range destinations {
+		d, _ := destination.(map[string]any)
+		target, _ := d["destination"].(map[string]any)
+		if target == nil {
+			v.errorf(fmt.Sprintf("%s.route[%d].destination", path, i), "destination is required")
+		} else {
+			v.requireString(target, fmt.Sprintf("%s.route[%d].destination", path, i), "host")
+		}
+		weight, _ := d["weight"].(float64)
+		weights += weight
+	}
+	if len(destinations) > 1 && weights != 100 {
+		v.errorf(path+".route", "the weights of the destinations add up to %v, not 100", weights)
+	}
+}
+
+// validateWorkloadSelector checks the workload selector of the selector-based kinds.
+func (v *manifestValidator) validateWorkloadSelector(kind string, spec map[string]any) {
+	path, field, labelsField := "spec.selector", "selector", "matchLabels"
+	if kind == "Sidecar" {
+		path, field, labelsField = "spec.workloadSelector", "workloadSelector", "labels"
+	}
+	selector, present := spec[field]
+	if !present {
+		if _, ok := spec["targetRef"]; ok {
+			return
+		}
+		if _, ok := spec["targetRefs"]; ok {
+			return
+		}
+		v.warnf(path, "no %s: the %s applies to all the workloads of the namespace (or of the mesh in the root namespace)", field, kind)
+		return
+	}
+	s, _ := selector.(map[string]any)
+	if labels, _ := s[labelsField].(map[string]any); len(labels) == 0 {
+		v.errorf(path+"."+labelsField, "%s must have at least one label", field)
+	}
+}
@@ -0,0 +1,80 @@
+package kiali
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// IstioObjectLocation identifies an Istio object compared by IstioObjectsDiff.
+type IstioObjectLocation struct {
+	Namespace string `json:"namespace"`
+	Group     string `json:"group"`
+	Version   string `json:"version"`
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Cluster   string `json:"cluster,omitempty"`
+}
+
+// String returns the location as "cluster:namespace/kind/name", without the cluster when not set.
+func (l IstioObjectLocation) String() string {
+	ret := l.Namespace + "/" + l.Kind + "/" + l.Name
+	if l.Cluster != "" {
+		ret = l.Cluster + ":" + ret
+	}
+	return ret
+}
+
+// IstioObjectsDiff lists the differences between the specs of two Istio objects. Before is the value of the
+// first object and After the value of the second one.
+type IstioObjectsDiff struct {
+	First     IstioObjectLocation `json:"first"`
+	Second    IstioObjectLocation `json:"second"`
+	Identical bool                `json:"identical"`
+	Changes   []SpecChange        `json:"changes"`
+}
+
+// IstioObjectsDiff fetches, concurrently with IstioObjectDetails, two Istio objects, e.g. the same
+// VirtualService in two namespaces or two clusters, and returns, as JSON, the differences between their specs.
+// Parameters:
+//   - first: the first object, its cluster is optional (default: the Kiali home cluster)
+//   - second: the second object, its cluster is optional (default: the Kiali home cluster)
+func (k *Kiali) IstioObjectsDiff(ctx context.Context, first IstioObjectLocation, second IstioObjectLocation) (string, error) {
+	if first == second {
+		return "", fmt.Errorf("the two objects are the same object %s", first)
+	}
+	locations := []IstioObjectLocation{first, second}
+	values := make([]map[string]string, len(locations))
+	g, gctx := errgroup.WithContext(ctx)
+	for i, l := range locations {
+		g.Go(func() error {
+			content, err := k.IstioObjectDetails(gctx, l.Namespace, l.Group, l.Version, l.Kind, l.Name, l.Cluster)
+			if err != nil {
+				return fmt.Errorf("failed to get %s: %v", l, err)
+			}
+			if values[i], err = patchedObjectValues(content); err != nil {
+				return fmt.Errorf("failed to read %s: %v", l, err)
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return "", err
+	}
+	diff := &IstioObjectsDiff{First: first, Second: second, Changes: make([]SpecChange, 0)}
+	for _, change := range diffSpecValues(values[0], values[1]) {
+		// Only the specs are compared, labels and annotations usually differ between environments
+		if change.Path == "spec" || strings.HasPrefix(change.Path, "spec.") || strings.HasPrefix(change.Path, "spec[") {
+			diff.Changes = append(diff.Changes, change)
+		}
+	}
+	diff.Identical = len(diff.Changes) == 0
+	result, err := json.MarshalIndent(diff, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal Istio objects diff: %v", err)
+	}
+	return string(result), nil
+}
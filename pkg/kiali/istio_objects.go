@@ -0,0 +1,211 @@
+package kiali
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// IstioObject is the subset of an Istio/Gateway API object used by the client-side analysis helpers.
+type IstioObject struct {
+	APIVersion string         `json:"apiVersion,omitempty"`
+	Kind       string         `json:"kind,omitempty"`
+	Metadata   ObjectMeta     `json:"metadata"`
+	Spec       map[string]any `json:"spec,omitempty"`
+}
+
+// ObjectMeta is the subset of Kubernetes object metadata used by the client-side analysis helpers.
+type ObjectMeta struct {
+	Name        string            `json:"name"`
+	Namespace   string            `json:"namespace,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// CreationTimestamp is zero when Kiali does not report it
+	CreationTimestamp time.Time `json:"creationTimestamp,omitzero"`
+}
+
+// legacyIstioConfigFields maps the per-kind list fields returned by older Kiali versions to the object kind.
+var legacyIstioConfigFields = map[string]string{
+	"authorizationPolicies":  "AuthorizationPolicy",
+	"destinationRules":       "DestinationRule",
+	"envoyFilters":           "EnvoyFilter",
+	"gateways":               "Gateway",
+	"k8sGateways":            "Gateway",
+	"k8sHTTPRoutes":          "HTTPRoute",
+	"peerAuthentications":    "PeerAuthentication",
+	"requestAuthentications": "RequestAuthentication",
+	"serviceEntries":         "ServiceEntry",
+	"sidecars":               "Sidecar",
+	"virtualServices":        "VirtualService",
+	"workloadEntries":        "WorkloadEntry",
+	"workloadGroups":         "WorkloadGroup",
+}
+
+// parseIstioConfigObjects flattens the response of the Kiali Istio config API into a list of objects.
+// Both the current `resources` map (keyed by "group/version, Kind=Kind") and the per-kind lists
+// returned by older Kiali versions are supported. Objects are sorted by kind, namespace and name.
+func parseIstioConfigObjects(content string) ([]IstioObject, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(content), &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse Istio config: %v", err)
+	}
+	objects := make([]IstioObject, 0)
+	if resources, ok := raw["resources"]; ok {
+		var byGVK map[string][]IstioObject
+		if err := json.Unmarshal(resources, &byGVK); err != nil {
+			return nil, fmt.Errorf("failed to parse Istio config resources: %v", err)
+		}
+		for gvk, list := range byGVK {
+			apiVersion, kind := splitGVKKey(gvk)
+			for _, obj := range list {
+				if obj.Kind == "" {
+					obj.Kind = kind
+				}
+				if obj.APIVersion == "" {
+					obj.APIVersion = apiVersion
+				}
+				objects = append(objects, obj)
+			}
+		}
+	}
+	for field, kind := range legacyIstioConfigFields {
+		list, ok := raw[field]
+		if !ok {
+			continue
+		}
+		var items []IstioObject
+		if err := json.Unmarshal(list, &items); err != nil {
+			continue
+		}
+		for _, obj := range items {
+			if obj.Kind == "" {
+				obj.Kind = kind
+			}
+			objects = append(objects, obj)
+		}
+	}
+	sort.SliceStable(objects, func(i, j int) bool {
+		if objects[i].Kind != objects[j].Kind {
+			return objects[i].Kind < objects[j].Kind
+		}
+		if objects[i].Metadata.Namespace != objects[j].Metadata.Namespace {
+			return objects[i].Metadata.Namespace < objects[j].Metadata.Namespace
+		}
+		return objects[i].Metadata.Name < objects[j].Metadata.Name
+	})
+	return objects, nil
+}
+
+// splitGVKKey splits a Kiali resources key such as "networking.istio.io/v1, Kind=VirtualService"
+// into its apiVersion and kind.
+func splitGVKKey(key string) (string, string) {
+	apiVersion, kind, found := strings.Cut(key, ", Kind=")
+	if !found {
+		return "", strings.TrimSpace(key)
+	}
+	return strings.TrimSpace(apiVersion), strings.TrimSpace(kind)
+}
+
+// istioObjectsOfKind returns the objects matching the given kind, optionally restricted to a namespace.
+func istioObjectsOfKind(objects []IstioObject, kind, namespace string) []IstioObject {
+	ret := make([]IstioObject, 0)
+	for _, obj := range objects {
+		if obj.Kind != kind {
+			continue
+		}
+		if namespace != "" && obj.Metadata.Namespace != namespace {
+			continue
+		}
+		ret = append(ret, obj)
+	}
+	return ret
+}
+
+// specStrings returns the string values of the given spec list field.
+func specStrings(spec map[string]any, field string) []string {
+	values, _ := spec[field].([]any)
+	ret := make([]string, 0, len(values))
+	for _, v := range values {
+		if s, ok := v.(string); ok && s != "" {
+			ret = append(ret, s)
+		}
+	}
+	return ret
+}
+
+// specString returns the string value of the given spec field.
+func specString(spec map[string]any, field string) string {
+	s, _ := spec[field].(string)
+	return s
+}
+
+// specMap returns the map value of the given spec field.
+func specMap(spec map[string]any, field string) map[string]any {
+	m, _ := spec[field].(map[string]any)
+	return m
+}
+
+// specLabels returns the string map value of the given spec field (e.g. a selector's matchLabels).
+func specLabels(spec map[string]any, field string) map[string]string {
+	raw := specMap(spec, field)
+	ret := make(map[string]string, len(raw))
+	for k, v := range raw {
+		if s, ok := v.(string); ok {
+			ret[k] = s
+		}
+	}
+	return ret
+}
+
+// workloadSelector returns the labels of the object's workload selector, supporting the
+// `selector.matchLabels` (policies), `workloadSelector.labels` (Sidecar) and plain `selector` (Gateway) shapes.
+func workloadSelector(spec map[string]any) map[string]string {
+	for _, field := range []string{"selector", "workloadSelector"} {
+		sel := specMap(spec, field)
+		if sel == nil {
+			continue
+		}
+		if labels := specLabels(sel, "matchLabels"); len(labels) > 0 {
+			return labels
+		}
+		if labels := specLabels(sel, "labels"); len(labels) > 0 {
+			return labels
+		}
+		if labels := specLabels(spec, field); len(labels) > 0 {
+			return labels
+		}
+	}
+	return nil
+}
+
+// qualifyHost expands a short Kubernetes service host to its FQDN using the given namespace.
+// Wildcards and hosts that are already fully qualified are returned unchanged.
+func qualifyHost(host, namespace string) string {
+	host = strings.ToLower(strings.TrimSpace(host))
+	if host == "" || strings.HasPrefix(host, "*") || namespace == "" {
+		return host
+	}
+	switch strings.Count(host, ".") {
+	case 0:
+		return host + "." + namespace + ".svc.cluster.local"
+	case 1:
+		return host + ".svc.cluster.local"
+	}
+	return host
+}
+
+// hostsOverlap reports whether two (qualified) hosts can match the same request, honoring wildcards.
+func hostsOverlap(a, b string) bool {
+	if a == b || a == "*" || b == "*" {
+		return true
+	}
+	if strings.HasPrefix(a, "*.") && strings.HasSuffix(b, a[1:]) {
+		return true
+	}
+	if strings.HasPrefix(b, "*.") && strings.HasSuffix(a, b[1:]) {
+		return true
+	}
+	return false
+}
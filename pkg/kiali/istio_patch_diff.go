@@ -0,0 +1,122 @@
+package kiali
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// IstioObjectPatchDiff lists the changes a patch made to the spec, labels and annotations of an Istio object.
+type IstioObjectPatchDiff struct {
+	Kind      string       `json:"kind"`
+	Namespace string       `json:"namespace"`
+	Name      string       `json:"name"`
+	Changes   []SpecChange `json:"changes"`
+	Note      string       `json:"note,omitempty"`
+}
+
+// SpecChange is a changed leaf value, addressed by its path as in flattenSpec (e.g. "spec.http[0].timeout").
+// Before is not set for added values and After is not set for removed values.
+type SpecChange struct {
+	Path   string  `json:"path"`
+	Before *string `json:"before,omitempty"`
+	After  *string `json:"after,omitempty"`
+}
+
+// IstioObjectPatchDiff patches an existing Istio object like IstioObjectPatch, but returns, as JSON, only the
+// changes between the object before and after the patch instead of the whole patched object.
+// The object is read before being patched, so the patch is not applied when it cannot be read.
+// If the patched object cannot be parsed, the Kiali response is returned unchanged.
+// Parameters:
+//   - namespace: the namespace containing the Istio object
+//   - group: the API group (e.g., "networking.istio.io", "gateway.networking.k8s.io")
+//   - version: the API version (e.g., "v1", "v1beta1")
+//   - kind: the resource kind (e.g., "DestinationRule", "VirtualService", "HTTPRoute")
+//   - name: the name of the resource
+//   - jsonPatch: the JSON patch data to apply
+//   - clusterName: the cluster of the resource in multi-cluster meshes (optional, default: the Kiali home cluster)
+func (k *Kiali) IstioObjectPatchDiff(ctx context.Context, namespace, group, version, kind, name, jsonPatch, clusterName string) (string, error) {
+	before, err := k.IstioObjectDetails(ctx, namespace, group, version, kind, name, clusterName)
+	if err != nil {
+		return "", err
+	}
+	beforeValues, err := patchedObjectValues(before)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the object before patching it: %v", err)
+	}
+	after, err := k.IstioObjectPatch(ctx, namespace, group, version, kind, name, jsonPatch, clusterName)
+	if err != nil {
+		return "", err
+	}
+	afterValues, err := patchedObjectValues(after)
+	if err != nil {
+		return after, nil
+	}
+	diff := &IstioObjectPatchDiff{Kind: kind, Namespace: namespace, Name: name, Changes: diffSpecValues(beforeValues, afterValues)}
+	if len(diff.Changes) == 0 {
+		diff.Note = "the patch did not change the spec, labels or annotations of the object"
+	}
+	result, err := json.MarshalIndent(diff, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal patch diff: %v", err)
+	}
+	return string(result), nil
+}
+
+// patchedObjectValues flattens the spec, labels and annotations of an Istio object, as returned by the Kiali
+// details and patch APIs, either wrapped in a "resource" field or not.
+func patchedObjectValues(content string) (map[string]string, error) {
+	var details struct {
+		Resource *IstioObject `json:"resource"`
+		IstioObject
+	}
+	if err := json.Unmarshal([]byte(content), &details); err != nil {
+		return nil, fmt.Errorf("failed to parse Istio object: %v", err)
+	}
+	object := details.IstioObject
+	if details.Resource != nil {
+		object = *details.Resource
+	}
+	if object.Metadata.Name == "" && object.Spec == nil {
+		return nil, fmt.Errorf("failed to parse Istio object: no metadata or spec")
+	}
+	values := map[string]any{"spec": object.Spec}
+	metadata := map[string]any{}
+	if len(object.Metadata.Labels) > 0 {
+		metadata["labels"] = stringMapValues(object.Metadata.Labels)
+	}
+	if len(object.Metadata.Annotations) > 0 {
+		metadata["annotations"] = stringMapValues(object.Metadata.Annotations)
+	}
+	values["metadata"] = metadata
+	return flattenSpecValues(values), nil
+}
+
+// stringMapValues converts a string map to a generic one, to flatten it with flattenSpecValues.
+func stringMapValues(m map[string]string) map[string]any {
+	ret := make(map[string]any, len(m))
+	for key, value := range m {
+		ret[key] = value
+	}
+	return ret
+}
+
+// diffSpecValues returns the changes between two flattened objects, sorted by path.
+func diffSpecValues(before, after map[string]string) []SpecChange {
+	changes := make([]SpecChange, 0)
+	for path, value := range before {
+		if afterValue, ok := after[path]; !ok {
+			changes = append(changes, SpecChange{Path: path, Before: &value})
+		} else if afterValue != value {
+			changes = append(changes, SpecChange{Path: path, Before: &value, After: &afterValue})
+		}
+	}
+	for path, value := range after {
+		if _, ok := before[path]; !ok {
+			changes = append(changes, SpecChange{Path: path, After: &value})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes
+}
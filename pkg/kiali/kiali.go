@@ -1,6 +1,7 @@
 package kiali
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"errors"
@@ -8,6 +9,8 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/kiali/kiali-mcp-server/pkg/config"
@@ -19,12 +22,30 @@ import (
 
 type Kiali struct {
 	manager *Manager
+	// cfg holds the kubeconfig credentials of the cluster targeted by the call, nil for the current context
+	cfg *rest.Config
 }
 
 type Manager struct {
 	cfg             *rest.Config
 	clientCmdConfig clientcmd.ClientConfig
-	staticConfig    *config.StaticConfig
+	// clusterConfigs holds the kubeconfig credentials of the clusters mapped by kiali_cluster_contexts
+	clusterConfigs map[string]*rest.Config
+	staticConfig   *config.StaticConfig
+	// namespaces caches the namespaces list, nil when caching is disabled
+	namespaces *namespacesCache
+	// inFlight counts the Kiali requests currently being executed
+	inFlight    atomic.Int32
+	stopRefresh context.CancelFunc
+	refreshDone chan struct{}
+	// healthSem bounds the Kiali health requests in flight, see healthSlots
+	healthSem       chan struct{}
+	healthSlotsOnce sync.Once
+	// validationSnapshots keeps the last validations snapshot per caller, see validationHistory
+	validationSnapshots     *validationSnapshots
+	validationSnapshotsOnce sync.Once
+	// healthRates caches the health configuration of Kiali, see healthRates
+	healthRates healthRatesCache
 }
 
 func NewManager(config *config.StaticConfig) (*Manager, error) {
@@ -36,13 +57,23 @@ func NewManager(config *config.StaticConfig) (*Manager, error) {
 		if err := resolveKialiRequiredConfigurations(kiali); err != nil {
 			return nil, err
 		}
+		kiali.initNamespacesCache(config)
+		if kiali.namespaces != nil && config.KialiNamespacesBackgroundRefresh {
+			interval := kiali.namespaces.ttl / 2
+			if d, err := time.ParseDuration(config.KialiNamespacesRefreshInterval); err == nil && d > 0 {
+				interval = d
+			}
+			kiali.startNamespacesRefresh(interval)
+		}
 	}
 	return kiali, nil
 }
 
 // NewFromConfig creates a new Kiali client backed by the given static configuration.
 func NewFromConfig(cfg *config.StaticConfig) *Kiali {
-	return &Kiali{manager: &Manager{staticConfig: cfg}}
+	m := &Manager{staticConfig: cfg}
+	m.initNamespacesCache(cfg)
+	return &Kiali{manager: m}
 }
 
 // validateAndGetBaseURL validates the Kiali client configuration and returns the base URL.
@@ -57,13 +88,50 @@ func (k *Kiali) validateAndGetBaseURL() (string, error) {
 	return baseURL, nil
 }
 
+// DefaultRequestTimeout is the timeout of a Kiali request when kiali_request_timeout is not set and the tool
+// call has no deadline of its own.
+const DefaultRequestTimeout = 30 * time.Second
+
+// ValidateRequestTimeout checks the configured Kiali request timeout.
+func ValidateRequestTimeout(timeout string) error {
+	if timeout == "" {
+		return nil
+	}
+	if d, err := time.ParseDuration(timeout); err != nil || d <= 0 {
+		return fmt.Errorf("invalid kiali_request_timeout: %q must be a positive duration (e.g. 1m)", timeout)
+	}
+	return nil
+}
+
 // createHTTPClient creates an HTTP client with appropriate TLS configuration.
-func (k *Kiali) createHTTPClient() *http.Client {
+// Redirects are not followed when kiali_disable_redirects is set.
+// When the context already carries a deadline (e.g. a per-tool timeout) the deadline governs the request,
+// otherwise the configured kiali_request_timeout applies.
+func (k *Kiali) createHTTPClient(ctx context.Context) *http.Client {
 	transport := &http.Transport{}
 	if k.manager.staticConfig.KialiInsecure {
 		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true} //nolint:gosec // allowed via configuration
 	}
-	return &http.Client{Transport: transport, Timeout: 30 * time.Second}
+	timeout := k.requestTimeout()
+	if _, ok := ctx.Deadline(); ok {
+		timeout = 0
+	}
+	client := &http.Client{Transport: transport, Timeout: timeout}
+	if k.manager.staticConfig.KialiDisableRedirects {
+		// Surface the redirect itself instead of the page it points to (e.g. an auth proxy login page)
+		client.CheckRedirect = func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		}
+	}
+	return client
+}
+
+// requestTimeout returns the configured Kiali request timeout, or DefaultRequestTimeout when it is not set.
+func (k *Kiali) requestTimeout() time.Duration {
+	if d, err := time.ParseDuration(k.manager.staticConfig.KialiRequestTimeout); err == nil && d > 0 {
+		return d
+	}
+	return DefaultRequestTimeout
 }
 
 // CurrentAuthorizationHeader returns the Authorization header value that the
@@ -74,11 +142,18 @@ func (k *Kiali) CurrentAuthorizationHeader(ctx context.Context) string {
 	token = strings.TrimSpace(token)
 
 	if token == "" {
-		// Fall back to using the same token that the Kubernetes client is using
-		if k == nil || k.manager == nil || k.manager.cfg == nil {
+		// Fall back to using the same token that the Kubernetes client is using, or the one of the cluster context
+		if k == nil || k.manager == nil {
 			return ""
 		}
-		token = strings.TrimSpace(k.manager.cfg.BearerToken)
+		cfg := k.cfg
+		if cfg == nil {
+			cfg = k.manager.cfg
+		}
+		if cfg == nil {
+			return ""
+		}
+		token = strings.TrimSpace(cfg.BearerToken)
 		if token == "" {
 			return ""
 		}
@@ -92,13 +167,49 @@ func (k *Kiali) CurrentAuthorizationHeader(ctx context.Context) string {
 }
 
 // executeRequest executes an HTTP request and handles common error scenarios.
+// Namespaces excluded by configuration are applied to the request and filtered out of the response.
+// Transient failures are retried up to kiali_max_retries times.
 func (k *Kiali) executeRequest(ctx context.Context, endpoint string) (string, error) {
-	klog.V(0).Infof("kiali API call: %s", endpoint)
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	endpoint, err := k.applyExcludedNamespaces(endpoint)
+	if err != nil {
+		return "", err
+	}
+	var body []byte
+	err = k.withRequestRetries(ctx, http.MethodGet, endpoint, func() error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+		if err != nil {
+			return err
+		}
+		done, err := k.prepareRequest(ctx, req, endpoint)
+		if err != nil {
+			return err
+		}
+		defer done()
+
+		client := k.createHTTPClient(ctx)
+		start := time.Now()
+		resp, err := client.Do(req)
+		if err != nil {
+			return classifyTimeout(err, endpoint, start)
+		}
+		defer resp.Body.Close()
+		body, _ = io.ReadAll(resp.Body)
+		if err := responseError(resp, body); err != nil {
+			return classifyNamespaceError(err, http.MethodGet, endpoint)
+		}
+		return nil
+	})
 	if err != nil {
 		return "", err
 	}
+	return k.filterExcludedNamespaces(endpoint, string(body))
+}
 
+// prepareRequest applies the setup shared by all the Kiali requests before they are sent: it logs the request,
+// described by label, with its correlation ID, sets its Authorization header and counts it as in flight until
+// the returned function is called.
+func (k *Kiali) prepareRequest(ctx context.Context, req *http.Request, label string) (func(), error) {
+	klog.V(0).Infof("kiali API call: %s%s", label, k.setCorrelationID(ctx, req))
 	authHeader := k.CurrentAuthorizationHeader(ctx)
 	if authHeader == "" {
 		// Ensure tests and mock servers receive an Authorization header
@@ -107,68 +218,80 @@ func (k *Kiali) executeRequest(ctx context.Context, endpoint string) (string, er
 	if authHeader != "" {
 		req.Header.Set("Authorization", authHeader)
 	} else if k.manager.staticConfig.RequireOAuth {
-		return "", fmt.Errorf("authorization token required for Kiali call")
+		return nil, fmt.Errorf("authorization token required for Kiali call")
 	}
-
-	client := k.createHTTPClient()
-	resp, err := client.Do(req)
-	if err != nil {
-		return "", err
-	}
-	defer resp.Body.Close()
-	body, _ := io.ReadAll(resp.Body)
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		if len(body) > 0 {
-			return "", fmt.Errorf("kiali API error: %s", strings.TrimSpace(string(body)))
-		}
-		return "", fmt.Errorf("kiali API error: status %d", resp.StatusCode)
-	}
-	return string(body), nil
+	k.manager.inFlight.Add(1)
+	return func() { k.manager.inFlight.Add(-1) }, nil
 }
 
 // executeRequestWithBody executes an HTTP request with a body and handles common error scenarios.
+// Calls targeting a namespace excluded by configuration are rejected.
+// Transient failures are retried up to kiali_max_retries times, only for idempotent methods: a POST or a PATCH
+// is attempted once.
 func (k *Kiali) executeRequestWithBody(ctx context.Context, method, endpoint, contentType string, body io.Reader) (string, error) {
-	klog.V(0).Infof("kiali API call: %s %s", method, endpoint)
-	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	endpoint, err := k.applyExcludedNamespaces(endpoint)
 	if err != nil {
 		return "", err
 	}
-	authHeader := k.CurrentAuthorizationHeader(ctx)
-	if authHeader == "" {
-		authHeader = "Bearer "
-	}
-	if authHeader != "" {
-		req.Header.Set("Authorization", authHeader)
-	} else if k.manager.staticConfig.RequireOAuth {
-		return "", fmt.Errorf("authorization token required for Kiali call")
-	}
-	if contentType != "" {
-		req.Header.Set("Content-Type", contentType)
+	// The body is read once, to be sent again by the retries
+	var payload []byte
+	if body != nil {
+		if payload, err = io.ReadAll(body); err != nil {
+			return "", err
+		}
 	}
+	var respBody []byte
+	err = k.withRequestRetries(ctx, method, endpoint, func() error {
+		var reqBody io.Reader
+		if body != nil {
+			reqBody = bytes.NewReader(payload)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, endpoint, reqBody)
+		if err != nil {
+			return err
+		}
+		done, err := k.prepareRequest(ctx, req, method+" "+endpoint)
+		if err != nil {
+			return err
+		}
+		defer done()
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
 
-	client := k.createHTTPClient()
-	resp, err := client.Do(req)
+		client := k.createHTTPClient(ctx)
+		start := time.Now()
+		resp, err := client.Do(req)
+		if err != nil {
+			return classifyTimeout(err, endpoint, start)
+		}
+		defer resp.Body.Close()
+		respBody, _ = io.ReadAll(resp.Body)
+		if err := responseError(resp, respBody); err != nil {
+			return classifyNamespaceError(err, method, endpoint)
+		}
+		return nil
+	})
 	if err != nil {
 		return "", err
 	}
-	defer resp.Body.Close()
-	respBody, _ := io.ReadAll(resp.Body)
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		if len(respBody) > 0 {
-			return "", fmt.Errorf("kiali API error: %s", strings.TrimSpace(string(respBody)))
-		}
-		return "", fmt.Errorf("kiali API error: status %d", resp.StatusCode)
-	}
 	return string(respBody), nil
 }
 
 func (m *Manager) Derived(ctx context.Context) (*Kiali, error) {
+	return m.DerivedForCluster(ctx, "")
+}
+
+// DerivedForCluster is Derived for a call targeting the given Kiali cluster: when kiali_cluster_contexts maps the
+// cluster to a kubeconfig context, the credentials of that context are used unless the call carries its own
+// token. Any other cluster, or an empty one, uses the current kubeconfig context.
+func (m *Manager) DerivedForCluster(ctx context.Context, clusterName string) (*Kiali, error) {
 	authorization, ok := ctx.Value(internalk8s.OAuthAuthorizationHeader).(string)
 	if !ok || !strings.HasPrefix(authorization, "Bearer ") {
 		if m.staticConfig != nil && m.staticConfig.RequireOAuth {
 			return nil, errors.New("oauth token required")
 		}
-		return &Kiali{manager: m}, nil
+		return &Kiali{manager: m, cfg: m.clusterConfigs[strings.TrimSpace(clusterName)]}, nil
 	}
 	// Authorization header is present; nothing special is needed for the Kiali HTTP client
 	klog.V(5).Infof("%s header found (Bearer)", internalk8s.OAuthAuthorizationHeader)
@@ -5,21 +5,45 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 )
 
+// DefaultWorkloadLogsMaxPods is the number of pods WorkloadLogs fetches the logs of when no limit is given.
+const DefaultWorkloadLogsMaxPods = 5
+
+// workloadDetailsAttempts is the minimum number of attempts of the workload details request WorkloadLogs discovers
+// the pods with, including the retries of kiali_max_retries.
+const workloadDetailsAttempts = 3
+
+// WorkloadLogsOptions are the optional parameters of WorkloadLogs.
+type WorkloadLogsOptions struct {
+	// Container is auto-detected with selectContainer for each pod when empty
+	Container string
+	Service   string
+	// Duration is the time window of the logs, e.g. "5m" or "1h"
+	Duration string
+	// LogType is the type of logs: app, proxy, ztunnel or waypoint
+	LogType string
+	// SinceTime and UntilTime are Unix timestamps bounding the logs, see PodLogs
+	SinceTime string
+	UntilTime string
+	// MaxLines is the maximum number of lines to return per pod
+	MaxLines string
+	// MaxPods is the maximum number of pods to fetch the logs of, DefaultWorkloadLogsMaxPods when not positive
+	MaxPods int
+}
+
 // WorkloadLogs returns logs for a specific workload's pods in a namespace.
 // This method first gets workload details to find associated pods, then retrieves logs for each pod.
-// Parameters:
-//   - namespace: the namespace containing the workload
-//   - workload: the name of the workload
-//   - container: container name (optional, will be auto-detected if not provided)
-//   - service: service name (optional)
-//   - duration: time duration (e.g., "5m", "1h") - optional
-//   - logType: type of logs (app, proxy, ztunnel, waypoint) - optional
-//   - sinceTime: Unix timestamp for start time - optional
-//   - maxLines: maximum number of lines to return - optional
-func (k *Kiali) WorkloadLogs(ctx context.Context, namespace string, workload string, container string, service string, duration string, logType string, sinceTime string, maxLines string) (string, error) {
+// Only the logs of the first maxPods pods in name order are fetched, the number of omitted pods is noted
+// at the end of the result.
+// The workload details request is retried on transient failures; a PodDiscoveryError is returned when it still
+// fails, and a plain error when the pods are found but none of their logs can be fetched.
+func (k *Kiali) WorkloadLogs(ctx context.Context, namespace string, workload string, opts WorkloadLogsOptions) (string, error) {
 	if namespace == "" {
 		return "", fmt.Errorf("namespace is required")
 	}
@@ -28,20 +52,28 @@ func (k *Kiali) WorkloadLogs(ctx context.Context, namespace string, workload str
 	}
 	// Container is optional - will be auto-detected if not provided
 
-	// First, get workload details to find associated pods
-	workloadDetails, err := k.WorkloadDetails(ctx, namespace, workload)
+	// First, get workload details to find associated pods. A transient failure of this single call would fail
+	// the whole request before any pod is attempted, so it is retried.
+	var workloadDetails string
+	requestAttempts := k.maxRetries() + 1
+	attempts := (workloadDetailsAttempts + requestAttempts - 1) / requestAttempts
+	err := retry(ctx, attempts, "workload details request", func() (err error) {
+		workloadDetails, err = k.WorkloadDetails(ctx, namespace, workload)
+		return err
+	})
 	if err != nil {
-		return "", fmt.Errorf("failed to get workload details: %v", err)
+		return "", &PodDiscoveryError{Namespace: namespace, Workload: workload, Err: err}
 	}
 
 	// Parse the workload details JSON to extract pod names and containers
+	type workloadPod struct {
+		Name       string `json:"name"`
+		Containers []struct {
+			Name string `json:"name"`
+		} `json:"containers"`
+	}
 	var workloadData struct {
-		Pods []struct {
-			Name       string `json:"name"`
-			Containers []struct {
-				Name string `json:"name"`
-			} `json:"containers"`
-		} `json:"pods"`
+		Pods []workloadPod `json:"pods"`
 	}
 
 	if err := json.Unmarshal([]byte(workloadDetails), &workloadData); err != nil {
@@ -52,23 +84,32 @@ func (k *Kiali) WorkloadLogs(ctx context.Context, namespace string, workload str
 		return "", fmt.Errorf("no pods found for workload %s in namespace %s", workload, namespace)
 	}
 
-	// Collect logs from all pods
+	// Collect logs from the first pods by name
+	pods := workloadData.Pods
+	slices.SortFunc(pods, func(a, b workloadPod) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	maxPods := opts.MaxPods
+	if maxPods <= 0 {
+		maxPods = DefaultWorkloadLogsMaxPods
+	}
+	omitted := 0
+	if len(pods) > maxPods {
+		omitted = len(pods) - maxPods
+		pods = pods[:maxPods]
+	}
 	var allLogs []string
-	for _, pod := range workloadData.Pods {
+	var lastErr error
+	fetched := 0
+	for _, pod := range pods {
 		// Auto-detect container if not provided
-		podContainer := container
+		podContainer := opts.Container
 		if podContainer == "" {
-			// Find the main application container (not istio-proxy or istio-init)
+			names := make([]string, 0, len(pod.Containers))
 			for _, c := range pod.Containers {
-				if c.Name != "istio-proxy" && c.Name != "istio-init" {
-					podContainer = c.Name
-					break
-				}
-			}
-			// If no app container found, use the first container
-			if podContainer == "" && len(pod.Containers) > 0 {
-				podContainer = pod.Containers[0].Name
+				names = append(names, c.Name)
 			}
+			podContainer = k.selectContainer(names, workload)
 		}
 
 		if podContainer == "" {
@@ -76,20 +117,28 @@ func (k *Kiali) WorkloadLogs(ctx context.Context, namespace string, workload str
 			continue
 		}
 
-		podLogs, err := k.PodLogs(ctx, namespace, pod.Name, podContainer, workload, service, duration, logType, sinceTime, maxLines)
+		podLogs, err := k.PodLogs(ctx, namespace, pod.Name, podContainer, workload, opts.Service, opts.Duration, opts.LogType, opts.SinceTime, opts.UntilTime, opts.MaxLines)
 		if err != nil {
 			// Log the error but continue with other pods
 			allLogs = append(allLogs, fmt.Sprintf("Error getting logs for pod %s: %v", pod.Name, err))
+			lastErr = err
 			continue
 		}
+		fetched++
 		if podLogs != "" {
 			allLogs = append(allLogs, fmt.Sprintf("=== Pod: %s (Container: %s) ===\n%s", pod.Name, podContainer, podLogs))
 		}
 	}
 
+	if fetched == 0 && lastErr != nil {
+		return "", fmt.Errorf("could not fetch the logs of the pods of workload %s in namespace %s: %v", workload, namespace, lastErr)
+	}
 	if len(allLogs) == 0 {
 		return "", fmt.Errorf("no logs found for workload %s in namespace %s", workload, namespace)
 	}
+	if omitted > 0 {
+		allLogs = append(allLogs, fmt.Sprintf("=== %d more pods omitted: only the logs of the first %d of %d pods by name are returned (maxPods) ===", omitted, maxPods, len(workloadData.Pods)))
+	}
 
 	return strings.Join(allLogs, "\n\n"), nil
 }
@@ -98,14 +147,17 @@ func (k *Kiali) WorkloadLogs(ctx context.Context, namespace string, workload str
 // Parameters:
 //   - namespace: the namespace containing the pod
 //   - podName: the name of the pod
-//   - container: container name (optional, will be auto-detected if not provided)
+//   - container: container name (optional, will be auto-detected with selectContainer if not provided)
 //   - workload: workload name (optional)
 //   - service: service name (optional)
 //   - duration: time duration (e.g., "5m", "1h") - optional
 //   - logType: type of logs (app, proxy, ztunnel, waypoint) - optional
 //   - sinceTime: Unix timestamp for start time - optional
+//   - untilTime: Unix timestamp for end time - optional. The Kiali logs API has no end time, so the logs are
+//     trimmed client-side: entries timestamped after untilTime are dropped, entries without a timestamp are kept.
+//     Note that maxLines is applied by Kiali before trimming.
 //   - maxLines: maximum number of lines to return - optional
-func (k *Kiali) PodLogs(ctx context.Context, namespace string, podName string, container string, workload string, service string, duration string, logType string, sinceTime string, maxLines string) (string, error) {
+func (k *Kiali) PodLogs(ctx context.Context, namespace string, podName string, container string, workload string, service string, duration string, logType string, sinceTime string, untilTime string, maxLines string) (string, error) {
 	baseURL, err := k.validateAndGetBaseURL()
 	if err != nil {
 		return "", err
@@ -116,6 +168,14 @@ func (k *Kiali) PodLogs(ctx context.Context, namespace string, podName string, c
 	if podName == "" {
 		return "", fmt.Errorf("pod name is required")
 	}
+	var until time.Time
+	if untilTime != "" {
+		seconds, err := strconv.ParseInt(untilTime, 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("invalid untilTime %q: must be a Unix timestamp in seconds", untilTime)
+		}
+		until = time.Unix(seconds, 0)
+	}
 	// Container is optional - will be auto-detected if not provided
 	podContainer := container
 	if podContainer == "" {
@@ -136,17 +196,11 @@ func (k *Kiali) PodLogs(ctx context.Context, namespace string, podName string, c
 			return "", fmt.Errorf("failed to parse pod details: %v", err)
 		}
 
-		// Find the main application container (not istio-proxy or istio-init)
+		names := make([]string, 0, len(podData.Containers))
 		for _, c := range podData.Containers {
-			if c.Name != "istio-proxy" && c.Name != "istio-init" {
-				podContainer = c.Name
-				break
-			}
-		}
-		// If no app container found, use the first container
-		if podContainer == "" && len(podData.Containers) > 0 {
-			podContainer = podData.Containers[0].Name
+			names = append(names, c.Name)
 		}
+		podContainer = k.selectContainer(names, workload)
 
 		if podContainer == "" {
 			return "", fmt.Errorf("no container found for pod %s in namespace %s", podName, namespace)
@@ -189,5 +243,129 @@ func (k *Kiali) PodLogs(ctx context.Context, namespace string, podName string, c
 	u.RawQuery = q.Encode()
 	endpoint = u.String()
 
-	return k.executeRequest(ctx, endpoint)
+	content, err := k.executeRequest(ctx, endpoint)
+	if err != nil || until.IsZero() {
+		return content, err
+	}
+	return trimLogsUntil(content, until), nil
+}
+
+// trimLogsUntil drops the log entries timestamped after until. Kiali JSON responses (`entries` with a
+// `timestampUnix` in milliseconds or a `timestamp`, or `logs` lines) and plain text lines starting with
+// an RFC 3339 timestamp are supported; entries whose timestamp cannot be determined are kept.
+func trimLogsUntil(content string, until time.Time) string {
+	var response map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(content), &response); err != nil {
+		lines := strings.Split(content, "\n")
+		kept := make([]string, 0, len(lines))
+		for _, line := range lines {
+			if !logLineAfter(line, until) {
+				kept = append(kept, line)
+			}
+		}
+		return strings.Join(kept, "\n")
+	}
+
+	if raw, ok := response["entries"]; ok {
+		var entries []struct {
+			Timestamp     string `json:"timestamp"`
+			TimestampUnix int64  `json:"timestampUnix"`
+		}
+		var rawEntries []json.RawMessage
+		if json.Unmarshal(raw, &entries) != nil || json.Unmarshal(raw, &rawEntries) != nil {
+			return content
+		}
+		kept := make([]json.RawMessage, 0, len(rawEntries))
+		for i, entry := range entries {
+			if entry.TimestampUnix > 0 {
+				if time.UnixMilli(entry.TimestampUnix).After(until) {
+					continue
+				}
+			} else if logLineAfter(entry.Timestamp, until) {
+				continue
+			}
+			kept = append(kept, rawEntries[i])
+		}
+		response["entries"], _ = json.Marshal(kept)
+	}
+	if raw, ok := response["logs"]; ok {
+		var lines []string
+		if json.Unmarshal(raw, &lines) != nil {
+			return content
+		}
+		kept := make([]string, 0, len(lines))
+		for _, line := range lines {
+			if !logLineAfter(line, until) {
+				kept = append(kept, line)
+			}
+		}
+		response["logs"], _ = json.Marshal(kept)
+	}
+	trimmed, err := json.Marshal(response)
+	if err != nil {
+		return content
+	}
+	return string(trimmed)
+}
+
+// logLineAfter reports whether the log line starts with an RFC 3339 timestamp later than until.
+func logLineAfter(line string, until time.Time) bool {
+	timestamp, _, _ := strings.Cut(strings.TrimSpace(line), " ")
+	t, err := time.Parse(time.RFC3339Nano, timestamp)
+	return err == nil && t.After(until)
+}
+
+// workloadPlaceholder is replaced by the quoted workload name in the preferred container pattern.
+const workloadPlaceholder = "{workload}"
+
+// ValidatePreferredContainer checks that the preferred container pattern is a valid regular expression.
+func ValidatePreferredContainer(pattern string) error {
+	_, err := regexp.Compile(strings.ReplaceAll(pattern, workloadPlaceholder, "workload"))
+	return err
+}
+
+// selectContainer deterministically picks the container to read logs from when none is given.
+// Container names are sorted, then the first match wins:
+//  1. an application container matching the configured preferred container pattern, where {workload}
+//     stands for the workload name (the pattern is skipped if it needs a workload name that is unknown)
+//  2. an application container, i.e. any container other than istio-proxy and istio-init
+//  3. any container
+//
+// An empty string is returned when there are no containers.
+func (k *Kiali) selectContainer(containers []string, workload string) string {
+	names := slices.Sorted(slices.Values(containers))
+	apps := make([]string, 0, len(names))
+	for _, name := range names {
+		if name != "istio-proxy" && name != "istio-init" {
+			apps = append(apps, name)
+		}
+	}
+	if preferred := k.preferredContainer(workload); preferred != nil {
+		for _, name := range apps {
+			if preferred.MatchString(name) {
+				return name
+			}
+		}
+	}
+	if len(apps) > 0 {
+		return apps[0]
+	}
+	if len(names) > 0 {
+		return names[0]
+	}
+	return ""
+}
+
+// preferredContainer compiles the configured preferred container pattern for the given workload.
+// Nil is returned when no pattern is configured, it is invalid, or it needs an unknown workload name.
+func (k *Kiali) preferredContainer(workload string) *regexp.Regexp {
+	pattern := k.manager.staticConfig.KialiLogsPreferredContainer
+	if pattern == "" || (workload == "" && strings.Contains(pattern, workloadPlaceholder)) {
+		return nil
+	}
+	re, err := regexp.Compile(strings.ReplaceAll(pattern, workloadPlaceholder, regexp.QuoteMeta(workload)))
+	if err != nil {
+		return nil
+	}
+	return re
 }
@@ -2,6 +2,8 @@ package kiali
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/url"
 	"strings"
 )
@@ -28,3 +30,101 @@ func (k *Kiali) MeshStatus(ctx context.Context) (string, error) {
 
 	return k.executeRequest(ctx, endpoint)
 }
+
+// Clusters calls the Kiali clusters API and returns, as JSON, the clusters of the mesh known to Kiali.
+func (k *Kiali) Clusters(ctx context.Context) (string, error) {
+	baseURL, err := k.validateAndGetBaseURL()
+	if err != nil {
+		return "", err
+	}
+	endpoint := strings.TrimRight(baseURL, "/") + "/api/clusters"
+
+	return k.executeRequest(ctx, endpoint)
+}
+
+// controlPlaneInfraTypes maps the mesh graph infra node types considered part of the control plane
+// to whether the component is optional (an optional component that is not installed is not a failure).
+var controlPlaneInfraTypes = map[string]bool{
+	"istiod":      false,
+	"kiali":       false,
+	"metricStore": false,
+	"grafana":     true,
+	"traceStore":  true,
+}
+
+// ControlPlaneHealth is the status of each control plane component and whether all of them are healthy.
+type ControlPlaneHealth struct {
+	Components map[string]string `json:"components"`
+	Healthy    bool              `json:"healthy"`
+}
+
+// ControlPlaneHealth returns, as JSON, the status of the control plane components (istiod, Kiali,
+// Prometheus, Grafana and the tracing backend) extracted from the mesh status, and an overall verdict.
+// The mesh is considered healthy when every component reports "Healthy"; optional add-ons
+// (Grafana, tracing) that are not installed do not affect the verdict.
+func (k *Kiali) ControlPlaneHealth(ctx context.Context) (string, error) {
+	health, err := k.controlPlaneHealth(ctx)
+	if err != nil {
+		return "", err
+	}
+	result, err := json.MarshalIndent(health, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal control plane health: %v", err)
+	}
+	return string(result), nil
+}
+
+// controlPlaneHealth fetches the mesh status and extracts the control plane components.
+func (k *Kiali) controlPlaneHealth(ctx context.Context) (*ControlPlaneHealth, error) {
+	content, err := k.MeshStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return parseControlPlaneHealth(content)
+}
+
+// parseControlPlaneHealth extracts the control plane components from the Kiali mesh graph.
+func parseControlPlaneHealth(content string) (*ControlPlaneHealth, error) {
+	var mesh struct {
+		Elements struct {
+			Nodes []any `json:"nodes"`
+		} `json:"elements"`
+	}
+	if err := json.Unmarshal([]byte(content), &mesh); err != nil {
+		return nil, fmt.Errorf("failed to parse mesh status: %v", err)
+	}
+	health := &ControlPlaneHealth{Components: map[string]string{}, Healthy: true}
+	for _, node := range mesh.Elements.Nodes {
+		data := elementData(node)
+		infraType, _ := data["infraType"].(string)
+		optional, ok := controlPlaneInfraTypes[infraType]
+		if !ok {
+			continue
+		}
+		name, _ := data["infraName"].(string)
+		if name == "" {
+			name = infraType
+		}
+		if _, exists := health.Components[name]; exists {
+			if cluster, _ := data["cluster"].(string); cluster != "" {
+				name += "@" + cluster
+			}
+		}
+		status, _ := data["healthData"].(string)
+		if status == "" {
+			status = "Unknown"
+			if infraType == "kiali" {
+				// Kiali answered the request, so it is up
+				status = "Healthy"
+			}
+		}
+		health.Components[name] = status
+		if status != "Healthy" && !(optional && status == "NotFound") {
+			health.Healthy = false
+		}
+	}
+	if len(health.Components) == 0 {
+		health.Healthy = false
+	}
+	return health, nil
+}
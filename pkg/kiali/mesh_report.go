@@ -0,0 +1,88 @@
+package kiali
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const (
+	// meshReportConcurrency is the maximum number of mesh report sections retrieved at once, so a constrained
+	// Kiali is not hit by all the sections at the same time.
+	meshReportConcurrency = 2
+	// meshReportTimeout is the deadline shared by all the sections of the mesh report when the call has no
+	// deadline of its own (e.g. a tool timeout). Sections still running or not started yet when it expires are
+	// reported as failed.
+	meshReportTimeout = 90 * time.Second
+)
+
+// MeshReport combines the control plane health, the mesh health summary and the validations summary.
+// A section that could not be retrieved is omitted and its error is reported in Errors, keyed by section.
+// Timings are the time each section took to be retrieved (e.g. "1.25s"), including failed sections.
+type MeshReport struct {
+	ControlPlane *ControlPlaneHealth `json:"controlPlane,omitempty"`
+	Health       *MeshHealthSummary  `json:"health,omitempty"`
+	Validations  *ValidationsSummary `json:"validations,omitempty"`
+	Errors       map[string]string   `json:"errors,omitempty"`
+	Timings      map[string]string   `json:"timings"`
+}
+
+// MeshReport builds, as JSON, a one-shot report of the mesh by retrieving the control plane health,
+// the mesh health summary and the validations summary, at most meshReportConcurrency at a time, within the
+// deadline of the call, or meshReportTimeout when it has none.
+// Partial failures are tolerated: each failed section is reported under "errors".
+// Parameters:
+//   - namespaces: comma-separated list of namespaces for the health and validations sections (optional, if empty all accessible namespaces)
+//   - rateInterval: rate interval for fetching error rate (optional, default: "10m")
+func (k *Kiali) MeshReport(ctx context.Context, namespaces string, rateInterval string) (string, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, meshReportTimeout)
+		defer cancel()
+	}
+
+	report := &MeshReport{Timings: map[string]string{}}
+	sections := []struct {
+		name  string
+		fetch func(ctx context.Context) error
+	}{
+		{"controlPlane", func(ctx context.Context) (err error) {
+			report.ControlPlane, err = k.controlPlaneHealth(ctx)
+			return err
+		}},
+		{"health", func(ctx context.Context) (err error) {
+			report.Health, err = k.meshHealthSummary(ctx, namespaces, rateInterval, false)
+			return err
+		}},
+		{"validations", func(ctx context.Context) (err error) {
+			report.Validations, err = k.validationsSummary(ctx, splitNamespaces(namespaces))
+			return err
+		}},
+	}
+
+	timings := make([]time.Duration, len(sections))
+	errs := k.fanOut(ctx, len(sections), meshReportConcurrency, func(ctx context.Context, i int) error {
+		start := time.Now()
+		defer func() { timings[i] = time.Since(start) }()
+		return sections[i].fetch(ctx)
+	})
+	for i, section := range sections {
+		report.Timings[section.name] = timings[i].Round(time.Millisecond).String()
+		if errs[i] != nil {
+			if report.Errors == nil {
+				report.Errors = map[string]string{}
+			}
+			report.Errors[section.name] = errs[i].Error()
+		}
+	}
+
+	if report.ControlPlane == nil && report.Health == nil && report.Validations == nil {
+		return "", fmt.Errorf("all mesh report sections failed: %v", report.Errors)
+	}
+	result, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal mesh report: %v", err)
+	}
+	return string(result), nil
+}
@@ -0,0 +1,139 @@
+package kiali
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// DefaultMeshTrafficTrendWindow is the default window sampled by MeshTrafficTrend.
+	DefaultMeshTrafficTrendWindow = 30 * time.Minute
+	// DefaultMeshTrafficTrendPoints is the default number of samples of MeshTrafficTrend.
+	DefaultMeshTrafficTrendPoints = 6
+	// MaxMeshTrafficTrendPoints bounds the number of graphs requested by MeshTrafficTrend.
+	MaxMeshTrafficTrendPoints = 12
+	// minMeshTrafficTrendStep is the shortest interval a sample can be computed over.
+	minMeshTrafficTrendStep = 30 * time.Second
+	// meshTrafficTrendConcurrency is the maximum number of graph requests in flight.
+	meshTrafficTrendConcurrency = 3
+)
+
+// MeshTrafficTrend is the total HTTP and gRPC request rate of the mesh over a window, sampled at regular
+// intervals. ChangePercent is the change of the request rate from the first to the last sample, not set when
+// there was no traffic in the first sample.
+type MeshTrafficTrend struct {
+	Namespaces    []string            `json:"namespaces,omitempty"`
+	Window        string              `json:"window"`
+	Step          string              `json:"step"`
+	Samples       []MeshTrafficSample `json:"samples"`
+	ChangePercent *float64            `json:"changePercent,omitempty"`
+}
+
+// MeshTrafficSample is the traffic of the mesh over the step ending at Time:
+//   - TotalRps is the total HTTP and gRPC request rate, in requests per second
+//   - ErrorRate is the percentage of failed HTTP and gRPC requests, not set without requests
+type MeshTrafficSample struct {
+	Time      time.Time `json:"time"`
+	TotalRps  float64   `json:"totalRps"`
+	ErrorRate *float64  `json:"errorRate,omitempty"`
+}
+
+// MeshTrafficTrend samples the mesh graph at regular intervals across the window and returns, as JSON, the total
+// request rate and error rate of each interval, oldest first, to tell whether the traffic is ramping up or down.
+// Each sample is a graph request over one interval, so the number of samples is bounded by
+// MaxMeshTrafficTrendPoints.
+// Parameters:
+//   - namespaces: the namespaces whose traffic is summed (optional, the mesh graph when empty)
+//   - window: the sampled window, ending now (DefaultMeshTrafficTrendWindow when not positive)
+//   - points: the number of samples (DefaultMeshTrafficTrendPoints when not positive, at most MaxMeshTrafficTrendPoints)
+func (k *Kiali) MeshTrafficTrend(ctx context.Context, namespaces []string, window time.Duration, points int) (string, error) {
+	if window <= 0 {
+		window = DefaultMeshTrafficTrendWindow
+	}
+	if points <= 0 {
+		points = DefaultMeshTrafficTrendPoints
+	}
+	if points > MaxMeshTrafficTrendPoints {
+		return "", fmt.Errorf("points must be at most %d", MaxMeshTrafficTrendPoints)
+	}
+	step := (window / time.Duration(points)).Truncate(time.Second)
+	if step < minMeshTrafficTrendStep {
+		return "", fmt.Errorf("window %s is too short for %d points: each point must cover at least %s", window, points, minMeshTrafficTrendStep)
+	}
+
+	end := time.Now().UTC().Truncate(time.Second)
+	samples := make([]MeshTrafficSample, points)
+	g, gCtx := errgroup.WithContext(ctx)
+	g.SetLimit(meshTrafficTrendConcurrency)
+	for i := range samples {
+		sampleTime := end.Add(-time.Duration(points-1-i) * step)
+		g.Go(func() error {
+			content, err := k.graph(gCtx, namespaces, graphOptions{
+				graphType: "versionedApp",
+				duration:  strconv.FormatInt(int64(step/time.Second), 10) + "s",
+				queryTime: strconv.FormatInt(sampleTime.Unix(), 10),
+				appenders: []string{"deadNode"},
+			})
+			if err != nil {
+				return fmt.Errorf("failed to get the graph at %s: %v", sampleTime.Format(time.RFC3339), err)
+			}
+			sample, err := parseMeshTrafficSample(content)
+			if err != nil {
+				return err
+			}
+			sample.Time = sampleTime
+			samples[i] = *sample
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return "", err
+	}
+
+	trend := &MeshTrafficTrend{Namespaces: namespaces, Window: window.String(), Step: step.String(), Samples: samples}
+	if first, last := samples[0].TotalRps, samples[len(samples)-1].TotalRps; first > 0 {
+		change := roundPercentage((last - first) * 100 / first)
+		trend.ChangePercent = &change
+	}
+	result, err := json.MarshalIndent(trend, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal mesh traffic trend: %v", err)
+	}
+	return string(result), nil
+}
+
+// parseMeshTrafficSample sums the HTTP and gRPC request rates of a Kiali graph response. Only the edges leaving
+// a non-service node are counted: with the injected service nodes, a request is also represented by the edge
+// from the service to its workload, which would count it twice.
+func parseMeshTrafficSample(content string) (*MeshTrafficSample, error) {
+	graph, err := parseGraph(content)
+	if err != nil {
+		return nil, err
+	}
+	services := map[string]bool{}
+	for _, n := range graph.Elements.Nodes {
+		services[n.field("id")] = n.field("nodeType") == "service"
+	}
+
+	sample := &MeshTrafficSample{}
+	var failed float64
+	for _, e := range graph.Elements.Edges {
+		rate, errorRate := e.rates()
+		if errorRate == nil || services[e.Data.Source] {
+			continue
+		}
+		sample.TotalRps += rate
+		failed += rate * *errorRate / 100
+	}
+	if sample.TotalRps > 0 {
+		errorRate := roundPercentage(failed * 100 / sample.TotalRps)
+		sample.ErrorRate = &errorRate
+	}
+	sample.TotalRps = roundTwoDecimals(sample.TotalRps)
+	return sample, nil
+}
@@ -0,0 +1,58 @@
+package kiali
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// MetricsEntityTypes lists the entity types supported by Metrics.
+var MetricsEntityTypes = []string{"app", "service", "workload"}
+
+// Metrics returns the raw Kiali metrics response of an app, service or workload for an explicit set of metrics.
+// Parameters:
+//   - namespace: the namespace containing the entity
+//   - entityType: the entity type, one of MetricsEntityTypes
+//   - name: the name of the entity
+//   - filters: the metric names to fetch (e.g. "request_count", "tcp_sent"), sent as repeated "filters[]" parameters.
+//     If empty, Kiali returns its default metrics set
+//   - byLabels: optional labels to group the metrics by, sent as repeated "byLabels[]" parameters
+//   - queryParams: optional query parameters map (e.g., "duration", "step", "rateInterval", "direction", "reporter")
+//   - quantiles: optional quantiles for histogram metrics, sent as repeated "quantiles[]" parameters
+func (k *Kiali) Metrics(ctx context.Context, namespace string, entityType string, name string, filters []string, byLabels []string, queryParams map[string]string, quantiles []string) (string, error) {
+	baseURL, err := k.validateAndGetBaseURL()
+	if err != nil {
+		return "", err
+	}
+	if namespace == "" {
+		return "", fmt.Errorf("namespace is required")
+	}
+	if !slices.Contains(MetricsEntityTypes, entityType) {
+		return "", fmt.Errorf("invalid entity type '%s': must be one of %s", entityType, strings.Join(MetricsEntityTypes, ", "))
+	}
+	if name == "" {
+		return "", fmt.Errorf("%s name is required", entityType)
+	}
+
+	u, err := url.Parse(fmt.Sprintf("%s/api/namespaces/%s/%ss/%s/metrics",
+		strings.TrimRight(baseURL, "/"), url.PathEscape(namespace), entityType, url.PathEscape(name)))
+	if err != nil {
+		return "", err
+	}
+	q := u.Query()
+	k.setQueryParams(q, queryParams)
+	for _, filter := range filters {
+		q.Add(k.queryParam("filters"), filter)
+	}
+	for _, label := range byLabels {
+		q.Add(k.queryParam("byLabels"), label)
+	}
+	for _, quantile := range quantiles {
+		q.Add(k.queryParam("quantiles"), quantile)
+	}
+	u.RawQuery = q.Encode()
+
+	return k.executeRequest(ctx, u.String())
+}
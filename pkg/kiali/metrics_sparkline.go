@@ -0,0 +1,137 @@
+package kiali
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+)
+
+// DefaultSparklineWidth is the number of points a metric is downsampled to when rendered as a sparkline.
+const DefaultSparklineWidth = 20
+
+// sparklineBlocks are the characters of a sparkline, from the lowest to the highest value.
+var sparklineBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// MetricSparkline is a compact rendering of a single metric: its series, summed over their labels, downsampled
+// to Width points and rendered as a unicode sparkline scaled between the lowest and highest point. Min, Max and
+// Last are the values of the full resolution series. Stat is the histogram statistic rendered, if any.
+type MetricSparkline struct {
+	Metric    string  `json:"metric"`
+	Stat      string  `json:"stat,omitempty"`
+	Sparkline string  `json:"sparkline"`
+	Width     int     `json:"width"`
+	From      int64   `json:"from,omitempty"`
+	To        int64   `json:"to,omitempty"`
+	Min       float64 `json:"min"`
+	Max       float64 `json:"max"`
+	Last      float64 `json:"last"`
+	Note      string  `json:"note,omitempty"`
+}
+
+// MetricSparklineJSON renders a metric of a Kiali metrics response as a sparkline and returns it as JSON.
+// The metric may be suffixed by the histogram statistic to render (e.g. "request_duration_millis:0.95"),
+// which defaults to "avg" for histograms.
+// Parameters:
+//   - content: the Kiali metrics response, metric series keyed by metric name
+//   - metric: the name of the metric to render, optionally with a ":<stat>" suffix
+//   - width: the number of points of the sparkline (0 for DefaultSparklineWidth)
+func MetricSparklineJSON(content string, metric string, width int) (string, error) {
+	var metrics map[string][]Metric
+	if err := json.Unmarshal([]byte(content), &metrics); err != nil {
+		return "", fmt.Errorf("failed to parse metrics: %v", err)
+	}
+	if width <= 0 {
+		width = DefaultSparklineWidth
+	}
+	name, stat, _ := strings.Cut(strings.TrimSpace(metric), ":")
+	series, ok := metrics[name]
+	if !ok {
+		return "", fmt.Errorf("metric '%s' not found, available metrics are: %s", name, strings.Join(slices.Sorted(maps.Keys(metrics)), ", "))
+	}
+	series, stat, err := selectMetricStat(series, stat)
+	if err != nil {
+		return "", fmt.Errorf("metric '%s': %v", name, err)
+	}
+
+	values := sumMetricSeries(series)
+	timestamps := make([]int64, 0, len(values))
+	for ts := range values {
+		timestamps = append(timestamps, ts)
+	}
+	slices.Sort(timestamps)
+	ret := &MetricSparkline{Metric: name, Stat: stat, Width: width}
+	if len(timestamps) == 0 {
+		ret.Width = 0
+		ret.Note = "the metric has no datapoints in the queried period"
+	} else {
+		ret.From, ret.To = timestamps[0], timestamps[len(timestamps)-1]
+		ret.Min, ret.Max = math.Inf(1), math.Inf(-1)
+		for _, ts := range timestamps {
+			ret.Min = math.Min(ret.Min, values[ts])
+			ret.Max = math.Max(ret.Max, values[ts])
+		}
+		ret.Min, ret.Max = roundMetricValue(ret.Min), roundMetricValue(ret.Max)
+		ret.Last = roundMetricValue(values[ret.To])
+		_, points := downsampleSeries(timestamps, values, width)
+		ret.Sparkline = renderSparkline(points)
+		ret.Width = len(points)
+	}
+	result, err := json.MarshalIndent(ret, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal metric sparkline: %v", err)
+	}
+	return string(result), nil
+}
+
+// selectMetricStat returns the series of the histogram statistic, "avg" by default. Series without statistic
+// (counters and gauges) are returned unchanged, and only when no statistic is requested.
+func selectMetricStat(series []Metric, stat string) ([]Metric, string, error) {
+	stats := map[string]bool{}
+	for _, m := range series {
+		if m.Stat != "" {
+			stats[m.Stat] = true
+		}
+	}
+	if len(stats) == 0 {
+		if stat != "" {
+			return nil, "", fmt.Errorf("statistic '%s' requested but the metric is not a histogram", stat)
+		}
+		return series, "", nil
+	}
+	if stat == "" {
+		stat = "avg"
+	}
+	if !stats[stat] {
+		return nil, "", fmt.Errorf("statistic '%s' not found, available statistics are: %s", stat, strings.Join(slices.Sorted(maps.Keys(stats)), ", "))
+	}
+	ret := make([]Metric, 0, len(series))
+	for _, m := range series {
+		if m.Stat == stat {
+			ret = append(ret, m)
+		}
+	}
+	return ret, stat, nil
+}
+
+// renderSparkline renders the points scaled between the lowest and the highest of them. A flat series is
+// rendered with the lowest block.
+func renderSparkline(points []float64) string {
+	low, high := slices.Min(points), slices.Max(points)
+	var sb strings.Builder
+	for _, v := range points {
+		level := 0
+		if high > low {
+			level = int(math.Round((v - low) / (high - low) * float64(len(sparklineBlocks)-1)))
+		}
+		sb.WriteRune(sparklineBlocks[level])
+	}
+	return sb.String()
+}
+
+// roundMetricValue rounds a metric value to 3 decimals, like the downsampled series.
+func roundMetricValue(v float64) float64 {
+	return math.Round(v*1000) / 1000
+}
@@ -0,0 +1,160 @@
+package kiali
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+)
+
+const (
+	// requestTrendDuration is the period, in seconds, covered by a request trend.
+	requestTrendDuration = 600
+	// requestTrendStep is the resolution, in seconds, of the metrics fetched for a request trend.
+	requestTrendStep = 30
+	// RequestTrendPoints is the number of points a request trend is downsampled to.
+	RequestTrendPoints = 10
+)
+
+// MetricValue is a metric sample value. Kiali encodes values that are not numbers (e.g. "NaN") as strings.
+type MetricValue float64
+
+func (v *MetricValue) UnmarshalJSON(data []byte) error {
+	var number float64
+	if err := json.Unmarshal(data, &number); err == nil {
+		*v = MetricValue(number)
+		return nil
+	}
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+	number, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		number = math.NaN()
+	}
+	*v = MetricValue(number)
+	return nil
+}
+
+// MetricDatapoint is a single sample of a metric series.
+type MetricDatapoint struct {
+	Timestamp int64       `json:"timestamp"`
+	Value     MetricValue `json:"value"`
+}
+
+// Metric is a metric series as returned by the Kiali metrics API.
+type Metric struct {
+	Name       string            `json:"name"`
+	Labels     map[string]string `json:"labels,omitempty"`
+	Stat       string            `json:"stat,omitempty"`
+	Datapoints []MetricDatapoint `json:"datapoints"`
+}
+
+// RequestTrend is a short, downsampled series of the recent inbound request and error rates.
+// Timestamps are Unix seconds marking the end of each bucket; rates are in requests per second.
+type RequestTrend struct {
+	Direction  string    `json:"direction"`
+	Unit       string    `json:"unit"`
+	Timestamps []int64   `json:"timestamps"`
+	Requests   []float64 `json:"requests"`
+	Errors     []float64 `json:"errors"`
+}
+
+// WorkloadRequestTrend returns, as JSON, the inbound request and error rates of a workload over the
+// last 10 minutes, downsampled to RequestTrendPoints points.
+// Parameters:
+//   - namespace: the namespace containing the workload
+//   - workload: the name of the workload
+func (k *Kiali) WorkloadRequestTrend(ctx context.Context, namespace string, workload string) (string, error) {
+	content, err := k.WorkloadMetrics(ctx, namespace, workload, requestTrendQueryParams(), nil)
+	if err != nil {
+		return "", err
+	}
+	return requestTrendJSON(content)
+}
+
+// ServiceRequestTrend returns, as JSON, the inbound request and error rates of a service over the
+// last 10 minutes, downsampled to RequestTrendPoints points.
+// Parameters:
+//   - namespace: the namespace containing the service
+//   - service: the name of the service
+func (k *Kiali) ServiceRequestTrend(ctx context.Context, namespace string, service string) (string, error) {
+	content, err := k.ServiceMetrics(ctx, namespace, service, requestTrendQueryParams(), nil)
+	if err != nil {
+		return "", err
+	}
+	return requestTrendJSON(content)
+}
+
+// requestTrendQueryParams returns the metrics query used to build a request trend.
+func requestTrendQueryParams() map[string]string {
+	return map[string]string{
+		"direction":    "inbound",
+		"reporter":     "destination",
+		"duration":     strconv.Itoa(requestTrendDuration),
+		"step":         strconv.Itoa(requestTrendStep),
+		"rateInterval": "1m",
+	}
+}
+
+// requestTrendJSON builds the request trend from a Kiali metrics response and marshals it.
+func requestTrendJSON(content string) (string, error) {
+	var metrics map[string][]Metric
+	if err := json.Unmarshal([]byte(content), &metrics); err != nil {
+		return "", fmt.Errorf("failed to parse metrics: %v", err)
+	}
+	requests := sumMetricSeries(metrics["request_count"])
+	errors := sumMetricSeries(metrics["request_error_count"])
+
+	timestamps := make([]int64, 0, len(requests))
+	for ts := range requests {
+		timestamps = append(timestamps, ts)
+	}
+	slices.Sort(timestamps)
+
+	trend := RequestTrend{Direction: "inbound", Unit: "requests/s"}
+	trend.Timestamps, trend.Requests = downsampleSeries(timestamps, requests, RequestTrendPoints)
+	_, trend.Errors = downsampleSeries(timestamps, errors, RequestTrendPoints)
+	result, err := json.MarshalIndent(trend, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal request trend: %v", err)
+	}
+	return string(result), nil
+}
+
+// sumMetricSeries adds up the datapoints of all the series (one per label set) by timestamp.
+// Samples that are not numbers are ignored.
+func sumMetricSeries(series []Metric) map[int64]float64 {
+	ret := map[int64]float64{}
+	for _, metric := range series {
+		for _, dp := range metric.Datapoints {
+			value := float64(dp.Value)
+			if math.IsNaN(value) || math.IsInf(value, 0) {
+				continue
+			}
+			ret[dp.Timestamp] += value
+		}
+	}
+	return ret
+}
+
+// downsampleSeries groups the sorted timestamps into at most `points` consecutive buckets and averages
+// the values of each bucket. Missing values count as zero. Bucket timestamps are the last timestamp of the bucket.
+func downsampleSeries(timestamps []int64, values map[int64]float64, points int) ([]int64, []float64) {
+	buckets := min(points, len(timestamps))
+	outTimestamps := make([]int64, 0, buckets)
+	outValues := make([]float64, 0, buckets)
+	for b := 0; b < buckets; b++ {
+		start, end := b*len(timestamps)/buckets, (b+1)*len(timestamps)/buckets
+		var sum float64
+		for _, ts := range timestamps[start:end] {
+			sum += values[ts]
+		}
+		outTimestamps = append(outTimestamps, timestamps[end-1])
+		outValues = append(outValues, math.Round(sum/float64(end-start)*1000)/1000)
+	}
+	return outTimestamps, outValues
+}
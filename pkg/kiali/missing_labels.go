@@ -0,0 +1,124 @@
+package kiali
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// Label names accepted for the app and version of an entity, the Istio canonical ones first.
+var (
+	appLabelNames     = []string{"app", "app.kubernetes.io/name", "service.istio.io/canonical-name"}
+	versionLabelNames = []string{"version", "app.kubernetes.io/version", "service.istio.io/canonical-revision"}
+)
+
+// MissingLabels is a workload or service lacking the labels Kiali relies on to build its graph: workloads
+// without an app label are shown as "unknown" and workloads without a version label cannot be told apart in
+// the versioned app graph. Only the app label is expected on services.
+type MissingLabels struct {
+	Kind      string   `json:"kind"`
+	Namespace string   `json:"namespace"`
+	Name      string   `json:"name"`
+	Cluster   string   `json:"cluster,omitempty"`
+	Missing   []string `json:"missing"`
+}
+
+// MissingLabels fetches, concurrently, the workloads and services of the namespaces and returns, as JSON, those
+// missing the recommended app and version labels, sorted by namespace, kind and name. The app and version
+// labels detected by Kiali, according to its configuration, are used when it reports them, otherwise the
+// labels are checked against the Istio recommended names. Services that are not from the Kubernetes registry
+// (e.g. ServiceEntries) are not checked.
+// Parameters:
+//   - namespaces: comma-separated list of namespaces (optional, if empty checks all accessible namespaces)
+func (k *Kiali) MissingLabels(ctx context.Context, namespaces string) (string, error) {
+	var services, workloads string
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() (err error) {
+		services, err = k.ServicesList(gctx, namespaces)
+		return err
+	})
+	g.Go(func() (err error) {
+		workloads, err = k.WorkloadsList(gctx, namespaces)
+		return err
+	})
+	if err := g.Wait(); err != nil {
+		return "", err
+	}
+	missing, err := parseMissingLabels(services, workloads)
+	if err != nil {
+		return "", err
+	}
+	result, err := json.MarshalIndent(missing, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal missing labels: %v", err)
+	}
+	return string(result), nil
+}
+
+// parseMissingLabels returns the workloads and services of the Kiali list responses missing labels.
+func parseMissingLabels(servicesContent, workloadsContent string) ([]MissingLabels, error) {
+	var workloads struct {
+		Workloads []workloadListItem `json:"workloads"`
+	}
+	if err := json.Unmarshal([]byte(workloadsContent), &workloads); err != nil {
+		return nil, fmt.Errorf("failed to parse workloads: %v", err)
+	}
+	var services struct {
+		Services []serviceListItem `json:"services"`
+	}
+	if err := json.Unmarshal([]byte(servicesContent), &services); err != nil {
+		return nil, fmt.Errorf("failed to parse services: %v", err)
+	}
+
+	ret := make([]MissingLabels, 0)
+	for _, w := range workloads.Workloads {
+		var missing []string
+		if !hasLabel(w.AppLabel, w.Labels, appLabelNames) {
+			missing = append(missing, "app")
+		}
+		if !hasLabel(w.VersionLabel, w.Labels, versionLabelNames) {
+			missing = append(missing, "version")
+		}
+		if len(missing) > 0 {
+			ret = append(ret, MissingLabels{Kind: "workload", Namespace: w.Namespace, Name: w.Name, Cluster: w.Cluster, Missing: missing})
+		}
+	}
+	for _, s := range services.Services {
+		if s.ServiceRegistry != "" && s.ServiceRegistry != "Kubernetes" {
+			continue
+		}
+		if !hasLabel(s.AppLabel, s.Labels, appLabelNames) {
+			ret = append(ret, MissingLabels{Kind: "service", Namespace: s.Namespace, Name: s.Name, Cluster: s.Cluster, Missing: []string{"app"}})
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Namespace != ret[j].Namespace {
+			return ret[i].Namespace < ret[j].Namespace
+		}
+		if ret[i].Kind != ret[j].Kind {
+			return ret[i].Kind < ret[j].Kind
+		}
+		if ret[i].Name != ret[j].Name {
+			return ret[i].Name < ret[j].Name
+		}
+		return ret[i].Cluster < ret[j].Cluster
+	})
+	return ret, nil
+}
+
+// hasLabel reports whether the entity has the label: as detected by Kiali when it reports it, otherwise when
+// one of the accepted label names is set.
+func hasLabel(detected *bool, labels map[string]string, names []string) bool {
+	if detected != nil {
+		return *detected
+	}
+	for _, name := range names {
+		if labels[name] != "" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,103 @@
+package kiali
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// mtlsGraphAppenders are the appenders needed to know the mTLS status of the graph edges: the securityPolicy
+// appender sets the percentage of mTLS traffic of each edge.
+var mtlsGraphAppenders = []string{"deadNode", "securityPolicy"}
+
+// MTLSCoverage is the share of the mesh graph edges whose traffic is fully mTLS-encrypted.
+// Percentage is not set when the graph has no edges.
+type MTLSCoverage struct {
+	Namespaces     []string        `json:"namespaces,omitempty"`
+	Duration       string          `json:"duration"`
+	TotalEdges     int             `json:"totalEdges"`
+	MTLSEdges      int             `json:"mtlsEdges"`
+	Percentage     *float64        `json:"percentage,omitempty"`
+	PlaintextEdges []PlaintextEdge `json:"plaintextEdges"`
+	Note           string          `json:"note,omitempty"`
+}
+
+// PlaintextEdge is a graph edge with traffic not encrypted with mTLS. MTLSPercentage is the share of its
+// traffic that is encrypted, above 0 for edges mixing mTLS and plaintext traffic.
+type PlaintextEdge struct {
+	Source         string  `json:"source"`
+	Target         string  `json:"target"`
+	Protocol       string  `json:"protocol"`
+	MTLSPercentage float64 `json:"mtlsPercentage"`
+}
+
+// MTLSCoverage requests the mesh graph with the mTLS information of the edges and returns, as JSON, the
+// percentage of edges whose traffic is fully mTLS-encrypted, and the edges with plaintext traffic, the least
+// encrypted first.
+// Parameters:
+//   - namespaces: the namespaces to include in the graph
+//   - duration: the window of traffic to consider (e.g. "10m", default: DefaultGraphDuration)
+//   - queryTime: the Unix timestamp, in seconds, of the end of the window (default: now)
+func (k *Kiali) MTLSCoverage(ctx context.Context, namespaces []string, duration string, queryTime string) (string, error) {
+	if duration == "" {
+		duration = DefaultGraphDuration
+	}
+	content, err := k.graph(ctx, namespaces, graphOptions{graphType: "versionedApp", duration: duration, queryTime: queryTime, appenders: mtlsGraphAppenders})
+	if err != nil {
+		return "", err
+	}
+	coverage, err := parseMTLSCoverage(content)
+	if err != nil {
+		return "", err
+	}
+	coverage.Namespaces = namespaces
+	coverage.Duration = duration
+	result, err := json.MarshalIndent(coverage, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal mTLS coverage: %v", err)
+	}
+	return string(result), nil
+}
+
+// parseMTLSCoverage computes the mTLS coverage of the edges of a Kiali graph response.
+// An edge is counted as mTLS-secured when all its traffic is encrypted.
+func parseMTLSCoverage(content string) (*MTLSCoverage, error) {
+	graph, err := parseGraph(content)
+	if err != nil {
+		return nil, err
+	}
+	names := graph.nodeNames()
+
+	coverage := &MTLSCoverage{TotalEdges: len(graph.Elements.Edges), PlaintextEdges: make([]PlaintextEdge, 0)}
+	for _, e := range graph.Elements.Edges {
+		mtls := parseRate(e.Data.IsMTLS)
+		if mtls >= 100 {
+			coverage.MTLSEdges++
+			continue
+		}
+		coverage.PlaintextEdges = append(coverage.PlaintextEdges, PlaintextEdge{
+			Source:         names.name(e.Data.Source),
+			Target:         names.name(e.Data.Target),
+			Protocol:       e.protocol(),
+			MTLSPercentage: roundPercentage(mtls),
+		})
+	}
+	sort.SliceStable(coverage.PlaintextEdges, func(i, j int) bool {
+		ei, ej := coverage.PlaintextEdges[i], coverage.PlaintextEdges[j]
+		if ei.MTLSPercentage != ej.MTLSPercentage {
+			return ei.MTLSPercentage < ej.MTLSPercentage
+		}
+		if ei.Source != ej.Source {
+			return ei.Source < ej.Source
+		}
+		return ei.Target < ej.Target
+	})
+	if coverage.TotalEdges == 0 {
+		coverage.Note = "no traffic in the graph: send traffic or widen the duration to measure the mTLS coverage"
+	} else {
+		percentage := roundPercentage(float64(coverage.MTLSEdges) * 100 / float64(coverage.TotalEdges))
+		coverage.Percentage = &percentage
+	}
+	return coverage, nil
+}
@@ -0,0 +1,120 @@
+package kiali
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+)
+
+// NamespaceMatrix is the namespace-to-namespace dependency matrix of the mesh: Matrix[from][to] is the traffic
+// sent by the nodes of namespace "from" to the nodes of namespace "to". Pairs without traffic have no cell.
+type NamespaceMatrix struct {
+	Namespaces []string                                   `json:"namespaces"`
+	Duration   string                                     `json:"duration"`
+	Matrix     map[string]map[string]*NamespaceMatrixCell `json:"matrix"`
+}
+
+// NamespaceMatrixCell is the aggregate traffic from a namespace to another.
+//   - RequestRate is the HTTP and gRPC request rate, in requests per second
+//   - ErrorRate is the percentage of failed HTTP and gRPC requests, not set without requests
+//   - TCPRate is the rate of bytes sent over TCP, in bytes per second
+type NamespaceMatrixCell struct {
+	Edges       int      `json:"edges"`
+	RequestRate float64  `json:"requestRate"`
+	ErrorRate   *float64 `json:"errorRate,omitempty"`
+	TCPRate     float64  `json:"tcpRate,omitempty"`
+}
+
+// NamespaceMatrix requests the mesh graph and returns, as JSON, the namespace adjacency matrix built from its edges.
+// When namespaces are given, the matrix is restricted to the traffic between them.
+// Parameters:
+//   - namespaces: the namespaces to include in the graph and the matrix (optional, the mesh graph when empty)
+//   - duration: the window the rates are computed over (e.g. "10m", default: DefaultGraphDuration)
+//   - queryTime: the Unix timestamp, in seconds, of the end of the window (default: now)
+func (k *Kiali) NamespaceMatrix(ctx context.Context, namespaces []string, duration string, queryTime string) (string, error) {
+	if duration == "" {
+		duration = DefaultGraphDuration
+	}
+	content, err := k.graph(ctx, namespaces, graphOptions{graphType: "versionedApp", duration: duration, queryTime: queryTime, appenders: []string{"deadNode"}})
+	if err != nil {
+		return "", err
+	}
+	matrix, err := parseNamespaceMatrix(content, namespaces)
+	if err != nil {
+		return "", err
+	}
+	matrix.Duration = duration
+	result, err := json.MarshalIndent(matrix, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal namespace matrix: %v", err)
+	}
+	return string(result), nil
+}
+
+// parseNamespaceMatrix sums the edges of a Kiali graph response by source and target namespace.
+// When namespaces are given, edges from or to other namespaces are ignored.
+func parseNamespaceMatrix(content string, namespaces []string) (*NamespaceMatrix, error) {
+	graph, err := parseGraph(content)
+	if err != nil {
+		return nil, err
+	}
+	nodeNamespaces := map[string]string{}
+	for _, n := range graph.Elements.Nodes {
+		id := n.field("id")
+		if id == "" || n.isBox() {
+			continue
+		}
+		nodeNamespaces[id] = n.field("namespace")
+	}
+	included := func(ns string) bool {
+		return ns != "" && (len(namespaces) == 0 || slices.Contains(namespaces, ns))
+	}
+
+	matrix := &NamespaceMatrix{Matrix: map[string]map[string]*NamespaceMatrixCell{}}
+	failed := map[*NamespaceMatrixCell]float64{}
+	seen := map[string]bool{}
+	for _, e := range graph.Elements.Edges {
+		from, to := nodeNamespaces[e.Data.Source], nodeNamespaces[e.Data.Target]
+		if !included(from) || !included(to) {
+			continue
+		}
+		if matrix.Matrix[from] == nil {
+			matrix.Matrix[from] = map[string]*NamespaceMatrixCell{}
+		}
+		cell := matrix.Matrix[from][to]
+		if cell == nil {
+			cell = &NamespaceMatrixCell{}
+			matrix.Matrix[from][to] = cell
+		}
+		seen[from], seen[to] = true, true
+		rate, errorRate := e.rates()
+		if errorRate != nil {
+			cell.RequestRate += rate
+			failed[cell] += rate * *errorRate / 100
+		} else {
+			cell.TCPRate += rate
+		}
+		cell.Edges++
+	}
+	for _, row := range matrix.Matrix {
+		for _, cell := range row {
+			if cell.RequestRate > 0 {
+				errorRate := roundPercentage(failed[cell] * 100 / cell.RequestRate)
+				cell.ErrorRate = &errorRate
+			}
+			cell.RequestRate = roundTwoDecimals(cell.RequestRate)
+			cell.TCPRate = roundTwoDecimals(cell.TCPRate)
+		}
+	}
+	matrix.Namespaces = slices.Sorted(maps.Keys(seen))
+	for _, ns := range namespaces {
+		if !seen[ns] {
+			matrix.Namespaces = append(matrix.Namespaces, ns)
+		}
+	}
+	sort.Strings(matrix.Namespaces)
+	return matrix, nil
+}
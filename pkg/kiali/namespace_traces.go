@@ -0,0 +1,165 @@
+package kiali
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultNamespaceTracesLimit is the default maximum number of traces returned by NamespaceTraces.
+	DefaultNamespaceTracesLimit = 100
+	// namespaceTracesConcurrency is the maximum number of app traces requests in flight.
+	namespaceTracesConcurrency = 5
+)
+
+// NamespaceTraces are the traces of the apps of a namespace, deduplicated by trace ID.
+// Apps whose traces could not be retrieved (e.g. because the request timed out) are reported in FailedApps
+// with their error. Truncated is set when more traces than the limit were found.
+type NamespaceTraces struct {
+	Namespace  string            `json:"namespace"`
+	Apps       []string          `json:"apps"`
+	Traces     []json.RawMessage `json:"traces"`
+	Truncated  bool              `json:"truncated,omitempty"`
+	FailedApps map[string]string `json:"failedApps,omitempty"`
+}
+
+// NamespaceTraces fetches, in parallel, the traces of every app in the namespace and returns them as JSON.
+// The app requests are bounded as described in fanOut; apps that fail or time out are reported while the
+// traces of the other apps are still returned. An error is only returned when every app failed.
+// Parameters:
+//   - namespace: the namespace to get traces for
+//   - queryParams: optional query parameters map for filtering traces (e.g., "startMicros", "endMicros", "minDuration", "tags")
+//   - limit: maximum number of traces returned across all apps, also used as the per-app limit (default: the
+//     configured default limit, or DefaultNamespaceTracesLimit)
+//
+// The configured default lookback applies when "startMicros" is not set.
+func (k *Kiali) NamespaceTraces(ctx context.Context, namespace string, queryParams map[string]string, limit int) (string, error) {
+	if namespace == "" {
+		return "", fmt.Errorf("namespace is required")
+	}
+	if limit <= 0 {
+		limit = k.defaultTracesLimit(DefaultNamespaceTracesLimit)
+	}
+	apps, err := k.appNames(ctx, namespace)
+	if err != nil {
+		return "", fmt.Errorf("failed to list apps: %v", err)
+	}
+	// The lookback is resolved once, so all the apps are queried over the same window
+	appParams := k.tracesQueryParams(queryParams)
+	appParams["limit"] = strconv.Itoa(limit)
+
+	traces := make([][]json.RawMessage, len(apps))
+	failed := map[string]string{}
+	errs := k.fanOut(ctx, len(apps), namespaceTracesConcurrency, func(ctx context.Context, i int) error {
+		content, err := k.AppTraces(ctx, namespace, apps[i], appParams)
+		if err == nil {
+			traces[i], err = parseTraces(content)
+		}
+		return err
+	})
+	for i, err := range errs {
+		if err != nil {
+			failed[apps[i]] = err.Error()
+		}
+	}
+
+	if len(apps) > 0 && len(failed) == len(apps) {
+		return "", fmt.Errorf("failed to get traces for all apps: %v", failed)
+	}
+	result := &NamespaceTraces{Namespace: namespace, Apps: apps, Traces: make([]json.RawMessage, 0)}
+	if len(failed) > 0 {
+		result.FailedApps = failed
+	}
+	seen := map[string]struct{}{}
+	for _, appTraces := range traces {
+		for _, trace := range appTraces {
+			id := traceID(trace)
+			if id != "" {
+				if _, ok := seen[id]; ok {
+					continue
+				}
+				seen[id] = struct{}{}
+			}
+			if len(result.Traces) == limit {
+				result.Truncated = true
+				break
+			}
+			result.Traces = append(result.Traces, trace)
+		}
+	}
+	ret, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal namespace traces: %v", err)
+	}
+	return string(ret), nil
+}
+
+// appNames returns the sorted names of the apps in the namespace.
+func (k *Kiali) appNames(ctx context.Context, namespace string) ([]string, error) {
+	baseURL, err := k.validateAndGetBaseURL()
+	if err != nil {
+		return nil, err
+	}
+	u, err := url.Parse(strings.TrimRight(baseURL, "/") + "/api/clusters/apps")
+	if err != nil {
+		return nil, err
+	}
+	k.addNamespacesQuery(u, namespace)
+	content, err := k.executeRequest(ctx, u.String())
+	if err != nil {
+		return nil, err
+	}
+	var list struct {
+		Applications []struct {
+			Name      string `json:"name"`
+			Namespace string `json:"namespace"`
+		} `json:"applications"`
+	}
+	if err := json.Unmarshal([]byte(content), &list); err != nil {
+		return nil, fmt.Errorf("failed to parse apps: %v", err)
+	}
+	names := make([]string, 0, len(list.Applications))
+	seen := map[string]struct{}{}
+	for _, app := range list.Applications {
+		if app.Namespace != "" && app.Namespace != namespace {
+			continue
+		}
+		// The same app can be listed once per cluster
+		if _, ok := seen[app.Name]; ok {
+			continue
+		}
+		seen[app.Name] = struct{}{}
+		names = append(names, app.Name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// parseTraces returns the traces of a Kiali traces response. Both the `data` (Kiali) and `traces` keys are accepted.
+func parseTraces(content string) ([]json.RawMessage, error) {
+	var response struct {
+		Data   []json.RawMessage `json:"data"`
+		Traces []json.RawMessage `json:"traces"`
+	}
+	if err := json.Unmarshal([]byte(content), &response); err != nil {
+		return nil, fmt.Errorf("failed to parse traces: %v", err)
+	}
+	if response.Data != nil {
+		return response.Data, nil
+	}
+	return response.Traces, nil
+}
+
+// traceID returns the ID of a trace, or an empty string if it has none.
+func traceID(trace json.RawMessage) string {
+	var t struct {
+		TraceID string `json:"traceID"`
+	}
+	_ = json.Unmarshal(trace, &t)
+	return t.TraceID
+}
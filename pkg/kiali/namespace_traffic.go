@@ -0,0 +1,128 @@
+package kiali
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DefaultNamespaceTrafficLimit is the default number of top contributing pairs returned by NamespaceTraffic.
+const DefaultNamespaceTrafficLimit = 10
+
+// NamespaceTraffic is the aggregate traffic crossing the boundary from one namespace to another.
+//   - RequestRate is the total HTTP and gRPC request rate, in requests per second
+//   - ErrorRate is the percentage of failed HTTP and gRPC requests, not set without requests
+//   - TCPRate is the total rate of bytes sent over TCP, in bytes per second
+//   - TopPairs are the edges contributing the most requests, then TCP bytes
+type NamespaceTraffic struct {
+	From        string           `json:"from"`
+	To          string           `json:"to"`
+	Duration    string           `json:"duration"`
+	Edges       int              `json:"edges"`
+	RequestRate float64          `json:"requestRate"`
+	ErrorRate   *float64         `json:"errorRate,omitempty"`
+	TCPRate     float64          `json:"tcpRate,omitempty"`
+	TopPairs    []ErrorGraphEdge `json:"topPairs"`
+}
+
+// NamespaceTraffic requests the mesh graph of both namespaces and returns, as JSON, the aggregate traffic of the
+// edges going from a node of the source namespace to a node of the target namespace, with the top contributing
+// source and destination pairs.
+// Parameters:
+//   - from: the source namespace
+//   - to: the target namespace
+//   - duration: the window the rates are computed over (e.g. "10m", default: DefaultGraphDuration)
+//   - queryTime: the Unix timestamp, in seconds, of the end of the window (default: now)
+//   - limit: the maximum number of pairs to return (default: DefaultNamespaceTrafficLimit)
+func (k *Kiali) NamespaceTraffic(ctx context.Context, from, to string, duration string, queryTime string, limit int) (string, error) {
+	from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+	if from == "" || to == "" {
+		return "", fmt.Errorf("both the source and the target namespaces are required")
+	}
+	if duration == "" {
+		duration = DefaultGraphDuration
+	}
+	if limit <= 0 {
+		limit = DefaultNamespaceTrafficLimit
+	}
+	content, err := k.graph(ctx, []string{from, to}, graphOptions{graphType: "versionedApp", duration: duration, queryTime: queryTime, appenders: []string{"deadNode"}})
+	if err != nil {
+		return "", err
+	}
+	traffic, err := parseNamespaceTraffic(content, from, to, limit)
+	if err != nil {
+		return "", err
+	}
+	traffic.Duration = duration
+	result, err := json.MarshalIndent(traffic, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal namespace traffic: %v", err)
+	}
+	return string(result), nil
+}
+
+// parseNamespaceTraffic sums the edges of a Kiali graph response crossing from one namespace to the other.
+func parseNamespaceTraffic(content string, from, to string, limit int) (*NamespaceTraffic, error) {
+	graph, err := parseGraph(content)
+	if err != nil {
+		return nil, err
+	}
+	type node struct {
+		name      string
+		namespace string
+	}
+	nodes := map[string]node{}
+	for _, n := range graph.Elements.Nodes {
+		id := n.field("id")
+		if id == "" || n.isBox() {
+			continue
+		}
+		nodes[id] = node{name: graphNodeName(n.Data), namespace: n.field("namespace")}
+	}
+
+	traffic := &NamespaceTraffic{From: from, To: to, TopPairs: make([]ErrorGraphEdge, 0)}
+	var failed float64
+	for _, e := range graph.Elements.Edges {
+		source, target := nodes[e.Data.Source], nodes[e.Data.Target]
+		if source.namespace != from || target.namespace != to {
+			continue
+		}
+		rate, errorRate := e.rates()
+		pair := ErrorGraphEdge{Source: source.name, Target: target.name, Protocol: e.protocol(), RequestRate: rate, ErrorRate: errorRate}
+		if errorRate != nil {
+			traffic.RequestRate += rate
+			failed += rate * *errorRate / 100
+		} else {
+			traffic.TCPRate += rate
+		}
+		traffic.Edges++
+		traffic.TopPairs = append(traffic.TopPairs, pair)
+	}
+	if traffic.RequestRate > 0 {
+		errorRate := roundPercentage(failed * 100 / traffic.RequestRate)
+		traffic.ErrorRate = &errorRate
+	}
+	traffic.RequestRate = roundTwoDecimals(traffic.RequestRate)
+	traffic.TCPRate = roundTwoDecimals(traffic.TCPRate)
+
+	// Requests first, then TCP bytes, as their rates are not comparable
+	sort.SliceStable(traffic.TopPairs, func(i, j int) bool {
+		pi, pj := traffic.TopPairs[i], traffic.TopPairs[j]
+		if (pi.ErrorRate != nil) != (pj.ErrorRate != nil) {
+			return pi.ErrorRate != nil
+		}
+		if pi.RequestRate != pj.RequestRate {
+			return pi.RequestRate > pj.RequestRate
+		}
+		if pi.Source != pj.Source {
+			return pi.Source < pj.Source
+		}
+		return pi.Target < pj.Target
+	})
+	if len(traffic.TopPairs) > limit {
+		traffic.TopPairs = traffic.TopPairs[:limit]
+	}
+	return traffic, nil
+}
@@ -2,12 +2,39 @@ package kiali
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
 	"strings"
 )
 
 // ListNamespaces calls the Kiali namespaces API using the provided Authorization header value.
 // Returns all namespaces in the mesh that the user has access to.
+// When the namespaces cache is enabled, the list is served from the cache of the caller's identity until it expires.
 func (k *Kiali) ListNamespaces(ctx context.Context) (string, error) {
+	if _, err := k.validateAndGetBaseURL(); err != nil {
+		return "", err
+	}
+	cache := k.manager.namespaces
+	if cache == nil {
+		return k.fetchNamespaces(ctx)
+	}
+	authorization := k.CurrentAuthorizationHeader(ctx)
+	if content, ok := cache.get(authorization); ok {
+		return content, nil
+	}
+	content, err := k.fetchNamespaces(ctx)
+	if err != nil {
+		return "", err
+	}
+	cache.set(authorization, content)
+	return content, nil
+}
+
+// fetchNamespaces calls the Kiali namespaces API, bypassing the cache.
+func (k *Kiali) fetchNamespaces(ctx context.Context) (string, error) {
 	baseURL, err := k.validateAndGetBaseURL()
 	if err != nil {
 		return "", err
@@ -16,3 +43,112 @@ func (k *Kiali) ListNamespaces(ctx context.Context) (string, error) {
 
 	return k.executeRequest(ctx, endpoint)
 }
+
+// NamespacesMeshMembership partitions the namespaces that the user has access to.
+type NamespacesMeshMembership struct {
+	Meshed    []string `json:"meshed"`
+	NotMeshed []string `json:"notMeshed"`
+}
+
+// MeshNamespaces returns, as JSON, the namespaces that the user has access to partitioned into the ones
+// that are part of the mesh and the ones that are not, based on their Istio labels.
+// A namespace listed by several clusters is considered meshed if it is meshed in any of them.
+func (k *Kiali) MeshNamespaces(ctx context.Context) (string, error) {
+	content, err := k.ListNamespaces(ctx)
+	if err != nil {
+		return "", err
+	}
+	membership, err := parseNamespacesMeshMembership(content)
+	if err != nil {
+		return "", err
+	}
+	result, err := json.MarshalIndent(membership, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal namespaces: %v", err)
+	}
+	return string(result), nil
+}
+
+// parseNamespacesMeshMembership partitions the namespaces of a Kiali namespaces response.
+func parseNamespacesMeshMembership(content string) (*NamespacesMeshMembership, error) {
+	var namespaces []struct {
+		Name           string            `json:"name"`
+		IsAmbient      bool              `json:"isAmbient"`
+		IsControlPlane bool              `json:"isControlPlane"`
+		Labels         map[string]string `json:"labels"`
+	}
+	if err := json.Unmarshal([]byte(content), &namespaces); err != nil {
+		return nil, fmt.Errorf("failed to parse namespaces: %v", err)
+	}
+	meshed := map[string]bool{}
+	for _, ns := range namespaces {
+		meshed[ns.Name] = meshed[ns.Name] || ns.IsAmbient || ns.IsControlPlane || isMeshedNamespace(ns.Labels)
+	}
+	membership := &NamespacesMeshMembership{Meshed: make([]string, 0), NotMeshed: make([]string, 0)}
+	for _, name := range slices.Sorted(maps.Keys(meshed)) {
+		if meshed[name] {
+			membership.Meshed = append(membership.Meshed, name)
+		} else {
+			membership.NotMeshed = append(membership.NotMeshed, name)
+		}
+	}
+	return membership, nil
+}
+
+// isMeshedNamespace reports whether the namespace labels enroll its workloads in the mesh: sidecar injection
+// (`istio-injection=enabled` or an `istio.io/rev` revision) or ambient mode (`istio.io/dataplane-mode=ambient`).
+// An explicit `istio-injection=disabled` takes precedence over a revision label, as it does for the injector.
+func isMeshedNamespace(labels map[string]string) bool {
+	if labels["istio.io/dataplane-mode"] == "ambient" {
+		return true
+	}
+	switch labels["istio-injection"] {
+	case "enabled":
+		return true
+	case "disabled":
+		return false
+	}
+	return labels["istio.io/rev"] != ""
+}
+
+// splitNamespaces splits the given comma-separated namespace lists into individual namespaces.
+// Names are trimmed, empty entries are dropped and duplicates are removed, keeping the first occurrence order.
+func splitNamespaces(values ...string) []string {
+	ret := make([]string, 0)
+	seen := map[string]struct{}{}
+	for _, value := range values {
+		for _, ns := range strings.Split(value, ",") {
+			ns = strings.TrimSpace(ns)
+			if ns == "" {
+				continue
+			}
+			if _, ok := seen[ns]; ok {
+				continue
+			}
+			seen[ns] = struct{}{}
+			ret = append(ret, ns)
+		}
+	}
+	return ret
+}
+
+// addNamespacesQuery appends the namespaces query parameter to the URL, after any other query parameters
+// have been encoded. Each namespace is escaped individually and joined with a literal comma, so reserved
+// characters within a name can never be confused with the list separator.
+// Nothing is added when no namespace is provided.
+func (k *Kiali) addNamespacesQuery(u *url.URL, namespaces ...string) {
+	cleaned := splitNamespaces(namespaces...)
+	if len(cleaned) == 0 {
+		return
+	}
+	escaped := make([]string, 0, len(cleaned))
+	for _, ns := range cleaned {
+		escaped = append(escaped, url.QueryEscape(ns))
+	}
+	param := url.QueryEscape(k.queryParam("namespaces")) + "=" + strings.Join(escaped, ",")
+	if u.RawQuery == "" {
+		u.RawQuery = param
+	} else {
+		u.RawQuery += "&" + param
+	}
+}
@@ -0,0 +1,129 @@
+package kiali
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"github.com/kiali/kiali-mcp-server/pkg/config"
+)
+
+// namespacesCache caches the Kiali namespaces list per Authorization header, since the visible namespaces
+// depend on the caller's permissions. The entries are keyed by a hash of the header, which keeps the tokens out
+// of the memory of the server, and the expired ones are evicted, so rotating tokens do not grow the cache.
+type namespacesCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]namespacesCacheEntry
+}
+
+type namespacesCacheEntry struct {
+	content string
+	expires time.Time
+}
+
+func newNamespacesCache(ttl time.Duration) *namespacesCache {
+	return &namespacesCache{ttl: ttl, entries: map[string]namespacesCacheEntry{}}
+}
+
+// get returns the cached namespaces list for the given Authorization header if it has not expired.
+func (c *namespacesCache) get(authorization string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := namespacesCacheKey(authorization)
+	entry, ok := c.entries[key]
+	if !ok {
+		return "", false
+	}
+	if time.Now().After(entry.expires) {
+		delete(c.entries, key)
+		return "", false
+	}
+	return entry.content, true
+}
+
+// set stores the namespaces list for the given Authorization header, resetting its expiration, and evicts the
+// expired entries.
+func (c *namespacesCache) set(authorization, content string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	for key, entry := range c.entries {
+		if now.After(entry.expires) {
+			delete(c.entries, key)
+		}
+	}
+	c.entries[namespacesCacheKey(authorization)] = namespacesCacheEntry{content: content, expires: now.Add(c.ttl)}
+}
+
+// namespacesCacheKey returns the cache key of an Authorization header.
+func namespacesCacheKey(authorization string) string {
+	sum := sha256.Sum256([]byte(authorization))
+	return hex.EncodeToString(sum[:])
+}
+
+// initNamespacesCache enables the namespaces cache when a positive TTL is configured.
+func (m *Manager) initNamespacesCache(cfg *config.StaticConfig) {
+	if cfg == nil || cfg.KialiNamespacesCacheTTL == "" {
+		return
+	}
+	ttl, err := time.ParseDuration(cfg.KialiNamespacesCacheTTL)
+	if err != nil || ttl <= 0 {
+		klog.Warningf("invalid kiali_namespaces_cache_ttl %q, namespaces cache disabled", cfg.KialiNamespacesCacheTTL)
+		return
+	}
+	m.namespaces = newNamespacesCache(ttl)
+}
+
+// startNamespacesRefresh refreshes the cached namespaces list of the server identity (i.e. without a
+// per-request OAuth token) on the given interval until Close is called.
+// A refresh is skipped while Kiali requests are in flight, so it only runs when the server is idle.
+func (m *Manager) startNamespacesRefresh(interval time.Duration) {
+	ctx, cancel := context.WithCancel(context.Background())
+	m.stopRefresh = cancel
+	m.refreshDone = make(chan struct{})
+	go func() {
+		defer close(m.refreshDone)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		m.refreshNamespaces(ctx)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				m.refreshNamespaces(ctx)
+			}
+		}
+	}()
+}
+
+// refreshNamespaces fetches the namespaces list and stores it in the cache, unless the server is busy.
+func (m *Manager) refreshNamespaces(ctx context.Context) {
+	if m.inFlight.Load() > 0 {
+		klog.V(5).Infof("skipping Kiali namespaces refresh: requests in flight")
+		return
+	}
+	k := &Kiali{manager: m}
+	content, err := k.fetchNamespaces(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			klog.V(1).Infof("failed to refresh Kiali namespaces: %v", err)
+		}
+		return
+	}
+	m.namespaces.set(k.CurrentAuthorizationHeader(ctx), content)
+}
+
+// Close stops the background namespaces refresh, if running, and waits for it to exit.
+func (m *Manager) Close() {
+	if m == nil || m.stopRefresh == nil {
+		return
+	}
+	m.stopRefresh()
+	<-m.refreshDone
+}
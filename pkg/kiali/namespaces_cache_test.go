@@ -0,0 +1,127 @@
+package kiali
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali-mcp-server/pkg/config"
+	internalk8s "github.com/kiali/kiali-mcp-server/pkg/kubernetes"
+)
+
+func TestNamespacesCache(t *testing.T) {
+	t.Run("entries are keyed by a hash of the authorization header", func(t *testing.T) {
+		cache := newNamespacesCache(time.Hour)
+		cache.set("Bearer secret-token", `[{"name":"bookinfo"}]`)
+		for key := range cache.entries {
+			assert.NotContains(t, key, "secret-token")
+		}
+		content, ok := cache.get("Bearer secret-token")
+		assert.True(t, ok)
+		assert.Equal(t, `[{"name":"bookinfo"}]`, content)
+		_, ok = cache.get("Bearer other-token")
+		assert.False(t, ok)
+	})
+
+	t.Run("expired entries are evicted", func(t *testing.T) {
+		cache := newNamespacesCache(time.Hour)
+		cache.set("Bearer first", "first")
+		cache.set("Bearer second", "second")
+		expire := func(authorization string) {
+			key := namespacesCacheKey(authorization)
+			entry := cache.entries[key]
+			entry.expires = time.Now().Add(-time.Second)
+			cache.entries[key] = entry
+		}
+
+		expire("Bearer first")
+		_, ok := cache.get("Bearer first")
+		assert.False(t, ok, "an expired entry is not served")
+		assert.Len(t, cache.entries, 1, "an expired entry is evicted when read")
+
+		expire("Bearer second")
+		cache.set("Bearer third", "third")
+		assert.Len(t, cache.entries, 1, "the expired entries are evicted when an entry is stored")
+		content, ok := cache.get("Bearer third")
+		assert.True(t, ok)
+		assert.Equal(t, "third", content)
+	})
+}
+
+func TestNamespacesRefresh(t *testing.T) {
+	var requests atomic.Int32
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		_, _ = w.Write([]byte(`[{"name":"bookinfo"}]`))
+	}))
+	defer mockServer.Close()
+	newManager := func() *Manager {
+		m := &Manager{staticConfig: &config.StaticConfig{KialiServerURL: mockServer.URL, KialiNamespacesCacheTTL: "1h"}}
+		m.initNamespacesCache(m.staticConfig)
+		require.NotNil(t, m.namespaces)
+		return m
+	}
+
+	t.Run("refreshes the cache of the server identity in the background", func(t *testing.T) {
+		requests.Store(0)
+		m := newManager()
+		m.startNamespacesRefresh(10 * time.Millisecond)
+		defer m.Close()
+		require.Eventually(t, func() bool { return requests.Load() >= 2 }, 5*time.Second, 5*time.Millisecond)
+		content, ok := m.namespaces.get((&Kiali{manager: m}).CurrentAuthorizationHeader(context.Background()))
+		assert.True(t, ok)
+		assert.Equal(t, `[{"name":"bookinfo"}]`, content)
+	})
+
+	t.Run("skips the refresh while requests are in flight", func(t *testing.T) {
+		requests.Store(0)
+		m := newManager()
+		m.inFlight.Add(1)
+		m.refreshNamespaces(context.Background())
+		assert.Equal(t, int32(0), requests.Load())
+		m.inFlight.Add(-1)
+		m.refreshNamespaces(context.Background())
+		assert.Equal(t, int32(1), requests.Load())
+	})
+
+	t.Run("close stops the refresh", func(t *testing.T) {
+		requests.Store(0)
+		m := newManager()
+		m.startNamespacesRefresh(10 * time.Millisecond)
+		require.Eventually(t, func() bool { return requests.Load() >= 1 }, 5*time.Second, 5*time.Millisecond)
+		m.Close()
+		select {
+		case <-m.refreshDone:
+		default:
+			t.Fatal("the refresh goroutine is still running after Close")
+		}
+		stopped := requests.Load()
+		time.Sleep(50 * time.Millisecond)
+		assert.Equal(t, stopped, requests.Load(), "no refresh runs after Close")
+		m.Close()
+	})
+
+	t.Run("close without refresh", func(t *testing.T) {
+		newManager().Close()
+		var m *Manager
+		m.Close()
+	})
+
+	t.Run("the cache of a per-request token is not refreshed", func(t *testing.T) {
+		requests.Store(0)
+		m := newManager()
+		k := &Kiali{manager: m}
+		ctx := context.WithValue(context.Background(), internalk8s.OAuthAuthorizationHeader, "Bearer user-token")
+		_, err := k.ListNamespaces(ctx)
+		require.NoError(t, err)
+		m.refreshNamespaces(context.Background())
+		assert.Equal(t, int32(2), requests.Load())
+		assert.Len(t, m.namespaces.entries, 2, "the server identity and the caller token have their own entry")
+	})
+}
@@ -0,0 +1,111 @@
+package kiali
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// OrphanedService is a Kubernetes service whose selector matches no workload.
+type OrphanedService struct {
+	Namespace string            `json:"namespace"`
+	Name      string            `json:"name"`
+	Cluster   string            `json:"cluster,omitempty"`
+	Selector  map[string]string `json:"selector"`
+}
+
+// serviceListItem holds the fields of a service returned by the Kiali services list API used to find orphaned
+// services and services missing labels.
+type serviceListItem struct {
+	Namespace       string            `json:"namespace"`
+	Name            string            `json:"name"`
+	Cluster         string            `json:"cluster,omitempty"`
+	Labels          map[string]string `json:"labels,omitempty"`
+	Selector        map[string]string `json:"selector,omitempty"`
+	AppLabel        *bool             `json:"appLabel,omitempty"`
+	ServiceRegistry string            `json:"serviceRegistry,omitempty"`
+}
+
+// OrphanedServices fetches, concurrently, the services and workloads of the namespaces and returns, as JSON,
+// the services with no backing workload, sorted by namespace and name.
+// A service is orphaned when it has a selector and the selector matches the labels of no workload in its
+// namespace and cluster. Services without a selector (ExternalName services or services with manually managed
+// endpoints) and services that are not from the Kubernetes registry (e.g. ServiceEntries) are never reported.
+// Parameters:
+//   - namespaces: comma-separated list of namespaces (optional, if empty checks all accessible namespaces)
+func (k *Kiali) OrphanedServices(ctx context.Context, namespaces string) (string, error) {
+	var services, workloads string
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() (err error) {
+		services, err = k.ServicesList(gctx, namespaces)
+		return err
+	})
+	g.Go(func() (err error) {
+		workloads, err = k.WorkloadsList(gctx, namespaces)
+		return err
+	})
+	if err := g.Wait(); err != nil {
+		return "", err
+	}
+	orphaned, err := parseOrphanedServices(services, workloads)
+	if err != nil {
+		return "", err
+	}
+	result, err := json.MarshalIndent(orphaned, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal orphaned services: %v", err)
+	}
+	return string(result), nil
+}
+
+// parseOrphanedServices cross-references the services and workloads list responses to find the orphaned services.
+func parseOrphanedServices(servicesContent, workloadsContent string) ([]OrphanedService, error) {
+	var services struct {
+		Services []serviceListItem `json:"services"`
+	}
+	if err := json.Unmarshal([]byte(servicesContent), &services); err != nil {
+		return nil, fmt.Errorf("failed to parse services: %v", err)
+	}
+	var workloads struct {
+		Workloads []workloadListItem `json:"workloads"`
+	}
+	if err := json.Unmarshal([]byte(workloadsContent), &workloads); err != nil {
+		return nil, fmt.Errorf("failed to parse workloads: %v", err)
+	}
+	ret := make([]OrphanedService, 0)
+	for _, s := range services.Services {
+		if len(s.Selector) == 0 || (s.ServiceRegistry != "" && s.ServiceRegistry != "Kubernetes") {
+			continue
+		}
+		matched := false
+		for _, w := range workloads.Workloads {
+			if w.Namespace == s.Namespace && w.Cluster == s.Cluster && labelsMatch(s.Selector, w.Labels) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			ret = append(ret, OrphanedService{Namespace: s.Namespace, Name: s.Name, Cluster: s.Cluster, Selector: s.Selector})
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Namespace != ret[j].Namespace {
+			return ret[i].Namespace < ret[j].Namespace
+		}
+		return ret[i].Name < ret[j].Name
+	})
+	return ret, nil
+}
+
+// labelsMatch reports whether the labels contain every key and value of the selector.
+func labelsMatch(selector, labels map[string]string) bool {
+	for key, value := range selector {
+		if v, ok := labels[key]; !ok || v != value {
+			return false
+		}
+	}
+	return true
+}
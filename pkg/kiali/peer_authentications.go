@@ -0,0 +1,250 @@
+package kiali
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// DefaultRootNamespace is the Istio root namespace, holding the mesh-wide policies, when not configured otherwise.
+const DefaultRootNamespace = "istio-system"
+
+// mTLS modes of a PeerAuthentication. UNSET inherits the mode of the parent scope, and the mesh falls back to
+// PERMISSIVE when no policy sets a mode.
+const (
+	mtlsModeUnset      = "UNSET"
+	mtlsModePermissive = "PERMISSIVE"
+)
+
+// PeerAuthentications is the mTLS enforcement map of the mesh: the effective mode of each namespace, resolved
+// from the mesh-wide and namespace-wide PeerAuthentications, and the workload-level policies overriding it.
+type PeerAuthentications struct {
+	RootNamespace string                      `json:"rootNamespace"`
+	MeshMode      string                      `json:"meshMode"`
+	MeshPolicy    string                      `json:"meshPolicy,omitempty"`
+	Namespaces    []NamespaceMTLSMode         `json:"namespaces"`
+	Policies      []PeerAuthenticationSummary `json:"policies"`
+	Notes         []string                    `json:"notes,omitempty"`
+}
+
+// NamespaceMTLSMode is the effective mTLS mode of a namespace. Source is "namespace" when the mode is set by a
+// namespace-wide policy of the namespace, "mesh" when it is inherited from the mesh-wide policy, and "default"
+// when no policy sets it. Policy is the policy setting the mode. WorkloadOverrides are the workload-level policies of the namespace.
+type NamespaceMTLSMode struct {
+	Namespace         string                 `json:"namespace"`
+	Mode              string                 `json:"mode"`
+	Source            string                 `json:"source"`
+	Policy            string                 `json:"policy,omitempty"`
+	WorkloadOverrides []WorkloadMTLSOverride `json:"workloadOverrides"`
+}
+
+// WorkloadMTLSOverride is a PeerAuthentication selecting workloads. Mode is its effective mode, the mode of the
+// namespace when the policy leaves it unset, and PortModes the effective modes of the ports set in portLevelMtls.
+// Workloads are the names of the workloads matching the selector.
+type WorkloadMTLSOverride struct {
+	Policy    string            `json:"policy"`
+	Selector  map[string]string `json:"selector"`
+	Mode      string            `json:"mode"`
+	PortModes map[string]string `json:"portModes,omitempty"`
+	Workloads []string          `json:"workloads"`
+}
+
+// PeerAuthenticationSummary is a condensed view of a PeerAuthentication. Scope is "mesh", "namespace" or
+// "workload", and Mode the mode set by the policy, UNSET when it inherits it.
+type PeerAuthenticationSummary struct {
+	Namespace string            `json:"namespace"`
+	Name      string            `json:"name"`
+	Scope     string            `json:"scope"`
+	Mode      string            `json:"mode"`
+	Selector  map[string]string `json:"selector,omitempty"`
+	PortModes map[string]string `json:"portModes,omitempty"`
+}
+
+// PeerAuthentications returns, as JSON, the PeerAuthentications of the mesh and the effective mTLS mode of each
+// namespace, taking into account the mesh-wide, namespace-wide and workload-level policies.
+// Parameters:
+//   - namespaces: comma-separated list of namespaces to report the mode of (optional, default: all accessible namespaces)
+//   - rootNamespace: the Istio root namespace holding the mesh-wide policy (optional, default: DefaultRootNamespace)
+func (k *Kiali) PeerAuthentications(ctx context.Context, namespaces string, rootNamespace string) (string, error) {
+	if rootNamespace = strings.TrimSpace(rootNamespace); rootNamespace == "" {
+		rootNamespace = DefaultRootNamespace
+	}
+	var config, namespacesContent, workloads string
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() (err error) {
+		config, err = k.IstioConfig(gctx)
+		return err
+	})
+	g.Go(func() (err error) {
+		namespacesContent, err = k.ListNamespaces(gctx)
+		return err
+	})
+	g.Go(func() (err error) {
+		workloads, err = k.WorkloadsList(gctx, namespaces)
+		return err
+	})
+	if err := g.Wait(); err != nil {
+		return "", err
+	}
+	objects, err := parseIstioConfigObjects(config)
+	if err != nil {
+		return "", err
+	}
+	var accessible []struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal([]byte(namespacesContent), &accessible); err != nil {
+		return "", fmt.Errorf("failed to parse namespaces: %v", err)
+	}
+	var list struct {
+		Workloads []workloadListItem `json:"workloads"`
+	}
+	if err := json.Unmarshal([]byte(workloads), &list); err != nil {
+		return "", fmt.Errorf("failed to parse workloads: %v", err)
+	}
+
+	names := splitNamespaces(namespaces)
+	if len(names) == 0 {
+		for _, ns := range accessible {
+			names = append(names, ns.Name)
+		}
+	}
+	report := computePeerAuthentications(istioObjectsOfKind(objects, "PeerAuthentication", ""), list.Workloads, names, rootNamespace)
+	result, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal peer authentications: %v", err)
+	}
+	return string(result), nil
+}
+
+// computePeerAuthentications resolves the effective mTLS mode of the namespaces from the PeerAuthentications.
+// When several policies apply to the same scope, the oldest one is used, as Istio does, the first one by name
+// among policies created at the same time, and a note reports the conflict.
+func computePeerAuthentications(policies []IstioObject, workloads []workloadListItem, namespaces []string, rootNamespace string) *PeerAuthentications {
+	sort.Slice(policies, func(i, j int) bool {
+		a, b := policies[i].Metadata, policies[j].Metadata
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if !a.CreationTimestamp.Equal(b.CreationTimestamp) {
+			return a.CreationTimestamp.Before(b.CreationTimestamp)
+		}
+		return a.Name < b.Name
+	})
+	report := &PeerAuthentications{
+		RootNamespace: rootNamespace,
+		MeshMode:      mtlsModePermissive,
+		Namespaces:    make([]NamespaceMTLSMode, 0),
+		Policies:      make([]PeerAuthenticationSummary, 0, len(policies)),
+	}
+	meshModeSet := false
+	namespacePolicies := map[string]PeerAuthenticationSummary{}
+	workloadPolicies := map[string][]PeerAuthenticationSummary{}
+	for _, policy := range policies {
+		summary := summarizePeerAuthentication(policy, rootNamespace)
+		report.Policies = append(report.Policies, summary)
+		key := summary.Namespace + "/" + summary.Name
+		switch summary.Scope {
+		case "mesh":
+			if report.MeshPolicy != "" {
+				report.Notes = append(report.Notes, fmt.Sprintf("several mesh-wide PeerAuthentications (%s, %s): only the oldest one, %s, applies", report.MeshPolicy, key, report.MeshPolicy))
+				continue
+			}
+			report.MeshPolicy = key
+			if summary.Mode != mtlsModeUnset {
+				report.MeshMode, meshModeSet = summary.Mode, true
+			}
+		case "namespace":
+			if existing, ok := namespacePolicies[summary.Namespace]; ok {
+				report.Notes = append(report.Notes, fmt.Sprintf("several namespace-wide PeerAuthentications in namespace '%s' (%s, %s): only the oldest one, %s, applies", summary.Namespace, existing.Name, summary.Name, existing.Name))
+				continue
+			}
+			namespacePolicies[summary.Namespace] = summary
+		default:
+			workloadPolicies[summary.Namespace] = append(workloadPolicies[summary.Namespace], summary)
+		}
+	}
+
+	names := slices.Clone(namespaces)
+	sort.Strings(names)
+	for _, namespace := range slices.Compact(names) {
+		ns := NamespaceMTLSMode{Namespace: namespace, Mode: report.MeshMode, Source: "default", WorkloadOverrides: make([]WorkloadMTLSOverride, 0)}
+		if meshModeSet {
+			ns.Source, ns.Policy = "mesh", report.MeshPolicy
+		}
+		if policy, ok := namespacePolicies[namespace]; ok && policy.Mode != mtlsModeUnset {
+			ns.Mode, ns.Source, ns.Policy = policy.Mode, "namespace", namespace+"/"+policy.Name
+		}
+		for _, policy := range workloadPolicies[namespace] {
+			override := WorkloadMTLSOverride{Policy: namespace + "/" + policy.Name, Selector: policy.Selector, Mode: policy.Mode, Workloads: make([]string, 0)}
+			if override.Mode == mtlsModeUnset {
+				override.Mode = ns.Mode
+			}
+			for port, mode := range policy.PortModes {
+				if mode == mtlsModeUnset {
+					mode = override.Mode
+				}
+				if override.PortModes == nil {
+					override.PortModes = map[string]string{}
+				}
+				override.PortModes[port] = mode
+			}
+			for _, w := range workloads {
+				if w.Namespace == namespace && labelsMatch(policy.Selector, w.Labels) {
+					override.Workloads = append(override.Workloads, w.Name)
+				}
+			}
+			sort.Strings(override.Workloads)
+			ns.WorkloadOverrides = append(ns.WorkloadOverrides, override)
+		}
+		report.Namespaces = append(report.Namespaces, ns)
+	}
+	if report.MeshPolicy == "" {
+		report.Notes = append(report.Notes, fmt.Sprintf("no mesh-wide PeerAuthentication in the root namespace '%s': namespaces without policy accept both mTLS and plaintext traffic (PERMISSIVE)", rootNamespace))
+	}
+	return report
+}
+
+// summarizePeerAuthentication condenses the spec of a PeerAuthentication. A policy without selector in the root
+// namespace applies to the whole mesh, and elsewhere to its namespace.
+func summarizePeerAuthentication(policy IstioObject, rootNamespace string) PeerAuthenticationSummary {
+	summary := PeerAuthenticationSummary{
+		Namespace: policy.Metadata.Namespace,
+		Name:      policy.Metadata.Name,
+		Mode:      peerAuthenticationMode(specMap(policy.Spec, "mtls")),
+		Selector:  workloadSelector(policy.Spec),
+	}
+	switch {
+	case len(summary.Selector) > 0:
+		summary.Scope = "workload"
+	case summary.Namespace == rootNamespace:
+		summary.Scope = "mesh"
+	default:
+		summary.Scope = "namespace"
+	}
+	if len(summary.Selector) == 0 {
+		summary.Selector = nil
+	}
+	// Port level modes only apply to policies selecting workloads
+	if ports := specMap(policy.Spec, "portLevelMtls"); len(ports) > 0 && summary.Scope == "workload" {
+		summary.PortModes = make(map[string]string, len(ports))
+		for port, value := range ports {
+			mtls, _ := value.(map[string]any)
+			summary.PortModes[port] = peerAuthenticationMode(mtls)
+		}
+	}
+	return summary
+}
+
+// peerAuthenticationMode returns the upper-cased mode of a PeerAuthentication mtls setting, UNSET when not set.
+func peerAuthenticationMode(mtls map[string]any) string {
+	if mode := strings.ToUpper(strings.TrimSpace(specString(mtls, "mode"))); mode != "" {
+		return mode
+	}
+	return mtlsModeUnset
+}
@@ -0,0 +1,156 @@
+package kiali
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// TokenPermissions is the RBAC scope of the token used to call Kiali: the namespaces it can read, and whether it
+// can create, update and delete Istio config in each of them.
+type TokenPermissions struct {
+	Namespaces []NamespacePermissions `json:"namespaces"`
+	Summary    string                 `json:"summary"`
+	Note       string                 `json:"note,omitempty"`
+}
+
+// NamespacePermissions are the permissions of the token in a namespace of a cluster.
+// Listed namespaces are always readable. The write permissions are not set when Kiali did not report them.
+type NamespacePermissions struct {
+	Namespace string `json:"namespace"`
+	Cluster   string `json:"cluster,omitempty"`
+	Read      bool   `json:"read"`
+	Write     *bool  `json:"write,omitempty"`
+	Create    *bool  `json:"create,omitempty"`
+	Update    *bool  `json:"update,omitempty"`
+	Delete    *bool  `json:"delete,omitempty"`
+}
+
+// istioPermissions are the Istio config permissions reported by Kiali for a namespace.
+type istioPermissions struct {
+	Create bool `json:"create"`
+	Update bool `json:"update"`
+	Delete bool `json:"delete"`
+}
+
+// TokenPermissions returns, as JSON, the namespaces the current token can read along with its Istio config
+// write permissions in each of them, sorted by namespace and cluster.
+// If Kiali does not report the permissions, the namespaces are returned without write permissions and a note.
+func (k *Kiali) TokenPermissions(ctx context.Context) (string, error) {
+	content, err := k.ListNamespaces(ctx)
+	if err != nil {
+		return "", err
+	}
+	var namespaces []struct {
+		Name    string `json:"name"`
+		Cluster string `json:"cluster"`
+	}
+	if err := json.Unmarshal([]byte(content), &namespaces); err != nil {
+		return "", fmt.Errorf("failed to parse namespaces: %v", err)
+	}
+	names := make([]string, 0, len(namespaces))
+	seen := map[string]bool{}
+	for _, ns := range namespaces {
+		if !seen[ns.Name] {
+			seen[ns.Name] = true
+			names = append(names, ns.Name)
+		}
+	}
+
+	token := &TokenPermissions{Namespaces: make([]NamespacePermissions, 0, len(namespaces))}
+	var permissions map[string]map[string]istioPermissions
+	if len(names) > 0 {
+		if permissions, err = k.istioPermissions(ctx, names); err != nil {
+			token.Note = fmt.Sprintf("write permissions unavailable: %v", err)
+		}
+	}
+	writable := 0
+	for _, ns := range namespaces {
+		entry := NamespacePermissions{Namespace: ns.Name, Cluster: ns.Cluster, Read: true}
+		if p, ok := permissionsFor(permissions[ns.Name], ns.Cluster); ok {
+			entry.Create, entry.Update, entry.Delete = &p.Create, &p.Update, &p.Delete
+			write := p.Create || p.Update || p.Delete
+			entry.Write = &write
+			if write {
+				writable++
+			}
+		}
+		token.Namespaces = append(token.Namespaces, entry)
+	}
+	sort.SliceStable(token.Namespaces, func(i, j int) bool {
+		if token.Namespaces[i].Namespace != token.Namespaces[j].Namespace {
+			return token.Namespaces[i].Namespace < token.Namespaces[j].Namespace
+		}
+		return token.Namespaces[i].Cluster < token.Namespaces[j].Cluster
+	})
+	switch {
+	case len(token.Namespaces) == 0:
+		token.Summary = "the token cannot read any namespace: empty results are caused by missing permissions"
+	case token.Note != "":
+		token.Summary = fmt.Sprintf("the token can read %d namespaces", len(token.Namespaces))
+	default:
+		token.Summary = fmt.Sprintf("the token can read %d namespaces and write Istio config in %d of them", len(token.Namespaces), writable)
+	}
+	result, err := json.MarshalIndent(token, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal token permissions: %v", err)
+	}
+	return string(result), nil
+}
+
+// istioPermissions calls the Kiali Istio permissions API for the given namespaces and returns the permissions
+// keyed by namespace and cluster. Kiali versions that do not key the permissions by cluster are reported under
+// an empty cluster name.
+func (k *Kiali) istioPermissions(ctx context.Context, namespaces []string) (map[string]map[string]istioPermissions, error) {
+	baseURL, err := k.validateAndGetBaseURL()
+	if err != nil {
+		return nil, err
+	}
+	u, err := url.Parse(strings.TrimRight(baseURL, "/") + "/api/istio/permissions")
+	if err != nil {
+		return nil, err
+	}
+	k.addNamespacesQuery(u, namespaces...)
+	content, err := k.executeRequest(ctx, u.String())
+	if err != nil {
+		return nil, err
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(content), &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse permissions: %v", err)
+	}
+	ret := make(map[string]map[string]istioPermissions, len(raw))
+	for ns, value := range raw {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(value, &fields); err != nil {
+			return nil, fmt.Errorf("failed to parse permissions: %v", err)
+		}
+		byCluster := map[string]istioPermissions{}
+		if _, ok := fields["create"]; ok {
+			var p istioPermissions
+			if err := json.Unmarshal(value, &p); err != nil {
+				return nil, fmt.Errorf("failed to parse permissions: %v", err)
+			}
+			byCluster[""] = p
+		} else {
+			if err := json.Unmarshal(value, &byCluster); err != nil {
+				return nil, fmt.Errorf("failed to parse permissions: %v", err)
+			}
+		}
+		ret[ns] = byCluster
+	}
+	return ret, nil
+}
+
+// permissionsFor returns the permissions of the given cluster, falling back to the permissions that are not
+// keyed by cluster.
+func permissionsFor(byCluster map[string]istioPermissions, cluster string) (istioPermissions, bool) {
+	if p, ok := byCluster[cluster]; ok {
+		return p, true
+	}
+	p, ok := byCluster[""]
+	return p, ok
+}
@@ -0,0 +1,128 @@
+package kiali
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// KialiPing is the result of a lightweight request to the Kiali status API, to diagnose the connection of the
+// server to Kiali. Reachable is false when no HTTP response was received, Error then tells why; it also
+// reports the API error of a non-2xx response.
+type KialiPing struct {
+	URL          string    `json:"url"`
+	Reachable    bool      `json:"reachable"`
+	StatusCode   int       `json:"statusCode,omitempty"`
+	LatencyMs    float64   `json:"latencyMs"`
+	KialiVersion string    `json:"kialiVersion,omitempty"`
+	Insecure     bool      `json:"insecure"`
+	TLS          *KialiTLS `json:"tls,omitempty"`
+	Error        string    `json:"error,omitempty"`
+}
+
+// KialiTLS is the TLS connection to Kiali and the certificate it presented. Verified tells whether the
+// certificate is trusted by the system roots for the Kiali host; when insecure mode is active the connection is
+// established regardless, and VerifyError tells why it is not trusted.
+type KialiTLS struct {
+	Version       string    `json:"version"`
+	Subject       string    `json:"subject"`
+	Issuer        string    `json:"issuer"`
+	DNSNames      []string  `json:"dnsNames,omitempty"`
+	NotAfter      time.Time `json:"notAfter"`
+	ExpiresInDays int       `json:"expiresInDays"`
+	Verified      bool      `json:"verified"`
+	VerifyError   string    `json:"verifyError,omitempty"`
+}
+
+// Ping issues a GET request to the Kiali status API and returns, as JSON, its round-trip latency, HTTP status
+// and TLS details. Failing to reach Kiali is reported in the result rather than as an error, as it is the
+// answer to the diagnostic.
+func (k *Kiali) Ping(ctx context.Context) (string, error) {
+	baseURL, err := k.validateAndGetBaseURL()
+	if err != nil {
+		return "", err
+	}
+	endpoint := strings.TrimRight(baseURL, "/") + "/api/status"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return "", err
+	}
+	done, err := k.prepareRequest(ctx, req, endpoint)
+	if err != nil {
+		return "", err
+	}
+	defer done()
+
+	ping := &KialiPing{URL: endpoint, Insecure: k.manager.staticConfig.KialiInsecure}
+	start := time.Now()
+	resp, err := k.createHTTPClient(ctx).Do(req)
+	if err != nil {
+		ping.LatencyMs = roundTwoDecimals(float64(time.Since(start).Microseconds()) / 1000)
+		ping.Error = err.Error()
+		return marshalKialiPing(ping)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	ping.LatencyMs = roundTwoDecimals(float64(time.Since(start).Microseconds()) / 1000)
+	ping.Reachable = true
+	ping.StatusCode = resp.StatusCode
+	if err := responseError(resp, body); err != nil {
+		ping.Error = err.Error()
+	} else {
+		ping.KialiVersion = parseKialiVersion(body)
+	}
+	if resp.TLS != nil {
+		ping.TLS = kialiTLS(resp.TLS, req.URL)
+	}
+	return marshalKialiPing(ping)
+}
+
+func marshalKialiPing(ping *KialiPing) (string, error) {
+	result, err := json.MarshalIndent(ping, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal kiali ping: %v", err)
+	}
+	return string(result), nil
+}
+
+// kialiTLS describes the TLS connection and verifies the leaf certificate against the system roots.
+func kialiTLS(state *tls.ConnectionState, u *url.URL) *KialiTLS {
+	ret := &KialiTLS{Version: tls.VersionName(state.Version)}
+	if len(state.PeerCertificates) == 0 {
+		return ret
+	}
+	cert := state.PeerCertificates[0]
+	ret.Subject = cert.Subject.String()
+	ret.Issuer = cert.Issuer.String()
+	ret.DNSNames = cert.DNSNames
+	ret.NotAfter = cert.NotAfter.UTC()
+	ret.ExpiresInDays = int(time.Until(cert.NotAfter).Hours() / 24)
+	intermediates := x509.NewCertPool()
+	for _, c := range state.PeerCertificates[1:] {
+		intermediates.AddCert(c)
+	}
+	if _, err := cert.Verify(x509.VerifyOptions{DNSName: u.Hostname(), Intermediates: intermediates}); err != nil {
+		ret.VerifyError = err.Error()
+	} else {
+		ret.Verified = true
+	}
+	return ret
+}
+
+// parseKialiVersion returns the Kiali version of a Kiali status response, empty when not found.
+func parseKialiVersion(body []byte) string {
+	var status struct {
+		Status map[string]string `json:"status"`
+	}
+	if err := json.Unmarshal(body, &status); err != nil {
+		return ""
+	}
+	return status.Status["Kiali version"]
+}
@@ -0,0 +1,149 @@
+package kiali
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Proxy sync statuses of the xDS components of a pod's proxy, as pushed by istiod.
+// ProxySyncUnknown is used for pods without a proxy status, e.g. without a sidecar.
+const (
+	ProxySyncSynced  = "SYNCED"
+	ProxySyncStale   = "STALE"
+	ProxySyncNotSent = "NOT_SENT"
+	ProxySyncUnknown = "UNKNOWN"
+)
+
+// proxySyncComponents are the xDS components reported in the pod proxy status.
+var proxySyncComponents = []string{"CDS", "EDS", "LDS", "RDS"}
+
+// WorkloadProxySync is the proxy sync status of the pods of a workload.
+// OutOfSync lists the pods whose proxy has a STALE or NOT_SENT (or any other non SYNCED) component;
+// they come first in Pods.
+type WorkloadProxySync struct {
+	Namespace string         `json:"namespace"`
+	Workload  string         `json:"workload"`
+	Summary   string         `json:"summary"`
+	OutOfSync []string       `json:"outOfSync"`
+	Pods      []PodProxySync `json:"pods"`
+}
+
+// PodProxySync is the proxy sync status of a pod: the worst status of its xDS components.
+type PodProxySync struct {
+	Pod        string            `json:"pod"`
+	Status     string            `json:"status"`
+	Components map[string]string `json:"components,omitempty"`
+}
+
+// WorkloadProxySyncStatus returns, as JSON, the proxy sync status (SYNCED, STALE or NOT_SENT) of each pod of a
+// workload, as reported in the workload details, with the out-of-sync pods flagged first.
+// A NotFoundError is returned when the workload does not exist.
+// Parameters:
+//   - namespace: the namespace containing the workload
+//   - workload: the name of the workload
+func (k *Kiali) WorkloadProxySyncStatus(ctx context.Context, namespace string, workload string) (string, error) {
+	content, err := k.WorkloadDetails(ctx, namespace, workload)
+	if err != nil {
+		return "", err
+	}
+	status, err := parseWorkloadProxySync(content)
+	if err != nil {
+		return "", err
+	}
+	status.Namespace = namespace
+	status.Workload = workload
+	result, err := json.MarshalIndent(status, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal workload proxy sync status: %v", err)
+	}
+	return string(result), nil
+}
+
+// parseWorkloadProxySync extracts the proxy status of the pods from the workload details.
+func parseWorkloadProxySync(content string) (*WorkloadProxySync, error) {
+	var details struct {
+		Pods []struct {
+			Name        string            `json:"name"`
+			ProxyStatus map[string]string `json:"proxyStatus"`
+		} `json:"pods"`
+	}
+	if err := json.Unmarshal([]byte(content), &details); err != nil {
+		return nil, fmt.Errorf("failed to parse workload details: %v", err)
+	}
+	status := &WorkloadProxySync{OutOfSync: make([]string, 0), Pods: make([]PodProxySync, 0, len(details.Pods))}
+	unknown := 0
+	for _, pod := range details.Pods {
+		podSync := PodProxySync{Pod: pod.Name, Status: ProxySyncUnknown}
+		for _, component := range proxySyncComponents {
+			value, ok := pod.ProxyStatus[component]
+			if !ok {
+				continue
+			}
+			if podSync.Components == nil {
+				podSync.Components = map[string]string{}
+			}
+			componentStatus := normalizeProxySync(value)
+			podSync.Components[component] = componentStatus
+			if proxySyncSeverity(componentStatus) > proxySyncSeverity(podSync.Status) {
+				podSync.Status = componentStatus
+			}
+		}
+		switch {
+		case proxySyncSeverity(podSync.Status) > proxySyncSeverity(ProxySyncSynced):
+			status.OutOfSync = append(status.OutOfSync, pod.Name)
+		case podSync.Status == ProxySyncUnknown:
+			unknown++
+		}
+		status.Pods = append(status.Pods, podSync)
+	}
+	sort.Strings(status.OutOfSync)
+	sort.Slice(status.Pods, func(i, j int) bool {
+		si, sj := proxySyncSeverity(status.Pods[i].Status), proxySyncSeverity(status.Pods[j].Status)
+		if si != sj {
+			return si > sj
+		}
+		return status.Pods[i].Pod < status.Pods[j].Pod
+	})
+
+	switch {
+	case len(status.Pods) == 0:
+		status.Summary = "the workload has no pods"
+	case len(status.OutOfSync) > 0:
+		status.Summary = fmt.Sprintf("%d/%d pods have a proxy out of sync with istiod: %s", len(status.OutOfSync), len(status.Pods), strings.Join(status.OutOfSync, ", "))
+	case unknown == len(status.Pods):
+		status.Summary = "no proxy status reported: the pods have no sidecar or istiod is not reachable by Kiali"
+	default:
+		status.Summary = fmt.Sprintf("all %d pods with a proxy status are in sync with istiod", len(status.Pods)-unknown)
+	}
+	return status, nil
+}
+
+// normalizeProxySync converts a Kiali xDS status (e.g. "Synced", "NOT_SENT") to one of the ProxySync constants.
+// Any other non-empty value is reported as is, upper-cased.
+func normalizeProxySync(value string) string {
+	switch normalized := strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(value), " ", "_")); normalized {
+	case "":
+		return ProxySyncUnknown
+	case "NOTSENT":
+		return ProxySyncNotSent
+	default:
+		return normalized
+	}
+}
+
+// proxySyncSeverity ranks the proxy sync statuses, the higher the worse. Unexpected statuses rank as STALE.
+func proxySyncSeverity(status string) int {
+	switch status {
+	case ProxySyncUnknown:
+		return 0
+	case ProxySyncSynced:
+		return 1
+	case ProxySyncNotSent:
+		return 2
+	default:
+		return 3
+	}
+}
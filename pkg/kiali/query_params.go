@@ -0,0 +1,62 @@
+package kiali
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// defaultQueryParams maps the logical names of the rate, interval and array query parameters to the
+// names sent to Kiali. Kiali versions that use different names can be supported by overriding
+// entries with the kiali_query_params configuration.
+var defaultQueryParams = map[string]string{
+	"rateInterval": "rateInterval",
+	"duration":     "duration",
+	"step":         "step",
+	"queryTime":    "queryTime",
+	"quantiles":    "quantiles[]",
+	"filters":      "filters[]",
+	"byLabels":     "byLabels[]",
+	"namespaces":   "namespaces",
+}
+
+// ValidateQueryParams checks that the query parameter overrides only use known logical names and
+// map them to non-empty parameter names.
+func ValidateQueryParams(overrides map[string]string) error {
+	for name, value := range overrides {
+		if _, ok := defaultQueryParams[name]; !ok {
+			names := make([]string, 0, len(defaultQueryParams))
+			for known := range defaultQueryParams {
+				names = append(names, known)
+			}
+			sort.Strings(names)
+			return fmt.Errorf("unknown query parameter %q, valid names are: %s", name, strings.Join(names, ", "))
+		}
+		if strings.TrimSpace(value) == "" {
+			return fmt.Errorf("query parameter %q must not be mapped to an empty name", name)
+		}
+	}
+	return nil
+}
+
+// queryParam returns the name sent to Kiali for the given logical query parameter, applying the
+// configured override, if any. Names without a mapping are returned unchanged.
+func (k *Kiali) queryParam(name string) string {
+	if k != nil && k.manager != nil && k.manager.staticConfig != nil {
+		if value := strings.TrimSpace(k.manager.staticConfig.KialiQueryParams[name]); value != "" {
+			return value
+		}
+	}
+	if value, ok := defaultQueryParams[name]; ok {
+		return value
+	}
+	return name
+}
+
+// setQueryParams sets the given query parameters, translating their names with queryParam.
+func (k *Kiali) setQueryParams(q url.Values, params map[string]string) {
+	for key, value := range params {
+		q.Set(k.queryParam(key), value)
+	}
+}
@@ -0,0 +1,77 @@
+package kiali
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// ReplicaShortfall is a workload with fewer available replicas than desired, e.g. during a rollout or when its
+// pods fail to start. SyncedProxies is only set when Kiali tracks the proxy status of the workload.
+type ReplicaShortfall struct {
+	Namespace     string       `json:"namespace"`
+	Name          string       `json:"name"`
+	Desired       int32        `json:"desired"`
+	Current       int32        `json:"current"`
+	Available     int32        `json:"available"`
+	SyncedProxies *int32       `json:"syncedProxies,omitempty"`
+	Status        HealthStatus `json:"status"`
+	Issue         string       `json:"issue,omitempty"`
+}
+
+// ReplicaShortfalls fetches the workload health of the namespaces and returns, as JSON, the workloads whose
+// available replicas are fewer than the desired ones, the largest shortfall first, then by namespace and name.
+// Parameters:
+//   - namespaces: comma-separated list of namespaces (optional, if empty checks all accessible namespaces)
+func (k *Kiali) ReplicaShortfalls(ctx context.Context, namespaces string) (string, error) {
+	health, err := k.clustersHealth(ctx, namespaces, "workload", "")
+	if err != nil {
+		return "", err
+	}
+	result, err := json.MarshalIndent(computeReplicaShortfalls(health), "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal replica shortfalls: %v", err)
+	}
+	return string(result), nil
+}
+
+// computeReplicaShortfalls returns the workloads of the health with a replica shortfall.
+func computeReplicaShortfalls(health *ClustersHealth) []ReplicaShortfall {
+	ret := make([]ReplicaShortfall, 0)
+	for namespace, workloads := range health.WorkloadHealth {
+		for name, workload := range workloads {
+			if workload == nil || workload.WorkloadStatus == nil {
+				continue
+			}
+			ws := workload.WorkloadStatus
+			if ws.AvailableReplicas >= ws.DesiredReplicas {
+				continue
+			}
+			status, issue := evaluateWorkloadStatus(ws)
+			shortfall := ReplicaShortfall{
+				Namespace: namespace,
+				Name:      name,
+				Desired:   ws.DesiredReplicas,
+				Current:   ws.CurrentReplicas,
+				Available: ws.AvailableReplicas,
+				Status:    status,
+				Issue:     issue,
+			}
+			if ws.SyncedProxies != nil && *ws.SyncedProxies >= 0 {
+				shortfall.SyncedProxies = ws.SyncedProxies
+			}
+			ret = append(ret, shortfall)
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if a, b := ret[i].Desired-ret[i].Available, ret[j].Desired-ret[j].Available; a != b {
+			return a > b
+		}
+		if ret[i].Namespace != ret[j].Namespace {
+			return ret[i].Namespace < ret[j].Namespace
+		}
+		return ret[i].Name < ret[j].Name
+	})
+	return ret
+}
@@ -0,0 +1,142 @@
+package kiali
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// DefaultReporterDeltaDuration is the default period, in seconds, compared by ReporterErrorDelta.
+	DefaultReporterDeltaDuration = "600"
+	// reporterDeltaTolerance is the error rate difference, in percentage points, below which both reporters agree.
+	reporterDeltaTolerance = 1.0
+)
+
+// ReporterErrorDelta compares the error rates reported by the source (caller) and destination (callee) proxies.
+//   - Layer is "network" when the callers see more errors than the destination reports, "application" when the
+//     errors are reported by the destination itself, "mixed" when both apply, "none" without significant errors
+//     and "no traffic" when neither reporter has requests
+//   - ErrorRateDelta is the source error rate minus the destination error rate, in percentage points
+type ReporterErrorDelta struct {
+	Namespace        string        `json:"namespace"`
+	Type             string        `json:"type"`
+	Name             string        `json:"name"`
+	Direction        string        `json:"direction"`
+	Duration         string        `json:"duration"`
+	Source           ReporterRates `json:"source"`
+	Destination      ReporterRates `json:"destination"`
+	RequestRateDelta float64       `json:"requestRateDelta"`
+	ErrorRateDelta   float64       `json:"errorRateDelta"`
+	Layer            string        `json:"layer"`
+	Explanation      string        `json:"explanation"`
+}
+
+// ReporterRates are the average request rate (requests/s) and error rate (percentage) seen by a reporter.
+type ReporterRates struct {
+	RequestRate float64 `json:"requestRate"`
+	ErrorRate   float64 `json:"errorRate"`
+}
+
+// ReporterErrorDelta fetches, concurrently, the request and error rates of an entity as reported by the
+// source and the destination proxies, and returns, as JSON, their delta and the likely origin of the errors.
+// Parameters:
+//   - namespace: the namespace containing the entity
+//   - entityType: the entity type, one of MetricsEntityTypes
+//   - name: the name of the entity
+//   - direction: the traffic direction, "inbound" or "outbound" (optional, default: "inbound")
+//   - duration: the compared period in seconds (optional, default: DefaultReporterDeltaDuration)
+func (k *Kiali) ReporterErrorDelta(ctx context.Context, namespace string, entityType string, name string, direction string, duration string) (string, error) {
+	if direction == "" {
+		direction = "inbound"
+	}
+	if duration == "" {
+		duration = DefaultReporterDeltaDuration
+	}
+	reporters := []string{"source", "destination"}
+	rates := make([]ReporterRates, len(reporters))
+	g, gctx := errgroup.WithContext(ctx)
+	for i, reporter := range reporters {
+		g.Go(func() error {
+			content, err := k.Metrics(gctx, namespace, entityType, name, []string{"request_count", "request_error_count"}, nil,
+				map[string]string{"direction": direction, "reporter": reporter, "duration": duration}, nil)
+			if err != nil {
+				return fmt.Errorf("failed to get %s reported metrics: %v", reporter, err)
+			}
+			rates[i], err = parseReporterRates(content)
+			return err
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return "", err
+	}
+	delta := &ReporterErrorDelta{
+		Namespace:   namespace,
+		Type:        entityType,
+		Name:        name,
+		Direction:   direction,
+		Duration:    duration,
+		Source:      rates[0],
+		Destination: rates[1],
+	}
+	delta.RequestRateDelta = math.Round((delta.Source.RequestRate-delta.Destination.RequestRate)*1000) / 1000
+	delta.ErrorRateDelta = roundPercentage(delta.Source.ErrorRate - delta.Destination.ErrorRate)
+	delta.Layer, delta.Explanation = classifyReporterDelta(delta.Source, delta.Destination)
+	result, err := json.MarshalIndent(delta, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal reporter error delta: %v", err)
+	}
+	return string(result), nil
+}
+
+// parseReporterRates averages the request and error rates of a Kiali metrics response over its datapoints.
+func parseReporterRates(content string) (ReporterRates, error) {
+	var metrics map[string][]Metric
+	if err := json.Unmarshal([]byte(content), &metrics); err != nil {
+		return ReporterRates{}, fmt.Errorf("failed to parse metrics: %v", err)
+	}
+	requests := averageMetricSeries(metrics["request_count"])
+	errors := averageMetricSeries(metrics["request_error_count"])
+	rates := ReporterRates{RequestRate: math.Round(requests*1000) / 1000}
+	if requests > 0 {
+		rates.ErrorRate = roundPercentage(errors / requests * 100)
+	}
+	return rates, nil
+}
+
+// averageMetricSeries returns the average over time of the sum of all the series.
+func averageMetricSeries(series []Metric) float64 {
+	values := sumMetricSeries(series)
+	if len(values) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, value := range values {
+		sum += value
+	}
+	return sum / float64(len(values))
+}
+
+// classifyReporterDelta returns the likely layer of the errors and an explanation, out of the rates of both reporters.
+func classifyReporterDelta(source, destination ReporterRates) (string, string) {
+	delta := source.ErrorRate - destination.ErrorRate
+	switch {
+	case source.RequestRate == 0 && destination.RequestRate == 0:
+		return "no traffic", "neither reporter has requests in the period"
+	case destination.RequestRate == 0 && source.ErrorRate > reporterDeltaTolerance:
+		return "network", "the callers report failing requests that never reach the destination (e.g. no healthy endpoints, connection refused, circuit breaking)"
+	case delta > reporterDeltaTolerance && destination.ErrorRate > reporterDeltaTolerance:
+		return "mixed", "the destination reports errors, and the callers see additional ones that occur between the proxies (e.g. timeouts, connection resets, circuit breaking)"
+	case delta > reporterDeltaTolerance:
+		return "network", "the callers see errors that the destination does not report: requests fail between the proxies (e.g. timeouts, connection resets, circuit breaking) or never reach the destination"
+	case destination.ErrorRate > reporterDeltaTolerance && -delta > reporterDeltaTolerance:
+		return "application", "the destination reports more errors than the callers see: its failures are likely retried successfully by the callers' proxies"
+	case destination.ErrorRate > reporterDeltaTolerance:
+		return "application", "both reporters see the same errors: they are returned by the destination application itself"
+	default:
+		return "none", "no significant errors are reported"
+	}
+}
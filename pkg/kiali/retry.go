@@ -0,0 +1,84 @@
+package kiali
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// retryableStatusCodes are the status codes of the Kiali API responses reporting a transient failure.
+var retryableStatusCodes = []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout}
+
+// idempotentMethods are the HTTP methods of the requests that can be retried without risking to apply a change
+// twice: a POST creating an object, or a PATCH, is never retried.
+var idempotentMethods = []string{http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete}
+
+// retryBackoff is the delay before the first retry, doubled at each further attempt.
+const retryBackoff = 100 * time.Millisecond
+
+// ValidateMaxRetries checks the configured number of retries of the Kiali requests.
+func ValidateMaxRetries(retries int) error {
+	if retries < 0 {
+		return fmt.Errorf("invalid kiali_max_retries: %d must not be negative", retries)
+	}
+	return nil
+}
+
+// maxRetries returns the configured number of retries of the Kiali requests, 0 when not set.
+func (k *Kiali) maxRetries() int {
+	return max(k.manager.staticConfig.KialiMaxRetries, 0)
+}
+
+// withRequestRetries performs a Kiali request, retrying it up to kiali_max_retries times on transient failures
+// when its method is idempotent.
+func (k *Kiali) withRequestRetries(ctx context.Context, method, endpoint string, fn func() error) error {
+	attempts := 1
+	if slices.Contains(idempotentMethods, method) {
+		attempts += k.maxRetries()
+	}
+	return retry(ctx, attempts, fmt.Sprintf("kiali API call %s %s", method, endpoint), fn)
+}
+
+// isRetryable reports whether a failed Kiali request may succeed if attempted again: the API responded with a
+// retryable status code, or the request failed at the network level. Timeouts are not retried, as they already
+// waited for the whole request timeout.
+func isRetryable(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return slices.Contains(retryableStatusCodes, apiErr.StatusCode)
+	}
+	if isTimeout(err) || errors.Is(err, context.Canceled) {
+		return false
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
+
+// retry calls fn up to attempts times, as long as it fails with a retryable error, waiting an exponential backoff
+// with jitter between the attempts, so that concurrent calls failing together do not retry in lockstep. The error
+// of the last attempt is returned, or the error of the attempt interrupted by the cancellation of the context.
+func retry(ctx context.Context, attempts int, what string, fn func() error) error {
+	backoff := retryBackoff
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= attempts || !isRetryable(err) {
+			return err
+		}
+		// Wait between half and the whole backoff
+		wait := backoff/2 + rand.N(backoff/2+1)
+		klog.V(1).Infof("%s failed (attempt %d of %d), retrying in %s: %v", what, attempt, attempts, wait, err)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(wait):
+		}
+		backoff *= 2
+	}
+}
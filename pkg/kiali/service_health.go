@@ -0,0 +1,137 @@
+package kiali
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// serviceHealthConcurrency is the maximum number of the Kiali requests of ServiceHealthWithWorkloads in flight:
+// the service details and the service and workload health
+const serviceHealthConcurrency = 3
+
+// CombinedServiceHealth is the health of a service computed from its requests and from the replica
+// availability of the workloads backing it, so a service without available backends is not reported
+// as healthy just because it receives no traffic.
+type CombinedServiceHealth struct {
+	EntityHealth
+	// Workloads is the computed health of the workloads backing the service
+	Workloads []EntityHealth `json:"workloads"`
+}
+
+// ServiceHealthWithWorkloads returns, as JSON, the combined health of a service and of its backing workloads.
+// Parameters:
+//   - namespace: the namespace containing the service
+//   - service: the name of the service
+//   - rateInterval: rate interval for fetching error rate (optional, default: "10m")
+func (k *Kiali) ServiceHealthWithWorkloads(ctx context.Context, namespace string, service string, rateInterval string) (string, error) {
+	if namespace == "" {
+		return "", fmt.Errorf("namespace is required")
+	}
+	if service == "" {
+		return "", fmt.Errorf("service name is required")
+	}
+
+	var details string
+	var serviceHealth, workloadHealth *ClustersHealth
+	g, gCtx := errgroup.WithContext(ctx)
+	g.SetLimit(serviceHealthConcurrency)
+	g.Go(func() (err error) {
+		details, err = k.ServiceDetails(gCtx, namespace, service)
+		return err
+	})
+	g.Go(func() (err error) {
+		if serviceHealth, err = k.clustersHealth(gCtx, namespace, "service", rateInterval); err != nil {
+			return fmt.Errorf("failed to get health: %v", err)
+		}
+		return nil
+	})
+	g.Go(func() (err error) {
+		if workloadHealth, err = k.clustersHealth(gCtx, namespace, "workload", rateInterval); err != nil {
+			return fmt.Errorf("failed to get health: %v", err)
+		}
+		return nil
+	})
+	if err := g.Wait(); err != nil {
+		return "", err
+	}
+
+	workloads, err := parseServiceWorkloads(details)
+	if err != nil {
+		return "", err
+	}
+	health := combineServiceHealth(namespace, service, serviceHealth.ServiceHealth[namespace][service], workloads, workloadHealth.WorkloadHealth[namespace])
+	result, err := json.MarshalIndent(health, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal service health: %v", err)
+	}
+	return string(result), nil
+}
+
+// parseServiceWorkloads returns the names of the workloads backing a service, from the service details.
+func parseServiceWorkloads(details string) ([]string, error) {
+	var service struct {
+		Workloads []struct {
+			Name string `json:"name"`
+		} `json:"workloads"`
+	}
+	if err := json.Unmarshal([]byte(details), &service); err != nil {
+		return nil, fmt.Errorf("failed to parse service details: %v", err)
+	}
+	names := make([]string, 0, len(service.Workloads))
+	for _, w := range service.Workloads {
+		names = append(names, w.Name)
+	}
+	return names, nil
+}
+
+// combineServiceHealth computes the service health from its requests and its backing workloads:
+//   - no backend with available replicas: UNHEALTHY (NOT_READY if every backend is scaled down)
+//   - some backends without available replicas: DEGRADED, since the others still serve the traffic
+//
+// Backends are judged on their replica availability only; their own request errors are reported in
+// Workloads but do not affect the service verdict, which already accounts for its inbound requests.
+func combineServiceHealth(namespace, service string, health *ServiceHealth, workloads []string, workloadHealth map[string]*WorkloadHealth) *CombinedServiceHealth {
+	ret := &CombinedServiceHealth{
+		EntityHealth: evaluateServiceHealth(namespace, service, health),
+		Workloads:    make([]EntityHealth, 0, len(workloads)),
+	}
+	if len(workloads) == 0 {
+		ret.Issues = append(ret.Issues, fmt.Sprintf("service %s has no backing workloads", service))
+		return ret
+	}
+	var available, notReady, unavailable int
+	for _, name := range workloads {
+		wh := workloadHealth[name]
+		ret.Workloads = append(ret.Workloads, evaluateWorkloadHealth(namespace, name, wh))
+		var ws *WorkloadStatus
+		if wh != nil {
+			ws = wh.WorkloadStatus
+		}
+		// Workloads without health data are unknown and not counted
+		switch status, _ := evaluateWorkloadStatus(ws); status {
+		case HealthStatusHealthy, HealthStatusDegraded:
+			available++
+		case HealthStatusNotReady:
+			notReady++
+		case HealthStatusUnhealthy:
+			unavailable++
+		}
+	}
+	sortEntityHealth(ret.Workloads)
+
+	switch {
+	case available == 0 && unavailable > 0:
+		ret.Status = mergeHealthStatus(ret.Status, HealthStatusUnhealthy)
+		ret.Issues = append(ret.Issues, fmt.Sprintf("no backing workload has available replicas (0/%d)", len(workloads)))
+	case available == 0 && notReady > 0:
+		ret.Status = mergeHealthStatus(ret.Status, HealthStatusNotReady)
+		ret.Issues = append(ret.Issues, fmt.Sprintf("backing workloads are scaled down to 0 replicas (%d/%d)", notReady, len(workloads)))
+	case unavailable > 0:
+		ret.Status = mergeHealthStatus(ret.Status, HealthStatusDegraded)
+		ret.Issues = append(ret.Issues, fmt.Sprintf("%d/%d backing workloads have no available replicas", unavailable, len(workloads)))
+	}
+	return ret
+}
@@ -0,0 +1,144 @@
+package kiali
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ServiceResilienceReport is the outlier detection and connection pool configuration of services. Unprotected
+// lists the services lacking outlier detection or connection pool limits.
+type ServiceResilienceReport struct {
+	Namespace   string              `json:"namespace"`
+	Services    []ServiceResilience `json:"services"`
+	Unprotected []string            `json:"unprotected"`
+}
+
+// ServiceResilience is the outlier detection (circuit breaking) and connection pool configuration applying to
+// the calls to a service, from the traffic policies of the DestinationRules targeting its host. Missing names
+// the protections not configured by any of them.
+type ServiceResilience struct {
+	Service          string              `json:"service"`
+	Host             string              `json:"host"`
+	DestinationRules []string            `json:"destinationRules"`
+	OutlierDetection []ResilienceSetting `json:"outlierDetection"`
+	ConnectionPool   []ResilienceSetting `json:"connectionPool"`
+	Missing          []string            `json:"missing,omitempty"`
+	Notes            []string            `json:"notes,omitempty"`
+}
+
+// ResilienceSetting is an outlier detection or connection pool setting of a DestinationRule traffic policy.
+// Scope is "host" for the top-level policy, "port <number>" for a port-level policy or "subset <name>" for the
+// policy of a subset. Settings are flattened to sorted "path=value" entries, e.g. "consecutive5xxErrors=5";
+// they are empty when the Istio defaults apply.
+type ResilienceSetting struct {
+	DestinationRule string   `json:"destinationRule"`
+	Scope           string   `json:"scope"`
+	Settings        []string `json:"settings,omitempty"`
+}
+
+// ServiceResilience returns, as JSON, the outlier detection and connection pool configuration of the service,
+// or of every service of the namespace, extracted from the DestinationRules targeting them, highlighting the
+// services lacking protection.
+// Parameters:
+//   - namespace: the namespace of the services
+//   - service: the name of the service (optional, all the services of the namespace when empty)
+func (k *Kiali) ServiceResilience(ctx context.Context, namespace string, service string) (string, error) {
+	if namespace == "" {
+		return "", fmt.Errorf("namespace is required")
+	}
+	services := []string{service}
+	if service == "" {
+		content, err := k.ServicesList(ctx, namespace)
+		if err != nil {
+			return "", err
+		}
+		items, err := parseServiceNames(content)
+		if err != nil {
+			return "", err
+		}
+		services = make([]string, 0, len(items))
+		for _, item := range items {
+			if item.Namespace == "" || item.Namespace == namespace {
+				services = append(services, item.Name)
+			}
+		}
+	}
+	content, err := k.IstioConfig(ctx)
+	if err != nil {
+		return "", err
+	}
+	objects, err := parseIstioConfigObjects(content)
+	if err != nil {
+		return "", err
+	}
+
+	report := &ServiceResilienceReport{Namespace: namespace, Services: make([]ServiceResilience, 0, len(services)), Unprotected: make([]string, 0)}
+	for _, name := range services {
+		resilience := computeServiceResilience(objects, namespace, name)
+		if len(resilience.Missing) > 0 {
+			report.Unprotected = append(report.Unprotected, name)
+		}
+		report.Services = append(report.Services, resilience)
+	}
+	result, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal service resilience: %v", err)
+	}
+	return string(result), nil
+}
+
+// computeServiceResilience extracts the resilience configuration of the service out of the Istio config objects.
+func computeServiceResilience(objects []IstioObject, namespace, service string) ServiceResilience {
+	host := qualifyHost(service, namespace)
+	ret := ServiceResilience{
+		Service:          service,
+		Host:             host,
+		DestinationRules: make([]string, 0),
+		OutlierDetection: make([]ResilienceSetting, 0),
+		ConnectionPool:   make([]ResilienceSetting, 0),
+	}
+	for _, dr := range istioObjectsOfKind(objects, "DestinationRule", "") {
+		if !hostsOverlap(qualifyHost(specString(dr.Spec, "host"), dr.Metadata.Namespace), host) {
+			continue
+		}
+		name := dr.Metadata.Namespace + "/" + dr.Metadata.Name
+		ret.DestinationRules = append(ret.DestinationRules, name)
+		addPolicy := func(scope string, policy map[string]any) {
+			if settings, ok := policy["outlierDetection"].(map[string]any); ok {
+				ret.OutlierDetection = append(ret.OutlierDetection, ResilienceSetting{DestinationRule: name, Scope: scope, Settings: flattenSpec(settings)})
+			}
+			if settings, ok := policy["connectionPool"].(map[string]any); ok {
+				ret.ConnectionPool = append(ret.ConnectionPool, ResilienceSetting{DestinationRule: name, Scope: scope, Settings: flattenSpec(settings)})
+			}
+		}
+		addPolicies := func(scope string, policy map[string]any) {
+			addPolicy(scope, policy)
+			for _, port := range specList(policy, "portLevelSettings") {
+				portScope := fmt.Sprintf("port %v", specMap(port, "port")["number"])
+				if scope != "host" {
+					portScope = scope + " " + portScope
+				}
+				addPolicy(portScope, port)
+			}
+		}
+		addPolicies("host", specMap(dr.Spec, "trafficPolicy"))
+		for _, subset := range specList(dr.Spec, "subsets") {
+			addPolicies("subset "+specString(subset, "name"), specMap(subset, "trafficPolicy"))
+		}
+	}
+	if len(ret.OutlierDetection) == 0 {
+		ret.Missing = append(ret.Missing, "outlierDetection")
+	}
+	if len(ret.ConnectionPool) == 0 {
+		ret.Missing = append(ret.Missing, "connectionPool")
+	}
+	switch {
+	case len(ret.DestinationRules) == 0:
+		ret.Notes = append(ret.Notes, "no DestinationRule targets this service: unhealthy endpoints are not ejected and the connections are only limited by the Istio defaults")
+	case len(ret.DestinationRules) > 1:
+		ret.Notes = append(ret.Notes, fmt.Sprintf("%d DestinationRules target this host (%s); only one of them is applied", len(ret.DestinationRules), strings.Join(ret.DestinationRules, ", ")))
+	}
+	return ret
+}
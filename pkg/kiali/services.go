@@ -13,15 +13,19 @@ func (k *Kiali) ServicesList(ctx context.Context, namespaces string) (string, er
 	if err != nil {
 		return "", err
 	}
-	endpoint := strings.TrimRight(baseURL, "/") + "/api/clusters/services?health=true&istioResources=true&rateInterval=60s&onlyDefinitions=false"
-	if namespaces != "" {
-		endpoint += "&namespaces=" + url.QueryEscape(namespaces)
+	u, err := url.Parse(fmt.Sprintf("%s/api/clusters/services?health=true&istioResources=true&%s=60s&onlyDefinitions=false",
+		strings.TrimRight(baseURL, "/"), url.QueryEscape(k.queryParam("rateInterval"))))
+	if err != nil {
+		return "", err
 	}
+	k.addNamespacesQuery(u, namespaces)
+	endpoint := u.String()
 
 	return k.executeRequest(ctx, endpoint)
 }
 
 // ServiceDetails returns the details for a specific service in a namespace.
+// A NotFoundError is returned when the service does not exist.
 func (k *Kiali) ServiceDetails(ctx context.Context, namespace string, service string) (string, error) {
 	baseURL, err := k.validateAndGetBaseURL()
 	if err != nil {
@@ -33,10 +37,14 @@ func (k *Kiali) ServiceDetails(ctx context.Context, namespace string, service st
 	if service == "" {
 		return "", fmt.Errorf("service name is required")
 	}
-	endpoint := fmt.Sprintf("%s/api/namespaces/%s/services/%s?validate=true&rateInterval=60s",
-		strings.TrimRight(baseURL, "/"), url.PathEscape(namespace), url.PathEscape(service))
+	endpoint := fmt.Sprintf("%s/api/namespaces/%s/services/%s?validate=true&%s=60s",
+		strings.TrimRight(baseURL, "/"), url.PathEscape(namespace), url.PathEscape(service), url.QueryEscape(k.queryParam("rateInterval")))
 
-	return k.executeRequest(ctx, endpoint)
+	content, err := k.executeRequest(ctx, endpoint)
+	if err != nil {
+		return "", classifyNotFound(err, "service", namespace, service)
+	}
+	return content, nil
 }
 
 // ServiceMetrics returns the metrics for a specific service in a namespace.
@@ -44,7 +52,10 @@ func (k *Kiali) ServiceDetails(ctx context.Context, namespace string, service st
 //   - namespace: the namespace containing the service
 //   - service: the name of the service
 //   - queryParams: optional query parameters map for filtering metrics (e.g., "duration", "step", "rateInterval", "direction", "reporter", "filters[]", "byLabels[]", etc.)
-func (k *Kiali) ServiceMetrics(ctx context.Context, namespace string, service string, queryParams map[string]string) (string, error) {
+//   - quantiles: optional quantiles for histogram metrics (e.g., "0.95", "0.99"), sent as repeated "quantiles[]" parameters
+//
+// Rate and interval parameter names (e.g. "rateInterval", "duration", "step") are translated with the configured query parameter names.
+func (k *Kiali) ServiceMetrics(ctx context.Context, namespace string, service string, queryParams map[string]string, quantiles []string) (string, error) {
 	baseURL, err := k.validateAndGetBaseURL()
 	if err != nil {
 		return "", err
@@ -60,14 +71,15 @@ func (k *Kiali) ServiceMetrics(ctx context.Context, namespace string, service st
 		strings.TrimRight(baseURL, "/"), url.PathEscape(namespace), url.PathEscape(service))
 
 	// Add query parameters if provided
-	if len(queryParams) > 0 {
+	if len(queryParams) > 0 || len(quantiles) > 0 {
 		u, err := url.Parse(endpoint)
 		if err != nil {
 			return "", err
 		}
 		q := u.Query()
-		for key, value := range queryParams {
-			q.Set(key, value)
+		k.setQueryParams(q, queryParams)
+		for _, quantile := range quantiles {
+			q.Add(k.queryParam("quantiles"), quantile)
 		}
 		u.RawQuery = q.Encode()
 		endpoint = u.String()
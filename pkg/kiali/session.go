@@ -0,0 +1,16 @@
+package kiali
+
+import "context"
+
+type sessionIDKey struct{}
+
+// WithSessionID returns a copy of the context carrying the ID of the MCP session of the tool call.
+func WithSessionID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, sessionIDKey{}, id)
+}
+
+// SessionID returns the MCP session ID carried by the context, or an empty string.
+func SessionID(ctx context.Context) string {
+	id, _ := ctx.Value(sessionIDKey{}).(string)
+	return id
+}
@@ -0,0 +1,100 @@
+package kiali
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// SizeMetrics are the Kiali metrics queried for the size summaries:
+//   - request_size and response_size: the istio_request_bytes and istio_response_bytes histograms of HTTP and
+//     gRPC traffic, in bytes, of which the "avg" stat is requested
+//   - tcp_sent and tcp_received: the rates of the istio_tcp_sent_bytes_total and istio_tcp_received_bytes_total
+//     counters, in bytes per second
+var SizeMetrics = []string{"request_size", "response_size", "tcp_sent", "tcp_received"}
+
+// SizeSummary is the average request and response size and the TCP bandwidth of an entity over the queried
+// period. Values are not set when the metric has no sample, e.g. the request sizes of a TCP-only workload.
+type SizeSummary struct {
+	Metrics         []string `json:"metrics"`
+	AvgRequestSize  *float64 `json:"avgRequestSize,omitempty"`
+	AvgResponseSize *float64 `json:"avgResponseSize,omitempty"`
+	TCPSentRate     *float64 `json:"tcpSentRate,omitempty"`
+	TCPReceivedRate *float64 `json:"tcpReceivedRate,omitempty"`
+}
+
+// WorkloadSizeSummary fetches the SizeMetrics of a workload and returns, as JSON, its average request and response
+// sizes, in bytes, and its average TCP sent and received rates, in bytes per second.
+// Parameters:
+//   - namespace: the namespace containing the workload
+//   - workload: the name of the workload
+//   - queryParams: optional query parameters map (e.g., "duration", "step", "rateInterval", "direction", "reporter")
+func (k *Kiali) WorkloadSizeSummary(ctx context.Context, namespace string, workload string, queryParams map[string]string) (string, error) {
+	content, err := k.Metrics(ctx, namespace, "workload", workload, SizeMetrics, nil, queryParams, []string{"avg"})
+	if err != nil {
+		return "", err
+	}
+	summary, err := parseSizeSummary(content)
+	if err != nil {
+		return "", err
+	}
+	result, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal size summary: %v", err)
+	}
+	return string(result), nil
+}
+
+// parseSizeSummary averages the size metrics of a Kiali metrics response.
+func parseSizeSummary(content string) (*SizeSummary, error) {
+	var metrics map[string][]Metric
+	if err := json.Unmarshal([]byte(content), &metrics); err != nil {
+		return nil, fmt.Errorf("failed to parse metrics: %v", err)
+	}
+	return &SizeSummary{
+		Metrics:         SizeMetrics,
+		AvgRequestSize:  averageHistogram(metrics["request_size"], "avg"),
+		AvgResponseSize: averageHistogram(metrics["response_size"], "avg"),
+		TCPSentRate:     averageSeries(sumMetricSeries(metrics["tcp_sent"])),
+		TCPReceivedRate: averageSeries(sumMetricSeries(metrics["tcp_received"])),
+	}, nil
+}
+
+// averageHistogram returns the mean of the samples of a stat of a histogram (e.g. "avg" or "0.99"),
+// or nil without samples.
+func averageHistogram(series []Metric, stat string) *float64 {
+	var sum float64
+	var count int
+	for _, metric := range series {
+		if metric.Stat != stat {
+			continue
+		}
+		for _, dp := range metric.Datapoints {
+			value := float64(dp.Value)
+			if math.IsNaN(value) || math.IsInf(value, 0) {
+				continue
+			}
+			sum += value
+			count++
+		}
+	}
+	if count == 0 {
+		return nil
+	}
+	ret := roundTwoDecimals(sum / float64(count))
+	return &ret
+}
+
+// averageSeries returns the mean of the values of a series, or nil when empty.
+func averageSeries(values map[int64]float64) *float64 {
+	if len(values) == 0 {
+		return nil
+	}
+	var sum float64
+	for _, value := range values {
+		sum += value
+	}
+	ret := roundTwoDecimals(sum / float64(len(values)))
+	return &ret
+}
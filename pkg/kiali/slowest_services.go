@@ -0,0 +1,165 @@
+package kiali
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+)
+
+const (
+	// DefaultSlowestServicesTopN is the default number of services returned by SlowestServices.
+	DefaultSlowestServicesTopN = 10
+	// DefaultSlowestServicesDuration is the default period, in seconds, the latencies are computed over.
+	DefaultSlowestServicesDuration = "600"
+	// slowestServicesQuantile is the latency quantile the services are ranked by.
+	slowestServicesQuantile = "0.99"
+	// slowestServicesConcurrency is the maximum number of service metrics requests in flight.
+	slowestServicesConcurrency = 5
+)
+
+// SlowestServices are the services with the highest p99 inbound request latency.
+// Services without inbound requests over the period are not ranked, only counted in NoTraffic. Services whose
+// metrics could not be retrieved are reported in FailedServices, keyed by "namespace/name", with their error.
+type SlowestServices struct {
+	Duration       string            `json:"duration"`
+	Quantile       string            `json:"quantile"`
+	Services       []ServiceLatency  `json:"services"`
+	Ranked         int               `json:"ranked"`
+	NoTraffic      int               `json:"noTraffic"`
+	FailedServices map[string]string `json:"failedServices,omitempty"`
+}
+
+// ServiceLatency is the p99 inbound request latency of a service, in milliseconds, averaged over the period,
+// and its average inbound request rate, in requests per second.
+type ServiceLatency struct {
+	Namespace   string  `json:"namespace"`
+	Name        string  `json:"name"`
+	P99Millis   float64 `json:"p99Millis"`
+	RequestRate float64 `json:"requestRate"`
+}
+
+// SlowestServices lists the services of the namespaces, fetches concurrently their inbound request duration
+// metrics and returns, as JSON, the topN services with the highest p99 latency, the slowest first.
+// An error is only returned when the metrics of every service failed.
+// Parameters:
+//   - namespaces: comma-separated list of namespaces (optional, if empty ranks the services of all accessible namespaces)
+//   - topN: the number of services returned (optional, DefaultSlowestServicesTopN when not positive)
+//   - duration: the period in seconds the latencies are computed over (optional, default: DefaultSlowestServicesDuration)
+func (k *Kiali) SlowestServices(ctx context.Context, namespaces string, topN int, duration string) (string, error) {
+	if topN <= 0 {
+		topN = DefaultSlowestServicesTopN
+	}
+	if duration == "" {
+		duration = DefaultSlowestServicesDuration
+	}
+	content, err := k.ServicesList(ctx, namespaces)
+	if err != nil {
+		return "", fmt.Errorf("failed to list services: %v", err)
+	}
+	services, err := parseServiceNames(content)
+	if err != nil {
+		return "", err
+	}
+
+	latencies := make([]*ServiceLatency, len(services))
+	failed := map[string]string{}
+	errs := k.fanOut(ctx, len(services), slowestServicesConcurrency, func(ctx context.Context, i int) error {
+		content, err := k.Metrics(ctx, services[i].Namespace, "service", services[i].Name, []string{"request_duration_millis", "request_count"}, nil,
+			map[string]string{"direction": "inbound", "reporter": "destination", "duration": duration}, []string{slowestServicesQuantile})
+		if err == nil {
+			latencies[i], err = parseServiceLatency(content)
+		}
+		return err
+	})
+	for i, err := range errs {
+		if err != nil {
+			failed[services[i].Namespace+"/"+services[i].Name] = err.Error()
+		}
+	}
+
+	if len(services) > 0 && len(failed) == len(services) {
+		return "", fmt.Errorf("failed to get the metrics of all services: %v", failed)
+	}
+	result := &SlowestServices{Duration: duration, Quantile: slowestServicesQuantile, Services: make([]ServiceLatency, 0)}
+	if len(failed) > 0 {
+		result.FailedServices = failed
+	}
+	for i, latency := range latencies {
+		if _, ok := failed[services[i].Namespace+"/"+services[i].Name]; ok {
+			continue
+		}
+		if latency == nil {
+			result.NoTraffic++
+			continue
+		}
+		latency.Namespace, latency.Name = services[i].Namespace, services[i].Name
+		result.Services = append(result.Services, *latency)
+	}
+	sort.Slice(result.Services, func(i, j int) bool {
+		si, sj := result.Services[i], result.Services[j]
+		if si.P99Millis != sj.P99Millis {
+			return si.P99Millis > sj.P99Millis
+		}
+		if si.Namespace != sj.Namespace {
+			return si.Namespace < sj.Namespace
+		}
+		return si.Name < sj.Name
+	})
+	result.Ranked = len(result.Services)
+	if len(result.Services) > topN {
+		result.Services = result.Services[:topN]
+	}
+	ret, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal slowest services: %v", err)
+	}
+	return string(ret), nil
+}
+
+// parseServiceNames returns the Kubernetes services of a Kiali services list response, sorted by namespace and
+// name. A service listed once per cluster is only returned once.
+func parseServiceNames(content string) ([]serviceListItem, error) {
+	var list struct {
+		Services []serviceListItem `json:"services"`
+	}
+	if err := json.Unmarshal([]byte(content), &list); err != nil {
+		return nil, fmt.Errorf("failed to parse services: %v", err)
+	}
+	services := make([]serviceListItem, 0, len(list.Services))
+	seen := map[string]struct{}{}
+	for _, s := range list.Services {
+		if s.ServiceRegistry != "" && s.ServiceRegistry != "Kubernetes" {
+			continue
+		}
+		key := s.Namespace + "/" + s.Name
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		services = append(services, s)
+	}
+	sort.Slice(services, func(i, j int) bool {
+		if services[i].Namespace != services[j].Namespace {
+			return services[i].Namespace < services[j].Namespace
+		}
+		return services[i].Name < services[j].Name
+	})
+	return services, nil
+}
+
+// parseServiceLatency returns the average p99 latency and request rate of a Kiali metrics response,
+// or nil when the service had no inbound request over the period.
+func parseServiceLatency(content string) (*ServiceLatency, error) {
+	var metrics map[string][]Metric
+	if err := json.Unmarshal([]byte(content), &metrics); err != nil {
+		return nil, fmt.Errorf("failed to parse metrics: %v", err)
+	}
+	requestRate := averageMetricSeries(metrics["request_count"])
+	p99 := averageHistogram(metrics["request_duration_millis"], slowestServicesQuantile)
+	if requestRate <= 0 || p99 == nil {
+		return nil, nil
+	}
+	return &ServiceLatency{P99Millis: *p99, RequestRate: math.Round(requestRate*1000) / 1000}, nil
+}
@@ -0,0 +1,172 @@
+package kiali
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// TraceWaterfall is the request flow of a trace: its spans ordered by start time, with their start offset from
+// the start of the trace and their duration, in milliseconds.
+type TraceWaterfall struct {
+	TraceID    string          `json:"traceID"`
+	DurationMs float64         `json:"durationMs"`
+	Spans      []WaterfallSpan `json:"spans"`
+}
+
+// WaterfallSpan is a span of a TraceWaterfall. Error is set when the span is tagged as failed.
+type WaterfallSpan struct {
+	Service       string  `json:"service"`
+	Operation     string  `json:"operation"`
+	StartOffsetMs float64 `json:"startOffsetMs"`
+	DurationMs    float64 `json:"durationMs"`
+	Error         bool    `json:"error"`
+}
+
+// TraceDetails returns the spans and processes of a trace from the Kiali tracing API.
+// Parameters:
+//   - traceID: the ID of the trace
+func (k *Kiali) TraceDetails(ctx context.Context, traceID string) (string, error) {
+	baseURL, err := k.validateAndGetBaseURL()
+	if err != nil {
+		return "", err
+	}
+	if traceID == "" {
+		return "", fmt.Errorf("trace ID is required")
+	}
+	endpoint := fmt.Sprintf("%s/api/traces/%s", strings.TrimRight(baseURL, "/"), url.PathEscape(traceID))
+	return k.executeRequest(ctx, endpoint)
+}
+
+// TraceWaterfall fetches a trace and returns, as JSON, its spans ordered by start time as a readable request
+// timeline. Spans starting at the same time are ordered by decreasing duration, so a parent comes before its
+// children.
+// Parameters:
+//   - traceID: the ID of the trace
+func (k *Kiali) TraceWaterfall(ctx context.Context, traceID string) (string, error) {
+	content, err := k.TraceDetails(ctx, traceID)
+	if err != nil {
+		return "", err
+	}
+	waterfall, err := parseTraceWaterfall(content)
+	if err != nil {
+		return "", err
+	}
+	if len(waterfall.Spans) == 0 {
+		return "", fmt.Errorf("trace '%s' not found", traceID)
+	}
+	result, err := json.MarshalIndent(waterfall, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal trace waterfall: %v", err)
+	}
+	return string(result), nil
+}
+
+// traceSpans is a trace in the Jaeger format used by the Kiali tracing API. Times are in microseconds.
+type traceSpans struct {
+	TraceID string `json:"traceID"`
+	Spans   []struct {
+		OperationName string `json:"operationName"`
+		StartTime     int64  `json:"startTime"`
+		Duration      int64  `json:"duration"`
+		ProcessID     string `json:"processID"`
+		Tags          []struct {
+			Key   string `json:"key"`
+			Value any    `json:"value"`
+		} `json:"tags"`
+	} `json:"spans"`
+	Processes map[string]struct {
+		ServiceName string `json:"serviceName"`
+	} `json:"processes"`
+}
+
+// parseTraceWaterfall builds the waterfall of a Kiali trace details response. The trace is accepted under
+// `data`, as an object or a single-element list, or at the top level.
+func parseTraceWaterfall(content string) (*TraceWaterfall, error) {
+	var response struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(content), &response); err != nil {
+		return nil, fmt.Errorf("failed to parse trace: %v", err)
+	}
+	raw := json.RawMessage(content)
+	if len(response.Data) > 0 && string(response.Data) != "null" {
+		raw = response.Data
+	}
+	var trace traceSpans
+	if strings.HasPrefix(strings.TrimSpace(string(raw)), "[") {
+		var traces []traceSpans
+		if err := json.Unmarshal(raw, &traces); err != nil {
+			return nil, fmt.Errorf("failed to parse trace: %v", err)
+		}
+		if len(traces) > 0 {
+			trace = traces[0]
+		}
+	} else if err := json.Unmarshal(raw, &trace); err != nil {
+		return nil, fmt.Errorf("failed to parse trace: %v", err)
+	}
+
+	waterfall := &TraceWaterfall{TraceID: trace.TraceID, Spans: make([]WaterfallSpan, 0, len(trace.Spans))}
+	if len(trace.Spans) == 0 {
+		return waterfall, nil
+	}
+	start, end := trace.Spans[0].StartTime, int64(0)
+	for _, span := range trace.Spans {
+		start = min(start, span.StartTime)
+		end = max(end, span.StartTime+span.Duration)
+	}
+	type timedSpan struct {
+		WaterfallSpan
+		start, duration int64
+	}
+	spans := make([]timedSpan, 0, len(trace.Spans))
+	for _, span := range trace.Spans {
+		s := timedSpan{
+			WaterfallSpan: WaterfallSpan{
+				Service:       trace.Processes[span.ProcessID].ServiceName,
+				Operation:     span.OperationName,
+				StartOffsetMs: microsToMillis(span.StartTime - start),
+				DurationMs:    microsToMillis(span.Duration),
+			},
+			start:    span.StartTime,
+			duration: span.Duration,
+		}
+		for _, tag := range span.Tags {
+			if isErrorTag(tag.Key, tag.Value) {
+				s.Error = true
+			}
+		}
+		spans = append(spans, s)
+	}
+	sort.SliceStable(spans, func(i, j int) bool {
+		if spans[i].start != spans[j].start {
+			return spans[i].start < spans[j].start
+		}
+		return spans[i].duration > spans[j].duration
+	})
+	for _, span := range spans {
+		waterfall.Spans = append(waterfall.Spans, span.WaterfallSpan)
+	}
+	waterfall.DurationMs = microsToMillis(end - start)
+	return waterfall, nil
+}
+
+// isErrorTag reports whether a span tag marks the span as failed: the Jaeger `error` tag or the
+// OpenTelemetry `otel.status_code` tag set to ERROR.
+func isErrorTag(key string, value any) bool {
+	switch key {
+	case "error":
+		return value == true || value == "true"
+	case "otel.status_code":
+		return value == "ERROR"
+	}
+	return false
+}
+
+// microsToMillis converts microseconds to milliseconds.
+func microsToMillis(micros int64) float64 {
+	return float64(micros) / 1000
+}
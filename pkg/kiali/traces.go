@@ -4,14 +4,68 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"strconv"
 	"strings"
+	"time"
 )
 
+// ValidateTracesDefaults checks the configured default limit and lookback of the trace tools.
+func ValidateTracesDefaults(limit int, lookback string) error {
+	if limit < 0 {
+		return fmt.Errorf("invalid kiali_traces_limit: %d must be a positive number", limit)
+	}
+	if lookback == "" {
+		return nil
+	}
+	if d, err := time.ParseDuration(lookback); err != nil || d <= 0 {
+		return fmt.Errorf("invalid kiali_traces_lookback: %q must be a positive duration (e.g. 15m)", lookback)
+	}
+	return nil
+}
+
+// tracesQueryParams returns a copy of the traces query parameters with the configured default limit and lookback
+// applied when the call does not set them. The lookback start is counted from endMicros when set, or from now.
+func (k *Kiali) tracesQueryParams(queryParams map[string]string) map[string]string {
+	ret := make(map[string]string, len(queryParams)+2)
+	for key, value := range queryParams {
+		ret[key] = value
+	}
+	if k == nil || k.manager == nil || k.manager.staticConfig == nil {
+		return ret
+	}
+	cfg := k.manager.staticConfig
+	if _, ok := ret["limit"]; !ok && cfg.KialiTracesLimit > 0 {
+		ret["limit"] = strconv.Itoa(cfg.KialiTracesLimit)
+	}
+	if _, ok := ret["startMicros"]; !ok && cfg.KialiTracesLookback != "" {
+		lookback, err := time.ParseDuration(cfg.KialiTracesLookback)
+		if err != nil || lookback <= 0 {
+			return ret
+		}
+		end := time.Now().UnixMicro()
+		if endMicros, err := strconv.ParseInt(ret["endMicros"], 10, 64); err == nil {
+			end = endMicros
+		}
+		ret["startMicros"] = strconv.FormatInt(end-lookback.Microseconds(), 10)
+	}
+	return ret
+}
+
+// defaultTracesLimit returns the configured default limit of the trace tools, or the given fallback.
+func (k *Kiali) defaultTracesLimit(fallback int) int {
+	if k != nil && k.manager != nil && k.manager.staticConfig != nil && k.manager.staticConfig.KialiTracesLimit > 0 {
+		return k.manager.staticConfig.KialiTracesLimit
+	}
+	return fallback
+}
+
 // AppTraces returns distributed tracing data for a specific app in a namespace.
 // Parameters:
 //   - namespace: the namespace containing the app
 //   - app: the name of the app
 //   - queryParams: optional query parameters map for filtering traces (e.g., "startMicros", "endMicros", "limit", "minDuration", "tags", "clusterName")
+//
+// The configured default limit and lookback apply when "limit" and "startMicros" are not set.
 func (k *Kiali) AppTraces(ctx context.Context, namespace string, app string, queryParams map[string]string) (string, error) {
 	baseURL, err := k.validateAndGetBaseURL()
 	if err != nil {
@@ -28,6 +82,7 @@ func (k *Kiali) AppTraces(ctx context.Context, namespace string, app string, que
 		strings.TrimRight(baseURL, "/"), url.PathEscape(namespace), url.PathEscape(app))
 
 	// Add query parameters if provided
+	queryParams = k.tracesQueryParams(queryParams)
 	if len(queryParams) > 0 {
 		u, err := url.Parse(endpoint)
 		if err != nil {
@@ -49,6 +104,8 @@ func (k *Kiali) AppTraces(ctx context.Context, namespace string, app string, que
 //   - namespace: the namespace containing the service
 //   - service: the name of the service
 //   - queryParams: optional query parameters map for filtering traces (e.g., "startMicros", "endMicros", "limit", "minDuration", "tags", "clusterName")
+//
+// The configured default limit and lookback apply when "limit" and "startMicros" are not set.
 func (k *Kiali) ServiceTraces(ctx context.Context, namespace string, service string, queryParams map[string]string) (string, error) {
 	baseURL, err := k.validateAndGetBaseURL()
 	if err != nil {
@@ -65,6 +122,7 @@ func (k *Kiali) ServiceTraces(ctx context.Context, namespace string, service str
 		strings.TrimRight(baseURL, "/"), url.PathEscape(namespace), url.PathEscape(service))
 
 	// Add query parameters if provided
+	queryParams = k.tracesQueryParams(queryParams)
 	if len(queryParams) > 0 {
 		u, err := url.Parse(endpoint)
 		if err != nil {
@@ -86,6 +144,8 @@ func (k *Kiali) ServiceTraces(ctx context.Context, namespace string, service str
 //   - namespace: the namespace containing the workload
 //   - workload: the name of the workload
 //   - queryParams: optional query parameters map for filtering traces (e.g., "startMicros", "endMicros", "limit", "minDuration", "tags", "clusterName")
+//
+// The configured default limit and lookback apply when "limit" and "startMicros" are not set.
 func (k *Kiali) WorkloadTraces(ctx context.Context, namespace string, workload string, queryParams map[string]string) (string, error) {
 	baseURL, err := k.validateAndGetBaseURL()
 	if err != nil {
@@ -102,6 +162,7 @@ func (k *Kiali) WorkloadTraces(ctx context.Context, namespace string, workload s
 		strings.TrimRight(baseURL, "/"), url.PathEscape(namespace), url.PathEscape(workload))
 
 	// Add query parameters if provided
+	queryParams = k.tracesQueryParams(queryParams)
 	if len(queryParams) > 0 {
 		u, err := url.Parse(endpoint)
 		if err != nil {
@@ -0,0 +1,167 @@
+package kiali
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Istio defaults applied to HTTP routes without an explicit timeout or retry policy.
+const (
+	defaultRouteTimeout  = "disabled"
+	defaultRetryAttempts = 2
+	defaultRetryOn       = "connect-failure,refused-stream,unavailable,cancelled,retriable-status-codes"
+)
+
+// ServiceTrafficPolicy is the effective timeout, retry and fault injection configuration of the HTTP routes
+// a mesh caller experiences for a service, along with the DestinationRules applying to its host.
+type ServiceTrafficPolicy struct {
+	Namespace        string                   `json:"namespace"`
+	Service          string                   `json:"service"`
+	Host             string                   `json:"host"`
+	Routes           []RoutePolicy            `json:"routes"`
+	DestinationRules []DestinationRuleSummary `json:"destinationRules"`
+	Notes            []string                 `json:"notes,omitempty"`
+}
+
+// RoutePolicy is the effective policy of a VirtualService HTTP route, in route evaluation order.
+// The match, destination and fault entries are flattened to "path=value" entries.
+type RoutePolicy struct {
+	VirtualService string      `json:"virtualService,omitempty"`
+	Name           string      `json:"name,omitempty"`
+	Match          []string    `json:"match,omitempty"`
+	Destinations   []string    `json:"destinations,omitempty"`
+	Timeout        string      `json:"timeout"`
+	TimeoutDefault bool        `json:"timeoutDefault,omitempty"`
+	Retries        RetryPolicy `json:"retries"`
+	Fault          []string    `json:"fault,omitempty"`
+}
+
+// RetryPolicy is the retry policy of a route. Default is set when the Istio default policy applies.
+type RetryPolicy struct {
+	Attempts      int    `json:"attempts"`
+	PerTryTimeout string `json:"perTryTimeout,omitempty"`
+	RetryOn       string `json:"retryOn,omitempty"`
+	Default       bool   `json:"default,omitempty"`
+}
+
+// ServiceTrafficPolicy returns, as JSON, the effective timeout, retry and fault injection policy of the service,
+// computed from the mesh VirtualServices routing its host and the DestinationRules targeting it.
+// Parameters:
+//   - namespace: the namespace of the service
+//   - service: the name of the service
+func (k *Kiali) ServiceTrafficPolicy(ctx context.Context, namespace string, service string) (string, error) {
+	if namespace == "" {
+		return "", fmt.Errorf("namespace is required")
+	}
+	if service == "" {
+		return "", fmt.Errorf("service name is required")
+	}
+	content, err := k.IstioConfig(ctx)
+	if err != nil {
+		return "", err
+	}
+	objects, err := parseIstioConfigObjects(content)
+	if err != nil {
+		return "", err
+	}
+	result, err := json.MarshalIndent(computeServiceTrafficPolicy(objects, namespace, service), "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal service traffic policy: %v", err)
+	}
+	return string(result), nil
+}
+
+// computeServiceTrafficPolicy computes the effective policy of the service out of the Istio config objects.
+func computeServiceTrafficPolicy(objects []IstioObject, namespace, service string) *ServiceTrafficPolicy {
+	host := qualifyHost(service, namespace)
+	policy := &ServiceTrafficPolicy{
+		Namespace:        namespace,
+		Service:          service,
+		Host:             host,
+		Routes:           make([]RoutePolicy, 0),
+		DestinationRules: make([]DestinationRuleSummary, 0),
+	}
+	virtualServices := make([]string, 0)
+	for _, vs := range istioObjectsOfKind(objects, "VirtualService", "") {
+		if !appliesToMesh(vs) || !routesHost(vs, host) {
+			continue
+		}
+		name := vs.Metadata.Namespace + "/" + vs.Metadata.Name
+		virtualServices = append(virtualServices, name)
+		for _, route := range specList(vs.Spec, "http") {
+			policy.Routes = append(policy.Routes, newRoutePolicy(name, route))
+		}
+	}
+	for _, dr := range istioObjectsOfKind(objects, "DestinationRule", "") {
+		if hostsOverlap(qualifyHost(specString(dr.Spec, "host"), dr.Metadata.Namespace), host) {
+			policy.DestinationRules = append(policy.DestinationRules, summarizeDestinationRule(dr))
+		}
+	}
+	switch {
+	case len(virtualServices) == 0:
+		policy.Routes = append(policy.Routes, newRoutePolicy("", nil))
+		policy.Notes = append(policy.Notes, "no VirtualService routes this service in the mesh, the Istio default timeout and retry policy apply")
+	case len(virtualServices) > 1:
+		policy.Notes = append(policy.Notes, fmt.Sprintf("%d VirtualServices route this host (%s); only one of them is applied to mesh traffic, so their routes may not all be effective",
+			len(virtualServices), strings.Join(virtualServices, ", ")))
+	}
+	if len(virtualServices) > 0 && len(policy.Routes) == 0 {
+		policy.Notes = append(policy.Notes, "the VirtualServices have no HTTP routes, timeouts and retries only apply to HTTP traffic")
+	}
+	return policy
+}
+
+// newRoutePolicy returns the effective policy of the given HTTP route, applying the Istio defaults.
+// A nil route stands for the default route of a service without VirtualService.
+func newRoutePolicy(virtualService string, route map[string]any) RoutePolicy {
+	ret := RoutePolicy{
+		VirtualService: virtualService,
+		Name:           specString(route, "name"),
+		Timeout:        specString(route, "timeout"),
+		Fault:          flattenSpec(specMap(route, "fault")),
+	}
+	for _, match := range specList(route, "match") {
+		ret.Match = append(ret.Match, strings.Join(flattenSpec(match), ", "))
+	}
+	for _, destination := range specList(route, "route") {
+		ret.Destinations = append(ret.Destinations, strings.Join(flattenSpec(destination), ", "))
+	}
+	if ret.Timeout == "" {
+		ret.Timeout = defaultRouteTimeout
+		ret.TimeoutDefault = true
+	}
+	retries, ok := route["retries"].(map[string]any)
+	if !ok {
+		ret.Retries = RetryPolicy{Attempts: defaultRetryAttempts, RetryOn: defaultRetryOn, Default: true}
+		return ret
+	}
+	attempts, _ := retries["attempts"].(float64)
+	ret.Retries = RetryPolicy{
+		Attempts:      int(attempts),
+		PerTryTimeout: specString(retries, "perTryTimeout"),
+		RetryOn:       specString(retries, "retryOn"),
+	}
+	if ret.Retries.Attempts > 0 && ret.Retries.RetryOn == "" {
+		ret.Retries.RetryOn = defaultRetryOn
+	}
+	return ret
+}
+
+// appliesToMesh reports whether the VirtualService applies to the sidecars, i.e. it is not bound to gateways only.
+func appliesToMesh(vs IstioObject) bool {
+	gateways := specStrings(vs.Spec, "gateways")
+	return len(gateways) == 0 || slices.Contains(gateways, "mesh")
+}
+
+// routesHost reports whether one of the VirtualService hosts matches the given qualified host.
+func routesHost(vs IstioObject, host string) bool {
+	for _, h := range specStrings(vs.Spec, "hosts") {
+		if hostsOverlap(qualifyHost(h, vs.Metadata.Namespace), host) {
+			return true
+		}
+	}
+	return false
+}
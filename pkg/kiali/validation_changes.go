@@ -0,0 +1,136 @@
+package kiali
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// maxValidationSnapshots bounds the number of sessions whose last validations snapshot is kept, the oldest
+// snapshot is evicted first.
+const maxValidationSnapshots = 100
+
+// ValidationChanges are the Istio config validation errors and warnings that appeared or were resolved since the
+// previous snapshot of the validations, taken at Since. Baseline is set when there was no previous snapshot: the
+// current validations are then recorded and no change is reported.
+type ValidationChanges struct {
+	Since    *time.Time        `json:"since,omitempty"`
+	Now      time.Time         `json:"now"`
+	Baseline bool              `json:"baseline,omitempty"`
+	Errors   int               `json:"errors"`
+	Warnings int               `json:"warnings"`
+	Appeared []ValidationError `json:"appeared"`
+	Resolved []ValidationError `json:"resolved"`
+}
+
+// validationSnapshots keeps in memory the last validations snapshot per caller, see validationSnapshotKey.
+type validationSnapshots struct {
+	mu      sync.Mutex
+	entries map[string]validationSnapshot
+}
+
+type validationSnapshot struct {
+	taken       time.Time
+	validations []ValidationError
+}
+
+// swap stores the snapshot for the given key and returns the previous one, if any.
+func (s *validationSnapshots) swap(key string, snapshot validationSnapshot) (validationSnapshot, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	previous, ok := s.entries[key]
+	if !ok && len(s.entries) >= maxValidationSnapshots {
+		oldest := ""
+		for k, entry := range s.entries {
+			if oldest == "" || entry.taken.Before(s.entries[oldest].taken) {
+				oldest = k
+			}
+		}
+		delete(s.entries, oldest)
+	}
+	s.entries[key] = snapshot
+	return previous, ok
+}
+
+// validationSnapshotKey returns the key of the validations snapshot of the caller: a hash of its Authorization
+// header, as the visible Istio config depends on the caller's permissions, which keeps the tokens out of the
+// memory of the server, scoped to its MCP session when it has one, so that the clients sharing the credentials
+// of the server do not consume each other's changes. The session ID is chosen by the client, so it never scopes
+// a snapshot on its own: two callers with different credentials and the same session ID get their own snapshot.
+func (k *Kiali) validationSnapshotKey(ctx context.Context) string {
+	sum := sha256.Sum256([]byte(k.CurrentAuthorizationHeader(ctx)))
+	key := "authorization:" + hex.EncodeToString(sum[:])
+	if id := SessionID(ctx); id != "" {
+		key += "/session:" + id
+	}
+	return key
+}
+
+// validationHistory returns the validations snapshots of the manager, created on first use.
+func (m *Manager) validationHistory() *validationSnapshots {
+	m.validationSnapshotsOnce.Do(func() {
+		m.validationSnapshots = &validationSnapshots{entries: map[string]validationSnapshot{}}
+	})
+	return m.validationSnapshots
+}
+
+// ValidationChangesSince fetches the validations of the Istio config of all the namespaces, snapshots them and
+// returns, as JSON, the validations that appeared or were resolved since the previous snapshot.
+// Kiali does not keep the validations history: the snapshots are only kept in the memory of the server, per
+// caller credentials and MCP session, and are lost when it restarts. The first call of a session only records
+// the baseline.
+func (k *Kiali) ValidationChangesSince(ctx context.Context) (string, error) {
+	content, err := k.IstioConfig(ctx)
+	if err != nil {
+		return "", err
+	}
+	current, err := parseValidationErrors(content)
+	if err != nil {
+		return "", err
+	}
+	now := time.Now().UTC()
+	previous, ok := k.manager.validationHistory().swap(k.validationSnapshotKey(ctx), validationSnapshot{taken: now, validations: current})
+	changes := &ValidationChanges{Now: now, Baseline: !ok, Appeared: make([]ValidationError, 0), Resolved: make([]ValidationError, 0)}
+	if ok {
+		changes.Since = &previous.taken
+		changes.Appeared, changes.Resolved = diffValidations(previous.validations, current)
+	}
+	for _, v := range current {
+		if v.Severity == "error" {
+			changes.Errors++
+		} else {
+			changes.Warnings++
+		}
+	}
+	result, err := json.MarshalIndent(changes, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal validation changes: %v", err)
+	}
+	return string(result), nil
+}
+
+// diffValidations returns the validations of after missing from before (appeared) and those of before missing
+// from after (resolved), in the order of the snapshots. A validation whose message changed is reported as both
+// resolved and appeared.
+func diffValidations(before, after []ValidationError) ([]ValidationError, []ValidationError) {
+	missing := func(from, in []ValidationError) []ValidationError {
+		keys := make(map[ValidationError]int, len(in))
+		for _, v := range in {
+			keys[v]++
+		}
+		ret := make([]ValidationError, 0)
+		for _, v := range from {
+			if keys[v] > 0 {
+				keys[v]--
+				continue
+			}
+			ret = append(ret, v)
+		}
+		return ret
+	}
+	return missing(after, before), missing(before, after)
+}
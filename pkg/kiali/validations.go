@@ -2,7 +2,12 @@ package kiali
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
 	"net/url"
+	"slices"
+	"sort"
 	"strings"
 )
 
@@ -16,23 +21,196 @@ func (k *Kiali) ValidationsList(ctx context.Context, namespaces []string) (strin
 	endpoint := strings.TrimRight(baseURL, "/") + "/api/istio/validations"
 
 	// Add namespaces query parameter if any provided
-	cleaned := make([]string, 0, len(namespaces))
-	for _, ns := range namespaces {
-		ns = strings.TrimSpace(ns)
-		if ns != "" {
-			cleaned = append(cleaned, ns)
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+	k.addNamespacesQuery(u, namespaces...)
+	endpoint = u.String()
+
+	return k.executeRequest(ctx, endpoint)
+}
+
+// NamespaceValidationSummary is the number of Istio config validation errors and warnings in a namespace.
+type NamespaceValidationSummary struct {
+	Cluster     string `json:"cluster,omitempty"`
+	Namespace   string `json:"namespace"`
+	Errors      int    `json:"errors"`
+	Warnings    int    `json:"warnings"`
+	ObjectCount int    `json:"objectCount"`
+}
+
+// ValidationsSummary is the total number of Istio config validation errors and warnings, with the per-namespace breakdown.
+type ValidationsSummary struct {
+	Errors      int                          `json:"errors"`
+	Warnings    int                          `json:"warnings"`
+	ObjectCount int                          `json:"objectCount"`
+	Namespaces  []NamespaceValidationSummary `json:"namespaces"`
+}
+
+// validationsSummary fetches the validations and adds up the per-namespace errors and warnings.
+func (k *Kiali) validationsSummary(ctx context.Context, namespaces []string) (*ValidationsSummary, error) {
+	content, err := k.ValidationsList(ctx, namespaces)
+	if err != nil {
+		return nil, err
+	}
+	return parseValidationsSummary(content)
+}
+
+// parseValidationsSummary parses the validations response, either a list of namespace summaries or
+// summaries nested in maps keyed by cluster and/or namespace, and computes the totals.
+func parseValidationsSummary(content string) (*ValidationsSummary, error) {
+	var raw any
+	if err := json.Unmarshal([]byte(content), &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse validations: %v", err)
+	}
+	summary := &ValidationsSummary{Namespaces: make([]NamespaceValidationSummary, 0)}
+	var walk func(value any, keys []string)
+	walk = func(value any, keys []string) {
+		switch v := value.(type) {
+		case []any:
+			for _, item := range v {
+				walk(item, keys)
+			}
+		case map[string]any:
+			if _, ok := v["objectCount"]; !ok {
+				for _, key := range slices.Sorted(maps.Keys(v)) {
+					walk(v[key], append(keys, key))
+				}
+				return
+			}
+			ns := NamespaceValidationSummary{
+				Errors:      jsonInt(v["errors"]),
+				Warnings:    jsonInt(v["warnings"]),
+				ObjectCount: jsonInt(v["objectCount"]),
+			}
+			ns.Cluster, _ = v["cluster"].(string)
+			ns.Namespace, _ = v["namespace"].(string)
+			if ns.Namespace == "" && len(keys) > 0 {
+				ns.Namespace = keys[len(keys)-1]
+			}
+			if ns.Cluster == "" && len(keys) > 1 {
+				ns.Cluster = keys[len(keys)-2]
+			}
+			summary.Errors += ns.Errors
+			summary.Warnings += ns.Warnings
+			summary.ObjectCount += ns.ObjectCount
+			summary.Namespaces = append(summary.Namespaces, ns)
 		}
 	}
-	if len(cleaned) > 0 {
-		u, err := url.Parse(endpoint)
-		if err != nil {
-			return "", err
+	walk(raw, nil)
+	return summary, nil
+}
+
+// ValidationError is a failed validation check of an Istio object. Severity is "error" or "warning".
+type ValidationError struct {
+	Cluster    string `json:"cluster,omitempty"`
+	Namespace  string `json:"namespace"`
+	ObjectType string `json:"objectType"`
+	Name       string `json:"name"`
+	Severity   string `json:"severity"`
+	Message    string `json:"message"`
+	Code       string `json:"code,omitempty"`
+	Path       string `json:"path,omitempty"`
+}
+
+// istioValidation is an Istio object validation as returned by the Kiali Istio config API with validate=true.
+type istioValidation struct {
+	Name       string `json:"name"`
+	Namespace  string `json:"namespace"`
+	Cluster    string `json:"cluster"`
+	ObjectType string `json:"objectType"`
+	ObjectGVK  struct {
+		Kind string `json:"Kind"`
+	} `json:"objectGVK"`
+	Checks []struct {
+		Code     string `json:"code"`
+		Message  string `json:"message"`
+		Severity string `json:"severity"`
+		Path     string `json:"path"`
+	} `json:"checks"`
+}
+
+// ValidationErrors fetches the validations of the Istio config of all the namespaces and returns, as JSON, the
+// failed checks of every object, the errors first, then by namespace, object type and name.
+func (k *Kiali) ValidationErrors(ctx context.Context) (string, error) {
+	content, err := k.IstioConfig(ctx)
+	if err != nil {
+		return "", err
+	}
+	validationErrors, err := parseValidationErrors(content)
+	if err != nil {
+		return "", err
+	}
+	result, err := json.MarshalIndent(validationErrors, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal validation errors: %v", err)
+	}
+	return string(result), nil
+}
+
+// parseValidationErrors flattens the validations of an Istio config response, keyed by object type (either a
+// "group/version, Kind=Kind" key or a lowercase kind) and then by "name.namespace", into the error and warning checks.
+func parseValidationErrors(content string) ([]ValidationError, error) {
+	var config struct {
+		Validations map[string]map[string]istioValidation `json:"validations"`
+	}
+	if err := json.Unmarshal([]byte(content), &config); err != nil {
+		return nil, fmt.Errorf("failed to parse Istio config validations: %v", err)
+	}
+	ret := make([]ValidationError, 0)
+	for key, objects := range config.Validations {
+		_, kind := splitGVKKey(key)
+		for objectKey, validation := range objects {
+			objectType := validation.ObjectGVK.Kind
+			if objectType == "" {
+				objectType = validation.ObjectType
+			}
+			if objectType == "" {
+				objectType = kind
+			}
+			name, namespace := validation.Name, validation.Namespace
+			if i := strings.LastIndex(objectKey, "."); i > 0 && (name == "" || namespace == "") {
+				name, namespace = objectKey[:i], objectKey[i+1:]
+			}
+			for _, check := range validation.Checks {
+				severity := strings.ToLower(check.Severity)
+				if severity != "error" && severity != "warning" {
+					continue
+				}
+				ret = append(ret, ValidationError{
+					Cluster:    validation.Cluster,
+					Namespace:  namespace,
+					ObjectType: objectType,
+					Name:       name,
+					Severity:   severity,
+					Message:    check.Message,
+					Code:       check.Code,
+					Path:       check.Path,
+				})
+			}
 		}
-		q := u.Query()
-		q.Set("namespaces", strings.Join(cleaned, ","))
-		u.RawQuery = q.Encode()
-		endpoint = u.String()
 	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		if ret[i].Severity != ret[j].Severity {
+			return ret[i].Severity == "error"
+		}
+		if ret[i].Namespace != ret[j].Namespace {
+			return ret[i].Namespace < ret[j].Namespace
+		}
+		if ret[i].ObjectType != ret[j].ObjectType {
+			return ret[i].ObjectType < ret[j].ObjectType
+		}
+		if ret[i].Name != ret[j].Name {
+			return ret[i].Name < ret[j].Name
+		}
+		return ret[i].Message < ret[j].Message
+	})
+	return ret, nil
+}
 
-	return k.executeRequest(ctx, endpoint)
+// jsonInt converts a decoded JSON number to an int, returning 0 for other values.
+func jsonInt(value any) int {
+	number, _ := value.(float64)
+	return int(number)
 }
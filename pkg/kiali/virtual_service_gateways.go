@@ -0,0 +1,213 @@
+package kiali
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// meshGateway is the reserved gateway name binding a VirtualService to the sidecars of the mesh.
+const meshGateway = "mesh"
+
+// VirtualServiceGateways is the binding of a VirtualService to its Gateways and the health of the ingress
+// workloads backing them.
+type VirtualServiceGateways struct {
+	Namespace      string           `json:"namespace"`
+	VirtualService string           `json:"virtualService"`
+	Hosts          []string         `json:"hosts"`
+	Gateways       []GatewayBinding `json:"gateways"`
+	Notes          []string         `json:"notes,omitempty"`
+}
+
+// GatewayBinding is a Gateway referenced by the `gateways` field of a VirtualService. Found is false when the
+// Gateway object does not exist. Servers are the "port/protocol hosts" entries of the Gateway servers, and
+// Workloads the gateway workloads selected by its selector, with their health. Status is the most severe status
+// of the workloads, NA when there is none.
+type GatewayBinding struct {
+	Gateway   string               `json:"gateway"`
+	Found     bool                 `json:"found"`
+	Selector  map[string]string    `json:"selector,omitempty"`
+	Servers   []string             `json:"servers,omitempty"`
+	Workloads []EntityHealthReport `json:"workloads"`
+	Status    HealthStatus         `json:"status"`
+	Notes     []string             `json:"notes,omitempty"`
+}
+
+// VirtualServiceGateways returns, as JSON, the Gateways the VirtualService is bound to, resolved to their Gateway
+// objects and the gateway workloads they select, along with the health of those workloads. A NotFoundError is
+// returned when the VirtualService does not exist.
+// Parameters:
+//   - namespace: the namespace of the VirtualService
+//   - name: the name of the VirtualService
+func (k *Kiali) VirtualServiceGateways(ctx context.Context, namespace string, name string) (string, error) {
+	if namespace == "" {
+		return "", fmt.Errorf("namespace is required")
+	}
+	if name == "" {
+		return "", fmt.Errorf("virtual service name is required")
+	}
+	var config, workloads string
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() (err error) {
+		config, err = k.IstioConfig(gctx)
+		return err
+	})
+	g.Go(func() (err error) {
+		workloads, err = k.WorkloadsList(gctx, "")
+		return err
+	})
+	if err := g.Wait(); err != nil {
+		return "", err
+	}
+	objects, err := parseIstioConfigObjects(config)
+	if err != nil {
+		return "", err
+	}
+	var list struct {
+		Workloads []workloadListItem `json:"workloads"`
+	}
+	if err := json.Unmarshal([]byte(workloads), &list); err != nil {
+		return "", fmt.Errorf("failed to parse workloads: %v", err)
+	}
+
+	report, ok := computeVirtualServiceGateways(objects, list.Workloads, namespace, name)
+	if !ok {
+		return "", &NotFoundError{Kind: "VirtualService", Namespace: namespace, Name: name}
+	}
+	if namespaces := gatewayWorkloadNamespaces(report); namespaces != "" {
+		health, err := k.clustersHealth(ctx, namespaces, "workload", "")
+		if err != nil {
+			return "", err
+		}
+		applyGatewayHealth(report, health)
+	}
+	result, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal virtual service gateways: %v", err)
+	}
+	return string(result), nil
+}
+
+// computeVirtualServiceGateways resolves the gateways of the VirtualService to their Gateway objects and the
+// workloads they select. False is returned when the VirtualService is not found.
+func computeVirtualServiceGateways(objects []IstioObject, workloads []workloadListItem, namespace, name string) (*VirtualServiceGateways, bool) {
+	var vs *IstioObject
+	for _, obj := range istioObjectsOfKind(objects, "VirtualService", namespace) {
+		if obj.Metadata.Name == name {
+			vs = &obj
+			break
+		}
+	}
+	if vs == nil {
+		return nil, false
+	}
+	report := &VirtualServiceGateways{
+		Namespace:      namespace,
+		VirtualService: name,
+		Hosts:          specStrings(vs.Spec, "hosts"),
+		Gateways:       make([]GatewayBinding, 0),
+	}
+	refs := specStrings(vs.Spec, "gateways")
+	if len(refs) == 0 {
+		report.Notes = append(report.Notes, "the VirtualService sets no gateways: it only applies to the sidecars of the mesh")
+		return report, true
+	}
+
+	gateways := make(map[string]IstioObject)
+	for _, gw := range istioObjectsOfKind(objects, "Gateway", "") {
+		// VirtualServices can only be bound to Istio Gateways, not to Gateway API ones
+		if strings.HasPrefix(gw.APIVersion, "gateway.networking.k8s.io") {
+			continue
+		}
+		gateways[gw.Metadata.Namespace+"/"+gw.Metadata.Name] = gw
+	}
+	for _, ref := range refs {
+		if ref == meshGateway {
+			report.Notes = append(report.Notes, "the VirtualService is also bound to the reserved 'mesh' gateway: it applies to the sidecars of the mesh")
+			continue
+		}
+		key := ref
+		if !strings.Contains(ref, "/") {
+			key = namespace + "/" + ref
+		}
+		binding := GatewayBinding{Gateway: key, Workloads: make([]EntityHealthReport, 0), Status: HealthStatusNA}
+		gw, found := gateways[key]
+		if !found {
+			binding.Notes = append(binding.Notes, "the Gateway does not exist: the routes of the VirtualService bound to it are not applied")
+			report.Gateways = append(report.Gateways, binding)
+			continue
+		}
+		binding.Found = true
+		binding.Selector = workloadSelector(gw.Spec)
+		exposed := false
+		for _, server := range specList(gw.Spec, "servers") {
+			port := specMap(server, "port")
+			hosts := specStrings(server, "hosts")
+			binding.Servers = append(binding.Servers, fmt.Sprintf("%v/%s %s", port["number"], specString(port, "protocol"), strings.Join(hosts, ",")))
+			for _, host := range hosts {
+				// Server hosts may be prefixed by the namespaces they are exposed to, e.g. "bookinfo/*.example.com"
+				if _, h, ok := strings.Cut(host, "/"); ok {
+					host = h
+				}
+				for _, vsHost := range report.Hosts {
+					if hostsOverlap(strings.ToLower(host), strings.ToLower(vsHost)) {
+						exposed = true
+					}
+				}
+			}
+		}
+		if !exposed {
+			binding.Notes = append(binding.Notes, "none of the hosts of the VirtualService are exposed by the servers of the Gateway")
+		}
+		if len(binding.Selector) == 0 {
+			binding.Notes = append(binding.Notes, "the Gateway has no selector: it does not select any gateway workload")
+		} else {
+			for _, w := range workloads {
+				if labelsMatch(binding.Selector, w.Labels) {
+					binding.Workloads = append(binding.Workloads, EntityHealthReport{Kind: "workload", Namespace: w.Namespace, Name: w.Name, Status: HealthStatusNA})
+				}
+			}
+			if len(binding.Workloads) == 0 {
+				binding.Notes = append(binding.Notes, "no workload matches the selector of the Gateway: no ingress gateway serves it")
+			}
+		}
+		sort.Slice(binding.Workloads, func(i, j int) bool {
+			if binding.Workloads[i].Namespace != binding.Workloads[j].Namespace {
+				return binding.Workloads[i].Namespace < binding.Workloads[j].Namespace
+			}
+			return binding.Workloads[i].Name < binding.Workloads[j].Name
+		})
+		report.Gateways = append(report.Gateways, binding)
+	}
+	return report, true
+}
+
+// gatewayWorkloadNamespaces returns the comma-separated namespaces of the gateway workloads of the report.
+func gatewayWorkloadNamespaces(report *VirtualServiceGateways) string {
+	namespaces := make(map[string]bool)
+	for _, binding := range report.Gateways {
+		for _, w := range binding.Workloads {
+			namespaces[w.Namespace] = true
+		}
+	}
+	return strings.Join(slices.Sorted(maps.Keys(namespaces)), ",")
+}
+
+// applyGatewayHealth sets the health of the gateway workloads of the report, and the resulting gateway status.
+func applyGatewayHealth(report *VirtualServiceGateways, health *ClustersHealth) {
+	for i := range report.Gateways {
+		binding := &report.Gateways[i]
+		for j, w := range binding.Workloads {
+			if evaluated, ok := evaluateEntityHealth(health, "workload", w.Namespace, w.Name); ok {
+				binding.Workloads[j] = *evaluated
+			}
+			binding.Status = mergeHealthStatus(binding.Status, binding.Workloads[j].Status)
+		}
+	}
+}
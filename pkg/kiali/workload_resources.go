@@ -0,0 +1,98 @@
+package kiali
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/metrics/pkg/apis/metrics"
+)
+
+// WorkloadResources is the resource usage of the pods of a workload.
+type WorkloadResources struct {
+	Namespace     string         `json:"namespace"`
+	Workload      string         `json:"workload"`
+	CPUMillicores int64          `json:"cpuMillicores"`
+	MemoryBytes   int64          `json:"memoryBytes"`
+	Pods          []PodResources `json:"pods"`
+	// MissingPods are the pods without metrics yet, e.g. because they just started
+	MissingPods []string `json:"missingPods,omitempty"`
+}
+
+// PodResources is the resource usage of the containers of a pod, over the window ending at Timestamp.
+type PodResources struct {
+	Name       string               `json:"name"`
+	Timestamp  string               `json:"timestamp"`
+	Window     string               `json:"window"`
+	Containers []ContainerResources `json:"containers"`
+}
+
+// ContainerResources is the CPU and memory usage of a container.
+type ContainerResources struct {
+	Name          string `json:"name"`
+	CPU           string `json:"cpu"`
+	CPUMillicores int64  `json:"cpuMillicores"`
+	Memory        string `json:"memory"`
+	MemoryBytes   int64  `json:"memoryBytes"`
+}
+
+// WorkloadResources returns, as JSON, the resource usage of the pods of a workload. The pods of the workload are
+// those listed by Kiali in the workload details, and their usage is looked up in the given metrics of the pods of
+// the namespace, as reported by the Kubernetes metrics API. A NotFoundError is returned when the workload does not exist.
+// Parameters:
+//   - namespace: the namespace containing the workload
+//   - workload: the name of the workload
+//   - podMetrics: the metrics of the pods of the namespace
+func (k *Kiali) WorkloadResources(ctx context.Context, namespace string, workload string, podMetrics []metrics.PodMetrics) (string, error) {
+	pods, err := k.WorkloadPods(ctx, namespace, workload)
+	if err != nil {
+		return "", err
+	}
+	result, err := json.MarshalIndent(newWorkloadResources(namespace, workload, pods, podMetrics), "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal workload resources: %v", err)
+	}
+	return string(result), nil
+}
+
+// newWorkloadResources returns the resource usage of the given pods of a workload, out of the metrics of their namespace.
+func newWorkloadResources(namespace, workload string, pods []string, podMetrics []metrics.PodMetrics) *WorkloadResources {
+	byName := make(map[string]metrics.PodMetrics, len(podMetrics))
+	for _, m := range podMetrics {
+		byName[m.Name] = m
+	}
+	ret := &WorkloadResources{Namespace: namespace, Workload: workload, Pods: make([]PodResources, 0, len(pods))}
+	for _, pod := range pods {
+		m, ok := byName[pod]
+		if !ok {
+			ret.MissingPods = append(ret.MissingPods, pod)
+			continue
+		}
+		podUsage := PodResources{
+			Name:       pod,
+			Timestamp:  m.Timestamp.UTC().Format(time.RFC3339),
+			Window:     m.Window.Duration.String(),
+			Containers: make([]ContainerResources, 0, len(m.Containers)),
+		}
+		for _, c := range m.Containers {
+			cpu, memory := c.Usage[corev1.ResourceCPU], c.Usage[corev1.ResourceMemory]
+			podUsage.Containers = append(podUsage.Containers, ContainerResources{
+				Name:          c.Name,
+				CPU:           cpu.String(),
+				CPUMillicores: cpu.MilliValue(),
+				Memory:        memory.String(),
+				MemoryBytes:   memory.Value(),
+			})
+			ret.CPUMillicores += cpu.MilliValue()
+			ret.MemoryBytes += memory.Value()
+		}
+		sort.Slice(podUsage.Containers, func(i, j int) bool {
+			return podUsage.Containers[i].Name < podUsage.Containers[j].Name
+		})
+		ret.Pods = append(ret.Pods, podUsage)
+	}
+	return ret
+}
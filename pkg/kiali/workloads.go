@@ -2,8 +2,10 @@ package kiali
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/url"
+	"sort"
 	"strings"
 )
 
@@ -13,15 +15,76 @@ func (k *Kiali) WorkloadsList(ctx context.Context, namespaces string) (string, e
 	if err != nil {
 		return "", err
 	}
-	endpoint := strings.TrimRight(baseURL, "/") + "/api/clusters/workloads?health=true&istioResources=true&rateInterval=60s"
-	if namespaces != "" {
-		endpoint += "&namespaces=" + url.QueryEscape(namespaces)
+	u, err := url.Parse(fmt.Sprintf("%s/api/clusters/workloads?health=true&istioResources=true&%s=60s",
+		strings.TrimRight(baseURL, "/"), url.QueryEscape(k.queryParam("rateInterval"))))
+	if err != nil {
+		return "", err
 	}
+	k.addNamespacesQuery(u, namespaces)
+	endpoint := u.String()
 
 	return k.executeRequest(ctx, endpoint)
 }
 
+// WorkloadTypes are the Kubernetes controller kinds a workload can be of.
+var WorkloadTypes = []string{"CronJob", "DaemonSet", "Deployment", "DeploymentConfig", "Job", "Pod", "ReplicaSet", "ReplicationController", "StatefulSet"}
+
+// ValidateWorkloadType checks that the workload type is a known Kubernetes controller kind, regardless of case,
+// and returns its canonical name.
+func ValidateWorkloadType(workloadType string) (string, error) {
+	for _, t := range WorkloadTypes {
+		if strings.EqualFold(t, workloadType) {
+			return t, nil
+		}
+	}
+	return "", fmt.Errorf("invalid workload type '%s': must be one of %s", workloadType, strings.Join(WorkloadTypes, ", "))
+}
+
+// WorkloadsListOfType returns the list of workloads across specified namespaces, keeping only the workloads
+// of the given controller kind. The rest of the Kiali response is returned unchanged.
+// Parameters:
+//   - namespaces: comma-separated list of namespaces (optional, if empty lists the workloads of all accessible namespaces)
+//   - workloadType: the controller kind of the workloads, one of WorkloadTypes (optional, if empty all workloads are returned)
+func (k *Kiali) WorkloadsListOfType(ctx context.Context, namespaces string, workloadType string) (string, error) {
+	if workloadType == "" {
+		return k.WorkloadsList(ctx, namespaces)
+	}
+	workloadType, err := ValidateWorkloadType(workloadType)
+	if err != nil {
+		return "", err
+	}
+	content, err := k.WorkloadsList(ctx, namespaces)
+	if err != nil {
+		return "", err
+	}
+	return filterWorkloadsByType(content, workloadType)
+}
+
+// filterWorkloadsByType drops from a workloads list response the workloads of another type. The type is read
+// from the `type` field of the workloads, or from the kind of their `gvk` in recent Kiali versions.
+func filterWorkloadsByType(content string, workloadType string) (string, error) {
+	var list map[string]any
+	if err := json.Unmarshal([]byte(content), &list); err != nil {
+		return "", fmt.Errorf("failed to parse workloads: %v", err)
+	}
+	workloads, _ := list["workloads"].([]any)
+	list["workloads"] = filterItems(workloads, func(workload map[string]any) bool {
+		kind, _ := workload["type"].(string)
+		if kind == "" {
+			gvk, _ := workload["gvk"].(map[string]any)
+			kind, _ = gvk["Kind"].(string)
+		}
+		return kind != workloadType
+	})
+	result, err := json.Marshal(list)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal workloads: %v", err)
+	}
+	return string(result), nil
+}
+
 // WorkloadDetails returns the details for a specific workload in a namespace.
+// A NotFoundError is returned when the workload does not exist.
 func (k *Kiali) WorkloadDetails(ctx context.Context, namespace string, workload string) (string, error) {
 	baseURL, err := k.validateAndGetBaseURL()
 	if err != nil {
@@ -33,10 +96,14 @@ func (k *Kiali) WorkloadDetails(ctx context.Context, namespace string, workload
 	if workload == "" {
 		return "", fmt.Errorf("workload name is required")
 	}
-	endpoint := fmt.Sprintf("%s/api/namespaces/%s/workloads/%s?validate=true&rateInterval=60s&health=true",
-		strings.TrimRight(baseURL, "/"), url.PathEscape(namespace), url.PathEscape(workload))
+	endpoint := fmt.Sprintf("%s/api/namespaces/%s/workloads/%s?validate=true&%s=60s&health=true",
+		strings.TrimRight(baseURL, "/"), url.PathEscape(namespace), url.PathEscape(workload), url.QueryEscape(k.queryParam("rateInterval")))
 
-	return k.executeRequest(ctx, endpoint)
+	content, err := k.executeRequest(ctx, endpoint)
+	if err != nil {
+		return "", classifyNotFound(err, "workload", namespace, workload)
+	}
+	return content, nil
 }
 
 // WorkloadMetrics returns the metrics for a specific workload in a namespace.
@@ -44,7 +111,10 @@ func (k *Kiali) WorkloadDetails(ctx context.Context, namespace string, workload
 //   - namespace: the namespace containing the workload
 //   - workload: the name of the workload
 //   - queryParams: optional query parameters map for filtering metrics (e.g., "duration", "step", "rateInterval", "direction", "reporter", "filters[]", "byLabels[]", etc.)
-func (k *Kiali) WorkloadMetrics(ctx context.Context, namespace string, workload string, queryParams map[string]string) (string, error) {
+//   - quantiles: optional quantiles for histogram metrics (e.g., "0.95", "0.99"), sent as repeated "quantiles[]" parameters
+//
+// Rate and interval parameter names (e.g. "rateInterval", "duration", "step") are translated with the configured query parameter names.
+func (k *Kiali) WorkloadMetrics(ctx context.Context, namespace string, workload string, queryParams map[string]string, quantiles []string) (string, error) {
 	baseURL, err := k.validateAndGetBaseURL()
 	if err != nil {
 		return "", err
@@ -60,14 +130,15 @@ func (k *Kiali) WorkloadMetrics(ctx context.Context, namespace string, workload
 		strings.TrimRight(baseURL, "/"), url.PathEscape(namespace), url.PathEscape(workload))
 
 	// Add query parameters if provided
-	if len(queryParams) > 0 {
+	if len(queryParams) > 0 || len(quantiles) > 0 {
 		u, err := url.Parse(endpoint)
 		if err != nil {
 			return "", err
 		}
 		q := u.Query()
-		for key, value := range queryParams {
-			q.Set(key, value)
+		k.setQueryParams(q, queryParams)
+		for _, quantile := range quantiles {
+			q.Add(k.queryParam("quantiles"), quantile)
 		}
 		u.RawQuery = q.Encode()
 		endpoint = u.String()
@@ -75,3 +146,186 @@ func (k *Kiali) WorkloadMetrics(ctx context.Context, namespace string, workload
 
 	return k.executeRequest(ctx, endpoint)
 }
+
+// workloadListItem holds the sidecar related fields and the labels of a workload returned by the Kiali workloads list API.
+type workloadListItem struct {
+	Namespace                string            `json:"namespace"`
+	Name                     string            `json:"name"`
+	Cluster                  string            `json:"cluster,omitempty"`
+	Labels                   map[string]string `json:"labels,omitempty"`
+	AppLabel                 *bool             `json:"appLabel,omitempty"`
+	VersionLabel             *bool             `json:"versionLabel,omitempty"`
+	IstioSidecar             bool              `json:"istioSidecar"`
+	IsAmbient                bool              `json:"isAmbient"`
+	IstioInjectionAnnotation *bool             `json:"istioInjectionAnnotation,omitempty"`
+}
+
+// WorkloadWithoutSidecar is a workload that is not part of the mesh because it has no injected proxy.
+// InjectionDisabled is set when the workload explicitly opts out of injection with the sidecar.istio.io/inject annotation.
+type WorkloadWithoutSidecar struct {
+	Namespace         string `json:"namespace"`
+	Name              string `json:"name"`
+	Cluster           string `json:"cluster,omitempty"`
+	InjectionDisabled bool   `json:"injectionDisabled,omitempty"`
+}
+
+// WorkloadsWithoutSidecar returns, as JSON, the workloads across the specified namespaces that have no injected
+// Istio sidecar. Ambient workloads are part of the mesh without a sidecar and are not reported.
+// Parameters:
+//   - namespaces: comma-separated list of namespaces (optional, if empty checks all accessible namespaces)
+func (k *Kiali) WorkloadsWithoutSidecar(ctx context.Context, namespaces string) (string, error) {
+	content, err := k.WorkloadsList(ctx, namespaces)
+	if err != nil {
+		return "", err
+	}
+	workloads, err := parseWorkloadsWithoutSidecar(content)
+	if err != nil {
+		return "", err
+	}
+	result, err := json.MarshalIndent(workloads, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal workloads without sidecar: %v", err)
+	}
+	return string(result), nil
+}
+
+// parseWorkloadsWithoutSidecar filters the workloads list response to the workloads lacking a sidecar,
+// sorted by namespace and name.
+func parseWorkloadsWithoutSidecar(content string) ([]WorkloadWithoutSidecar, error) {
+	var list struct {
+		Workloads []workloadListItem `json:"workloads"`
+	}
+	if err := json.Unmarshal([]byte(content), &list); err != nil {
+		return nil, fmt.Errorf("failed to parse workloads: %v", err)
+	}
+	ret := make([]WorkloadWithoutSidecar, 0)
+	for _, w := range list.Workloads {
+		if w.IstioSidecar || w.IsAmbient {
+			continue
+		}
+		ret = append(ret, WorkloadWithoutSidecar{
+			Namespace:         w.Namespace,
+			Name:              w.Name,
+			Cluster:           w.Cluster,
+			InjectionDisabled: w.IstioInjectionAnnotation != nil && !*w.IstioInjectionAnnotation,
+		})
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Namespace != ret[j].Namespace {
+			return ret[i].Namespace < ret[j].Namespace
+		}
+		return ret[i].Name < ret[j].Name
+	})
+	return ret, nil
+}
+
+// IstioObjectRef identifies an Istio object.
+type IstioObjectRef struct {
+	Kind       string `json:"kind"`
+	APIVersion string `json:"apiVersion,omitempty"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+}
+
+// WorkloadIstioConfig returns, as JSON, the Istio objects associated with a workload (e.g. Sidecars,
+// DestinationRules through their subsets, AuthorizationPolicies through their selectors) as reported
+// in the workload details, sorted by kind, namespace and name.
+// A NotFoundError is returned when the workload does not exist.
+// Parameters:
+//   - namespace: the namespace containing the workload
+//   - workload: the name of the workload
+func (k *Kiali) WorkloadIstioConfig(ctx context.Context, namespace string, workload string) (string, error) {
+	content, err := k.WorkloadDetails(ctx, namespace, workload)
+	if err != nil {
+		return "", err
+	}
+	refs, err := parseWorkloadIstioConfig(content)
+	if err != nil {
+		return "", err
+	}
+	result, err := json.MarshalIndent(refs, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal workload Istio config: %v", err)
+	}
+	return string(result), nil
+}
+
+// parseWorkloadIstioConfig extracts the associated Istio objects from the workload details.
+// Both the Istio resources embedded in the details and the `istioReferences` are considered.
+func parseWorkloadIstioConfig(content string) ([]IstioObjectRef, error) {
+	objects, err := parseIstioConfigObjects(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse workload details: %v", err)
+	}
+	var details struct {
+		IstioReferences []struct {
+			ObjectGVK struct {
+				Group   string `json:"Group"`
+				Version string `json:"Version"`
+				Kind    string `json:"Kind"`
+			} `json:"objectGVK"`
+			Name      string `json:"name"`
+			Namespace string `json:"namespace"`
+		} `json:"istioReferences"`
+	}
+	if err := json.Unmarshal([]byte(content), &details); err != nil {
+		return nil, fmt.Errorf("failed to parse workload details: %v", err)
+	}
+
+	refs := make([]IstioObjectRef, 0, len(objects)+len(details.IstioReferences))
+	seen := map[IstioObjectRef]struct{}{}
+	add := func(ref IstioObjectRef) {
+		key := IstioObjectRef{Kind: ref.Kind, Namespace: ref.Namespace, Name: ref.Name}
+		if _, ok := seen[key]; ok || ref.Kind == "" || ref.Name == "" {
+			return
+		}
+		seen[key] = struct{}{}
+		refs = append(refs, ref)
+	}
+	for _, obj := range objects {
+		add(IstioObjectRef{Kind: obj.Kind, APIVersion: obj.APIVersion, Namespace: obj.Metadata.Namespace, Name: obj.Metadata.Name})
+	}
+	for _, ref := range details.IstioReferences {
+		apiVersion := ref.ObjectGVK.Version
+		if ref.ObjectGVK.Group != "" && apiVersion != "" {
+			apiVersion = ref.ObjectGVK.Group + "/" + apiVersion
+		}
+		add(IstioObjectRef{Kind: ref.ObjectGVK.Kind, APIVersion: apiVersion, Namespace: ref.Namespace, Name: ref.Name})
+	}
+	sort.SliceStable(refs, func(i, j int) bool {
+		if refs[i].Kind != refs[j].Kind {
+			return refs[i].Kind < refs[j].Kind
+		}
+		if refs[i].Namespace != refs[j].Namespace {
+			return refs[i].Namespace < refs[j].Namespace
+		}
+		return refs[i].Name < refs[j].Name
+	})
+	return refs, nil
+}
+
+// WorkloadPods returns the names of the pods of a workload, as reported in the workload details.
+// A NotFoundError is returned when the workload does not exist.
+// Parameters:
+//   - namespace: the namespace containing the workload
+//   - workload: the name of the workload
+func (k *Kiali) WorkloadPods(ctx context.Context, namespace string, workload string) ([]string, error) {
+	content, err := k.WorkloadDetails(ctx, namespace, workload)
+	if err != nil {
+		return nil, err
+	}
+	var details struct {
+		Pods []struct {
+			Name string `json:"name"`
+		} `json:"pods"`
+	}
+	if err := json.Unmarshal([]byte(content), &details); err != nil {
+		return nil, fmt.Errorf("failed to parse workload details: %v", err)
+	}
+	pods := make([]string, 0, len(details.Pods))
+	for _, pod := range details.Pods {
+		pods = append(pods, pod.Name)
+	}
+	sort.Strings(pods)
+	return pods, nil
+}
@@ -0,0 +1,132 @@
+package kiali
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+const (
+	// DefaultWorkloadsListMax is the default maximum number of workloads returned by WorkloadsListPerNamespace.
+	DefaultWorkloadsListMax = 500
+	// workloadsListConcurrency is the maximum number of namespace workloads requests in flight.
+	workloadsListConcurrency = 5
+)
+
+// NamespacedWorkloads is a bounded workloads listing, fetched namespace by namespace. NamespacesScanned is the
+// number of namespaces whose workloads were fetched out of NamespacesTotal. CapReached is set when workloads
+// were left out, either from the scanned namespaces or because the remaining namespaces were not scanned.
+// Namespaces whose workloads could not be retrieved are reported in FailedNamespaces with their error.
+type NamespacedWorkloads struct {
+	Workloads         []json.RawMessage `json:"workloads"`
+	NamespacesScanned int               `json:"namespacesScanned"`
+	NamespacesTotal   int               `json:"namespacesTotal"`
+	MaxWorkloads      int               `json:"maxWorkloads"`
+	CapReached        bool              `json:"capReached"`
+	FailedNamespaces  map[string]string `json:"failedNamespaces,omitempty"`
+}
+
+// WorkloadsListPerNamespace lists the workloads one namespace at a time, with bounded concurrency, instead of in
+// a single call, and returns, as JSON, at most maxWorkloads of them. The namespaces are scanned in batches, in
+// order, and the scan stops at the first batch reaching the cap, so that large meshes get a bounded listing.
+// An error is only returned when every scanned namespace failed.
+// Parameters:
+//   - namespaces: comma-separated list of namespaces (optional, if empty scans all accessible namespaces in name order)
+//   - workloadType: the controller kind of the workloads, one of WorkloadTypes (optional, if empty all workloads are returned)
+//   - maxWorkloads: the maximum number of workloads returned (optional, DefaultWorkloadsListMax when not positive)
+func (k *Kiali) WorkloadsListPerNamespace(ctx context.Context, namespaces string, workloadType string, maxWorkloads int) (string, error) {
+	if maxWorkloads <= 0 {
+		maxWorkloads = DefaultWorkloadsListMax
+	}
+	if workloadType != "" {
+		var err error
+		if workloadType, err = ValidateWorkloadType(workloadType); err != nil {
+			return "", err
+		}
+	}
+	names := splitNamespaces(namespaces)
+	if len(names) == 0 {
+		content, err := k.ListNamespaces(ctx)
+		if err != nil {
+			return "", fmt.Errorf("failed to list namespaces: %v", err)
+		}
+		if names, err = parseNamespaceNames(content); err != nil {
+			return "", err
+		}
+	}
+
+	result := &NamespacedWorkloads{
+		Workloads:       make([]json.RawMessage, 0),
+		NamespacesTotal: len(names),
+		MaxWorkloads:    maxWorkloads,
+	}
+	failed := map[string]string{}
+	for start := 0; start < len(names); start += workloadsListConcurrency {
+		batch := names[start:min(start+workloadsListConcurrency, len(names))]
+		workloads := make([][]json.RawMessage, len(batch))
+		errs := k.fanOut(ctx, len(batch), len(batch), func(ctx context.Context, i int) error {
+			content, err := k.WorkloadsListOfType(ctx, batch[i], workloadType)
+			if err == nil {
+				workloads[i], err = parseWorkloadItems(content)
+			}
+			return err
+		})
+		for i, err := range errs {
+			if err != nil {
+				failed[batch[i]] = err.Error()
+			}
+		}
+		result.NamespacesScanned += len(batch)
+		for _, items := range workloads {
+			result.Workloads = append(result.Workloads, items...)
+		}
+		if len(result.Workloads) >= maxWorkloads {
+			result.CapReached = len(result.Workloads) > maxWorkloads || result.NamespacesScanned < len(names)
+			result.Workloads = result.Workloads[:maxWorkloads]
+			break
+		}
+	}
+
+	if len(names) > 0 && len(failed) == result.NamespacesScanned {
+		return "", fmt.Errorf("failed to list the workloads of all namespaces: %v", failed)
+	}
+	if len(failed) > 0 {
+		result.FailedNamespaces = failed
+	}
+	ret, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal workloads: %v", err)
+	}
+	return string(ret), nil
+}
+
+// parseNamespaceNames returns the names of the namespaces of a Kiali namespaces response, sorted. A namespace
+// listed once per cluster is only returned once.
+func parseNamespaceNames(content string) ([]string, error) {
+	var namespaces []struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal([]byte(content), &namespaces); err != nil {
+		return nil, fmt.Errorf("failed to parse namespaces: %v", err)
+	}
+	names := map[string]struct{}{}
+	for _, ns := range namespaces {
+		if ns.Name != "" {
+			names[ns.Name] = struct{}{}
+		}
+	}
+	return slices.Sorted(maps.Keys(names)), nil
+}
+
+// parseWorkloadItems returns the workloads of a Kiali workloads list response.
+func parseWorkloadItems(content string) ([]json.RawMessage, error) {
+	var list struct {
+		Workloads []json.RawMessage `json:"workloads"`
+	}
+	if err := json.Unmarshal([]byte(content), &list); err != nil {
+		return nil, fmt.Errorf("failed to parse workloads: %v", err)
+	}
+	return list.Workloads, nil
+}
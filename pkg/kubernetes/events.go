@@ -7,36 +7,19 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"strings"
+	"time"
 )
 
 func (k *Kubernetes) EventsList(ctx context.Context, namespace string) ([]map[string]any, error) {
 	var eventMap []map[string]any
-	raw, err := k.ResourcesList(ctx, &schema.GroupVersionKind{
-		Group: "", Version: "v1", Kind: "Event",
-	}, namespace, ResourceListOptions{})
+	events, err := k.Events(ctx, namespace)
 	if err != nil {
 		return eventMap, err
 	}
-	unstructuredList := raw.(*unstructured.UnstructuredList)
-	if len(unstructuredList.Items) == 0 {
-		return eventMap, nil
-	}
-	for _, item := range unstructuredList.Items {
-		event := &v1.Event{}
-		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, event); err != nil {
-			return eventMap, err
-		}
-		timestamp := event.EventTime.Time
-		if timestamp.IsZero() && event.Series != nil {
-			timestamp = event.Series.LastObservedTime.Time
-		} else if timestamp.IsZero() && event.Count > 1 {
-			timestamp = event.LastTimestamp.Time
-		} else if timestamp.IsZero() {
-			timestamp = event.FirstTimestamp.Time
-		}
+	for _, event := range events {
 		eventMap = append(eventMap, map[string]any{
 			"Namespace": event.Namespace,
-			"Timestamp": timestamp.String(),
+			"Timestamp": EventTimestamp(&event).String(),
 			"Type":      event.Type,
 			"Reason":    event.Reason,
 			"InvolvedObject": map[string]string{
@@ -49,3 +32,36 @@ func (k *Kubernetes) EventsList(ctx context.Context, namespace string) ([]map[st
 	}
 	return eventMap, nil
 }
+
+// Events returns the events of the given namespace, or of every namespace when namespace is empty.
+func (k *Kubernetes) Events(ctx context.Context, namespace string) ([]v1.Event, error) {
+	raw, err := k.ResourcesList(ctx, &schema.GroupVersionKind{
+		Group: "", Version: "v1", Kind: "Event",
+	}, namespace, ResourceListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	unstructuredList := raw.(*unstructured.UnstructuredList)
+	events := make([]v1.Event, 0, len(unstructuredList.Items))
+	for _, item := range unstructuredList.Items {
+		event := v1.Event{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, &event); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// EventTimestamp returns the time an event was last observed, out of the timestamps set by the reporter of the event.
+func EventTimestamp(event *v1.Event) time.Time {
+	timestamp := event.EventTime.Time
+	if timestamp.IsZero() && event.Series != nil {
+		timestamp = event.Series.LastObservedTime.Time
+	} else if timestamp.IsZero() && event.Count > 1 {
+		timestamp = event.LastTimestamp.Time
+	} else if timestamp.IsZero() {
+		timestamp = event.FirstTimestamp.Time
+	}
+	return timestamp
+}
@@ -3,14 +3,55 @@ package mcp
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
+	"math"
 
+	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"k8s.io/utils/ptr"
 
 	"github.com/kiali/kiali-mcp-server/pkg/api"
+	"github.com/kiali/kiali-mcp-server/pkg/output"
 )
 
+// maxBytesArgument is the argument added to every read-only tool to cap the size of its result.
+const maxBytesArgument = "maxBytes"
+
+// withMaxBytes returns a copy of the input schema of a read-only tool with the maxBytes argument added.
+// The tool definition is shared, so its schema is never modified in place.
+func withMaxBytes(schema *jsonschema.Schema) *jsonschema.Schema {
+	ret := &jsonschema.Schema{Type: "object"}
+	if schema != nil {
+		copied := *schema
+		ret = &copied
+	}
+	ret.Properties = maps.Clone(ret.Properties)
+	if ret.Properties == nil {
+		ret.Properties = map[string]*jsonschema.Schema{}
+	}
+	ret.Properties[maxBytesArgument] = &jsonschema.Schema{
+		Type:        "integer",
+		Description: "Optional maximum size of the result in bytes. Longer results are cut at a character boundary and end with a '[truncated N of M bytes]' marker",
+		Minimum:     ptr.To(float64(1)),
+	}
+	return ret
+}
+
+// maxBytes returns the maxBytes argument of the request, or 0 if it is not set.
+func maxBytes(request mcp.CallToolRequest) (int, error) {
+	value, ok := request.GetArguments()[maxBytesArgument]
+	if !ok || value == nil {
+		return 0, nil
+	}
+	if v, ok := value.(float64); ok && v >= 1 && v == math.Trunc(v) {
+		return int(v), nil
+	}
+	return 0, fmt.Errorf("%s must be a positive integer", maxBytesArgument)
+}
+
 func ServerToolToM3LabsServerTool(s *Server, tools []api.ServerTool) ([]server.ServerTool, error) {
 	m3labTools := make([]server.ServerTool, 0)
 	for _, tool := range tools {
@@ -25,8 +66,13 @@ func ServerToolToM3LabsServerTool(s *Server, tools []api.ServerTool) ([]server.S
 				OpenWorldHint:   tool.Tool.Annotations.OpenWorldHint,
 			},
 		}
-		if tool.Tool.InputSchema != nil {
-			schema, err := json.Marshal(tool.Tool.InputSchema)
+		readOnly := ptr.Deref(tool.Tool.Annotations.ReadOnlyHint, false)
+		inputSchema := tool.Tool.InputSchema
+		if readOnly {
+			inputSchema = withMaxBytes(inputSchema)
+		}
+		if inputSchema != nil {
+			schema, err := json.Marshal(inputSchema)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal tool input schema for tool %s: %v", tool.Tool.Name, err)
 			}
@@ -38,15 +84,28 @@ func ServerToolToM3LabsServerTool(s *Server, tools []api.ServerTool) ([]server.S
 			}
 			m3labTool.RawInputSchema = schema
 		}
+		timeout := s.configuration.toolTimeout(tool)
 		m3labHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, timeout)
+				defer cancel()
+			}
 			k, err := s.k.Derived(ctx)
 			if err != nil {
 				return nil, err
 			}
-			kiali, err := s.kiali.Derived(ctx)
+			clusterName, _ := request.GetArguments()["clusterName"].(string)
+			kiali, err := s.kiali.DerivedForCluster(ctx, clusterName)
 			if err != nil {
 				return nil, err
 			}
+			limit := 0
+			if readOnly {
+				if limit, err = maxBytes(request); err != nil {
+					return NewTextResult("", err), nil
+				}
+			}
 			result, err := tool.Handler(api.ToolHandlerParams{
 				Context:         ctx,
 				Kubernetes:      k,
@@ -57,6 +116,16 @@ func ServerToolToM3LabsServerTool(s *Server, tools []api.ServerTool) ([]server.S
 			if err != nil {
 				return nil, err
 			}
+			if s.configuration.StaticConfig.CompactOutput {
+				result.Content = output.Compact(result.Content)
+			}
+			if redactor := s.configuration.Redactor(); redactor != nil {
+				result.Content = redactor.Redact(result.Content)
+				if result.Error != nil {
+					result.Error = errors.New(redactor.Redact(result.Error.Error()))
+				}
+			}
+			result.Content = output.Truncate(result.Content, limit)
 			return NewTextResult(result.Content, result.Error), nil
 		}
 		m3labTools = append(m3labTools, server.ServerTool{Tool: m3labTool, Handler: m3labHandler})
@@ -0,0 +1,216 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"k8s.io/klog/v2"
+	"k8s.io/klog/v2/textlogger"
+	"k8s.io/utils/ptr"
+
+	"github.com/kiali/kiali-mcp-server/internal/test"
+	"github.com/kiali/kiali-mcp-server/pkg/api"
+	"github.com/kiali/kiali-mcp-server/pkg/config"
+)
+
+// newM3LabsTestServer returns a server for the given configuration, backed by a mock Kubernetes API server.
+func newM3LabsTestServer(t *testing.T, staticConfig *config.StaticConfig) *Server {
+	mockServer := test.NewMockServer()
+	t.Cleanup(mockServer.Close)
+	mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		// Requests performed by the DiscoveryClient to Kube API (Get API Groups)
+		switch req.URL.Path {
+		case "/api":
+			_, _ = w.Write([]byte(`{"kind":"APIVersions","versions":[],"serverAddressByClientCIDRs":[{"clientCIDR":"0.0.0.0/0"}]}`))
+		case "/apis":
+			_, _ = w.Write([]byte(`{"kind":"APIGroupList","groups":[]}`))
+		}
+	}))
+	staticConfig.KubeConfig = mockServer.KubeconfigFile(t)
+	s, err := NewServer(Configuration{StaticConfig: staticConfig})
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	t.Cleanup(s.Close)
+	return s
+}
+
+// m3LabsTestHandler converts the given tool and returns its MCP handler.
+func m3LabsTestHandler(t *testing.T, s *Server, tool api.ServerTool) server.ToolHandlerFunc {
+	tools, err := ServerToolToM3LabsServerTool(s, []api.ServerTool{tool})
+	if err != nil {
+		t.Fatalf("failed to convert tool %s: %v", tool.Tool.Name, err)
+	}
+	return tools[0].Handler
+}
+
+// deadlineTool returns a tool with the given default timeout, whose handler reports the time left before the
+// deadline of its context.
+func deadlineTool(timeout time.Duration, remaining *time.Duration) api.ServerTool {
+	return api.ServerTool{
+		Tool:    api.Tool{Name: "deadline_tool"},
+		Timeout: timeout,
+		Handler: func(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+			*remaining = 0
+			if deadline, ok := params.Context.Deadline(); ok {
+				*remaining = time.Until(deadline)
+			}
+			return api.NewToolCallResult("", nil), nil
+		},
+	}
+}
+
+func TestToolTimeout(t *testing.T) {
+	t.Run("the default timeout of the tool reaches the handler context", func(t *testing.T) {
+		var remaining time.Duration
+		s := newM3LabsTestServer(t, &config.StaticConfig{})
+		handler := m3LabsTestHandler(t, s, deadlineTool(time.Minute, &remaining))
+		if _, err := handler(context.Background(), mcp.CallToolRequest{}); err != nil {
+			t.Fatalf("call tool failed %v", err)
+		}
+		if remaining <= 50*time.Second || remaining > time.Minute {
+			t.Errorf("expected the handler deadline to be about 1m away, got %s", remaining)
+		}
+	})
+	t.Run("the configured timeout replaces the default timeout of the tool", func(t *testing.T) {
+		var remaining time.Duration
+		s := newM3LabsTestServer(t, &config.StaticConfig{ToolTimeouts: map[string]string{"deadline_tool": "5s"}})
+		handler := m3LabsTestHandler(t, s, deadlineTool(time.Minute, &remaining))
+		if _, err := handler(context.Background(), mcp.CallToolRequest{}); err != nil {
+			t.Fatalf("call tool failed %v", err)
+		}
+		if remaining <= 0 || remaining > 5*time.Second {
+			t.Errorf("expected the handler deadline to be at most 5s away, got %s", remaining)
+		}
+	})
+	t.Run("the configured timeout applies to tools without a default timeout", func(t *testing.T) {
+		var remaining time.Duration
+		s := newM3LabsTestServer(t, &config.StaticConfig{ToolTimeouts: map[string]string{"deadline_tool": "5s"}})
+		handler := m3LabsTestHandler(t, s, deadlineTool(0, &remaining))
+		if _, err := handler(context.Background(), mcp.CallToolRequest{}); err != nil {
+			t.Fatalf("call tool failed %v", err)
+		}
+		if remaining <= 0 || remaining > 5*time.Second {
+			t.Errorf("expected the handler deadline to be at most 5s away, got %s", remaining)
+		}
+	})
+	t.Run("no deadline without a timeout", func(t *testing.T) {
+		remaining := time.Hour
+		s := newM3LabsTestServer(t, &config.StaticConfig{})
+		handler := m3LabsTestHandler(t, s, deadlineTool(0, &remaining))
+		if _, err := handler(context.Background(), mcp.CallToolRequest{}); err != nil {
+			t.Fatalf("call tool failed %v", err)
+		}
+		if remaining != 0 {
+			t.Errorf("expected no handler deadline, got one %s away", remaining)
+		}
+	})
+	t.Run("an invalid configured timeout falls back to the default timeout with a warning", func(t *testing.T) {
+		klogState := klog.CaptureState()
+		defer klogState.Restore()
+		flags := flag.NewFlagSet("test", flag.ContinueOnError)
+		klog.InitFlags(flags)
+		var logBuffer bytes.Buffer
+		klog.SetLogger(textlogger.NewLogger(textlogger.NewConfig(textlogger.Output(&logBuffer))))
+
+		c := &Configuration{StaticConfig: &config.StaticConfig{ToolTimeouts: map[string]string{"deadline_tool": "soon"}}}
+		if timeout := c.toolTimeout(api.ServerTool{Tool: api.Tool{Name: "deadline_tool"}, Timeout: time.Minute}); timeout != time.Minute {
+			t.Errorf("expected the default timeout of 1m, got %s", timeout)
+		}
+		expectedLog := `invalid timeout \"soon\" configured for tool deadline_tool, using default`
+		if !strings.Contains(logBuffer.String(), expectedLog) {
+			t.Errorf("Expected log to contain '%s', got: %s", expectedLog, logBuffer.String())
+		}
+	})
+}
+
+func TestWithMaxBytes(t *testing.T) {
+	schema := &jsonschema.Schema{
+		Type:       "object",
+		Properties: map[string]*jsonschema.Schema{"namespace": {Type: "string"}},
+		Required:   []string{"namespace"},
+	}
+	withLimit := withMaxBytes(schema)
+	t.Run("adds the maxBytes property", func(t *testing.T) {
+		property, ok := withLimit.Properties["maxBytes"]
+		if !ok {
+			t.Fatalf("expected the maxBytes property, got %v", withLimit.Properties)
+		}
+		if property.Type != "integer" || property.Minimum == nil || *property.Minimum != 1 {
+			t.Errorf("expected maxBytes to be an integer of at least 1, got %+v", property)
+		}
+		if _, ok := withLimit.Properties["namespace"]; !ok || len(withLimit.Required) != 1 {
+			t.Errorf("expected the tool properties to be kept, got %v", withLimit.Properties)
+		}
+	})
+	t.Run("does not modify the schema of the tool", func(t *testing.T) {
+		if _, ok := schema.Properties["maxBytes"]; ok {
+			t.Errorf("expected the tool schema to be left unchanged, got %v", schema.Properties)
+		}
+	})
+	t.Run("adds the maxBytes property to tools without a schema", func(t *testing.T) {
+		if _, ok := withMaxBytes(nil).Properties["maxBytes"]; !ok {
+			t.Errorf("expected the maxBytes property")
+		}
+	})
+}
+
+func TestMaxBytes(t *testing.T) {
+	s := newM3LabsTestServer(t, &config.StaticConfig{})
+	content := strings.Repeat("a", 100)
+	tool := func(readOnly bool) api.ServerTool {
+		return api.ServerTool{
+			Tool: api.Tool{Name: "content_tool", Annotations: api.ToolAnnotations{ReadOnlyHint: ptr.To(readOnly)}},
+			Handler: func(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+				return api.NewToolCallResult(content, nil), nil
+			},
+		}
+	}
+	call := func(t *testing.T, readOnly bool, arguments map[string]any) *mcp.CallToolResult {
+		request := mcp.CallToolRequest{}
+		request.Params.Arguments = arguments
+		result, err := m3LabsTestHandler(t, s, tool(readOnly))(context.Background(), request)
+		if err != nil {
+			t.Fatalf("call tool failed %v", err)
+		}
+		return result
+	}
+	for _, value := range []any{float64(0), float64(-10), 1.5, "10"} {
+		t.Run(fmt.Sprintf("rejects %#v", value), func(t *testing.T) {
+			result := call(t, true, map[string]any{"maxBytes": value})
+			if !result.IsError || result.Content[0].(mcp.TextContent).Text != "maxBytes must be a positive integer" {
+				t.Errorf("expected maxBytes to be rejected, got %v", result.Content)
+			}
+		})
+	}
+	t.Run("truncates the result", func(t *testing.T) {
+		result := call(t, true, map[string]any{"maxBytes": float64(10)})
+		if result.IsError {
+			t.Fatalf("call tool failed %v", result.Content)
+		}
+		expected := "aaaaaaaaaa\n[truncated 90 of 100 bytes]"
+		if text := result.Content[0].(mcp.TextContent).Text; text != expected {
+			t.Errorf("expected %q, got %q", expected, text)
+		}
+	})
+	t.Run("keeps the result without maxBytes", func(t *testing.T) {
+		if text := call(t, true, nil).Content[0].(mcp.TextContent).Text; text != content {
+			t.Errorf("expected the full result, got %q", text)
+		}
+	})
+	t.Run("ignores maxBytes for tools that are not read-only", func(t *testing.T) {
+		if text := call(t, false, map[string]any{"maxBytes": float64(10)}).Content[0].(mcp.TextContent).Text; text != content {
+			t.Errorf("expected the full result, got %q", text)
+		}
+	})
+}
@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"maps"
 	"net/http"
 	"slices"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -30,13 +32,12 @@ type Configuration struct {
 	*config.StaticConfig
 	listOutput output.Output
 	toolsets   []api.Toolset
+	redactor   *output.Redactor
 }
 
 func (c *Configuration) Toolsets() []api.Toolset {
 	if c.toolsets == nil {
-		for _, toolset := range c.StaticConfig.Toolsets {
-			c.toolsets = append(c.toolsets, toolsets.ToolsetFromString(toolset))
-		}
+		c.toolsets = toolsets.Enabled(c.StaticConfig.Toolsets)
 	}
 	return c.toolsets
 }
@@ -48,6 +49,11 @@ func (c *Configuration) ListOutput() output.Output {
 	return c.listOutput
 }
 
+// Redactor returns the redactor applied to tool outputs, or nil if redaction is disabled.
+func (c *Configuration) Redactor() *output.Redactor {
+	return c.redactor
+}
+
 func (c *Configuration) isToolApplicable(tool api.ServerTool) bool {
 	if c.StaticConfig.ReadOnly && !ptr.Deref(tool.Tool.Annotations.ReadOnlyHint, false) {
 		return false
@@ -64,6 +70,18 @@ func (c *Configuration) isToolApplicable(tool api.ServerTool) bool {
 	return true
 }
 
+// toolTimeout returns the deadline to apply to calls of the given tool.
+// The tool_timeouts configuration takes precedence over the tool's built-in default.
+func (c *Configuration) toolTimeout(tool api.ServerTool) time.Duration {
+	if value, ok := c.StaticConfig.ToolTimeouts[tool.Tool.Name]; ok {
+		if timeout, err := time.ParseDuration(value); err == nil {
+			return timeout
+		}
+		klog.Warningf("invalid timeout %q configured for tool %s, using default", value, tool.Tool.Name)
+	}
+	return tool.Timeout
+}
+
 type Server struct {
 	configuration *Configuration
 	server        *server.MCPServer
@@ -85,6 +103,15 @@ func NewServer(configuration Configuration) (*Server, error) {
 		serverOptions = append(serverOptions, server.WithToolHandlerMiddleware(toolScopedAuthorizationMiddleware))
 	}
 
+	if configuration.StaticConfig.Redact {
+		// Built once, before serving, as it is shared by the concurrent tool calls
+		redactor, err := output.NewRedactor(configuration.StaticConfig.RedactFields, configuration.StaticConfig.RedactPatterns)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction configuration: %v", err)
+		}
+		configuration.redactor = redactor
+	}
+
 	s := &Server{
 		configuration: &configuration,
 		server: server.NewMCPServer(
@@ -109,6 +136,9 @@ func (s *Server) reloadKialiClient() error {
 	if err != nil {
 		return err
 	}
+	if s.kiali != nil {
+		s.kiali.Close()
+	}
 	s.kiali = kiali
 
 	return nil
@@ -185,6 +215,9 @@ func (s *Server) Close() {
 	if s.k != nil {
 		s.k.Close()
 	}
+	if s.kiali != nil {
+		s.kiali.Close()
+	}
 }
 
 func NewTextResult(content string, err error) *mcp.CallToolResult {
@@ -225,17 +258,65 @@ func contextFunc(ctx context.Context, r *http.Request) context.Context {
 	return ctx
 }
 
+// toolCallLoggingMiddleware logs every tool call with its outcome and duration. Only the names of the
+// arguments are logged by default, their values (which may hold secrets) are only logged at level 5.
+// Each call gets a correlation ID, sent to Kiali with its requests, logged with the call and appended to its errors,
+// and carries the ID of its MCP session.
 func toolCallLoggingMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
 	return func(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		klog.V(5).Infof("mcp tool call: %s(%v)", ctr.Params.Name, ctr.Params.Arguments)
+		correlationID := internalkiali.NewCorrelationID()
+		ctx = internalkiali.WithCorrelationID(ctx, correlationID)
+		if session := server.ClientSessionFromContext(ctx); session != nil {
+			ctx = internalkiali.WithSessionID(ctx, session.SessionID())
+		}
+		klog.V(5).InfoS(fmt.Sprintf("mcp tool call: %s(%v)", ctr.Params.Name, ctr.Params.Arguments), "correlationId", correlationID)
 		if ctr.Header != nil {
 			buffer := bytes.NewBuffer(make([]byte, 0))
 			if err := ctr.Header.WriteSubset(buffer, map[string]bool{"Authorization": true, "authorization": true}); err == nil {
 				klog.V(7).Infof("mcp tool call headers: %s", buffer)
 			}
 		}
-		return next(ctx, ctr)
+		start := time.Now()
+		result, err := next(ctx, ctr)
+		keysAndValues := []any{
+			"tool", ctr.Params.Name,
+			"arguments", slices.Sorted(maps.Keys(ctr.GetArguments())),
+			"duration", time.Since(start),
+			"outcome", toolCallOutcome(result, err),
+		}
+		if err != nil {
+			keysAndValues = append(keysAndValues, "error", err.Error())
+		}
+		keysAndValues = append(keysAndValues, "correlationId", correlationID)
+		klog.V(1).InfoS("mcp tool call completed", keysAndValues...)
+		if err != nil {
+			return result, fmt.Errorf("%w (correlation ID: %s)", err, correlationID)
+		}
+		return withCorrelationID(result, correlationID), nil
+	}
+}
+
+// withCorrelationID appends the correlation ID of the tool call to the text of an error result.
+func withCorrelationID(result *mcp.CallToolResult, correlationID string) *mcp.CallToolResult {
+	if result == nil || !result.IsError {
+		return result
+	}
+	for i, content := range result.Content {
+		if text, ok := content.(mcp.TextContent); ok {
+			text.Text = fmt.Sprintf("%s (correlation ID: %s)", text.Text, correlationID)
+			result.Content[i] = text
+			return result
+		}
+	}
+	return result
+}
+
+// toolCallOutcome returns "success" or "error" depending on the result of a tool call.
+func toolCallOutcome(result *mcp.CallToolResult, err error) string {
+	if err != nil || result == nil || result.IsError {
+		return "error"
 	}
+	return "success"
 }
 
 func toolScopedAuthorizationMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
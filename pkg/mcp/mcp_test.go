@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/kiali/kiali-mcp-server/internal/test"
+	"github.com/kiali/kiali-mcp-server/pkg/config"
 	"github.com/mark3labs/mcp-go/client"
 	"github.com/mark3labs/mcp-go/mcp"
 )
@@ -48,6 +49,13 @@ func TestWatchKubeConfig(t *testing.T) {
 	})
 }
 
+func TestNewServerInvalidRedaction(t *testing.T) {
+	_, err := NewServer(Configuration{StaticConfig: &config.StaticConfig{Redact: true, RedactPatterns: []string{"("}}})
+	if err == nil {
+		t.Fatalf("NewServer accepted an invalid redaction pattern")
+	}
+}
+
 func TestSseHeaders(t *testing.T) {
 	mockServer := test.NewMockServer()
 	defer mockServer.Close()
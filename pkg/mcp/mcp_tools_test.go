@@ -131,6 +131,15 @@ func TestToolCallLogging(t *testing.T) {
 				t.Errorf("Expected log to contain '%s', got: %s", expectedLog, c.logBuffer.String())
 			}
 		})
+		t.Run("Logs tool call outcome with argument names only", func(t *testing.T) {
+			expectedLog := `"mcp tool call completed" tool="configuration_view" arguments=["minified"]`
+			if !strings.Contains(c.logBuffer.String(), expectedLog) {
+				t.Errorf("Expected log to contain '%s', got: %s", expectedLog, c.logBuffer.String())
+			}
+			if !strings.Contains(c.logBuffer.String(), `outcome="success"`) {
+				t.Errorf("Expected log to contain the success outcome, got: %s", c.logBuffer.String())
+			}
+		})
 		t.Run("Logs tool call arguments", func(t *testing.T) {
 			expected := `"mcp tool call: configuration_view\((.+)\)"`
 			m := regexp.MustCompile(expected).FindStringSubmatch(c.logBuffer.String())
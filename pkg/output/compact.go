@@ -0,0 +1,21 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// Compact returns JSON content without insignificant whitespace, e.g. the indentation of pretty-printed
+// results, to save tokens. Content that is not a JSON object or array is returned unchanged.
+func Compact(content string) string {
+	trimmed := strings.TrimSpace(content)
+	if !strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[") {
+		return content
+	}
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, []byte(trimmed)); err != nil {
+		return content
+	}
+	return buf.String()
+}
@@ -0,0 +1,28 @@
+package output
+
+import (
+	"testing"
+)
+
+func TestCompact(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{name: "indented object", content: "{\n  \"name\": \"reviews\",\n  \"ports\": [\n    9080\n  ]\n}", expected: `{"name":"reviews","ports":[9080]}`},
+		{name: "indented array", content: "[\n  {\n    \"name\": \"a b\"\n  }\n]\n", expected: `[{"name":"a b"}]`},
+		{name: "already compact", content: `{"name":"reviews"}`, expected: `{"name":"reviews"}`},
+		{name: "whitespace in strings is kept", content: "{ \"message\": \"not  found\\n\" }", expected: `{"message":"not  found\n"}`},
+		{name: "invalid JSON", content: "{\n  \"name\": ", expected: "{\n  \"name\": "},
+		{name: "plain text", content: "NAME  READY\nreviews  1/1", expected: "NAME  READY\nreviews  1/1"},
+		{name: "JSON scalar", content: " \"reviews\" ", expected: " \"reviews\" "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := Compact(tt.content); actual != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, actual)
+			}
+		})
+	}
+}
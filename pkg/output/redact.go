@@ -0,0 +1,103 @@
+package output
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// RedactedValue replaces the sensitive values found in tool outputs.
+const RedactedValue = "***"
+
+// DefaultRedactPatterns are the built-in regular expressions of sensitive values.
+// When a pattern has a capture group, only the first group is masked, otherwise the whole match is.
+var DefaultRedactPatterns = []string{
+	// Authorization headers, e.g. "Authorization: Bearer xyz" or "authorization":"Basic xyz"
+	`(?i)\b(?:proxy-)?authorization["']?\s*[:=]\s*["']?(?:(?:bearer|basic)\s+)?([^\s"',;]+)`,
+	// Bearer tokens outside of an Authorization header
+	`(?i)\bbearer\s+([A-Za-z0-9._~+/=-]+)`,
+	// JSON Web Tokens
+	`\beyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`,
+	// Tokens, passwords and keys in query strings or key=value log lines
+	`(?i)\b(?:access_token|refresh_token|id_token|token|api[_-]?key|password|passwd|secret)=([^\s&"',;]+)`,
+}
+
+// DefaultRedactFields are the built-in JSON field names whose string values are masked (case-insensitive).
+var DefaultRedactFields = []string{
+	"authorization",
+	"password",
+	"token",
+	"accessToken",
+	"access_token",
+	"refreshToken",
+	"refresh_token",
+	"clientSecret",
+	"client_secret",
+	"apiKey",
+	"api_key",
+	"privateKey",
+	"private_key",
+}
+
+// Redactor masks sensitive values in tool outputs.
+type Redactor struct {
+	patterns []*regexp.Regexp
+}
+
+// NewRedactor returns a Redactor masking the values matched by the built-in patterns and fields,
+// extended with the given JSON field names and regular expressions.
+func NewRedactor(fields []string, patterns []string) (*Redactor, error) {
+	r := &Redactor{}
+	for _, pattern := range append(append([]string{}, DefaultRedactPatterns...), patterns...) {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %v", pattern, err)
+		}
+		r.patterns = append(r.patterns, re)
+	}
+	quoted := make([]string, 0, len(DefaultRedactFields)+len(fields))
+	for _, field := range append(append([]string{}, DefaultRedactFields...), fields...) {
+		if field = strings.TrimSpace(field); field == "" {
+			return nil, fmt.Errorf("field names must not be empty")
+		}
+		quoted = append(quoted, regexp.QuoteMeta(field))
+	}
+	// Matches the string value of any of the fields, e.g. "password": "value" (escaped quotes included)
+	r.patterns = append(r.patterns, regexp.MustCompile(`(?i)"(?:`+strings.Join(quoted, "|")+`)"\s*:\s*"((?:[^"\\]|\\.)*)"`))
+	return r, nil
+}
+
+// Redact returns the content with the sensitive values replaced by RedactedValue.
+func (r *Redactor) Redact(content string) string {
+	if r == nil {
+		return content
+	}
+	for _, re := range r.patterns {
+		content = redactMatches(re, content)
+	}
+	return content
+}
+
+// redactMatches masks the first capture group of each match of re, or the whole match if there is no such group.
+func redactMatches(re *regexp.Regexp, content string) string {
+	matches := re.FindAllStringSubmatchIndex(content, -1)
+	if len(matches) == 0 {
+		return content
+	}
+	var sb strings.Builder
+	last := 0
+	for _, m := range matches {
+		start, end := m[0], m[1]
+		if len(m) >= 4 && m[2] >= 0 {
+			start, end = m[2], m[3]
+		}
+		if end == start {
+			continue
+		}
+		sb.WriteString(content[last:start])
+		sb.WriteString(RedactedValue)
+		last = end
+	}
+	sb.WriteString(content[last:])
+	return sb.String()
+}
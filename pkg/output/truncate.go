@@ -0,0 +1,20 @@
+package output
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// Truncate returns the content cut to at most maxBytes bytes, at a UTF-8 character boundary, followed by a
+// "[truncated N of M bytes]" marker giving the number of bytes dropped and the original size.
+// Content that fits, or a non-positive maxBytes, is returned unchanged.
+func Truncate(content string, maxBytes int) string {
+	if maxBytes <= 0 || len(content) <= maxBytes {
+		return content
+	}
+	cut := maxBytes
+	for cut > 0 && !utf8.RuneStart(content[cut]) {
+		cut--
+	}
+	return fmt.Sprintf("%s\n[truncated %d of %d bytes]", content[:cut], len(content)-cut, len(content))
+}
@@ -0,0 +1,28 @@
+package output
+
+import (
+	"testing"
+)
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		maxBytes int
+		expected string
+	}{
+		{name: "fits", content: "reviews", maxBytes: 7, expected: "reviews"},
+		{name: "no limit", content: "reviews", maxBytes: 0, expected: "reviews"},
+		{name: "ascii", content: "reviews-v1", maxBytes: 7, expected: "reviews\n[truncated 3 of 10 bytes]"},
+		// "é" is encoded on 2 bytes and must not be split
+		{name: "multi-byte character boundary", content: "café au lait", maxBytes: 4, expected: "caf\n[truncated 10 of 13 bytes]"},
+		{name: "after multi-byte character", content: "café au lait", maxBytes: 5, expected: "café\n[truncated 8 of 13 bytes]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := Truncate(tt.content, tt.maxBytes); actual != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, actual)
+			}
+		})
+	}
+}
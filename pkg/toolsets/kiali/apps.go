@@ -0,0 +1,100 @@
+package kiali
+
+import (
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/kiali/kiali-mcp-server/pkg/api"
+)
+
+func initApps() []api.ServerTool {
+	ret := make([]api.ServerTool, 0)
+
+	// Apps list tool
+	ret = append(ret, api.ServerTool{
+		Tool: api.Tool{
+			Name:        "apps_list",
+			Description: "Get all apps in the mesh across specified namespaces with health and Istio resource information. An app groups the workloads sharing the same 'app' label, typically the versions of a service, as shown by the versionedApp graph",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespaces": {
+						Type:        "string",
+						Description: "Comma-separated list of namespaces to get apps from (e.g. 'bookinfo' or 'bookinfo,default'). If not provided, will list apps from all accessible namespaces",
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Apps: List",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: appsListHandler,
+	})
+
+	// App details tool
+	ret = append(ret, api.ServerTool{
+		Tool: api.Tool{
+			Name:        "app_details",
+			Description: "Get detailed information for a specific app in a namespace, including its versioned workloads, services and health status",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace containing the app",
+					},
+					"app": {
+						Type:        "string",
+						Description: "Name of the app to get details for, the value of the 'app' label of its workloads",
+					},
+				},
+				Required: []string{"namespace", "app"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "App: Details",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: appDetailsHandler,
+	})
+
+	return ret
+}
+
+func appsListHandler(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	namespaces, _ := params.GetArguments()["namespaces"].(string)
+
+	content, err := params.AppsList(params.Context, namespaces)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list apps: %v", err)), nil
+	}
+	if isEmptyResult(content, "applications") {
+		return api.NewToolCallResult(emptyListResult("apps", namespaces), nil), nil
+	}
+	return api.NewToolCallResult(content, nil), nil
+}
+
+func appDetailsHandler(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	namespace, _ := params.GetArguments()["namespace"].(string)
+	app, _ := params.GetArguments()["app"].(string)
+
+	if namespace == "" {
+		return api.NewToolCallResult("", fmt.Errorf("namespace parameter is required")), nil
+	}
+	if app == "" {
+		return api.NewToolCallResult("", fmt.Errorf("app parameter is required")), nil
+	}
+
+	content, err := params.AppDetails(params.Context, namespace, app)
+	if err != nil {
+		return api.NewToolCallResult("", detailsError(err, "app details", "apps_list")), nil
+	}
+	return api.NewToolCallResult(content, nil), nil
+}
@@ -0,0 +1,70 @@
+package kiali
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali-mcp-server/pkg/api"
+	"github.com/kiali/kiali-mcp-server/pkg/config"
+	internalkiali "github.com/kiali/kiali-mcp-server/pkg/kiali"
+)
+
+func TestApps_KialiClient(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/clusters/apps":
+			assert.Equal(t, "true", r.URL.Query().Get("health"))
+			assert.Equal(t, "true", r.URL.Query().Get("istioResources"))
+			assert.Equal(t, "60s", r.URL.Query().Get("rateInterval"))
+			if r.URL.Query().Get("namespaces") == "empty" {
+				_, _ = w.Write([]byte(`{"cluster": "east", "applications": []}`))
+				return
+			}
+			assert.Equal(t, "bookinfo", r.URL.Query().Get("namespaces"))
+			_, _ = w.Write([]byte(`{"cluster": "east", "applications": [{"namespace": "bookinfo", "name": "reviews"}]}`))
+		case "/api/namespaces/bookinfo/apps/reviews":
+			assert.Equal(t, "true", r.URL.Query().Get("health"))
+			assert.Equal(t, "60s", r.URL.Query().Get("rateInterval"))
+			_, _ = w.Write([]byte(`{"name": "reviews", "workloads": [{"workloadName": "reviews-v1"}, {"workloadName": "reviews-v2"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"Not found"}`))
+		}
+	}))
+	defer mockServer.Close()
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+	call := func(t *testing.T, handler api.ToolHandlerFunc, arguments argumentsRequest) *api.ToolCallResult {
+		result, err := handler(api.ToolHandlerParams{Context: context.Background(), Kiali: kialiClient, ToolCallRequest: arguments})
+		require.NoError(t, err)
+		return result
+	}
+
+	t.Run("apps list", func(t *testing.T) {
+		result := call(t, appsListHandler, argumentsRequest{"namespaces": "bookinfo"})
+		require.NoError(t, result.Error)
+		assert.Contains(t, result.Content, `"name": "reviews"`)
+	})
+	t.Run("no apps", func(t *testing.T) {
+		result := call(t, appsListHandler, argumentsRequest{"namespaces": "empty"})
+		require.NoError(t, result.Error)
+		assert.Equal(t, "No apps found in the specified namespaces (empty)", result.Content)
+	})
+	t.Run("app details", func(t *testing.T) {
+		result := call(t, appDetailsHandler, argumentsRequest{"namespace": "bookinfo", "app": "reviews"})
+		require.NoError(t, result.Error)
+		assert.Contains(t, result.Content, `"workloadName": "reviews-v2"`)
+	})
+	t.Run("missing app", func(t *testing.T) {
+		result := call(t, appDetailsHandler, argumentsRequest{"namespace": "bookinfo", "app": "ratings"})
+		assert.EqualError(t, result.Error, "app 'ratings' not found in namespace 'bookinfo'. Use the apps_list tool to find the available names")
+	})
+	t.Run("required parameters", func(t *testing.T) {
+		result := call(t, appDetailsHandler, argumentsRequest{"namespace": "bookinfo"})
+		assert.EqualError(t, result.Error, "app parameter is required")
+	})
+}
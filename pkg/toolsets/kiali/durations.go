@@ -0,0 +1,93 @@
+package kiali
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kiali/kiali-mcp-server/pkg/api"
+)
+
+// durationPart matches a number followed by an optional unit, e.g. "5m", "1.5 h" or "30 seconds".
+var durationPart = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*([a-z]*)`)
+
+// durationUnits are the units accepted in duration arguments, by their spellings.
+var durationUnits = map[string]time.Duration{
+	"ms": time.Millisecond,
+	"s":  time.Second, "sec": time.Second, "secs": time.Second, "second": time.Second, "seconds": time.Second,
+	"m": time.Minute, "min": time.Minute, "mins": time.Minute, "minute": time.Minute, "minutes": time.Minute,
+	"h": time.Hour, "hr": time.Hour, "hrs": time.Hour, "hour": time.Hour, "hours": time.Hour,
+	"d": 24 * time.Hour, "day": 24 * time.Hour, "days": 24 * time.Hour,
+}
+
+// parseDuration parses a duration argument: a Go duration ("5m", "1h30m"), a bare number of seconds ("300"), or
+// a duration with spaces or spelled units ("5 min", "1 hour"). Days are accepted as "d". The duration must be a
+// positive number of whole seconds.
+func parseDuration(value string) (time.Duration, error) {
+	s := strings.ToLower(strings.TrimSpace(value))
+	var d time.Duration
+	if seconds, err := strconv.ParseInt(s, 10, 64); err == nil {
+		d = time.Duration(seconds) * time.Second
+	} else {
+		for s != "" {
+			part := durationPart.FindStringSubmatch(s)
+			if part == nil {
+				return 0, fmt.Errorf("'%s' is not a duration", value)
+			}
+			unit, ok := durationUnits[part[2]]
+			if !ok {
+				return 0, fmt.Errorf("'%s' is not a duration", value)
+			}
+			n, _ := strconv.ParseFloat(part[1], 64)
+			d += time.Duration(n * float64(unit))
+			s = strings.TrimSpace(s[len(part[0]):])
+		}
+	}
+	if d < time.Second || d%time.Second != 0 {
+		return 0, fmt.Errorf("'%s' must be a positive number of whole seconds", value)
+	}
+	return d, nil
+}
+
+// formatDuration formats the duration in its largest whole unit, the format of Kiali and Prometheus
+// (e.g. 300s is formatted as "5m" and 90m as "90m").
+func formatDuration(d time.Duration) string {
+	switch {
+	case d%time.Hour == 0:
+		return fmt.Sprintf("%dh", d/time.Hour)
+	case d%time.Minute == 0:
+		return fmt.Sprintf("%dm", d/time.Minute)
+	default:
+		return fmt.Sprintf("%ds", d/time.Second)
+	}
+}
+
+// durationArgument returns the duration argument normalized to Kiali's format (e.g. "5m", "1h"), empty when the
+// argument is not set.
+func durationArgument(params api.ToolHandlerParams, key string) (string, error) {
+	value, _ := params.GetArguments()[key].(string)
+	if strings.TrimSpace(value) == "" {
+		return "", nil
+	}
+	d, err := parseDuration(value)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s: %v (e.g. 5m, 1h)", key, err)
+	}
+	return formatDuration(d), nil
+}
+
+// secondsArgument returns the duration argument as a number of seconds, the format of the Kiali metrics
+// `duration` and `step` parameters, empty when the argument is not set.
+func secondsArgument(params api.ToolHandlerParams, key string) (string, error) {
+	value, _ := params.GetArguments()[key].(string)
+	if strings.TrimSpace(value) == "" {
+		return "", nil
+	}
+	d, err := parseDuration(value)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s: %v (e.g. 1800 or 30m)", key, err)
+	}
+	return strconv.FormatInt(int64(d/time.Second), 10), nil
+}
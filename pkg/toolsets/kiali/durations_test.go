@@ -0,0 +1,64 @@
+package kiali
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali-mcp-server/pkg/api"
+)
+
+func TestDurationArgument(t *testing.T) {
+	for _, tc := range []struct {
+		value    string
+		expected string
+		seconds  string
+	}{
+		{value: "5m", expected: "5m", seconds: "300"},
+		{value: "300s", expected: "5m", seconds: "300"},
+		{value: "300", expected: "5m", seconds: "300"},
+		{value: "5 min", expected: "5m", seconds: "300"},
+		{value: " 1 Hour ", expected: "1h", seconds: "3600"},
+		{value: "1h30m", expected: "90m", seconds: "5400"},
+		{value: "1.5h", expected: "90m", seconds: "5400"},
+		{value: "90s", expected: "90s", seconds: "90"},
+		{value: "1d", expected: "24h", seconds: "86400"},
+		{value: "2 minutes 30 seconds", expected: "150s", seconds: "150"},
+	} {
+		t.Run(tc.value, func(t *testing.T) {
+			params := api.ToolHandlerParams{ToolCallRequest: argumentsRequest{"rateInterval": tc.value, "duration": tc.value}}
+			rateInterval, err := durationArgument(params, "rateInterval")
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, rateInterval)
+			duration, err := secondsArgument(params, "duration")
+			require.NoError(t, err)
+			assert.Equal(t, tc.seconds, duration)
+		})
+	}
+
+	t.Run("not set", func(t *testing.T) {
+		params := api.ToolHandlerParams{ToolCallRequest: argumentsRequest{"since": " "}}
+		since, err := durationArgument(params, "since")
+		require.NoError(t, err)
+		assert.Empty(t, since)
+		step, err := secondsArgument(params, "step")
+		require.NoError(t, err)
+		assert.Empty(t, step)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for value, expected := range map[string]string{
+			"5 parsecs": "invalid since: '5 parsecs' is not a duration (e.g. 5m, 1h)",
+			"-5m":       "invalid since: '-5m' is not a duration (e.g. 5m, 1h)",
+			"m5":        "invalid since: 'm5' is not a duration (e.g. 5m, 1h)",
+			"0":         "invalid since: '0' must be a positive number of whole seconds (e.g. 5m, 1h)",
+			"1500ms":    "invalid since: '1500ms' must be a positive number of whole seconds (e.g. 5m, 1h)",
+		} {
+			_, err := durationArgument(api.ToolHandlerParams{ToolCallRequest: argumentsRequest{"since": value}}, "since")
+			assert.EqualError(t, err, expected)
+		}
+		_, err := secondsArgument(api.ToolHandlerParams{ToolCallRequest: argumentsRequest{"step": "soon"}}, "step")
+		assert.EqualError(t, err, "invalid step: 'soon' is not a duration (e.g. 1800 or 30m)")
+	})
+}
@@ -0,0 +1,23 @@
+package kiali
+
+import (
+	"fmt"
+
+	internalkiali "github.com/kiali/kiali-mcp-server/pkg/kiali"
+)
+
+// detailsError returns the error reported by an entity detail tool. Missing entities get a uniform,
+// actionable message pointing to the tool that lists the available ones. A missing or inaccessible namespace
+// is reported as such, so that the namespace is created or access to it requested.
+func detailsError(err error, what string, listTool string) error {
+	switch {
+	case internalkiali.IsNamespaceNotFound(err):
+		return fmt.Errorf("%v. Create the namespace or use the namespaces tool to find the available ones", err)
+	case internalkiali.IsNamespaceNotAccessible(err):
+		return fmt.Errorf("%v. Request access to the namespace", err)
+	}
+	if internalkiali.IsNotFound(err) {
+		return fmt.Errorf("%v. Use the %s tool to find the available names", err, listTool)
+	}
+	return fmt.Errorf("failed to get %s: %v", what, err)
+}
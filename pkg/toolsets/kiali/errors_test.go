@@ -0,0 +1,415 @@
+package kiali
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali-mcp-server/pkg/config"
+	internalkiali "github.com/kiali/kiali-mcp-server/pkg/kiali"
+)
+
+func TestDetailsNotFound_KialiClient(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/namespaces/bookinfo/workloads/reviews-v9", "/api/namespaces/bookinfo/services/reviews-v9":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"Not found"}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"error":"boom"}`))
+		}
+	}))
+	defer mockServer.Close()
+
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+
+	t.Run("missing workload", func(t *testing.T) {
+		_, err := kialiClient.WorkloadDetails(context.Background(), "bookinfo", "reviews-v9")
+		require.Error(t, err)
+		var notFound *internalkiali.NotFoundError
+		require.True(t, errors.As(err, &notFound))
+		assert.Equal(t, &internalkiali.NotFoundError{Kind: "workload", Namespace: "bookinfo", Name: "reviews-v9"}, notFound)
+		assert.Equal(t, "workload 'reviews-v9' not found in namespace 'bookinfo'", err.Error())
+		assert.Equal(t, "workload 'reviews-v9' not found in namespace 'bookinfo'. Use the workloads_list tool to find the available names",
+			detailsError(err, "workload details", "workloads_list").Error())
+	})
+
+	t.Run("missing service", func(t *testing.T) {
+		_, err := kialiClient.ServiceDetails(context.Background(), "bookinfo", "reviews-v9")
+		require.Error(t, err)
+		assert.True(t, internalkiali.IsNotFound(err))
+		assert.Equal(t, "service 'reviews-v9' not found in namespace 'bookinfo'", err.Error())
+	})
+
+	t.Run("other API errors are not classified as not found", func(t *testing.T) {
+		_, err := kialiClient.WorkloadDetails(context.Background(), "bookinfo", "reviews-v1")
+		require.Error(t, err)
+		assert.False(t, internalkiali.IsNotFound(err))
+		var apiErr *internalkiali.APIError
+		require.True(t, errors.As(err, &apiErr))
+		assert.Equal(t, http.StatusInternalServerError, apiErr.StatusCode)
+		assert.Equal(t, `failed to get workload details: kiali API error: {"error":"boom"}`,
+			detailsError(err, "workload details", "workloads_list").Error())
+	})
+}
+
+func TestNamespaceErrors_KialiClient(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/namespaces/missing/workloads/reviews-v1":
+			// Kiali JSON error from the Kubernetes API
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"namespaces \"missing\" not found"}`))
+		case "/api/namespaces/missing/services/reviews":
+			// Kiali plain text error
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`Namespace [missing] is not found`))
+		case "/api/namespaces/secret/workloads/reviews-v1":
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"error":"Namespace [secret] is not accessible for cluster [east]"}`))
+		case "/api/namespaces/secret/services/reviews":
+			// Kiali reports inaccessible namespaces with a 404 in some versions
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`namespaces "secret" is forbidden: User "system:serviceaccount:mcp:mcp" cannot get resource "namespaces"`))
+		case "/api/namespaces/secret/apps/reviews/traces":
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"error":"Forbidden"}`))
+		case "/api/namespaces/bookinfo/istio/networking.istio.io/v1/VirtualService/reviews":
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"error":"virtualservices.networking.istio.io \"reviews\" is forbidden: User \"dev\" cannot patch resource \"virtualservices\" in API group \"networking.istio.io\" in the namespace \"bookinfo\""}`))
+		case "/api/clusters/health":
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"error":"Namespace [secret] is not accessible for cluster [east]"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"Not found"}`))
+		}
+	}))
+	defer mockServer.Close()
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+	ctx := context.Background()
+
+	t.Run("namespace not found, JSON error", func(t *testing.T) {
+		_, err := kialiClient.WorkloadDetails(ctx, "missing", "reviews-v1")
+		var notFound *internalkiali.NamespaceNotFoundError
+		require.True(t, errors.As(err, &notFound))
+		assert.Equal(t, "missing", notFound.Namespace)
+		assert.True(t, internalkiali.IsNotFound(err))
+		assert.False(t, internalkiali.IsNamespaceNotAccessible(err))
+		assert.Equal(t, "namespace 'missing' not found: it does not exist. Create the namespace or use the namespaces tool to find the available ones",
+			detailsError(err, "workload details", "workloads_list").Error())
+	})
+
+	t.Run("namespace not found, plain text error", func(t *testing.T) {
+		_, err := kialiClient.ServiceDetails(ctx, "missing", "reviews")
+		assert.True(t, internalkiali.IsNamespaceNotFound(err))
+		assert.Equal(t, "namespace 'missing' not found: it does not exist", err.Error())
+	})
+
+	t.Run("namespace not accessible, JSON error", func(t *testing.T) {
+		_, err := kialiClient.WorkloadDetails(ctx, "secret", "reviews-v1")
+		var notAccessible *internalkiali.NamespaceNotAccessibleError
+		require.True(t, errors.As(err, &notAccessible))
+		assert.Equal(t, "secret", notAccessible.Namespace)
+		assert.False(t, internalkiali.IsNotFound(err))
+		assert.False(t, internalkiali.IsNamespaceNotFound(err))
+		var apiErr *internalkiali.APIError
+		require.True(t, errors.As(err, &apiErr))
+		assert.Equal(t, http.StatusForbidden, apiErr.StatusCode)
+		assert.Equal(t, "namespace 'secret' is not accessible: the credentials in use are not allowed to access it. Request access to the namespace",
+			detailsError(err, "workload details", "workloads_list").Error())
+	})
+
+	t.Run("namespace not accessible, Kubernetes error with a 404", func(t *testing.T) {
+		_, err := kialiClient.ServiceDetails(ctx, "secret", "reviews")
+		assert.True(t, internalkiali.IsNamespaceNotAccessible(err))
+		assert.False(t, internalkiali.IsNotFound(err))
+	})
+
+	t.Run("forbidden namespace-scoped call", func(t *testing.T) {
+		_, err := kialiClient.AppTraces(ctx, "secret", "reviews", nil)
+		var notAccessible *internalkiali.NamespaceNotAccessibleError
+		require.True(t, errors.As(err, &notAccessible))
+		assert.Equal(t, "secret", notAccessible.Namespace)
+	})
+
+	t.Run("forbidden write keeps its message", func(t *testing.T) {
+		_, err := kialiClient.IstioObjectPatch(ctx, "bookinfo", "networking.istio.io", "v1", "VirtualService", "reviews", `{"spec":{}}`, "")
+		require.Error(t, err)
+		assert.False(t, internalkiali.IsNamespaceNotAccessible(err))
+		var apiErr *internalkiali.APIError
+		require.True(t, errors.As(err, &apiErr))
+		assert.Equal(t, http.StatusForbidden, apiErr.StatusCode)
+		assert.Contains(t, err.Error(), `User \"dev\" cannot patch resource \"virtualservices\"`)
+	})
+
+	t.Run("namespace named in the error of a multi-namespace call", func(t *testing.T) {
+		_, err := kialiClient.Health(ctx, "bookinfo,secret", nil)
+		var notAccessible *internalkiali.NamespaceNotAccessibleError
+		require.True(t, errors.As(err, &notAccessible))
+		assert.Equal(t, "secret", notAccessible.Namespace)
+	})
+
+	t.Run("missing entity in an existing namespace", func(t *testing.T) {
+		_, err := kialiClient.WorkloadDetails(ctx, "bookinfo", "reviews-v9")
+		assert.False(t, internalkiali.IsNamespaceNotFound(err))
+		assert.Equal(t, "workload 'reviews-v9' not found in namespace 'bookinfo'", err.Error())
+	})
+}
+
+func TestDisableRedirects_KialiClient(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/oauth/login" {
+			w.Header().Set("Content-Type", "text/html")
+			_, _ = w.Write([]byte(`<html>Sign in</html>`))
+			return
+		}
+		http.Redirect(w, r, "/oauth/login", http.StatusFound)
+	}))
+	defer mockServer.Close()
+
+	t.Run("redirects followed by default", func(t *testing.T) {
+		kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+		content, err := kialiClient.ListNamespaces(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, `<html>Sign in</html>`, content)
+	})
+
+	t.Run("redirects disabled", func(t *testing.T) {
+		kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL, KialiDisableRedirects: true})
+		_, err := kialiClient.ListNamespaces(context.Background())
+		require.Error(t, err)
+		var redirect *internalkiali.RedirectError
+		require.True(t, errors.As(err, &redirect))
+		assert.Equal(t, http.StatusFound, redirect.StatusCode)
+		assert.Equal(t, "unexpected redirect (possible auth proxy): status 302 to /oauth/login", err.Error())
+	})
+}
+
+func TestGzipErrorBody_KialiClient(t *testing.T) {
+	gzipped := func(t *testing.T, content string) []byte {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		_, err := gz.Write([]byte(content))
+		require.NoError(t, err)
+		require.NoError(t, gz.Close())
+		return buf.Bytes()
+	}
+	tests := []struct {
+		name            string
+		contentEncoding string
+		body            func(t *testing.T) []byte
+		expected        string
+	}{
+		{
+			name:            "gzip content encoding",
+			contentEncoding: "gzip",
+			body:            func(t *testing.T) []byte { return gzipped(t, "internal error: prometheus unavailable\n") },
+			expected:        "kiali API error: internal error: prometheus unavailable",
+		},
+		{
+			name:     "gzipped body without content encoding",
+			body:     func(t *testing.T) []byte { return gzipped(t, "internal error: prometheus unavailable") },
+			expected: "kiali API error: internal error: prometheus unavailable",
+		},
+		{
+			name:            "corrupted gzip body",
+			contentEncoding: "gzip",
+			body:            func(t *testing.T) []byte { return gzipped(t, "internal error")[:12] },
+			expected:        "kiali API error: status 500",
+		},
+		{
+			name:     "gzipped body decompressing above the size limit",
+			body:     func(t *testing.T) []byte { return gzipped(t, strings.Repeat("internal error ", 1<<17)) },
+			expected: "kiali API error: status 500",
+		},
+		{
+			name:     "binary body",
+			body:     func(t *testing.T) []byte { return []byte{0xff, 0xfe, 0x00, 0x01} },
+			expected: "kiali API error: status 500",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := tt.body(t)
+			mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.contentEncoding != "" {
+					w.Header().Set("Content-Encoding", tt.contentEncoding)
+				}
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write(body)
+			}))
+			defer mockServer.Close()
+
+			kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+			_, err := kialiClient.ListNamespaces(context.Background())
+			require.Error(t, err)
+			var apiErr *internalkiali.APIError
+			require.True(t, errors.As(err, &apiErr))
+			assert.Equal(t, http.StatusInternalServerError, apiErr.StatusCode)
+			assert.Equal(t, tt.expected, err.Error())
+		})
+	}
+}
+
+func TestCorrelationID_KialiClient(t *testing.T) {
+	var headers http.Header
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header.Clone()
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer mockServer.Close()
+
+	t.Run("default header", func(t *testing.T) {
+		kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+		ctx := internalkiali.WithCorrelationID(context.Background(), "0123456789abcdef")
+		_, err := kialiClient.PodConfigDump(ctx, "bookinfo", "reviews-v1-pod")
+		require.NoError(t, err)
+		assert.Equal(t, "0123456789abcdef", headers.Get(internalkiali.DefaultCorrelationIDHeader))
+	})
+
+	t.Run("configured header", func(t *testing.T) {
+		kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL, KialiCorrelationIDHeader: "X-Correlation-Id"})
+		ctx := internalkiali.WithCorrelationID(context.Background(), "0123456789abcdef")
+		_, err := kialiClient.PodConfigDump(ctx, "bookinfo", "reviews-v1-pod")
+		require.NoError(t, err)
+		assert.Equal(t, "0123456789abcdef", headers.Get("X-Correlation-Id"))
+		assert.Empty(t, headers.Get(internalkiali.DefaultCorrelationIDHeader))
+	})
+
+	t.Run("no correlation ID", func(t *testing.T) {
+		kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+		_, err := kialiClient.PodConfigDump(context.Background(), "bookinfo", "reviews-v1-pod")
+		require.NoError(t, err)
+		assert.Empty(t, headers.Get(internalkiali.DefaultCorrelationIDHeader))
+	})
+
+	t.Run("random IDs", func(t *testing.T) {
+		id := internalkiali.NewCorrelationID()
+		assert.Len(t, id, 32)
+		assert.NotEqual(t, id, internalkiali.NewCorrelationID())
+	})
+}
+
+func TestRequestTimeout_KialiClient(t *testing.T) {
+	release := make(chan struct{})
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer mockServer.Close()
+	defer close(release)
+
+	t.Run("client timeout", func(t *testing.T) {
+		kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL, KialiRequestTimeout: "100ms"})
+		_, err := kialiClient.ServicesList(context.Background(), "bookinfo")
+		require.Error(t, err)
+		assert.True(t, internalkiali.IsTimeout(err))
+		assert.Contains(t, err.Error(), "kiali request to "+mockServer.URL+"/api/clusters/services timed out after ")
+		assert.Contains(t, err.Error(), "increase kiali_request_timeout")
+		assert.NotContains(t, err.Error(), "?")
+	})
+	t.Run("tool deadline", func(t *testing.T) {
+		kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		_, err := kialiClient.ServicesList(ctx, "bookinfo")
+		require.Error(t, err)
+		assert.True(t, internalkiali.IsTimeout(err))
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+		assert.Contains(t, err.Error(), "timed out after 100ms")
+	})
+	t.Run("other errors are unchanged", func(t *testing.T) {
+		kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: "http://127.0.0.1:1"})
+		_, err := kialiClient.ServicesList(context.Background(), "bookinfo")
+		require.Error(t, err)
+		assert.False(t, internalkiali.IsTimeout(err))
+	})
+}
+
+func TestRequestRetries_KialiClient(t *testing.T) {
+	// newServer returns a server failing the first failures requests with the given status, or by closing the
+	// connection when the status is 0, and the number of requests it received
+	newServer := func(t *testing.T, failures int, status int) (*httptest.Server, *int) {
+		calls := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			if calls <= failures {
+				if status == 0 {
+					conn, _, err := w.(http.Hijacker).Hijack()
+					require.NoError(t, err)
+					_ = conn.Close()
+					return
+				}
+				w.WriteHeader(status)
+				return
+			}
+			_, _ = w.Write([]byte(`{"services": []}`))
+		}))
+		t.Cleanup(server.Close)
+		return server, &calls
+	}
+
+	for _, tc := range []struct {
+		name          string
+		failures      int
+		status        int
+		maxRetries    int
+		expectedCalls int
+		expectedError bool
+	}{
+		{name: "no retry by default", failures: 1, status: http.StatusServiceUnavailable, expectedCalls: 1, expectedError: true},
+		{name: "503 retried until success", failures: 2, status: http.StatusServiceUnavailable, maxRetries: 2, expectedCalls: 3},
+		{name: "429 retried", failures: 1, status: http.StatusTooManyRequests, maxRetries: 2, expectedCalls: 2},
+		{name: "502 retried", failures: 1, status: http.StatusBadGateway, maxRetries: 1, expectedCalls: 2},
+		{name: "504 retried", failures: 1, status: http.StatusGatewayTimeout, maxRetries: 1, expectedCalls: 2},
+		{name: "network error retried", failures: 1, status: 0, maxRetries: 1, expectedCalls: 2},
+		{name: "retries exhausted", failures: 3, status: http.StatusServiceUnavailable, maxRetries: 2, expectedCalls: 3, expectedError: true},
+		{name: "500 not retried", failures: 1, status: http.StatusInternalServerError, maxRetries: 2, expectedCalls: 1, expectedError: true},
+		{name: "404 not retried", failures: 1, status: http.StatusNotFound, maxRetries: 2, expectedCalls: 1, expectedError: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			server, calls := newServer(t, tc.failures, tc.status)
+			kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: server.URL, KialiMaxRetries: tc.maxRetries})
+			result, err := kialiClient.ServicesList(context.Background(), "bookinfo")
+			assert.Equal(t, tc.expectedCalls, *calls)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, `{"services": []}`, result)
+		})
+	}
+
+	t.Run("POST is not retried", func(t *testing.T) {
+		server, calls := newServer(t, 1, http.StatusServiceUnavailable)
+		kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: server.URL, KialiMaxRetries: 2})
+		_, err := kialiClient.IstioObjectCreate(context.Background(), "bookinfo", "networking.istio.io", "v1", "DestinationRule",
+			`{"metadata": {"name": "reviews"}, "spec": {"host": "reviews"}}`, "")
+		require.Error(t, err)
+		assert.Equal(t, 1, *calls)
+	})
+
+	t.Run("cancelled context stops the retries", func(t *testing.T) {
+		server, calls := newServer(t, 10, http.StatusServiceUnavailable)
+		kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: server.URL, KialiMaxRetries: 10})
+		ctx, cancel := context.WithTimeout(context.Background(), 250*time.Millisecond)
+		defer cancel()
+		_, err := kialiClient.ServicesList(ctx, "bookinfo")
+		require.Error(t, err)
+		assert.Less(t, *calls, 10)
+	})
+}
@@ -2,12 +2,16 @@ package kiali
 
 import (
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/google/jsonschema-go/jsonschema"
 	"k8s.io/utils/ptr"
 
 	"github.com/kiali/kiali-mcp-server/pkg/api"
+	internalkiali "github.com/kiali/kiali-mcp-server/pkg/kiali"
 )
 
 func initGraph() []api.ServerTool {
@@ -27,6 +31,46 @@ func initGraph() []api.ServerTool {
 						Type:        "string",
 						Description: "Optional comma-separated list of namespaces to include in the graph",
 					},
+					"protocol": {
+						Type:        "string",
+						Description: "Optional edge protocol to keep in the graph: 'http', 'grpc' or 'tcp'. Edges using other protocols and nodes left without edges are removed",
+					},
+					"includeHealth": {
+						Type:        "boolean",
+						Description: "Whether to compute the health of the graph nodes and edges (default: true, or false when lightweight). Set to false to quickly fetch the pure topology of very large meshes",
+					},
+					"lightweight": {
+						Type:        "boolean",
+						Description: "Minimize the Prometheus query load, for heavily loaded environments (default: false). Trades detail for speed: only dead nodes are detected (no Istio config, service entry, mesh check and workload entry information), health is not computed unless includeHealth is set, and the default duration is '30s'",
+					},
+					"responseTime": {
+						Type:        "boolean",
+						Description: "Whether to annotate the HTTP/gRPC edges with their response time in milliseconds (95th percentile), to answer latency questions along with error questions in one fetch (default: false). Adds Prometheus queries",
+					},
+					"duration": {
+						Type:        "string",
+						Description: "Time window of traffic the graph reflects, ending at queryTime (e.g., '5m', '1h'). Default: '60s', or '30s' when lightweight",
+					},
+					"graphType": {
+						Type:        "string",
+						Description: "Type of graph: " + strings.Join(internalkiali.GraphTypes, ", ") + ". 'app' aggregates the versions of an app into one node, 'versionedApp' (default) has a node per app version, 'workload' a node per workload and 'service' a node per service",
+					},
+					"injectServiceNodes": {
+						Type:        "boolean",
+						Description: "Whether to add service nodes between the callers and the workloads backing the services (default: true)",
+					},
+					"includeIdleEdges": {
+						Type:        "boolean",
+						Description: "Whether to include the edges without traffic in the time window (default: false)",
+					},
+					"queryTime": {
+						Type:        "string",
+						Description: "Optional end of the time window, as an RFC 3339 timestamp (e.g., '2024-01-01T10:00:00Z') or Unix timestamp in seconds, to look at a past window for post-incident analysis. Default: now. Limited to the Prometheus retention period; rates are averaged over the whole duration",
+					},
+					"format": {
+						Type:        "string",
+						Description: "Output format: 'cytoscape' (default) for the raw Kiali graph, or 'adjacency' for a compact {nodes: [{id, name, namespace, kind}], edges: [{from, to, protocol, rps, errorRate, responseTimeMs}]} list, easier to process programmatically. Edges reference node ids; errorRate is the percentage of failed HTTP/gRPC requests; responseTimeMs is only set with responseTime",
+					},
 				},
 				Required: []string{},
 			},
@@ -39,11 +83,483 @@ func initGraph() []api.ServerTool {
 			},
 		}, Handler: graphHandler,
 	})
+	ret = append(ret, api.ServerTool{
+		Tool: api.Tool{
+			Name:        "error_graph",
+			Description: "Get an error-focused view of the mesh graph: the traffic edges between services, workloads and apps with their protocol, request rate and error rate (percentage of failed HTTP/gRPC requests), the edges with the most errors first",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Optional single namespace to include in the graph (alternative to namespaces)",
+					},
+					"namespaces": {
+						Type:        "string",
+						Description: "Optional comma-separated list of namespaces to include in the graph",
+					},
+					"duration": {
+						Type:        "string",
+						Description: "Time window the rates are computed over, ending at queryTime (e.g., '5m', '1h'). Default: '60s'",
+					},
+					"queryTime": {
+						Type:        "string",
+						Description: "Optional end of the time window, as an RFC 3339 timestamp (e.g., '2024-01-01T10:00:00Z') or Unix timestamp in seconds, to look at a past window for post-incident analysis. Default: now. Limited to the Prometheus retention period; rates are averaged over the whole duration",
+					},
+				},
+				Required: []string{},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Graph: Errors",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: errorGraphHandler,
+	})
+	ret = append(ret, api.ServerTool{
+		Tool: api.Tool{
+			Name:        "mtls_coverage",
+			Description: "Measure the zero-trust progress of the mesh: the percentage of traffic edges in the mesh graph whose traffic is fully mTLS-encrypted, and the list of edges carrying plaintext traffic (with the share of their traffic that is encrypted), the least encrypted first",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Optional single namespace to include in the graph (alternative to namespaces)",
+					},
+					"namespaces": {
+						Type:        "string",
+						Description: "Optional comma-separated list of namespaces to include in the graph",
+					},
+					"duration": {
+						Type:        "string",
+						Description: "Time window of traffic to consider, ending at queryTime (e.g., '5m', '1h'). Default: '60s'",
+					},
+					"queryTime": {
+						Type:        "string",
+						Description: "Optional end of the time window, as an RFC 3339 timestamp (e.g., '2024-01-01T10:00:00Z') or Unix timestamp in seconds. Default: now",
+					},
+				},
+				Required: []string{},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Graph: mTLS Coverage",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: mtlsCoverageHandler,
+	})
+	ret = append(ret, api.ServerTool{
+		Tool: api.Tool{
+			Name:        "namespace_traffic",
+			Description: "Summarize the traffic crossing the boundary from one namespace to another, for cross-team analysis: the total HTTP/gRPC request rate and error rate, the TCP bytes rate, and the top contributing source and destination pairs, from the mesh graph of both namespaces",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"from": {
+						Type:        "string",
+						Description: "Source namespace of the traffic",
+					},
+					"to": {
+						Type:        "string",
+						Description: "Target namespace of the traffic",
+					},
+					"duration": {
+						Type:        "string",
+						Description: "Time window the rates are computed over, ending at queryTime (e.g., '5m', '1h'). Default: '60s'",
+					},
+					"queryTime": {
+						Type:        "string",
+						Description: "Optional end of the time window, as an RFC 3339 timestamp (e.g., '2024-01-01T10:00:00Z') or Unix timestamp in seconds. Default: now",
+					},
+					"limit": {
+						Type:        "integer",
+						Description: "Maximum number of top contributing pairs to return (default: 10)",
+					},
+				},
+				Required: []string{"from", "to"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Graph: Namespace Traffic",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: namespaceTrafficHandler,
+	})
+	ret = append(ret, api.ServerTool{
+		Tool: api.Tool{
+			Name:        "namespace_matrix",
+			Description: "Get the namespace-to-namespace dependency matrix of the mesh, for architecture reviews: which namespaces call which, built from the edges of the mesh graph. Returns a nested map matrix[from][to] with, per cell, the number of edges, the HTTP/gRPC request rate and error rate, and the TCP bytes rate. Pairs without traffic have no cell",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Optional single namespace to include in the matrix (alternative to namespaces)",
+					},
+					"namespaces": {
+						Type:        "string",
+						Description: "Optional comma-separated list of namespaces to restrict the matrix to, to keep it manageable. If not provided, all the namespaces of the mesh graph are included",
+					},
+					"duration": {
+						Type:        "string",
+						Description: "Time window the rates are computed over, ending at queryTime (e.g., '5m', '1h'). Default: '60s'",
+					},
+					"queryTime": {
+						Type:        "string",
+						Description: "Optional end of the time window, as an RFC 3339 timestamp (e.g., '2024-01-01T10:00:00Z') or Unix timestamp in seconds. Default: now",
+					},
+				},
+				Required: []string{},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Graph: Namespace Dependency Matrix",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: namespaceMatrixHandler,
+	})
+	ret = append(ret, api.ServerTool{
+		Tool: api.Tool{
+			Name:        "blast_radius",
+			Description: "Compute the blast radius of a failing service: all the upstream services and workloads that call it, directly or transitively, grouped by their distance to it (1 = direct callers). Based on the traffic observed in the mesh graph",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the failing service",
+					},
+					"service": {
+						Type:        "string",
+						Description: "Name of the failing service",
+					},
+					"namespaces": {
+						Type:        "string",
+						Description: "Optional comma-separated list of additional namespaces whose callers should be traversed. Only direct callers from other namespaces are found otherwise",
+					},
+					"duration": {
+						Type:        "string",
+						Description: "Time window of traffic to consider (e.g., '1h', '30m'). Default: '10m'",
+					},
+				},
+				Required: []string{"namespace", "service"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Graph: Blast Radius",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: blastRadiusHandler,
+	})
+	ret = append(ret, api.ServerTool{
+		Tool: api.Tool{
+			Name:        "mesh_traffic_trend",
+			Description: "Get the trend of the overall mesh request rate, to tell whether traffic is ramping up or down: samples the mesh graph at regular intervals across a window ending now and returns, oldest first, the total HTTP/gRPC requests per second and error rate of each interval, with the change from the first to the last sample",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespaces": {
+						Type:        "string",
+						Description: "Optional comma-separated list of namespaces whose traffic is summed. If not provided, the traffic of the mesh graph is summed",
+					},
+					"window": {
+						Type:        "string",
+						Description: "Time window to sample, ending now (e.g., '30m', '2h'). Default: '30m'",
+					},
+					"points": {
+						Type:        "integer",
+						Description: "Number of samples across the window, each one a graph request over its interval (default: 6, maximum: 12)",
+						Minimum:     ptr.To(float64(1)),
+					},
+				},
+				Required: []string{},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Graph: Mesh Traffic Trend",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: meshTrafficTrendHandler,
+		Timeout: 2 * time.Minute,
+	})
+	ret = append(ret, api.ServerTool{
+		Tool: api.Tool{
+			Name:        "graph_link",
+			Description: "Get a shareable link to the graph view of the Kiali UI for the given namespaces, graph type and duration, so a graph analyzed with the other graph tools can be opened in the Kiali UI. Returns the URL of the UI page (not an API URL), built from the configured Kiali URL without contacting Kiali",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Optional single namespace to show in the graph (alternative to namespaces)",
+					},
+					"namespaces": {
+						Type:        "string",
+						Description: "Optional comma-separated list of namespaces to show in the graph. If not provided, the Kiali UI keeps its current namespace selection",
+					},
+					"graphType": {
+						Type:        "string",
+						Description: "Graph type: 'versionedApp' (default), 'app', 'workload' or 'service'",
+					},
+					"duration": {
+						Type:        "string",
+						Description: "Time window of traffic the graph reflects (e.g., '5m', '1h'). Default: '60s'",
+					},
+				},
+				Required: []string{},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Graph: Kiali UI Link",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(false),
+			},
+		}, Handler: graphLinkHandler,
+	})
 	return ret
 }
 
 func graphHandler(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	namespaces := graphNamespaces(params)
+
+	protocol, _ := params.GetArguments()["protocol"].(string)
+	protocol = strings.ToLower(strings.TrimSpace(protocol))
+	if protocol != "" && !slices.Contains(internalkiali.GraphProtocols, protocol) {
+		return api.NewToolCallResult("", fmt.Errorf("invalid protocol '%s': must be one of %s", protocol, strings.Join(internalkiali.GraphProtocols, ", "))), nil
+	}
+
+	format, _ := params.GetArguments()["format"].(string)
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format != "" && !slices.Contains(internalkiali.GraphFormats, format) {
+		return api.NewToolCallResult("", fmt.Errorf("invalid format '%s': must be one of %s", format, strings.Join(internalkiali.GraphFormats, ", "))), nil
+	}
+
+	lightweight, _ := params.GetArguments()["lightweight"].(bool)
+	includeHealth := !lightweight
+	if v, ok := params.GetArguments()["includeHealth"].(bool); ok {
+		includeHealth = v
+	}
+	responseTime, _ := params.GetArguments()["responseTime"].(bool)
+	duration, queryTime, err := graphWindow(params)
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	queryParams := make(map[string]string)
+	if graphType, _ := params.GetArguments()["graphType"].(string); strings.TrimSpace(graphType) != "" {
+		graphType = strings.TrimSpace(graphType)
+		if !slices.Contains(internalkiali.GraphTypes, graphType) {
+			return api.NewToolCallResult("", fmt.Errorf("invalid graphType '%s': must be one of %s", graphType, strings.Join(internalkiali.GraphTypes, ", "))), nil
+		}
+		queryParams["graphType"] = graphType
+	}
+	for _, key := range []string{"injectServiceNodes", "includeIdleEdges"} {
+		if v, ok := params.GetArguments()[key].(bool); ok {
+			queryParams[key] = strconv.FormatBool(v)
+		}
+	}
+
+	content, err := params.Graph(params.Context, namespaces, internalkiali.GraphOptions{
+		IncludeHealth: includeHealth,
+		Lightweight:   lightweight,
+		ResponseTime:  responseTime,
+		Duration:      duration,
+		QueryTime:     queryTime,
+		QueryParams:   queryParams,
+	})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to retrieve mesh graph: %v", err)), nil
+	}
+	if protocol != "" {
+		content, err = internalkiali.FilterGraphByProtocol(content, protocol)
+		if err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to filter mesh graph by protocol: %v", err)), nil
+		}
+	}
+	if format == internalkiali.GraphFormatAdjacency {
+		content, err = internalkiali.ToGraphAdjacency(content)
+		if err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to convert mesh graph to adjacency list: %v", err)), nil
+		}
+	}
+	return api.NewToolCallResult(content, nil), nil
+}
+
+func errorGraphHandler(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	namespaces := graphNamespaces(params)
+
+	duration, queryTime, err := graphWindow(params)
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+
+	content, err := params.ErrorGraph(params.Context, namespaces, duration, queryTime)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to retrieve error graph: %v", err)), nil
+	}
+	return api.NewToolCallResult(content, nil), nil
+}
+
+func mtlsCoverageHandler(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	namespaces := graphNamespaces(params)
 
+	duration, queryTime, err := graphWindow(params)
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+
+	content, err := params.MTLSCoverage(params.Context, namespaces, duration, queryTime)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to compute mTLS coverage: %v", err)), nil
+	}
+	return api.NewToolCallResult(content, nil), nil
+}
+
+func namespaceTrafficHandler(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	from, _ := params.GetArguments()["from"].(string)
+	to, _ := params.GetArguments()["to"].(string)
+	from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+	if from == "" {
+		return api.NewToolCallResult("", fmt.Errorf("from parameter is required")), nil
+	}
+	if to == "" {
+		return api.NewToolCallResult("", fmt.Errorf("to parameter is required")), nil
+	}
+	limit := internalkiali.DefaultNamespaceTrafficLimit
+	if v, ok := params.GetArguments()["limit"].(float64); ok {
+		if v < 1 || v != float64(int(v)) {
+			return api.NewToolCallResult("", fmt.Errorf("limit must be a positive integer")), nil
+		}
+		limit = int(v)
+	}
+	duration, queryTime, err := graphWindow(params)
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+
+	content, err := params.NamespaceTraffic(params.Context, from, to, duration, queryTime, limit)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to summarize namespace traffic: %v", err)), nil
+	}
+	return api.NewToolCallResult(content, nil), nil
+}
+
+func namespaceMatrixHandler(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	namespaces := graphNamespaces(params)
+
+	duration, queryTime, err := graphWindow(params)
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+
+	content, err := params.NamespaceMatrix(params.Context, namespaces, duration, queryTime)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to build namespace matrix: %v", err)), nil
+	}
+	return api.NewToolCallResult(content, nil), nil
+}
+
+func blastRadiusHandler(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	namespace, _ := params.GetArguments()["namespace"].(string)
+	service, _ := params.GetArguments()["service"].(string)
+	namespace, service = strings.TrimSpace(namespace), strings.TrimSpace(service)
+	if namespace == "" {
+		return api.NewToolCallResult("", fmt.Errorf("namespace parameter is required")), nil
+	}
+	if service == "" {
+		return api.NewToolCallResult("", fmt.Errorf("service parameter is required")), nil
+	}
+	namespaces, _ := params.GetArguments()["namespaces"].(string)
+
+	duration, err := durationArgument(params, "duration")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+
+	content, err := params.BlastRadius(params.Context, namespace, service, splitList(namespaces), duration)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to compute blast radius: %v", err)), nil
+	}
+	return api.NewToolCallResult(content, nil), nil
+}
+
+func meshTrafficTrendHandler(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	namespaces := graphNamespaces(params)
+
+	window := internalkiali.DefaultMeshTrafficTrendWindow
+	if v, _ := params.GetArguments()["window"].(string); strings.TrimSpace(v) != "" {
+		d, err := parseDuration(v)
+		if err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("invalid window: %v (e.g. 30m, 2h)", err)), nil
+		}
+		window = d
+	}
+	points := internalkiali.DefaultMeshTrafficTrendPoints
+	if v, ok := params.GetArguments()["points"].(float64); ok {
+		if v < 1 || v != float64(int(v)) {
+			return api.NewToolCallResult("", fmt.Errorf("points must be a positive integer")), nil
+		}
+		points = int(v)
+	}
+
+	content, err := params.MeshTrafficTrend(params.Context, namespaces, window, points)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get mesh traffic trend: %v", err)), nil
+	}
+	return api.NewToolCallResult(content, nil), nil
+}
+
+func graphLinkHandler(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	graphType, _ := params.GetArguments()["graphType"].(string)
+	duration, err := durationArgument(params, "duration")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+
+	content, err := params.GraphLink(graphNamespaces(params), strings.TrimSpace(graphType), duration)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to build graph link: %v", err)), nil
+	}
+	return api.NewToolCallResult(content, nil), nil
+}
+
+// graphWindow returns the normalized `duration` and the `queryTime` arguments, the latter converted to a Unix
+// timestamp.
+func graphWindow(params api.ToolHandlerParams) (string, string, error) {
+	duration, err := durationArgument(params, "duration")
+	if err != nil {
+		return "", "", err
+	}
+	queryTime, _ := params.GetArguments()["queryTime"].(string)
+	if queryTime = strings.TrimSpace(queryTime); queryTime == "" {
+		return duration, "", nil
+	}
+	queryTime, err = parseTimestamp(queryTime)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid queryTime: %v", err)
+	}
+	seconds, _ := strconv.ParseInt(queryTime, 10, 64)
+	if time.Unix(seconds, 0).After(time.Now()) {
+		return "", "", fmt.Errorf("invalid queryTime: %s is in the future", time.Unix(seconds, 0).UTC().Format(time.RFC3339))
+	}
+	return duration, queryTime, nil
+}
+
+// graphNamespaces returns the namespaces requested with the `namespace` and/or `namespaces` arguments.
+func graphNamespaces(params api.ToolHandlerParams) []string {
 	// Parse arguments: allow either `namespace` or `namespaces` (comma-separated string)
 	namespaces := make([]string, 0)
 	if v, ok := params.GetArguments()["namespace"].(string); ok {
@@ -77,10 +593,5 @@ func graphHandler(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
 		}
 		namespaces = unique
 	}
-
-	content, err := params.Graph(params.Context, namespaces)
-	if err != nil {
-		return api.NewToolCallResult("", fmt.Errorf("failed to retrieve mesh graph: %v", err)), nil
-	}
-	return api.NewToolCallResult(content, nil), nil
+	return namespaces
 }
@@ -0,0 +1,598 @@
+package kiali
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+
+	"github.com/kiali/kiali-mcp-server/pkg/api"
+	"github.com/kiali/kiali-mcp-server/pkg/config"
+	internalkiali "github.com/kiali/kiali-mcp-server/pkg/kiali"
+)
+
+const graphResponse = `{
+  "timestamp": 1700000000,
+  "duration": 600,
+  "graphType": "versionedApp",
+  "elements": {
+    "nodes": [
+      {"data": {"id": "box-productpage", "nodeType": "box", "isBox": "app", "app": "productpage"}},
+      {"data": {"id": "productpage", "parent": "box-productpage", "nodeType": "app", "app": "productpage"}},
+      {"data": {"id": "reviews", "nodeType": "app", "app": "reviews"}},
+      {"data": {"id": "ratings", "nodeType": "app", "app": "ratings"}},
+      {"data": {"id": "mysql", "nodeType": "app", "app": "mysql"}}
+    ],
+    "edges": [
+      {"data": {"id": "e1", "source": "productpage", "target": "reviews", "traffic": {"protocol": "http"}}},
+      {"data": {"id": "e2", "source": "reviews", "target": "ratings", "traffic": {"protocol": "grpc"}}},
+      {"data": {"id": "e3", "source": "ratings", "target": "mysql", "traffic": {"protocol": "tcp"}}}
+    ]
+  }
+}`
+
+func TestFilterGraphByProtocol(t *testing.T) {
+	elementIDs := func(t *testing.T, content string) ([]string, []string) {
+		var graph struct {
+			Elements struct {
+				Nodes []struct {
+					Data map[string]any `json:"data"`
+				} `json:"nodes"`
+				Edges []struct {
+					Data map[string]any `json:"data"`
+				} `json:"edges"`
+			} `json:"elements"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(content), &graph))
+		nodes := make([]string, 0)
+		for _, n := range graph.Elements.Nodes {
+			nodes = append(nodes, n.Data["id"].(string))
+		}
+		edges := make([]string, 0)
+		for _, e := range graph.Elements.Edges {
+			edges = append(edges, e.Data["id"].(string))
+		}
+		return nodes, edges
+	}
+
+	tests := []struct {
+		protocol      string
+		expectedNodes []string
+		expectedEdges []string
+	}{
+		{protocol: "http", expectedNodes: []string{"box-productpage", "productpage", "reviews"}, expectedEdges: []string{"e1"}},
+		{protocol: "GRPC", expectedNodes: []string{"reviews", "ratings"}, expectedEdges: []string{"e2"}},
+		{protocol: "tcp", expectedNodes: []string{"ratings", "mysql"}, expectedEdges: []string{"e3"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.protocol, func(t *testing.T) {
+			result, err := internalkiali.FilterGraphByProtocol(graphResponse, tt.protocol)
+			require.NoError(t, err)
+			nodes, edges := elementIDs(t, result)
+			assert.Equal(t, tt.expectedNodes, nodes)
+			assert.Equal(t, tt.expectedEdges, edges)
+			assert.Contains(t, result, `"graphType":"versionedApp"`)
+		})
+	}
+
+	t.Run("invalid protocol", func(t *testing.T) {
+		_, err := internalkiali.FilterGraphByProtocol(graphResponse, "udp")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid protocol")
+	})
+}
+
+func TestToGraphAdjacency(t *testing.T) {
+	content := `{"elements": {
+		"nodes": [
+			{"data": {"id": "box-reviews", "nodeType": "box", "isBox": "app", "namespace": "bookinfo", "app": "reviews"}},
+			{"data": {"id": "n1", "nodeType": "workload", "namespace": "bookinfo", "workload": "productpage-v1"}},
+			{"data": {"id": "n2", "parent": "box-reviews", "nodeType": "app", "namespace": "bookinfo", "app": "reviews", "version": "v1"}},
+			{"data": {"id": "n3", "nodeType": "service", "namespace": "bookinfo", "service": "mysql"}}
+		],
+		"edges": [
+			{"data": {"id": "e1", "source": "n1", "target": "n2", "responseTime": "87", "traffic": {"protocol": "http", "rates": {"http": "12.50", "httpPercentErr": "4.0"}}}},
+			{"data": {"id": "e2", "source": "n2", "target": "n3", "traffic": {"protocol": "tcp", "rates": {"tcp": "300.00"}}}}
+		]
+	}}`
+	result, err := internalkiali.ToGraphAdjacency(content)
+	require.NoError(t, err)
+
+	var adjacency internalkiali.GraphAdjacency
+	require.NoError(t, json.Unmarshal([]byte(result), &adjacency))
+	assert.Equal(t, []internalkiali.AdjacencyNode{
+		{ID: "n1", Name: "productpage-v1", Namespace: "bookinfo", Kind: "workload"},
+		{ID: "n2", Name: "reviews:v1", Namespace: "bookinfo", Kind: "app"},
+		{ID: "n3", Name: "mysql", Namespace: "bookinfo", Kind: "service"},
+	}, adjacency.Nodes, "box nodes are dropped")
+	assert.Equal(t, []internalkiali.AdjacencyEdge{
+		{From: "n1", To: "n2", Protocol: "http", RPS: 12.5, ErrorRate: ptr.To(4.0), ResponseTimeMs: ptr.To(87.0)},
+		{From: "n2", To: "n3", Protocol: "tcp", RPS: 300},
+	}, adjacency.Edges)
+
+	_, err = internalkiali.ToGraphAdjacency("not json")
+	assert.Error(t, err)
+}
+
+func TestGraphHealthAppender_KialiClient(t *testing.T) {
+	var appenders, duration string
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/namespaces/graph", r.URL.Path)
+		appenders = r.URL.Query().Get("appenders")
+		duration = r.URL.Query().Get("duration")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(graphResponse))
+	}))
+	defer mockServer.Close()
+
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+
+	_, err := kialiClient.Graph(context.Background(), []string{"bookinfo"}, internalkiali.GraphOptions{IncludeHealth: true})
+	require.NoError(t, err)
+	assert.Equal(t, "deadNode,istio,serviceEntry,meshCheck,workloadEntry,health", appenders)
+
+	_, err = kialiClient.Graph(context.Background(), []string{"bookinfo"}, internalkiali.GraphOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "deadNode,istio,serviceEntry,meshCheck,workloadEntry", appenders)
+
+	_, err = kialiClient.Graph(context.Background(), []string{"bookinfo"}, internalkiali.GraphOptions{Lightweight: true})
+	require.NoError(t, err)
+	assert.Equal(t, "deadNode", appenders)
+	assert.Equal(t, internalkiali.LightweightGraphDuration, duration)
+
+	_, err = kialiClient.Graph(context.Background(), []string{"bookinfo"}, internalkiali.GraphOptions{IncludeHealth: true, Lightweight: true, Duration: "5m"})
+	require.NoError(t, err)
+	assert.Equal(t, "deadNode,health", appenders)
+	assert.Equal(t, "5m", duration)
+
+	_, err = kialiClient.Graph(context.Background(), []string{"bookinfo"}, internalkiali.GraphOptions{Lightweight: true, ResponseTime: true})
+	require.NoError(t, err)
+	assert.Equal(t, "deadNode,responseTime", appenders)
+}
+
+func TestGraphQueryParams_KialiClient(t *testing.T) {
+	var query url.Values
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(graphResponse))
+	}))
+	defer mockServer.Close()
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+
+	t.Run("defaults", func(t *testing.T) {
+		_, err := kialiClient.Graph(context.Background(), []string{"bookinfo"}, internalkiali.GraphOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "versionedApp", query.Get("graphType"))
+		assert.Equal(t, "true", query.Get("injectServiceNodes"))
+		assert.Equal(t, "false", query.Get("includeIdleEdges"))
+		assert.Equal(t, "cluster,namespace,app", query.Get("boxBy"))
+		assert.Equal(t, "requests", query.Get("rateHttp"))
+	})
+
+	t.Run("overrides and unknown parameters", func(t *testing.T) {
+		_, err := kialiClient.Graph(context.Background(), []string{"bookinfo"}, internalkiali.GraphOptions{
+			QueryParams: map[string]string{"graphType": "workload", "boxBy": "namespace", "rateHttp": "throughput", "showWaypoints": "true"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "workload", query.Get("graphType"))
+		assert.Equal(t, "namespace", query.Get("boxBy"))
+		assert.Equal(t, "throughput", query.Get("rateHttp"))
+		assert.Equal(t, "true", query.Get("showWaypoints"))
+		assert.Equal(t, "true", query.Get("injectServiceNodes"), "parameters not overridden keep their default")
+	})
+
+	t.Run("tool arguments", func(t *testing.T) {
+		arguments := argumentsRequest{"namespace": "bookinfo", "graphType": "service", "injectServiceNodes": false, "includeIdleEdges": true}
+		result, err := graphHandler(api.ToolHandlerParams{Context: context.Background(), Kiali: kialiClient, ToolCallRequest: arguments})
+		require.NoError(t, err)
+		require.NoError(t, result.Error)
+		assert.Equal(t, "service", query.Get("graphType"))
+		assert.Equal(t, "false", query.Get("injectServiceNodes"))
+		assert.Equal(t, "true", query.Get("includeIdleEdges"))
+
+		result, err = graphHandler(api.ToolHandlerParams{Context: context.Background(), Kiali: kialiClient, ToolCallRequest: argumentsRequest{"graphType": "pods"}})
+		require.NoError(t, err)
+		assert.EqualError(t, result.Error, "invalid graphType 'pods': must be one of app, versionedApp, workload, service")
+	})
+}
+
+func TestErrorGraph_KialiClient(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/namespaces/graph", r.URL.Path)
+		assert.Equal(t, "deadNode,health", r.URL.Query().Get("appenders"))
+		assert.Equal(t, "10m", r.URL.Query().Get("duration"))
+		assert.Equal(t, "bookinfo", r.URL.Query().Get("namespaces"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"elements": {
+			"nodes": [
+				{"data": {"id": "n1", "nodeType": "app", "namespace": "bookinfo", "app": "productpage", "version": "v1"}},
+				{"data": {"id": "n2", "nodeType": "service", "namespace": "bookinfo", "service": "reviews"}},
+				{"data": {"id": "n3", "nodeType": "workload", "namespace": "bookinfo", "workload": "ratings-v1"}},
+				{"data": {"id": "n4", "nodeType": "service", "namespace": "bookinfo", "service": "mysql"}}
+			],
+			"edges": [
+				{"data": {"source": "n1", "target": "n2", "traffic": {"protocol": "http", "rates": {"http": "10.00", "httpPercentErr": "2.5"}}}},
+				{"data": {"source": "n2", "target": "n3", "traffic": {"protocol": "grpc", "rates": {"grpc": "4.00", "grpcPercentErr": "25.0"}}}},
+				{"data": {"source": "n3", "target": "n4", "traffic": {"protocol": "tcp", "rates": {"tcp": "120.5"}}}}
+			]
+		}}`))
+	}))
+	defer mockServer.Close()
+
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+	result, err := kialiClient.ErrorGraph(context.Background(), []string{"bookinfo"}, "10m", "")
+	require.NoError(t, err)
+
+	var graph internalkiali.ErrorGraph
+	require.NoError(t, json.Unmarshal([]byte(result), &graph))
+	assert.Equal(t, "10m", graph.Duration)
+	require.Len(t, graph.Edges, 3)
+	assert.Equal(t, internalkiali.ErrorGraphEdge{Source: "bookinfo/reviews", Target: "bookinfo/ratings-v1", Protocol: "grpc", RequestRate: 4, ErrorRate: ptr.To(25.0)}, graph.Edges[0])
+	assert.Equal(t, internalkiali.ErrorGraphEdge{Source: "bookinfo/productpage:v1", Target: "bookinfo/reviews", Protocol: "http", RequestRate: 10, ErrorRate: ptr.To(2.5)}, graph.Edges[1])
+	assert.Equal(t, internalkiali.ErrorGraphEdge{Source: "bookinfo/ratings-v1", Target: "bookinfo/mysql", Protocol: "tcp", RequestRate: 120.5}, graph.Edges[2])
+}
+
+func TestMTLSCoverage_KialiClient(t *testing.T) {
+	edges := `[
+		{"data": {"source": "n1", "target": "n2", "isMTLS": "100", "traffic": {"protocol": "http"}}},
+		{"data": {"source": "n2", "target": "n3", "isMTLS": "50", "traffic": {"protocol": "grpc"}}},
+		{"data": {"source": "n3", "target": "n4", "traffic": {"protocol": "tcp"}}},
+		{"data": {"source": "n1", "target": "n3", "isMTLS": "100", "traffic": {"protocol": "http"}}}
+	]`
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "deadNode,securityPolicy", r.URL.Query().Get("appenders"))
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("namespaces") == "empty" {
+			_, _ = w.Write([]byte(`{"elements": {"nodes": [], "edges": []}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"elements": {
+			"nodes": [
+				{"data": {"id": "n1", "nodeType": "app", "namespace": "bookinfo", "app": "productpage"}},
+				{"data": {"id": "n2", "nodeType": "service", "namespace": "bookinfo", "service": "reviews"}},
+				{"data": {"id": "n3", "nodeType": "workload", "namespace": "bookinfo", "workload": "ratings-v1"}},
+				{"data": {"id": "n4", "nodeType": "service", "namespace": "legacy", "service": "mysql"}}
+			],
+			"edges": ` + edges + `
+		}}`))
+	}))
+	defer mockServer.Close()
+
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+
+	t.Run("mixed edges", func(t *testing.T) {
+		result, err := kialiClient.MTLSCoverage(context.Background(), []string{"bookinfo"}, "10m", "")
+		require.NoError(t, err)
+
+		var coverage internalkiali.MTLSCoverage
+		require.NoError(t, json.Unmarshal([]byte(result), &coverage))
+		assert.Equal(t, 4, coverage.TotalEdges)
+		assert.Equal(t, 2, coverage.MTLSEdges)
+		assert.Equal(t, ptr.To(50.0), coverage.Percentage)
+		assert.Equal(t, []internalkiali.PlaintextEdge{
+			{Source: "bookinfo/ratings-v1", Target: "legacy/mysql", Protocol: "tcp", MTLSPercentage: 0},
+			{Source: "bookinfo/reviews", Target: "bookinfo/ratings-v1", Protocol: "grpc", MTLSPercentage: 50},
+		}, coverage.PlaintextEdges)
+	})
+
+	t.Run("no traffic", func(t *testing.T) {
+		result, err := kialiClient.MTLSCoverage(context.Background(), []string{"empty"}, "", "")
+		require.NoError(t, err)
+
+		var coverage internalkiali.MTLSCoverage
+		require.NoError(t, json.Unmarshal([]byte(result), &coverage))
+		assert.Nil(t, coverage.Percentage)
+		assert.Empty(t, coverage.PlaintextEdges)
+		assert.Contains(t, coverage.Note, "no traffic")
+	})
+}
+
+func TestNamespaceTraffic_KialiClient(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "frontend,backend", r.URL.Query().Get("namespaces"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"elements": {
+			"nodes": [
+				{"data": {"id": "box-fe", "isBox": "namespace", "namespace": "frontend"}},
+				{"data": {"id": "web", "nodeType": "app", "namespace": "frontend", "app": "web"}},
+				{"data": {"id": "admin", "nodeType": "app", "namespace": "frontend", "app": "admin"}},
+				{"data": {"id": "orders", "nodeType": "service", "namespace": "backend", "service": "orders"}},
+				{"data": {"id": "users", "nodeType": "service", "namespace": "backend", "service": "users"}},
+				{"data": {"id": "db", "nodeType": "service", "namespace": "backend", "service": "db"}},
+				{"data": {"id": "orders-v1", "nodeType": "workload", "namespace": "backend", "workload": "orders-v1"}}
+			],
+			"edges": [
+				{"data": {"source": "web", "target": "orders", "traffic": {"protocol": "http", "rates": {"http": "30.00", "httpPercentErr": "10.0"}}}},
+				{"data": {"source": "web", "target": "users", "traffic": {"protocol": "grpc", "rates": {"grpc": "10.00"}}}},
+				{"data": {"source": "admin", "target": "db", "traffic": {"protocol": "tcp", "rates": {"tcp": "500.00"}}}},
+				{"data": {"source": "admin", "target": "users", "traffic": {"protocol": "http", "rates": {"http": "2.00"}}}},
+				{"data": {"source": "orders", "target": "orders-v1", "traffic": {"protocol": "http", "rates": {"http": "30.00"}}}},
+				{"data": {"source": "orders-v1", "target": "web", "traffic": {"protocol": "http", "rates": {"http": "5.00"}}}}
+			]
+		}}`))
+	}))
+	defer mockServer.Close()
+
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+	result, err := kialiClient.NamespaceTraffic(context.Background(), "frontend", "backend", "10m", "", 3)
+	require.NoError(t, err)
+
+	var traffic internalkiali.NamespaceTraffic
+	require.NoError(t, json.Unmarshal([]byte(result), &traffic))
+	assert.Equal(t, 4, traffic.Edges, "edges within a namespace or in the other direction are not counted")
+	assert.Equal(t, 42.0, traffic.RequestRate)
+	assert.Equal(t, ptr.To(7.14), traffic.ErrorRate)
+	assert.Equal(t, 500.0, traffic.TCPRate)
+	assert.Equal(t, []internalkiali.ErrorGraphEdge{
+		{Source: "frontend/web", Target: "backend/orders", Protocol: "http", RequestRate: 30, ErrorRate: ptr.To(10.0)},
+		{Source: "frontend/web", Target: "backend/users", Protocol: "grpc", RequestRate: 10, ErrorRate: ptr.To(0.0)},
+		{Source: "frontend/admin", Target: "backend/users", Protocol: "http", RequestRate: 2, ErrorRate: ptr.To(0.0)},
+	}, traffic.TopPairs)
+}
+
+func TestNamespaceMatrix_KialiClient(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"elements": {
+			"nodes": [
+				{"data": {"id": "box-fe", "isBox": "namespace", "namespace": "frontend"}},
+				{"data": {"id": "web", "nodeType": "app", "namespace": "frontend", "app": "web"}},
+				{"data": {"id": "orders", "nodeType": "service", "namespace": "backend", "service": "orders"}},
+				{"data": {"id": "orders-v1", "nodeType": "workload", "namespace": "backend", "workload": "orders-v1"}},
+				{"data": {"id": "db", "nodeType": "service", "namespace": "data", "service": "db"}}
+			],
+			"edges": [
+				{"data": {"source": "web", "target": "orders", "traffic": {"protocol": "http", "rates": {"http": "30.00", "httpPercentErr": "10.0"}}}},
+				{"data": {"source": "web", "target": "orders-v1", "traffic": {"protocol": "grpc", "rates": {"grpc": "10.00"}}}},
+				{"data": {"source": "orders", "target": "orders-v1", "traffic": {"protocol": "http", "rates": {"http": "30.00"}}}},
+				{"data": {"source": "orders-v1", "target": "db", "traffic": {"protocol": "tcp", "rates": {"tcp": "500.00"}}}}
+			]
+		}}`))
+	}))
+	defer mockServer.Close()
+
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+
+	t.Run("mesh", func(t *testing.T) {
+		result, err := kialiClient.NamespaceMatrix(context.Background(), nil, "", "")
+		require.NoError(t, err)
+
+		var matrix internalkiali.NamespaceMatrix
+		require.NoError(t, json.Unmarshal([]byte(result), &matrix))
+		assert.Equal(t, []string{"backend", "data", "frontend"}, matrix.Namespaces)
+		assert.Equal(t, map[string]map[string]*internalkiali.NamespaceMatrixCell{
+			"frontend": {"backend": {Edges: 2, RequestRate: 40, ErrorRate: ptr.To(7.5)}},
+			"backend": {
+				"backend": {Edges: 1, RequestRate: 30, ErrorRate: ptr.To(0.0)},
+				"data":    {Edges: 1, TCPRate: 500},
+			},
+		}, matrix.Matrix)
+	})
+
+	t.Run("subset of namespaces", func(t *testing.T) {
+		result, err := kialiClient.NamespaceMatrix(context.Background(), []string{"frontend", "data"}, "", "")
+		require.NoError(t, err)
+
+		var matrix internalkiali.NamespaceMatrix
+		require.NoError(t, json.Unmarshal([]byte(result), &matrix))
+		assert.Equal(t, []string{"data", "frontend"}, matrix.Namespaces)
+		assert.Empty(t, matrix.Matrix)
+	})
+}
+
+func TestBlastRadius_KialiClient(t *testing.T) {
+	var requestedQuery url.Values
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedQuery = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		// gateway -> productpage -> reviews -> ratings, details -> ratings, reviews -> productpage (cycle)
+		_, _ = w.Write([]byte(`{"elements": {
+			"nodes": [
+				{"data": {"id": "box", "isBox": "namespace", "namespace": "bookinfo"}},
+				{"data": {"id": "gw", "nodeType": "app", "namespace": "istio-system", "app": "istio-ingressgateway"}},
+				{"data": {"id": "pp", "nodeType": "service", "namespace": "bookinfo", "service": "productpage"}},
+				{"data": {"id": "rv", "nodeType": "service", "namespace": "bookinfo", "service": "reviews"}},
+				{"data": {"id": "dt", "nodeType": "service", "namespace": "bookinfo", "service": "details"}},
+				{"data": {"id": "rt", "nodeType": "service", "namespace": "bookinfo", "service": "ratings"}}
+			],
+			"edges": [
+				{"data": {"source": "gw", "target": "pp"}},
+				{"data": {"source": "pp", "target": "rv"}},
+				{"data": {"source": "rv", "target": "pp"}},
+				{"data": {"source": "rv", "target": "rt"}},
+				{"data": {"source": "dt", "target": "rt"}}
+			]
+		}}`))
+	}))
+	defer mockServer.Close()
+
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+	result, err := kialiClient.BlastRadius(context.Background(), "bookinfo", "ratings", []string{"istio-system"}, "")
+	require.NoError(t, err)
+	assert.Equal(t, "service", requestedQuery.Get("graphType"))
+	assert.Equal(t, "10m", requestedQuery.Get("duration"))
+	assert.Equal(t, "bookinfo,istio-system", requestedQuery.Get("namespaces"))
+
+	var blastRadius internalkiali.BlastRadius
+	require.NoError(t, json.Unmarshal([]byte(result), &blastRadius))
+	assert.Equal(t, 4, blastRadius.Total)
+	assert.Equal(t, []internalkiali.BlastRadiusLevel{
+		{Distance: 1, Nodes: []string{"bookinfo/details", "bookinfo/reviews"}},
+		{Distance: 2, Nodes: []string{"bookinfo/productpage"}},
+		{Distance: 3, Nodes: []string{"istio-system/istio-ingressgateway"}},
+	}, blastRadius.Dependents)
+
+	_, err = kialiClient.BlastRadius(context.Background(), "bookinfo", "missing", nil, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "service missing not found")
+}
+
+func TestGraphQueryTime_KialiClient(t *testing.T) {
+	var requestedQuery url.Values
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedQuery = r.URL.Query()
+		_, _ = w.Write([]byte(`{"elements": {}}`))
+	}))
+	defer mockServer.Close()
+
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+	_, err := kialiClient.Graph(context.Background(), []string{"bookinfo"}, internalkiali.GraphOptions{Duration: "30m", QueryTime: "1704103200"})
+	require.NoError(t, err)
+	assert.Equal(t, "30m", requestedQuery.Get("duration"))
+	assert.Equal(t, "1704103200", requestedQuery.Get("queryTime"))
+
+	_, err = kialiClient.Graph(context.Background(), []string{"bookinfo"}, internalkiali.GraphOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, internalkiali.DefaultGraphDuration, requestedQuery.Get("duration"))
+	assert.False(t, requestedQuery.Has("queryTime"))
+}
+
+type argumentsRequest map[string]any
+
+func (a argumentsRequest) GetArguments() map[string]any {
+	return a
+}
+
+func TestGraphWindow(t *testing.T) {
+	tests := []struct {
+		name              string
+		arguments         argumentsRequest
+		expectedDuration  string
+		expectedQueryTime string
+		expectedError     string
+	}{
+		{name: "defaults", arguments: argumentsRequest{}},
+		{name: "RFC 3339 query time", arguments: argumentsRequest{"duration": "10m", "queryTime": "2024-01-01T10:00:00Z"}, expectedDuration: "10m", expectedQueryTime: "1704103200"},
+		{name: "Unix query time", arguments: argumentsRequest{"queryTime": "1704103200"}, expectedQueryTime: "1704103200"},
+		{name: "invalid query time", arguments: argumentsRequest{"queryTime": "yesterday"}, expectedError: "invalid queryTime"},
+		{name: "future query time", arguments: argumentsRequest{"queryTime": "4102444800"}, expectedError: "is in the future"},
+		{name: "invalid duration", arguments: argumentsRequest{"duration": "-5m"}, expectedError: "invalid duration"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			duration, queryTime, err := graphWindow(api.ToolHandlerParams{ToolCallRequest: tt.arguments})
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedDuration, duration)
+			assert.Equal(t, tt.expectedQueryTime, queryTime)
+		})
+	}
+}
+
+func TestMeshTrafficTrend_KialiClient(t *testing.T) {
+	var mu sync.Mutex
+	durations := map[string]bool{}
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		durations[r.URL.Query().Get("duration")] = true
+		mu.Unlock()
+		queryTime, _ := strconv.ParseInt(r.URL.Query().Get("queryTime"), 10, 64)
+		// The traffic ramps up by 10 rps every 5 minutes, up to 100 rps now
+		stepsAgo := (time.Now().Unix() - queryTime + 150) / 300
+		rate := 100 - 10*stepsAgo
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"elements": {
+			"nodes": [
+				{"data": {"id": "web", "nodeType": "app", "namespace": "frontend", "app": "web"}},
+				{"data": {"id": "orders", "nodeType": "service", "namespace": "backend", "service": "orders"}},
+				{"data": {"id": "orders-v1", "nodeType": "workload", "namespace": "backend", "workload": "orders-v1"}},
+				{"data": {"id": "db", "nodeType": "service", "namespace": "data", "service": "db"}}
+			],
+			"edges": [
+				{"data": {"source": "web", "target": "orders", "traffic": {"protocol": "http", "rates": {"http": "` + strconv.FormatInt(rate, 10) + `", "httpPercentErr": "10.0"}}}},
+				{"data": {"source": "orders", "target": "orders-v1", "traffic": {"protocol": "http", "rates": {"http": "` + strconv.FormatInt(rate, 10) + `"}}}},
+				{"data": {"source": "orders-v1", "target": "db", "traffic": {"protocol": "tcp", "rates": {"tcp": "500.00"}}}}
+			]
+		}}`))
+	}))
+	defer mockServer.Close()
+
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+
+	t.Run("samples the window", func(t *testing.T) {
+		result, err := kialiClient.MeshTrafficTrend(context.Background(), nil, 30*time.Minute, 6)
+		require.NoError(t, err)
+
+		var trend internalkiali.MeshTrafficTrend
+		require.NoError(t, json.Unmarshal([]byte(result), &trend))
+		assert.Equal(t, "30m0s", trend.Window)
+		assert.Equal(t, "5m0s", trend.Step)
+		assert.Equal(t, map[string]bool{"300s": true}, durations)
+		require.Len(t, trend.Samples, 6)
+		for i, sample := range trend.Samples {
+			assert.Equal(t, float64(50+10*i), sample.TotalRps, "the request through the service node is counted once")
+			assert.Equal(t, ptr.To(10.0), sample.ErrorRate)
+			if i > 0 {
+				assert.Equal(t, 5*time.Minute, sample.Time.Sub(trend.Samples[i-1].Time))
+			}
+		}
+		assert.Equal(t, ptr.To(100.0), trend.ChangePercent)
+	})
+
+	t.Run("too many points", func(t *testing.T) {
+		_, err := kialiClient.MeshTrafficTrend(context.Background(), nil, time.Hour, 13)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "points must be at most 12")
+	})
+
+	t.Run("window too short", func(t *testing.T) {
+		_, err := kialiClient.MeshTrafficTrend(context.Background(), nil, time.Minute, 6)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "too short")
+	})
+}
+
+func TestGraphLink_KialiClient(t *testing.T) {
+	link := func(t *testing.T, serverURL string, namespaces []string, graphType, duration string) internalkiali.GraphLink {
+		kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: serverURL})
+		result, err := kialiClient.GraphLink(namespaces, graphType, duration)
+		require.NoError(t, err)
+		var ret internalkiali.GraphLink
+		require.NoError(t, json.Unmarshal([]byte(result), &ret))
+		return ret
+	}
+
+	t.Run("namespaces, graph type and duration", func(t *testing.T) {
+		l := link(t, "https://kiali.example.com/kiali/", []string{"bookinfo", "travels"}, "workload", "10m")
+		u, err := url.Parse(l.URL)
+		require.NoError(t, err)
+		assert.Equal(t, "kiali.example.com", u.Host)
+		assert.Equal(t, "/kiali/console/graph/namespaces/", u.Path)
+		assert.Equal(t, "bookinfo,travels", u.Query().Get("namespaces"))
+		assert.Equal(t, "workload", u.Query().Get("graphType"))
+		assert.Equal(t, "600", u.Query().Get("duration"))
+		assert.Equal(t, "10m0s", l.Duration)
+		assert.Empty(t, l.Note)
+	})
+	t.Run("defaults", func(t *testing.T) {
+		l := link(t, "https://kiali.example.com", nil, "", "")
+		assert.Equal(t, "https://kiali.example.com/console/graph/namespaces/?duration=60&graphType=versionedApp", l.URL)
+		assert.Empty(t, l.Namespaces)
+	})
+	t.Run("in-cluster URL", func(t *testing.T) {
+		l := link(t, "http://kiali.istio-system:20001/kiali", []string{"bookinfo"}, "", "")
+		assert.Contains(t, l.Note, "looks like an in-cluster address")
+		assert.Empty(t, link(t, "http://localhost:20001/kiali", nil, "", "").Note)
+	})
+	t.Run("invalid parameters", func(t *testing.T) {
+		kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: "https://kiali.example.com"})
+		_, err := kialiClient.GraphLink(nil, "pods", "")
+		assert.EqualError(t, err, "invalid graph type 'pods': must be one of app, versionedApp, workload, service")
+		_, err = kialiClient.GraphLink(nil, "", "500ms")
+		assert.EqualError(t, err, "invalid duration '500ms': must be a duration of at least 1s (e.g. 5m, 1h)")
+	})
+}
@@ -2,6 +2,8 @@ package kiali
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/jsonschema-go/jsonschema"
 	"k8s.io/utils/ptr"
@@ -36,6 +38,14 @@ func initHealth() []api.ServerTool {
 						Type:        "string",
 						Description: "Unix timestamp (in seconds) for the prometheus query. If not provided, uses current time. Optional",
 					},
+					"partial": {
+						Type:        "boolean",
+						Description: "When several namespaces are requested and Kiali rejects the request because one of them does not exist, query each namespace separately and return the health of the valid ones, with the failed namespaces and their errors in 'failedNamespaces' (default: false)",
+					},
+					"clusterName": {
+						Type:        "string",
+						Description: "Optional cluster of the namespaces in multi-cluster meshes (e.g., 'east'), so the health is computed from the Prometheus of that cluster. Default: the Kiali home cluster. An unknown cluster fails the call with the list of known clusters",
+					},
 				},
 			},
 			Annotations: api.ToolAnnotations{
@@ -48,6 +58,203 @@ func initHealth() []api.ServerTool {
 		}, Handler: clusterHealthHandler,
 	})
 
+	// Entity health tool
+	ret = append(ret, api.ServerTool{
+		Tool: api.Tool{
+			Name:        "entity_health",
+			Description: "Get the health of a single app, service or workload: its status (HEALTHY, DEGRADED, UNHEALTHY or NA), the issue found, the inbound request error rate and, for apps and workloads, the desired, current and available replicas",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"kind": {
+						Type:        "string",
+						Description: "Kind of the entity: 'app', 'service', or 'workload'",
+					},
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace containing the entity",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the app, service or workload",
+					},
+					"rateInterval": {
+						Type:        "string",
+						Description: "Rate interval for fetching error rate (e.g., '10m', '5m', '1h'). Default: '10m'",
+					},
+				},
+				Required: []string{"kind", "namespace", "name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Health: Entity",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: entityHealthHandler,
+	})
+
+	// Unhealthy apps tool
+	ret = append(ret, api.ServerTool{
+		Tool: api.Tool{
+			Name:        "unhealthy_apps",
+			Description: "List only the apps whose health is DEGRADED or UNHEALTHY, with their namespace and the issues found (unavailable replicas, unsynced proxies, request error rates). Healthy apps are omitted",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespaces": {
+						Type:        "string",
+						Description: "Comma-separated list of namespaces to check (e.g. 'bookinfo' or 'bookinfo,default'). If not provided, checks all accessible namespaces",
+					},
+					"rateInterval": {
+						Type:        "string",
+						Description: "Rate interval for fetching error rate (e.g., '10m', '5m', '1h'). Default: '10m'",
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Health: Unhealthy Apps",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: unhealthyAppsHandler,
+	})
+
+	// Replica shortfalls tool
+	ret = append(ret, api.ServerTool{
+		Tool: api.Tool{
+			Name:        "replica_shortfalls",
+			Description: "List the workloads across the mesh whose available replicas are fewer than desired, for rollout monitoring: returns each workload with a replica shortfall with its desired, current and available replicas, synced proxies, health status and issue, the largest shortfall first. Workloads with all their replicas available are omitted",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespaces": {
+						Type:        "string",
+						Description: "Comma-separated list of namespaces to check (e.g. 'bookinfo' or 'bookinfo,default'). If not provided, checks all accessible namespaces",
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Health: Replica Shortfalls",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: replicaShortfallsHandler,
+	})
+
+	return ret
+}
+
+func initHealthSummary() []api.ServerTool {
+	ret := make([]api.ServerTool, 0)
+	ret = append(ret, api.ServerTool{
+		Tool: api.Tool{
+			Name:        "mesh_health_summary",
+			Description: "Get an aggregated health summary of the mesh computed from the app, service and workload health: overall status, availability, inbound error rate, entity counts per status (per type and per namespace) and the list of degraded or unhealthy entities with their issues",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespaces": {
+						Type:        "string",
+						Description: "Comma-separated list of namespaces to summarize (e.g. 'bookinfo' or 'bookinfo,default'). If not provided, summarizes all accessible namespaces",
+					},
+					"rateInterval": {
+						Type:        "string",
+						Description: "Rate interval for fetching error rate (e.g., '10m', '5m', '1h'). Default: '10m'",
+					},
+					"types": {
+						Type:        "string",
+						Description: "Comma-separated list of the health types to fetch and aggregate: 'app', 'service' and/or 'workload' (e.g. 'app' to only summarize the apps, with a third of the Prometheus load). The entity counts, overall status, availability and error rate only reflect the requested types. Default: all three",
+					},
+					"trafficWeighted": {
+						Type:        "boolean",
+						Description: "Weight each entity by its inbound request volume to compute the overall status, so that it reflects the user-facing impact: UNHEALTHY when 20% or more of the traffic is served by unhealthy entities, DEGRADED when 5% or more is served by degraded or unhealthy entities. The share of impacted traffic is reported as impactedTraffic. Entities without traffic are ignored. Default: false (the overall status is the most severe status of any entity)",
+					},
+					"includeRaw": {
+						Type:        "boolean",
+						Description: "Attach the raw Kiali health responses the summary was computed from under a 'raw' key, keyed by type, to debug discrepancies. Default: false. The raw responses list every entity with its request rates per response code, so they are typically much larger than the summary and may exceed the maxBytes limit on large meshes",
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Health: Mesh Summary",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		},
+		Handler: meshHealthSummaryHandler,
+		// Aggregates three health queries, which can be slow on large meshes
+		Timeout: 2 * time.Minute,
+	})
+	ret = append(ret, api.ServerTool{
+		Tool: api.Tool{
+			Name:        "mesh_health_metrics",
+			Description: "Get the aggregated mesh health summary rendered in the Prometheus text exposition format, for scraping or alerting on the same health logic: mesh_entity_health{type,namespace,status} entity counts, mesh_availability and mesh_error_rate (percentages)",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespaces": {
+						Type:        "string",
+						Description: "Comma-separated list of namespaces to summarize (e.g. 'bookinfo' or 'bookinfo,default'). If not provided, summarizes all accessible namespaces",
+					},
+					"rateInterval": {
+						Type:        "string",
+						Description: "Rate interval for fetching error rate (e.g., '10m', '5m', '1h'). Default: '10m'",
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Health: Mesh Metrics",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		},
+		Handler: meshHealthMetricsHandler,
+		// Aggregates three health queries, which can be slow on large meshes
+		Timeout: 2 * time.Minute,
+	})
+	ret = append(ret, api.ServerTool{
+		Tool: api.Tool{
+			Name:        "mesh_health_table",
+			Description: "Get the computed health of every app, service and workload of the mesh, healthy or not, as a flat list for dashboards: type, namespace, name, status, inbound error rate and replica availability (percentages) of each entity, sorted by decreasing severity (UNHEALTHY, DEGRADED, NOT_READY, HEALTHY, NA), then by namespace and name",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespaces": {
+						Type:        "string",
+						Description: "Comma-separated list of namespaces to list (e.g. 'bookinfo' or 'bookinfo,default'). If not provided, lists all accessible namespaces",
+					},
+					"rateInterval": {
+						Type:        "string",
+						Description: "Rate interval for fetching error rate (e.g., '10m', '5m', '1h'). Default: '10m'",
+					},
+					"types": {
+						Type:        "string",
+						Description: "Comma-separated list of the health types to list: 'app', 'service' and/or 'workload'. Default: all three",
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Health: Mesh Table",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		},
+		Handler: meshHealthTableHandler,
+		// Aggregates three health queries, which can be slow on large meshes
+		Timeout: 2 * time.Minute,
+	})
 	return ret
 }
 
@@ -64,16 +271,143 @@ func clusterHealthHandler(params api.ToolHandlerParams) (*api.ToolCallResult, er
 		}
 		queryParams["type"] = healthType
 	}
-	if rateInterval, ok := params.GetArguments()["rateInterval"].(string); ok && rateInterval != "" {
+	rateInterval, err := durationArgument(params, "rateInterval")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	if rateInterval != "" {
 		queryParams["rateInterval"] = rateInterval
 	}
 	if queryTime, ok := params.GetArguments()["queryTime"].(string); ok && queryTime != "" {
 		queryParams["queryTime"] = queryTime
 	}
+	if clusterName, ok := params.GetArguments()["clusterName"].(string); ok && clusterName != "" {
+		queryParams["clusterName"] = clusterName
+	}
 
-	content, err := params.Health(params.Context, namespaces, queryParams)
+	health := params.Health
+	if partial, _ := params.GetArguments()["partial"].(bool); partial {
+		health = params.HealthPartial
+	}
+	content, err := health(params.Context, namespaces, queryParams)
 	if err != nil {
 		return api.NewToolCallResult("", fmt.Errorf("failed to get health: %v", err)), nil
 	}
 	return api.NewToolCallResult(content, nil), nil
 }
+
+// entityHealthListTools are the tools listing the names of each kind of entity.
+var entityHealthListTools = map[string]string{
+	"app":      "health",
+	"service":  "services_list",
+	"workload": "workloads_list",
+}
+
+func entityHealthHandler(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	kind, _ := params.GetArguments()["kind"].(string)
+	namespace, _ := params.GetArguments()["namespace"].(string)
+	name, _ := params.GetArguments()["name"].(string)
+	rateInterval, err := durationArgument(params, "rateInterval")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	listTool, ok := entityHealthListTools[kind]
+	if !ok {
+		return api.NewToolCallResult("", fmt.Errorf("invalid kind parameter: must be one of 'app', 'service', or 'workload'")), nil
+	}
+	if namespace == "" {
+		return api.NewToolCallResult("", fmt.Errorf("namespace parameter is required")), nil
+	}
+	if name == "" {
+		return api.NewToolCallResult("", fmt.Errorf("name parameter is required")), nil
+	}
+
+	content, err := params.EntityHealthStatus(params.Context, kind, namespace, name, rateInterval)
+	if err != nil {
+		return api.NewToolCallResult("", detailsError(err, kind+" health", listTool)), nil
+	}
+	return api.NewToolCallResult(content, nil), nil
+}
+
+func unhealthyAppsHandler(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	namespaces, _ := params.GetArguments()["namespaces"].(string)
+	rateInterval, err := durationArgument(params, "rateInterval")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+
+	content, err := params.UnhealthyApps(params.Context, namespaces, rateInterval)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get unhealthy apps: %v", err)), nil
+	}
+	return api.NewToolCallResult(content, nil), nil
+}
+
+func replicaShortfallsHandler(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	namespaces, _ := params.GetArguments()["namespaces"].(string)
+
+	content, err := params.ReplicaShortfalls(params.Context, namespaces)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get replica shortfalls: %v", err)), nil
+	}
+	return api.NewToolCallResult(content, nil), nil
+}
+
+func meshHealthSummaryHandler(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	namespaces, _ := params.GetArguments()["namespaces"].(string)
+	rateInterval, err := durationArgument(params, "rateInterval")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	trafficWeighted, _ := params.GetArguments()["trafficWeighted"].(bool)
+	includeRaw, _ := params.GetArguments()["includeRaw"].(bool)
+	types := healthTypesArgument(params)
+
+	content, err := params.MeshHealthSummary(params.Context, namespaces, rateInterval, trafficWeighted, types, includeRaw)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get mesh health summary: %v", err)), nil
+	}
+	return api.NewToolCallResult(content, nil), nil
+}
+
+func meshHealthMetricsHandler(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	namespaces, _ := params.GetArguments()["namespaces"].(string)
+	rateInterval, err := durationArgument(params, "rateInterval")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+
+	content, err := params.MeshHealthMetrics(params.Context, namespaces, rateInterval)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get mesh health metrics: %v", err)), nil
+	}
+	return api.NewToolCallResult(content, nil), nil
+}
+
+func meshHealthTableHandler(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	namespaces, _ := params.GetArguments()["namespaces"].(string)
+	rateInterval, err := durationArgument(params, "rateInterval")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	types := healthTypesArgument(params)
+
+	content, err := params.MeshHealthTable(params.Context, namespaces, rateInterval, types)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get mesh health table: %v", err)), nil
+	}
+	return api.NewToolCallResult(content, nil), nil
+}
+
+// healthTypesArgument returns the health types of the comma-separated types argument.
+func healthTypesArgument(params api.ToolHandlerParams) []string {
+	var types []string
+	if v, _ := params.GetArguments()["types"].(string); v != "" {
+		for _, healthType := range strings.Split(v, ",") {
+			if healthType = strings.TrimSpace(healthType); healthType != "" {
+				types = append(types, healthType)
+			}
+		}
+	}
+	return types
+}
@@ -7,10 +7,14 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
 
 	"github.com/kiali/kiali-mcp-server/pkg/api"
 	"github.com/kiali/kiali-mcp-server/pkg/config"
@@ -455,6 +459,80 @@ func TestHealth_KialiClient(t *testing.T) {
 }
 
 // TestHealthToolDefinition tests the tool definition
+func TestHealthPartial_KialiClient(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("namespaces") {
+		case "bookinfo":
+			_, _ = w.Write([]byte(`{"namespaceAppHealth": {"bookinfo": {"reviews": {"requests": {}}}}}`))
+		case "default":
+			_, _ = w.Write([]byte(`{"namespaceAppHealth": {"default": {"httpbin": {"requests": {}}}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`namespace not found`))
+		}
+	}))
+	defer mockServer.Close()
+
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+	ctx := context.Background()
+	queryParams := map[string]string{"type": "app"}
+
+	t.Run("one missing namespace", func(t *testing.T) {
+		result, err := kialiClient.HealthPartial(ctx, "bookinfo,missing,default", queryParams)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"namespaceAppHealth": {"bookinfo": {"reviews": {"requests": {}}}, "default": {"httpbin": {"requests": {}}}},
+			"failedNamespaces": {"missing": "kiali API error: namespace not found"}
+		}`, result)
+	})
+
+	t.Run("all namespaces missing", func(t *testing.T) {
+		_, err := kialiClient.HealthPartial(ctx, "missing,other", queryParams)
+		require.Error(t, err)
+		assert.True(t, internalkiali.IsNotFound(err))
+	})
+
+	t.Run("single namespace is not retried", func(t *testing.T) {
+		_, err := kialiClient.HealthPartial(ctx, "missing", queryParams)
+		require.Error(t, err)
+	})
+
+	t.Run("without errors", func(t *testing.T) {
+		result, err := kialiClient.HealthPartial(ctx, "bookinfo", queryParams)
+		require.NoError(t, err)
+		assert.NotContains(t, result, "failedNamespaces")
+	})
+}
+
+func TestHealthClusterName_KialiClient(t *testing.T) {
+	var requests []string
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/clusters" {
+			_, _ = w.Write([]byte(`[{"name": "east", "isKialiHome": true}, {"name": "west"}]`))
+			return
+		}
+		requests = append(requests, r.URL.Path+"?"+r.URL.RawQuery)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer mockServer.Close()
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+
+	_, err := kialiClient.Health(context.Background(), "bookinfo", map[string]string{"type": "app", "clusterName": "west"})
+	require.NoError(t, err)
+	_, err = kialiClient.Health(context.Background(), "bookinfo", map[string]string{"type": "app"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"/api/clusters/health?clusterName=west&namespaces=bookinfo&type=app",
+		"/api/clusters/health?type=app&namespaces=bookinfo",
+	}, requests)
+
+	_, err = kialiClient.Health(context.Background(), "bookinfo", map[string]string{"clusterName": "north"})
+	require.Error(t, err)
+	assert.Equal(t, `unknown cluster "north", known clusters are: east, west`, err.Error())
+	assert.Len(t, requests, 2, "no health request is sent for an unknown cluster")
+}
+
 func TestHealthToolDefinition(t *testing.T) {
 	tools := initHealth()
 
@@ -488,7 +566,7 @@ func TestHealthToolDefinition(t *testing.T) {
 		assert.NotNil(t, schema.Properties)
 
 		expectedParams := []string{
-			"namespaces", "type", "rateInterval", "queryTime",
+			"namespaces", "type", "rateInterval", "queryTime", "partial",
 		}
 
 		for _, param := range expectedParams {
@@ -512,8 +590,12 @@ func TestHealthToolDefinition(t *testing.T) {
 		require.NotNil(t, schema)
 
 		for name, prop := range schema.Properties {
-			assert.Equal(t, "string", prop.Type,
-				"Parameter %s should be of type string", name)
+			expectedType := "string"
+			if name == "partial" {
+				expectedType = "boolean"
+			}
+			assert.Equal(t, expectedType, prop.Type,
+				"Parameter %s should be of type %s", name, expectedType)
 		}
 	})
 
@@ -690,3 +772,509 @@ func TestHealthRealWorldScenarios(t *testing.T) {
 		assert.Equal(t, "workload", capturedURL.Query().Get("type"))
 	})
 }
+
+// TestUnhealthyApps_KialiClient tests the Kiali client UnhealthyApps method
+func TestUnhealthyApps_KialiClient(t *testing.T) {
+	var capturedURL *url.URL
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		capturedURL = r.URL
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+  "namespaceAppHealth": {
+    "bookinfo": {
+      "productpage": {
+        "workloadStatuses": [{"name": "productpage-v1", "desiredReplicas": 1, "currentReplicas": 1, "availableReplicas": 1, "syncedProxies": 1}],
+        "requests": {"inbound": {"http": {"200": 9.5, "500": 0.5}}, "outbound": {}}
+      },
+      "reviews": {
+        "workloadStatuses": [
+          {"name": "reviews-v1", "desiredReplicas": 1, "currentReplicas": 1, "availableReplicas": 1, "syncedProxies": 1},
+          {"name": "reviews-v2", "desiredReplicas": 2, "currentReplicas": 2, "availableReplicas": 0, "syncedProxies": 0}
+        ],
+        "requests": {"inbound": {}, "outbound": {}}
+      },
+      "ratings": {
+        "workloadStatuses": [{"name": "ratings-v1", "desiredReplicas": 1, "currentReplicas": 1, "availableReplicas": 1, "syncedProxies": -1}],
+        "requests": {"inbound": {"http": {"200": 10, "404": 0.5}, "grpc": {"0": 5}}, "outbound": {}}
+      }
+    },
+    "default": {
+      "sleep": {
+        "workloadStatuses": [{"name": "sleep", "desiredReplicas": 0, "currentReplicas": 0, "availableReplicas": 0}],
+        "requests": {}
+      }
+    }
+  }
+}`))
+	}))
+	defer mockServer.Close()
+
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+	result, err := kialiClient.UnhealthyApps(context.Background(), "bookinfo,default", "5m")
+	require.NoError(t, err)
+
+	require.NotNil(t, capturedURL)
+	assert.Equal(t, "app", capturedURL.Query().Get("type"))
+	assert.Equal(t, "5m", capturedURL.Query().Get("rateInterval"))
+	assert.Equal(t, "bookinfo,default", capturedURL.Query().Get("namespaces"))
+
+	var apps []internalkiali.EntityHealth
+	require.NoError(t, json.Unmarshal([]byte(result), &apps))
+	require.Len(t, apps, 2)
+
+	assert.Equal(t, "reviews", apps[0].Name)
+	assert.Equal(t, "bookinfo", apps[0].Namespace)
+	assert.Equal(t, internalkiali.HealthStatusUnhealthy, apps[0].Status)
+	assert.Equal(t, []string{"workload reviews-v2 has no available replicas (0/2)"}, apps[0].Issues)
+
+	assert.Equal(t, "productpage", apps[1].Name)
+	assert.Equal(t, internalkiali.HealthStatusDegraded, apps[1].Status)
+	assert.Equal(t, []string{"5.00% HTTP 5xx errors on inbound requests"}, apps[1].Issues)
+}
+
+// clustersHealthResponses are the health responses returned by the mock Kiali server, per health type
+var clustersHealthResponses = map[string]string{
+	"app": `{"namespaceAppHealth": {"bookinfo": {
+		"productpage": {"workloadStatuses": [{"name": "productpage-v1", "desiredReplicas": 1, "currentReplicas": 1, "availableReplicas": 1, "syncedProxies": 1}], "requests": {"inbound": {"http": {"200": 10}}}},
+		"reviews": {"workloadStatuses": [{"name": "reviews-v1", "desiredReplicas": 2, "currentReplicas": 2, "availableReplicas": 1, "syncedProxies": 1}], "requests": {}}
+	}}}`,
+	"service": `{"namespaceServiceHealth": {"bookinfo": {
+		"productpage": {"requests": {"inbound": {"http": {"200": 9, "503": 1}}}},
+		"reviews": {"requests": {"inbound": {"http": {"200": 10}}}}
+	}, "default": {
+		"sleep": {"requests": {}}
+	}}}`,
+	"workload": `{"namespaceWorkloadHealth": {"bookinfo": {
+		"productpage-v1": {"workloadStatus": {"name": "productpage-v1", "desiredReplicas": 1, "currentReplicas": 1, "availableReplicas": 1, "syncedProxies": 1}, "requests": {}},
+		"reviews-v1": {"workloadStatus": {"name": "reviews-v1", "desiredReplicas": 2, "currentReplicas": 2, "availableReplicas": 1, "syncedProxies": 1}, "requests": {}},
+		"details-v1": {"workloadStatus": {"name": "details-v1", "desiredReplicas": 0, "currentReplicas": 0, "availableReplicas": 0}, "requests": {}}
+	}}}`,
+}
+
+// TestEntityHealth_KialiClient tests the Kiali client EntityHealthStatus method
+func TestReplicaShortfalls_KialiClient(t *testing.T) {
+	var capturedURL *url.URL
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		capturedURL = r.URL
+		_, _ = w.Write([]byte(`{"namespaceWorkloadHealth": {
+			"bookinfo": {
+				"reviews-v1": {"workloadStatus": {"name": "reviews-v1", "desiredReplicas": 3, "currentReplicas": 3, "availableReplicas": 3, "syncedProxies": 3}, "requests": {}},
+				"reviews-v2": {"workloadStatus": {"name": "reviews-v2", "desiredReplicas": 3, "currentReplicas": 4, "availableReplicas": 2, "syncedProxies": 2}, "requests": {}},
+				"ratings-v1": {"workloadStatus": {"name": "ratings-v1", "desiredReplicas": 2, "currentReplicas": 2, "availableReplicas": 0, "syncedProxies": -1}, "requests": {}}
+			},
+			"default": {
+				"sleep": {"workloadStatus": {"name": "sleep", "desiredReplicas": 0, "currentReplicas": 0, "availableReplicas": 0}, "requests": {}},
+				"httpbin": {"workloadStatus": {"name": "httpbin", "desiredReplicas": 1, "currentReplicas": 1, "availableReplicas": 0}, "requests": {}}
+			}
+		}}`))
+	}))
+	defer mockServer.Close()
+
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+	result, err := kialiClient.ReplicaShortfalls(context.Background(), "bookinfo,default")
+	require.NoError(t, err)
+	require.NotNil(t, capturedURL)
+	assert.Equal(t, "workload", capturedURL.Query().Get("type"))
+	assert.Equal(t, "bookinfo,default", capturedURL.Query().Get("namespaces"))
+
+	var shortfalls []internalkiali.ReplicaShortfall
+	require.NoError(t, json.Unmarshal([]byte(result), &shortfalls))
+	assert.Equal(t, []internalkiali.ReplicaShortfall{
+		{Namespace: "bookinfo", Name: "ratings-v1", Desired: 2, Current: 2, Available: 0, Status: internalkiali.HealthStatusUnhealthy, Issue: "workload ratings-v1 has no available replicas (0/2)"},
+		{Namespace: "bookinfo", Name: "reviews-v2", Desired: 3, Current: 4, Available: 2, SyncedProxies: ptr.To(int32(2)), Status: internalkiali.HealthStatusDegraded, Issue: "workload reviews-v2 has 2/3 replicas available"},
+		{Namespace: "default", Name: "httpbin", Desired: 1, Current: 1, Available: 0, Status: internalkiali.HealthStatusUnhealthy, Issue: "workload httpbin has no available replicas (0/1)"},
+	}, shortfalls)
+}
+
+func TestEntityHealth_KialiClient(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/config" {
+			// No health configuration, the default tolerances apply
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		assert.Equal(t, "/api/clusters/health", r.URL.Path)
+		assert.Equal(t, "bookinfo", r.URL.Query().Get("namespaces"))
+		_, _ = w.Write([]byte(clustersHealthResponses[r.URL.Query().Get("type")]))
+	}))
+	defer mockServer.Close()
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+
+	t.Run("app sums the replicas of its workloads", func(t *testing.T) {
+		result, err := kialiClient.EntityHealthStatus(context.Background(), "app", "bookinfo", "reviews", "")
+		require.NoError(t, err)
+		var report internalkiali.EntityHealthReport
+		require.NoError(t, json.Unmarshal([]byte(result), &report))
+		assert.Equal(t, internalkiali.HealthStatusDegraded, report.Status)
+		assert.Contains(t, report.Issue, "reviews-v1")
+		assert.Nil(t, report.ErrorRate)
+		assert.Equal(t, &internalkiali.ReplicaCounts{Desired: 2, Current: 2, Available: 1}, report.Replicas)
+	})
+
+	t.Run("service reports its error rate", func(t *testing.T) {
+		result, err := kialiClient.EntityHealthStatus(context.Background(), "service", "bookinfo", "productpage", "")
+		require.NoError(t, err)
+		var report internalkiali.EntityHealthReport
+		require.NoError(t, json.Unmarshal([]byte(result), &report))
+		assert.Equal(t, "service", report.Kind)
+		assert.Equal(t, internalkiali.HealthStatusUnhealthy, report.Status)
+		require.NotNil(t, report.ErrorRate)
+		assert.Equal(t, 10.0, *report.ErrorRate)
+		assert.Nil(t, report.Replicas)
+	})
+
+	t.Run("healthy workload", func(t *testing.T) {
+		result, err := kialiClient.EntityHealthStatus(context.Background(), "workload", "bookinfo", "productpage-v1", "")
+		require.NoError(t, err)
+		var report internalkiali.EntityHealthReport
+		require.NoError(t, json.Unmarshal([]byte(result), &report))
+		assert.Equal(t, internalkiali.HealthStatusHealthy, report.Status)
+		assert.Empty(t, report.Issue)
+		assert.Equal(t, &internalkiali.ReplicaCounts{Desired: 1, Current: 1, Available: 1}, report.Replicas)
+	})
+
+	t.Run("unknown entity", func(t *testing.T) {
+		_, err := kialiClient.EntityHealthStatus(context.Background(), "workload", "bookinfo", "ratings-v1", "")
+		require.Error(t, err)
+		assert.True(t, internalkiali.IsNotFound(err))
+	})
+
+	t.Run("invalid kind", func(t *testing.T) {
+		_, err := kialiClient.EntityHealthStatus(context.Background(), "pod", "bookinfo", "productpage", "")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid kind")
+	})
+}
+
+// TestEntityHealth_KialiClient_Tolerances tests that the tolerances configured in Kiali and the
+// health.kiali.io/rate annotations replace the default tolerances
+func TestEntityHealth_KialiClient_Tolerances(t *testing.T) {
+	healthConfig := `{"healthConfig": {"rate": [
+		{"namespace": "bookinfo", "kind": "service", "name": "product.*", "tolerance": [{"code": "5XX", "degraded": 5, "failure": 20, "protocol": "http", "direction": ".*"}]},
+		{"namespace": ".*", "kind": ".*", "name": ".*", "tolerance": [{"code": "5XX", "degraded": 0, "failure": 10, "protocol": "http", "direction": ".*"}]}
+	]}}`
+	serviceHealth := `{"namespaceServiceHealth": {"bookinfo": {
+		"productpage": {"requests": {"inbound": {"http": {"200": 9, "503": 1}}}},
+		"reviews": {"requests": {"inbound": {"http": {"200": 9, "503": 1}}, "healthAnnotations": {"health.kiali.io/rate": "5XX,20,30,http,inbound"}}},
+		"ratings": {"requests": {"inbound": {"http": {"200": 9, "404": 1}}}}
+	}}}`
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/config":
+			_, _ = w.Write([]byte(healthConfig))
+		case "/api/clusters/health":
+			_, _ = w.Write([]byte(serviceHealth))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockServer.Close()
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+
+	tests := []struct {
+		name     string
+		service  string
+		expected internalkiali.HealthStatus
+	}{
+		{name: "configured tolerance of the first matching rate", service: "productpage", expected: internalkiali.HealthStatusDegraded},
+		{name: "annotation overrides the configured tolerances", service: "reviews", expected: internalkiali.HealthStatusHealthy},
+		{name: "codes without configured tolerance are not errors", service: "ratings", expected: internalkiali.HealthStatusHealthy},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := kialiClient.EntityHealthStatus(context.Background(), "service", "bookinfo", tt.service, "")
+			require.NoError(t, err)
+			var report internalkiali.EntityHealthReport
+			require.NoError(t, json.Unmarshal([]byte(result), &report))
+			assert.Equal(t, tt.expected, report.Status)
+		})
+	}
+}
+
+// TestMeshHealthSummary_KialiClient tests the Kiali client MeshHealthSummary method
+func TestMeshHealthSummary_KialiClient(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/config" {
+			// No health configuration, the default tolerances apply
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		assert.Equal(t, "/api/clusters/health", r.URL.Path)
+		assert.Equal(t, "bookinfo,default", r.URL.Query().Get("namespaces"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(clustersHealthResponses[r.URL.Query().Get("type")]))
+	}))
+	defer mockServer.Close()
+
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+	result, err := kialiClient.MeshHealthSummary(context.Background(), "bookinfo,default", "", false, nil, false)
+	require.NoError(t, err)
+
+	var summary internalkiali.MeshHealthSummary
+	require.NoError(t, json.Unmarshal([]byte(result), &summary))
+
+	assert.Equal(t, internalkiali.HealthStatusUnhealthy, summary.OverallStatus)
+	assert.Nil(t, summary.ImpactedTraffic)
+	assert.Equal(t, &internalkiali.EntityCounts{Total: 2, Healthy: 1, Degraded: 1}, summary.Entities["app"])
+	assert.Equal(t, &internalkiali.EntityCounts{Total: 3, Healthy: 1, Unhealthy: 1, NA: 1}, summary.Entities["service"])
+	assert.Equal(t, &internalkiali.EntityCounts{Total: 3, Healthy: 1, Degraded: 1, NotReady: 1}, summary.Entities["workload"])
+	// 3 healthy out of 6 entities with health data
+	assert.Equal(t, 50.0, summary.Availability)
+	// 1 failed out of 20 inbound service requests (10% for productpage alone, hence UNHEALTHY)
+	assert.Equal(t, 5.0, summary.ErrorRate)
+
+	require.Contains(t, summary.Namespaces, "default")
+	assert.Equal(t, internalkiali.HealthStatusNA, summary.Namespaces["default"].Status)
+	assert.Equal(t, internalkiali.HealthStatusUnhealthy, summary.Namespaces["bookinfo"].Status)
+
+	names := make([]string, 0)
+	for _, entity := range summary.Unhealthy {
+		names = append(names, entity.Type+"/"+entity.Name)
+	}
+	assert.Equal(t, []string{"service/productpage", "app/reviews", "workload/reviews-v1"}, names)
+	assert.Nil(t, summary.Raw, "raw responses are only attached on request")
+
+	t.Run("include raw", func(t *testing.T) {
+		result, err := kialiClient.MeshHealthSummary(context.Background(), "bookinfo,default", "", false, nil, true)
+		require.NoError(t, err)
+
+		var summary internalkiali.MeshHealthSummary
+		require.NoError(t, json.Unmarshal([]byte(result), &summary))
+		require.Len(t, summary.Raw, 3)
+		for _, healthType := range internalkiali.HealthTypes {
+			assert.JSONEq(t, clustersHealthResponses[healthType], string(summary.Raw[healthType]))
+		}
+		assert.Equal(t, 5.0, summary.ErrorRate)
+	})
+}
+
+func TestHealthConfigCache_KialiClient(t *testing.T) {
+	var configRequests atomic.Int32
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/config" {
+			configRequests.Add(1)
+			_, _ = w.Write([]byte(`{"healthConfig": {"rate": [{"tolerance": [{"code": "5XX", "degraded": 0, "failure": 10}]}]}}`))
+			return
+		}
+		_, _ = w.Write([]byte(clustersHealthResponses[r.URL.Query().Get("type")]))
+	}))
+	defer mockServer.Close()
+
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+	for i := 0; i < 2; i++ {
+		_, err := kialiClient.MeshHealthSummary(context.Background(), "bookinfo,default", "", false, nil, false)
+		require.NoError(t, err)
+	}
+	_, err := kialiClient.EntityHealthStatus(context.Background(), "service", "bookinfo", "productpage", "")
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), configRequests.Load(), "the health configuration is fetched once and shared by the health types and calls")
+}
+
+func TestMeshHealthSummary_KialiClient_Types(t *testing.T) {
+	var requestedTypes []string
+	var mu sync.Mutex
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/config" {
+			// No health configuration, the default tolerances apply
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		mu.Lock()
+		requestedTypes = append(requestedTypes, r.URL.Query().Get("type"))
+		mu.Unlock()
+		_, _ = w.Write([]byte(clustersHealthResponses[r.URL.Query().Get("type")]))
+	}))
+	defer mockServer.Close()
+
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+	result, err := kialiClient.MeshHealthSummary(context.Background(), "bookinfo", "", false, []string{"app"}, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"app"}, requestedTypes)
+
+	var summary internalkiali.MeshHealthSummary
+	require.NoError(t, json.Unmarshal([]byte(result), &summary))
+	assert.Equal(t, []string{"app"}, summary.Types)
+	// Only the degraded app counts, not the unhealthy service
+	assert.Equal(t, internalkiali.HealthStatusDegraded, summary.OverallStatus)
+	assert.Len(t, summary.Entities, 1)
+	assert.Contains(t, summary.Entities, "app")
+	assert.Equal(t, 50.0, summary.Availability)
+	assert.Equal(t, 0.0, summary.ErrorRate)
+	require.Len(t, summary.Unhealthy, 1)
+	assert.Equal(t, "reviews", summary.Unhealthy[0].Name)
+
+	t.Run("invalid type", func(t *testing.T) {
+		_, err := kialiClient.MeshHealthSummary(context.Background(), "bookinfo", "", false, []string{"app", "pod"}, false)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid health type 'pod'")
+	})
+}
+
+func TestMeshHealthSummary_KialiClient_Concurrency(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/clusters/health" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			observed := maxInFlight.Load()
+			if current <= observed || maxInFlight.CompareAndSwap(observed, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		_, _ = w.Write([]byte(clustersHealthResponses[r.URL.Query().Get("type")]))
+	}))
+	defer mockServer.Close()
+
+	tests := []struct {
+		name        string
+		concurrency int
+		expectedMax int32
+	}{
+		{name: "configured limit", concurrency: 1, expectedMax: 1},
+		{name: "default limit", concurrency: 0, expectedMax: internalkiali.DefaultHealthConcurrency},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			maxInFlight.Store(0)
+			kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL, KialiHealthConcurrency: tt.concurrency})
+			// Two concurrent summaries share the limit of the client
+			var wg sync.WaitGroup
+			for range 2 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					_, err := kialiClient.MeshHealthSummary(context.Background(), "bookinfo", "", false, nil, false)
+					assert.NoError(t, err)
+				}()
+			}
+			wg.Wait()
+			assert.LessOrEqual(t, maxInFlight.Load(), tt.expectedMax)
+		})
+	}
+}
+
+func TestMeshHealthSummary_KialiClient_TrafficWeighted(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(clustersHealthResponses[r.URL.Query().Get("type")]))
+	}))
+	defer mockServer.Close()
+
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+	result, err := kialiClient.MeshHealthSummary(context.Background(), "bookinfo,default", "", true, nil, false)
+	require.NoError(t, err)
+
+	var summary internalkiali.MeshHealthSummary
+	require.NoError(t, json.Unmarshal([]byte(result), &summary))
+	// The productpage service serves 10 of the 30 inbound requests of the entities with traffic, the degraded
+	// reviews app and workload have no traffic
+	assert.Equal(t, internalkiali.HealthStatusUnhealthy, summary.OverallStatus)
+	require.NotNil(t, summary.ImpactedTraffic)
+	assert.Equal(t, 33.33, *summary.ImpactedTraffic)
+	// The entity counts are not weighted
+	assert.Equal(t, 50.0, summary.Availability)
+}
+
+// TestMeshHealthMetrics_KialiClient tests the Kiali client MeshHealthMetrics method
+func TestMeshHealthMetrics_KialiClient(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(clustersHealthResponses[r.URL.Query().Get("type")]))
+	}))
+	defer mockServer.Close()
+
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+	result, err := kialiClient.MeshHealthMetrics(context.Background(), "bookinfo,default", "")
+	require.NoError(t, err)
+
+	assert.Contains(t, result, "# TYPE mesh_entity_health gauge\n")
+	assert.Contains(t, result, `mesh_entity_health{type="app",namespace="bookinfo",status="HEALTHY"} 1`+"\n")
+	assert.Contains(t, result, `mesh_entity_health{type="app",namespace="bookinfo",status="DEGRADED"} 1`+"\n")
+	assert.Contains(t, result, `mesh_entity_health{type="service",namespace="bookinfo",status="UNHEALTHY"} 1`+"\n")
+	assert.Contains(t, result, `mesh_entity_health{type="service",namespace="default",status="NA"} 1`+"\n")
+	assert.Contains(t, result, `mesh_entity_health{type="service",namespace="default",status="HEALTHY"} 0`+"\n")
+	assert.Contains(t, result, `mesh_entity_health{type="workload",namespace="bookinfo",status="NOT_READY"} 1`+"\n")
+	assert.NotContains(t, result, `type="app",namespace="default"`)
+	assert.Contains(t, result, "\nmesh_availability 50\n")
+	assert.Contains(t, result, "\nmesh_error_rate 5\n")
+}
+
+// TestMeshHealthTable_KialiClient tests the Kiali client MeshHealthTable method
+func TestMeshHealthTable_KialiClient(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(clustersHealthResponses[r.URL.Query().Get("type")]))
+	}))
+	defer mockServer.Close()
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+
+	t.Run("every entity sorted by severity", func(t *testing.T) {
+		result, err := kialiClient.MeshHealthTable(context.Background(), "bookinfo,default", "", nil)
+		require.NoError(t, err)
+		var rows []internalkiali.HealthTableRow
+		require.NoError(t, json.Unmarshal([]byte(result), &rows))
+
+		entity := func(row internalkiali.HealthTableRow) string {
+			return row.Type + ":" + row.Namespace + "/" + row.Name + "=" + string(row.Status)
+		}
+		entities := make([]string, 0, len(rows))
+		for _, row := range rows {
+			entities = append(entities, entity(row))
+		}
+		assert.Equal(t, []string{
+			"service:bookinfo/productpage=UNHEALTHY",
+			"app:bookinfo/reviews=DEGRADED",
+			"workload:bookinfo/reviews-v1=DEGRADED",
+			"workload:bookinfo/details-v1=NOT_READY",
+			"app:bookinfo/productpage=HEALTHY",
+			"workload:bookinfo/productpage-v1=HEALTHY",
+			"service:bookinfo/reviews=HEALTHY",
+			"service:default/sleep=NA",
+		}, entities)
+
+		require.NotNil(t, rows[0].ErrorRate)
+		assert.Equal(t, 10.0, *rows[0].ErrorRate)
+		assert.Nil(t, rows[0].Availability, "services have no replicas")
+		require.NotNil(t, rows[1].Availability)
+		assert.Equal(t, 50.0, *rows[1].Availability)
+		assert.Nil(t, rows[1].ErrorRate, "no inbound traffic")
+		assert.Nil(t, rows[3].Availability, "scaled down workloads have no desired replicas")
+		require.NotNil(t, rows[4].Availability)
+		assert.Equal(t, 100.0, *rows[4].Availability)
+		// The columns are always present so that clients can render a stable table
+		assert.Contains(t, result, `"errorRate": null`)
+		assert.Contains(t, result, `"availability": null`)
+	})
+
+	t.Run("types", func(t *testing.T) {
+		result, err := kialiClient.MeshHealthTable(context.Background(), "bookinfo", "", []string{"service"})
+		require.NoError(t, err)
+		var rows []internalkiali.HealthTableRow
+		require.NoError(t, json.Unmarshal([]byte(result), &rows))
+		for _, row := range rows {
+			assert.Equal(t, "service", row.Type)
+		}
+	})
+
+	t.Run("invalid type", func(t *testing.T) {
+		_, err := kialiClient.MeshHealthTable(context.Background(), "bookinfo", "", []string{"pod"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid health type 'pod'")
+	})
+}
+
+func TestInitHealthSummary(t *testing.T) {
+	tools := initHealthSummary()
+	require.Len(t, tools, 3)
+	assert.Equal(t, "mesh_health_summary", tools[0].Tool.Name)
+	assert.Equal(t, "mesh_health_metrics", tools[1].Tool.Name)
+	assert.Equal(t, "mesh_health_table", tools[2].Tool.Name)
+	for _, tool := range tools {
+		assert.NotZero(t, tool.Timeout, "aggregation tool %s should define a default timeout", tool.Tool.Name)
+	}
+}
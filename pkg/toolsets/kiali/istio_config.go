@@ -7,6 +7,7 @@ import (
 	"k8s.io/utils/ptr"
 
 	"github.com/kiali/kiali-mcp-server/pkg/api"
+	internalkiali "github.com/kiali/kiali-mcp-server/pkg/kiali"
 )
 
 func initIstioConfig() []api.ServerTool {
@@ -69,6 +70,10 @@ func initIstioObjectDetails() []api.ServerTool {
 						Type:        "string",
 						Description: "Name of the Istio object",
 					},
+					"clusterName": {
+						Type:        "string",
+						Description: "Optional cluster name of the Istio object in multi-cluster meshes (e.g., 'east'). Default: the Kiali home cluster",
+					},
 				},
 				Required: []string{"namespace", "group", "version", "kind", "name"},
 			},
@@ -91,8 +96,9 @@ func istioObjectDetailsHandler(params api.ToolHandlerParams) (*api.ToolCallResul
 	version, _ := params.GetArguments()["version"].(string)
 	kind, _ := params.GetArguments()["kind"].(string)
 	name, _ := params.GetArguments()["name"].(string)
+	clusterName, _ := params.GetArguments()["clusterName"].(string)
 
-	content, err := params.IstioObjectDetails(params.Context, namespace, group, version, kind, name)
+	content, err := params.IstioObjectDetails(params.Context, namespace, group, version, kind, name, clusterName)
 	if err != nil {
 		return api.NewToolCallResult("", fmt.Errorf("failed to retrieve Istio object details: %v", err)), nil
 	}
@@ -132,6 +138,18 @@ func initIstioObjectPatch() []api.ServerTool {
 						Type:        "string",
 						Description: "JSON patch data to apply to the object",
 					},
+					"skip_gvk_validation": {
+						Type:        "boolean",
+						Description: "Skip the validation of the group, version and kind against the known Istio and Gateway API resources, for CRDs not supported yet (default: false)",
+					},
+					"clusterName": {
+						Type:        "string",
+						Description: "Optional cluster name of the Istio object in multi-cluster meshes (e.g., 'east'). Default: the Kiali home cluster",
+					},
+					"diff_only": {
+						Type:        "boolean",
+						Description: "Return only the changes the patch made to the spec, labels and annotations (path, before and after values) instead of the whole patched object (default: false). The object is read before being patched",
+					},
 				},
 				Required: []string{"namespace", "group", "version", "kind", "name", "json_patch"},
 			},
@@ -155,8 +173,19 @@ func istioObjectPatchHandler(params api.ToolHandlerParams) (*api.ToolCallResult,
 	kind, _ := params.GetArguments()["kind"].(string)
 	name, _ := params.GetArguments()["name"].(string)
 	jsonPatch, _ := params.GetArguments()["json_patch"].(string)
+	clusterName, _ := params.GetArguments()["clusterName"].(string)
 
-	content, err := params.IstioObjectPatch(params.Context, namespace, group, version, kind, name, jsonPatch)
+	if skip, _ := params.GetArguments()["skip_gvk_validation"].(bool); !skip {
+		if err := internalkiali.ValidateIstioGVK(group, version, kind); err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to patch Istio object: %v (set skip_gvk_validation to true for other CRDs)", err)), nil
+		}
+	}
+
+	patch := params.IstioObjectPatch
+	if diffOnly, _ := params.GetArguments()["diff_only"].(bool); diffOnly {
+		patch = params.IstioObjectPatchDiff
+	}
+	content, err := patch(params.Context, namespace, group, version, kind, name, jsonPatch, clusterName)
 	if err != nil {
 		return api.NewToolCallResult("", fmt.Errorf("failed to patch Istio object: %v", err)), nil
 	}
@@ -168,7 +197,7 @@ func initIstioObjectCreate() []api.ServerTool {
 	ret = append(ret, api.ServerTool{
 		Tool: api.Tool{
 			Name:        "istio_object_create",
-			Description: "Create a new Istio object using POST method. The JSON data will be used to create the new object.",
+			Description: "Create a new Istio object using POST method. The JSON data will be used to create the new object. Give the object a deterministic metadata.name: if the request times out, the server checks whether the object exists and reports whether retrying is safe.",
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
@@ -190,7 +219,15 @@ func initIstioObjectCreate() []api.ServerTool {
 					},
 					"json_data": {
 						Type:        "string",
-						Description: "JSON data for the new object",
+						Description: "JSON data for the new object, including its metadata.name",
+					},
+					"skip_gvk_validation": {
+						Type:        "boolean",
+						Description: "Skip the validation of the group, version and kind against the known Istio and Gateway API resources, for CRDs not supported yet (default: false)",
+					},
+					"clusterName": {
+						Type:        "string",
+						Description: "Optional cluster name of the Istio object in multi-cluster meshes (e.g., 'east'). Default: the Kiali home cluster",
 					},
 				},
 				Required: []string{"namespace", "group", "version", "kind", "json_data"},
@@ -214,8 +251,15 @@ func istioObjectCreateHandler(params api.ToolHandlerParams) (*api.ToolCallResult
 	version, _ := params.GetArguments()["version"].(string)
 	kind, _ := params.GetArguments()["kind"].(string)
 	jsonData, _ := params.GetArguments()["json_data"].(string)
+	clusterName, _ := params.GetArguments()["clusterName"].(string)
+
+	if skip, _ := params.GetArguments()["skip_gvk_validation"].(bool); !skip {
+		if err := internalkiali.ValidateIstioGVK(group, version, kind); err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to create Istio object: %v (set skip_gvk_validation to true for other CRDs)", err)), nil
+		}
+	}
 
-	content, err := params.IstioObjectCreate(params.Context, namespace, group, version, kind, jsonData)
+	content, err := params.IstioObjectCreate(params.Context, namespace, group, version, kind, jsonData, clusterName)
 	if err != nil {
 		return api.NewToolCallResult("", fmt.Errorf("failed to create Istio object: %v", err)), nil
 	}
@@ -251,6 +295,14 @@ func initIstioObjectDelete() []api.ServerTool {
 						Type:        "string",
 						Description: "Name of the Istio object",
 					},
+					"skip_gvk_validation": {
+						Type:        "boolean",
+						Description: "Skip the validation of the group, version and kind against the known Istio and Gateway API resources, for CRDs not supported yet (default: false)",
+					},
+					"clusterName": {
+						Type:        "string",
+						Description: "Optional cluster name of the Istio object in multi-cluster meshes (e.g., 'east'). Default: the Kiali home cluster",
+					},
 				},
 				Required: []string{"namespace", "group", "version", "kind", "name"},
 			},
@@ -273,11 +325,485 @@ func istioObjectDeleteHandler(params api.ToolHandlerParams) (*api.ToolCallResult
 	version, _ := params.GetArguments()["version"].(string)
 	kind, _ := params.GetArguments()["kind"].(string)
 	name, _ := params.GetArguments()["name"].(string)
+	clusterName, _ := params.GetArguments()["clusterName"].(string)
+
+	if skip, _ := params.GetArguments()["skip_gvk_validation"].(bool); !skip {
+		if err := internalkiali.ValidateIstioGVK(group, version, kind); err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to delete Istio object: %v (set skip_gvk_validation to true for other CRDs)", err)), nil
+		}
+	}
 
-	content, err := params.IstioObjectDelete(params.Context, namespace, group, version, kind, name)
+	content, err := params.IstioObjectDelete(params.Context, namespace, group, version, kind, name, clusterName)
 	if err != nil {
 		return api.NewToolCallResult("", fmt.Errorf("failed to delete Istio object: %v", err)), nil
 	}
 
 	return api.NewToolCallResult(content, nil), nil
 }
+
+func initIstioObjectConflicts() []api.ServerTool {
+	ret := make([]api.ServerTool, 0)
+	ret = append(ret, api.ServerTool{
+		Tool: api.Tool{
+			Name:        "istio_object_conflicts",
+			Description: "Check a proposed Istio object against the existing Istio configuration before creating it. Detects objects with the same name, VirtualServices claiming overlapping hosts for the same gateways, DestinationRules for the same host, Gateways exposing the same host and port, and policies or Sidecars sharing the same workload selector. Returns the list of potential conflicts (empty if none).",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace where the Istio object would be created",
+					},
+					"group": {
+						Type:        "string",
+						Description: "API group of the Istio object (e.g., 'networking.istio.io', 'gateway.networking.k8s.io')",
+					},
+					"version": {
+						Type:        "string",
+						Description: "API version of the Istio object (e.g., 'v1', 'v1beta1')",
+					},
+					"kind": {
+						Type:        "string",
+						Description: "Kind of the Istio object (e.g., 'DestinationRule', 'VirtualService', 'HTTPRoute', 'Gateway')",
+					},
+					"json_data": {
+						Type:        "string",
+						Description: "JSON data for the proposed object (same format as istio_object_create)",
+					},
+				},
+				Required: []string{"namespace", "group", "version", "kind", "json_data"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Istio Object: Check Conflicts",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: istioObjectConflictsHandler,
+	})
+	return ret
+}
+
+func istioObjectConflictsHandler(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	// Extract required parameters
+	namespace, _ := params.GetArguments()["namespace"].(string)
+	group, _ := params.GetArguments()["group"].(string)
+	version, _ := params.GetArguments()["version"].(string)
+	kind, _ := params.GetArguments()["kind"].(string)
+	jsonData, _ := params.GetArguments()["json_data"].(string)
+
+	content, err := params.IstioObjectConflicts(params.Context, namespace, group, version, kind, jsonData)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to check Istio object conflicts: %v", err)), nil
+	}
+	return api.NewToolCallResult(content, nil), nil
+}
+
+func initIstioObjectValidate() []api.ServerTool {
+	ret := make([]api.ServerTool, 0)
+	ret = append(ret, api.ServerTool{
+		Tool: api.Tool{
+			Name:        "istio_object_validate",
+			Description: "Validate the manifest of an Istio object locally, without contacting the cluster, as a cheap pre-flight check before istio_object_create: the apiVersion and kind must be a known Istio or Gateway API combination, metadata.name must be a valid name, the fields required by the kind must be set (e.g. the hosts and routes of a VirtualService, the host of a DestinationRule, the servers of a Gateway) and the workload selector must have labels. Returns whether the object is valid and the list of issues, with their severity (error or warning) and path. Only the structure is checked: the references to other objects are not",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"document": {
+						Type:        "string",
+						Description: "YAML or JSON manifest of a single Istio object, including its apiVersion, kind, metadata and spec",
+					},
+				},
+				Required: []string{"document"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Istio Object: Validate",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(false),
+			},
+		}, Handler: istioObjectValidateHandler,
+	})
+	return ret
+}
+
+func istioObjectValidateHandler(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	document, _ := params.GetArguments()["document"].(string)
+	if document == "" {
+		return api.NewToolCallResult("", fmt.Errorf("document parameter is required")), nil
+	}
+
+	content, err := internalkiali.ValidateIstioManifest(document)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to validate Istio object: %v", err)), nil
+	}
+	return api.NewToolCallResult(content, nil), nil
+}
+
+func initIstioObjectsDiff() []api.ServerTool {
+	ret := make([]api.ServerTool, 0)
+	ret = append(ret, api.ServerTool{
+		Tool: api.Tool{
+			Name:        "istio_objects_diff",
+			Description: "Compare the specs of two Istio objects, e.g. the same VirtualService in two namespaces or two clusters, to detect configuration drift between environments. The second object defaults to the namespace, group, version, kind, name and cluster of the first one, so only what differs must be given (e.g. otherNamespace or otherClusterName). Returns whether the specs are identical and the changed values (path, value in the first object as 'before' and in the second one as 'after'). Labels and annotations are not compared",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace containing the first Istio object",
+					},
+					"group": {
+						Type:        "string",
+						Description: "API group of the first Istio object (e.g., 'networking.istio.io', 'gateway.networking.k8s.io')",
+					},
+					"version": {
+						Type:        "string",
+						Description: "API version of the first Istio object (e.g., 'v1', 'v1beta1')",
+					},
+					"kind": {
+						Type:        "string",
+						Description: "Kind of the first Istio object (e.g., 'DestinationRule', 'VirtualService', 'HTTPRoute', 'Gateway')",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the first Istio object",
+					},
+					"clusterName": {
+						Type:        "string",
+						Description: "Optional cluster name of the first Istio object in multi-cluster meshes (e.g., 'east'). Default: the Kiali home cluster",
+					},
+					"otherNamespace": {
+						Type:        "string",
+						Description: "Optional namespace containing the second Istio object. Default: namespace",
+					},
+					"otherGroup": {
+						Type:        "string",
+						Description: "Optional API group of the second Istio object. Default: group",
+					},
+					"otherVersion": {
+						Type:        "string",
+						Description: "Optional API version of the second Istio object. Default: version",
+					},
+					"otherKind": {
+						Type:        "string",
+						Description: "Optional kind of the second Istio object. Default: kind",
+					},
+					"otherName": {
+						Type:        "string",
+						Description: "Optional name of the second Istio object. Default: name",
+					},
+					"otherClusterName": {
+						Type:        "string",
+						Description: "Optional cluster name of the second Istio object in multi-cluster meshes (e.g., 'west'). Default: clusterName",
+					},
+				},
+				Required: []string{"namespace", "group", "version", "kind", "name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Istio Objects: Diff",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: istioObjectsDiffHandler,
+	})
+	return ret
+}
+
+func istioObjectsDiffHandler(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	// Extract the first object, the second one defaults to it
+	first := internalkiali.IstioObjectLocation{}
+	for _, field := range []struct {
+		name  string
+		value *string
+	}{
+		{"namespace", &first.Namespace},
+		{"group", &first.Group},
+		{"version", &first.Version},
+		{"kind", &first.Kind},
+		{"name", &first.Name},
+	} {
+		*field.value, _ = params.GetArguments()[field.name].(string)
+		if *field.value == "" {
+			return api.NewToolCallResult("", fmt.Errorf("%s parameter is required", field.name)), nil
+		}
+	}
+	first.Cluster, _ = params.GetArguments()["clusterName"].(string)
+
+	second := first
+	for name, value := range map[string]*string{
+		"otherNamespace":   &second.Namespace,
+		"otherGroup":       &second.Group,
+		"otherVersion":     &second.Version,
+		"otherKind":        &second.Kind,
+		"otherName":        &second.Name,
+		"otherClusterName": &second.Cluster,
+	} {
+		if v, _ := params.GetArguments()[name].(string); v != "" {
+			*value = v
+		}
+	}
+
+	content, err := params.IstioObjectsDiff(params.Context, first, second)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to diff Istio objects: %v", err)), nil
+	}
+	return api.NewToolCallResult(content, nil), nil
+}
+
+func initAuthorizationPolicies() []api.ServerTool {
+	ret := make([]api.ServerTool, 0)
+	ret = append(ret, api.ServerTool{
+		Tool: api.Tool{
+			Name:        "authz_policies",
+			Description: "Summarize the AuthorizationPolicies of a namespace for security reviews: for each policy its action (ALLOW, DENY, AUDIT, CUSTOM), workload selector and rules, each rule summarized as its from (sources), to (operations) and when (conditions) clauses",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace to summarize the AuthorizationPolicies for. Note that policies in the Istio root namespace (usually istio-system) apply to the whole mesh",
+					},
+				},
+				Required: []string{"namespace"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Istio Config: Authorization Policies",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: authorizationPoliciesHandler,
+	})
+	return ret
+}
+
+func authorizationPoliciesHandler(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	namespace, _ := params.GetArguments()["namespace"].(string)
+	if namespace == "" {
+		return api.NewToolCallResult("", fmt.Errorf("namespace parameter is required")), nil
+	}
+
+	content, err := params.AuthorizationPolicies(params.Context, namespace)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to retrieve authorization policies: %v", err)), nil
+	}
+	return api.NewToolCallResult(content, nil), nil
+}
+
+func initPeerAuthentications() []api.ServerTool {
+	ret := make([]api.ServerTool, 0)
+	ret = append(ret, api.ServerTool{
+		Tool: api.Tool{
+			Name:        "peer_authentications",
+			Description: "List the PeerAuthentication policies of the mesh and the effective mTLS mode (STRICT, PERMISSIVE or DISABLE) of each namespace, resolved from the mesh-wide policy of the root namespace and the namespace-wide policies. For each namespace, the workload-level policies overriding its mode are listed with their effective mode, port-level modes and the workloads they select. Use it as an mTLS enforcement map for security reviews",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespaces": {
+						Type:        "string",
+						Description: "Comma-separated list of namespaces to report the mTLS mode of (e.g. 'bookinfo' or 'bookinfo,default'). If not provided, will report all accessible namespaces",
+					},
+					"rootNamespace": {
+						Type:        "string",
+						Description: "The Istio root namespace, whose PeerAuthentication without selector applies to the whole mesh (default: " + internalkiali.DefaultRootNamespace + ")",
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Istio Config: Peer Authentications",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: peerAuthenticationsHandler,
+	})
+	return ret
+}
+
+func peerAuthenticationsHandler(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	namespaces, _ := params.GetArguments()["namespaces"].(string)
+	rootNamespace, _ := params.GetArguments()["rootNamespace"].(string)
+
+	content, err := params.PeerAuthentications(params.Context, namespaces, rootNamespace)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to retrieve peer authentications: %v", err)), nil
+	}
+	return api.NewToolCallResult(content, nil), nil
+}
+
+func initDestinationRules() []api.ServerTool {
+	ret := make([]api.ServerTool, 0)
+	ret = append(ret, api.ServerTool{
+		Tool: api.Tool{
+			Name:        "destination_rules_list",
+			Description: "List the DestinationRules with their host, subsets (name and labels) and traffic policies (load balancing, connection pool, outlier detection, TLS). Useful to debug canary releases and traffic splitting by correlating the subsets with VirtualService routes",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Optional namespace to list the DestinationRules of. If not provided, lists the DestinationRules of all namespaces",
+					},
+				},
+				Required: []string{},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Istio Config: Destination Rules",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: destinationRulesHandler,
+	})
+	ret = append(ret, api.ServerTool{
+		Tool: api.Tool{
+			Name:        "dangling_subsets",
+			Description: "Detect VirtualServices routing to nonexistent subsets: cross-checks the subsets of all the VirtualService route and mirror destinations against the subsets defined by the DestinationRules of their hosts, and returns the dangling references with the VirtualService namespace/name, the route, the host, the missing subset and the available subsets. Traffic routed to a dangling subset fails with 503 errors",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Optional namespace of the VirtualServices to check. If not provided, checks the VirtualServices of all namespaces",
+					},
+				},
+				Required: []string{},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Istio Config: Dangling Subsets",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: danglingSubsetsHandler,
+	})
+	return ret
+}
+
+func initHostReachability() []api.ServerTool {
+	ret := make([]api.ServerTool, 0)
+	ret = append(ret, api.ServerTool{
+		Tool: api.Tool{
+			Name:        "host_reachability",
+			Description: "Check whether a host, external (e.g. 'api.example.com') or internal, is reachable through the mesh configuration: searches the Istio config for the ServiceEntries defining the host (location, resolution mode, ports, exportTo) and the mesh VirtualServices routing it, and returns whether the host is defined, its HTTP/TLS/TCP routes and the DestinationRules applying to it, with notes explaining the reachability. Answers questions like 'can my service call api.example.com through the mesh'",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"host": {
+						Type:        "string",
+						Description: "Host called (e.g. 'api.example.com', 'reviews.bookinfo.svc.cluster.local' or 'reviews' with a namespace)",
+					},
+					"namespace": {
+						Type:        "string",
+						Description: "Optional namespace of the calling service. Short host names are resolved in it, and the ServiceEntries and VirtualServices not exported to it are reported as not visible",
+					},
+				},
+				Required: []string{"host"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Istio Config: Host Reachability",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: hostReachabilityHandler,
+	})
+	return ret
+}
+
+func hostReachabilityHandler(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	host, _ := params.GetArguments()["host"].(string)
+	namespace, _ := params.GetArguments()["namespace"].(string)
+
+	if host == "" {
+		return api.NewToolCallResult("", fmt.Errorf("host parameter is required")), nil
+	}
+
+	content, err := params.HostReachability(params.Context, host, namespace)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to check host reachability: %v", err)), nil
+	}
+	return api.NewToolCallResult(content, nil), nil
+}
+
+func initVirtualServiceGateways() []api.ServerTool {
+	ret := make([]api.ServerTool, 0)
+	ret = append(ret, api.ServerTool{
+		Tool: api.Tool{
+			Name:        "virtual_service_gateways",
+			Description: "Get the Gateways a VirtualService is bound to and their status, for ingress debugging: reads the 'gateways' field of the VirtualService, resolves each Gateway object (its selector and servers) and the ingress gateway workloads it selects, and returns the binding along with the health of those workloads. Reports missing Gateways, Gateways not exposing the hosts of the VirtualService and selectors matching no workload",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the VirtualService",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the VirtualService",
+					},
+				},
+				Required: []string{"namespace", "name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Istio Config: VirtualService Gateways",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: virtualServiceGatewaysHandler,
+	})
+	return ret
+}
+
+func virtualServiceGatewaysHandler(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	namespace, _ := params.GetArguments()["namespace"].(string)
+	name, _ := params.GetArguments()["name"].(string)
+
+	if namespace == "" {
+		return api.NewToolCallResult("", fmt.Errorf("namespace parameter is required")), nil
+	}
+	if name == "" {
+		return api.NewToolCallResult("", fmt.Errorf("name parameter is required")), nil
+	}
+
+	content, err := params.VirtualServiceGateways(params.Context, namespace, name)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get virtual service gateways: %v", err)), nil
+	}
+	return api.NewToolCallResult(content, nil), nil
+}
+
+func destinationRulesHandler(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	namespace, _ := params.GetArguments()["namespace"].(string)
+
+	content, err := params.DestinationRules(params.Context, namespace)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to retrieve destination rules: %v", err)), nil
+	}
+	if isEmptyResult(content, "") {
+		return api.NewToolCallResult(emptyListResult("DestinationRules", namespace), nil), nil
+	}
+	return api.NewToolCallResult(content, nil), nil
+}
+
+func danglingSubsetsHandler(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	namespace, _ := params.GetArguments()["namespace"].(string)
+
+	content, err := params.DanglingSubsets(params.Context, namespace)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to detect dangling subsets: %v", err)), nil
+	}
+	return api.NewToolCallResult(content, nil), nil
+}
@@ -0,0 +1,1008 @@
+package kiali
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+
+	"github.com/kiali/kiali-mcp-server/pkg/config"
+	internalkiali "github.com/kiali/kiali-mcp-server/pkg/kiali"
+)
+
+const istioConfigResponse = `{
+  "resources": {
+    "networking.istio.io/v1, Kind=VirtualService": [
+      {"metadata": {"name": "reviews", "namespace": "bookinfo"}, "spec": {"hosts": ["reviews"], "http": []}},
+      {"metadata": {"name": "ingress", "namespace": "bookinfo"}, "spec": {"hosts": ["*"], "gateways": ["bookinfo-gateway"]}}
+    ],
+    "networking.istio.io/v1, Kind=DestinationRule": [
+      {"metadata": {"name": "reviews", "namespace": "bookinfo"}, "spec": {"host": "reviews.bookinfo.svc.cluster.local"}}
+    ],
+    "security.istio.io/v1, Kind=PeerAuthentication": [
+      {"metadata": {"name": "default", "namespace": "bookinfo"}, "spec": {"mtls": {"mode": "STRICT"}}}
+    ]
+  },
+  "validations": {}
+}`
+
+func TestIstioObjectConflicts_KialiClient(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/istio/config", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(istioConfigResponse))
+	}))
+	defer mockServer.Close()
+
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+
+	tests := []struct {
+		name              string
+		kind              string
+		jsonData          string
+		expectedConflicts []string
+	}{
+		{
+			name:              "virtual service with short host overlapping an existing FQDN host",
+			kind:              "VirtualService",
+			jsonData:          `{"metadata": {"name": "reviews-canary"}, "spec": {"hosts": ["reviews.bookinfo.svc.cluster.local"]}}`,
+			expectedConflicts: []string{"reviews"},
+		},
+		{
+			name:              "virtual service bound to a different gateway does not conflict with mesh routes",
+			kind:              "VirtualService",
+			jsonData:          `{"metadata": {"name": "reviews-edge"}, "spec": {"hosts": ["reviews"], "gateways": ["other-gateway"]}}`,
+			expectedConflicts: []string{},
+		},
+		{
+			name:              "virtual service matching the wildcard gateway host",
+			kind:              "VirtualService",
+			jsonData:          `{"metadata": {"name": "shop"}, "spec": {"hosts": ["shop.example.com"], "gateways": ["bookinfo/bookinfo-gateway"]}}`,
+			expectedConflicts: []string{"ingress"},
+		},
+		{
+			name:              "destination rule for the same host",
+			kind:              "DestinationRule",
+			jsonData:          `{"metadata": {"name": "reviews-v2"}, "spec": {"host": "reviews"}}`,
+			expectedConflicts: []string{"reviews"},
+		},
+		{
+			name:              "namespace-wide peer authentication",
+			kind:              "PeerAuthentication",
+			jsonData:          `{"metadata": {"name": "permissive"}, "spec": {"mtls": {"mode": "PERMISSIVE"}}}`,
+			expectedConflicts: []string{"default"},
+		},
+		{
+			name:              "workload scoped peer authentication does not conflict",
+			kind:              "PeerAuthentication",
+			jsonData:          `{"metadata": {"name": "ratings"}, "spec": {"selector": {"matchLabels": {"app": "ratings"}}}}`,
+			expectedConflicts: []string{},
+		},
+		{
+			name:              "object with the same name",
+			kind:              "DestinationRule",
+			jsonData:          `{"metadata": {"name": "reviews"}, "spec": {"host": "details"}}`,
+			expectedConflicts: []string{"reviews"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := kialiClient.IstioObjectConflicts(context.Background(), "bookinfo", "networking.istio.io", "v1", tt.kind, tt.jsonData)
+			require.NoError(t, err)
+
+			var conflicts []internalkiali.IstioConflict
+			require.NoError(t, json.Unmarshal([]byte(result), &conflicts))
+			names := make([]string, 0, len(conflicts))
+			for _, c := range conflicts {
+				assert.NotEmpty(t, c.Reason)
+				names = append(names, c.Name)
+			}
+			assert.Equal(t, tt.expectedConflicts, names)
+		})
+	}
+
+	t.Run("invalid proposed object", func(t *testing.T) {
+		_, err := kialiClient.IstioObjectConflicts(context.Background(), "bookinfo", "networking.istio.io", "v1", "VirtualService", "not-json")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to parse proposed object")
+	})
+}
+
+func TestValidateIstioGVK(t *testing.T) {
+	tests := []struct {
+		name            string
+		group           string
+		version         string
+		kind            string
+		expectedError   bool
+		expectedMessage string
+	}{
+		{name: "Istio networking v1", group: "networking.istio.io", version: "v1", kind: "VirtualService"},
+		{name: "EnvoyFilter v1alpha3", group: "networking.istio.io", version: "v1alpha3", kind: "EnvoyFilter"},
+		{name: "Gateway API HTTPRoute", group: "gateway.networking.k8s.io", version: "v1", kind: "HTTPRoute"},
+		{
+			name:            "known kind with wrong version",
+			group:           "networking.istio.io",
+			version:         "v1",
+			kind:            "EnvoyFilter",
+			expectedError:   true,
+			expectedMessage: "valid combinations for EnvoyFilter are: networking.istio.io/v1alpha3/EnvoyFilter",
+		},
+		{
+			name:            "known kind with wrong group",
+			group:           "networking.istio.io",
+			version:         "v1",
+			kind:            "AuthorizationPolicy",
+			expectedError:   true,
+			expectedMessage: "security.istio.io/v1/AuthorizationPolicy, security.istio.io/v1beta1/AuthorizationPolicy",
+		},
+		{
+			name:            "unknown kind",
+			group:           "example.com",
+			version:         "v1",
+			kind:            "Widget",
+			expectedError:   true,
+			expectedMessage: "valid combinations are: extensions.istio.io/v1alpha1: WasmPlugin;",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := internalkiali.ValidateIstioGVK(tt.group, tt.version, tt.kind)
+			if !tt.expectedError {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedMessage)
+		})
+	}
+}
+
+func TestValidateIstioManifest(t *testing.T) {
+	tests := []struct {
+		name           string
+		document       string
+		expectedValid  bool
+		expectedIssues []internalkiali.ManifestIssue
+	}{
+		{
+			name: "valid VirtualService in YAML",
+			document: `apiVersion: networking.istio.io/v1
+kind: VirtualService
+metadata:
+  name: reviews
+  namespace: bookinfo
+spec:
+  hosts: [reviews]
+  http:
+  - route:
+    - destination: {host: reviews, subset: v1}
+      weight: 80
+    - destination: {host: reviews, subset: v2}
+      weight: 20
+`,
+			expectedValid:  true,
+			expectedIssues: []internalkiali.ManifestIssue{},
+		},
+		{
+			name:          "VirtualService with missing fields in JSON",
+			document:      `{"apiVersion": "networking.istio.io/v1", "kind": "VirtualService", "metadata": {"name": "reviews"}, "spec": {"http": [{"route": [{"destination": {}, "weight": 50}, {"destination": {"host": "reviews"}, "weight": 40}]}]}}`,
+			expectedValid: false,
+			expectedIssues: []internalkiali.ManifestIssue{
+				{Severity: "error", Path: "spec.hosts", Message: "hosts must be a non-empty list"},
+				{Severity: "error", Path: "spec.http[0].route[0].destination.host", Message: "host is required"},
+				{Severity: "error", Path: "spec.http[0].route", Message: "the weights of the destinations add up to 90, not 100"},
+			},
+		},
+		{
+			name: "DestinationRule with duplicate subsets",
+			document: `apiVersion: networking.istio.io/v1
+kind: DestinationRule
+metadata: {name: reviews}
+spec:
+  host: reviews
+  subsets:
+  - {name: v1, labels: {version: v1}}
+  - {name: v1}
+`,
+			expectedValid: false,
+			expectedIssues: []internalkiali.ManifestIssue{
+				{Severity: "error", Path: "spec.subsets[1].name", Message: "duplicate subset name 'v1'"},
+				{Severity: "warning", Path: "spec.subsets[1].labels", Message: "subset has no labels: it selects all the endpoints of the host"},
+			},
+		},
+		{
+			name: "AuthorizationPolicy without selector",
+			document: `apiVersion: security.istio.io/v1
+kind: AuthorizationPolicy
+metadata: {name: deny-all, namespace: bookinfo}
+spec: {}
+`,
+			expectedValid: true,
+			expectedIssues: []internalkiali.ManifestIssue{
+				{Severity: "warning", Path: "spec.selector", Message: "no selector: the AuthorizationPolicy applies to all the workloads of the namespace (or of the mesh in the root namespace)"},
+			},
+		},
+		{
+			name: "Sidecar with empty workload selector",
+			document: `apiVersion: networking.istio.io/v1
+kind: Sidecar
+metadata: {name: default}
+spec:
+  workloadSelector: {}
+`,
+			expectedValid: false,
+			expectedIssues: []internalkiali.ManifestIssue{
+				{Severity: "error", Path: "spec.workloadSelector.labels", Message: "workloadSelector must have at least one label"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := internalkiali.ValidateIstioManifest(tt.document)
+			require.NoError(t, err)
+			var validation internalkiali.ManifestValidation
+			require.NoError(t, json.Unmarshal([]byte(result), &validation))
+			assert.Equal(t, tt.expectedValid, validation.Valid)
+			if tt.expectedIssues != nil {
+				assert.Equal(t, tt.expectedIssues, validation.Issues)
+			}
+		})
+	}
+
+	t.Run("unknown kind, invalid name and no spec", func(t *testing.T) {
+		result, err := internalkiali.ValidateIstioManifest(`{"apiVersion": "networking.istio.io/v1", "kind": "Widget", "metadata": {"name": "Reviews"}}`)
+		require.NoError(t, err)
+		var validation internalkiali.ManifestValidation
+		require.NoError(t, json.Unmarshal([]byte(result), &validation))
+		paths := make([]string, 0, len(validation.Issues))
+		for _, issue := range validation.Issues {
+			paths = append(paths, issue.Path)
+		}
+		assert.False(t, validation.Valid)
+		assert.Equal(t, []string{"apiVersion", "metadata.name", "spec"}, paths)
+	})
+
+	t.Run("not an object", func(t *testing.T) {
+		_, err := internalkiali.ValidateIstioManifest("- a\n- b")
+		require.Error(t, err)
+	})
+}
+
+func TestAuthorizationPolicies_KialiClient(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/istio/config", r.URL.Path)
+		_, _ = w.Write([]byte(`{"resources": {"security.istio.io/v1, Kind=AuthorizationPolicy": [
+			{"metadata": {"name": "reviews-viewer", "namespace": "bookinfo"}, "spec": {
+				"selector": {"matchLabels": {"app": "reviews"}},
+				"rules": [{
+					"from": [{"source": {"principals": ["cluster.local/ns/bookinfo/sa/productpage"]}}, {"source": {"namespaces": ["monitoring"]}}],
+					"to": [{"operation": {"methods": ["GET"], "paths": ["/reviews/*"]}}],
+					"when": [{"key": "request.headers[version]", "values": ["v1", "v2"]}]
+				}]
+			}},
+			{"metadata": {"name": "deny-external", "namespace": "bookinfo"}, "spec": {
+				"action": "DENY",
+				"rules": [{"from": [{"source": {"notNamespaces": ["bookinfo"]}}]}]
+			}},
+			{"metadata": {"name": "allow-nothing", "namespace": "bookinfo"}, "spec": {}},
+			{"metadata": {"name": "other", "namespace": "legacy"}, "spec": {"rules": [{}]}}
+		]}}`))
+	}))
+	defer mockServer.Close()
+
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+	result, err := kialiClient.AuthorizationPolicies(context.Background(), "bookinfo")
+	require.NoError(t, err)
+
+	var policies []internalkiali.AuthorizationPolicySummary
+	require.NoError(t, json.Unmarshal([]byte(result), &policies))
+	require.Len(t, policies, 3)
+
+	assert.Equal(t, "allow-nothing", policies[0].Name)
+	assert.Equal(t, "ALLOW", policies[0].Action)
+	assert.Empty(t, policies[0].Rules)
+	assert.Contains(t, policies[0].Note, "all requests to the selected workloads are denied")
+
+	assert.Equal(t, "deny-external", policies[1].Name)
+	assert.Equal(t, "DENY", policies[1].Action)
+	assert.Equal(t, []internalkiali.AuthorizationRuleSummary{{From: []string{"notNamespaces=[bookinfo]"}}}, policies[1].Rules)
+
+	assert.Equal(t, internalkiali.AuthorizationPolicySummary{
+		Name:      "reviews-viewer",
+		Namespace: "bookinfo",
+		Action:    "ALLOW",
+		Selector:  map[string]string{"app": "reviews"},
+		Rules: []internalkiali.AuthorizationRuleSummary{{
+			From: []string{"principals=[cluster.local/ns/bookinfo/sa/productpage]", "namespaces=[monitoring]"},
+			To:   []string{"methods=[GET]; paths=[/reviews/*]"},
+			When: []string{"request.headers[version] in [v1, v2]"},
+		}},
+	}, policies[2])
+}
+
+func TestIstioObjectCreateTimeout_KialiClient(t *testing.T) {
+	tests := []struct {
+		name            string
+		jsonData        string
+		getStatus       int
+		expectedMessage string
+	}{
+		{
+			name:            "object exists",
+			jsonData:        `{"metadata": {"name": "reviews"}}`,
+			getStatus:       http.StatusOK,
+			expectedMessage: "DestinationRule bookinfo/reviews exists: yes",
+		},
+		{
+			name:            "object does not exist",
+			jsonData:        `{"metadata": {"name": "reviews"}}`,
+			getStatus:       http.StatusNotFound,
+			expectedMessage: "DestinationRule bookinfo/reviews exists: no",
+		},
+		{
+			name:            "check fails",
+			jsonData:        `{"metadata": {"name": "reviews"}}`,
+			getStatus:       http.StatusInternalServerError,
+			expectedMessage: "DestinationRule bookinfo/reviews exists: unknown",
+		},
+		{
+			name:            "no deterministic name",
+			jsonData:        `{"metadata": {"generateName": "reviews-"}}`,
+			expectedMessage: "has no metadata.name",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gets := 0
+			// Unblocks the hanging create request, whose connection may outlive the client timeout
+			done := make(chan struct{})
+			mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodPost {
+					select {
+					case <-r.Context().Done():
+					case <-done:
+					}
+					return
+				}
+				gets++
+				assert.Equal(t, "/api/namespaces/bookinfo/istio/networking.istio.io/v1/DestinationRule/reviews", r.URL.Path)
+				w.WriteHeader(tt.getStatus)
+			}))
+			defer mockServer.Close()
+			defer close(done)
+
+			kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+			_, err := kialiClient.IstioObjectCreate(ctx, "bookinfo", "networking.istio.io", "v1", "DestinationRule", tt.jsonData, "")
+			require.Error(t, err)
+			var statusErr *internalkiali.CreateStatusUnknownError
+			require.ErrorAs(t, err, &statusErr)
+			assert.Contains(t, err.Error(), "creation status unknown")
+			assert.Contains(t, err.Error(), tt.expectedMessage)
+			if tt.getStatus == 0 {
+				assert.Zero(t, gets)
+			}
+		})
+	}
+}
+
+func TestDestinationRules_KialiClient(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"resources": {"networking.istio.io/v1, Kind=DestinationRule": [
+			{"metadata": {"name": "reviews", "namespace": "bookinfo"}, "spec": {
+				"host": "reviews",
+				"trafficPolicy": {
+					"loadBalancer": {"simple": "ROUND_ROBIN"},
+					"outlierDetection": {"consecutive5xxErrors": 7, "interval": "5m"},
+					"portLevelSettings": [{"port": {"number": 9080}, "tls": {"mode": "ISTIO_MUTUAL"}}]
+				},
+				"subsets": [
+					{"name": "v1", "labels": {"version": "v1"}},
+					{"name": "v2", "labels": {"version": "v2"}, "trafficPolicy": {"loadBalancer": {"simple": "LEAST_REQUEST"}}}
+				]
+			}},
+			{"metadata": {"name": "ratings", "namespace": "legacy"}, "spec": {"host": "ratings.legacy.svc.cluster.local"}}
+		]}}`))
+	}))
+	defer mockServer.Close()
+
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+	result, err := kialiClient.DestinationRules(context.Background(), "")
+	require.NoError(t, err)
+
+	var rules []internalkiali.DestinationRuleSummary
+	require.NoError(t, json.Unmarshal([]byte(result), &rules))
+	require.Len(t, rules, 2)
+	assert.Equal(t, internalkiali.DestinationRuleSummary{
+		Namespace: "bookinfo",
+		Name:      "reviews",
+		Host:      "reviews",
+		Subsets: []internalkiali.SubsetSummary{
+			{Name: "v1", Labels: map[string]string{"version": "v1"}},
+			{Name: "v2", Labels: map[string]string{"version": "v2"}, TrafficPolicy: []string{"loadBalancer.simple=LEAST_REQUEST"}},
+		},
+		TrafficPolicy: []string{
+			"loadBalancer.simple=ROUND_ROBIN",
+			"outlierDetection.consecutive5xxErrors=7",
+			"outlierDetection.interval=5m",
+			"portLevelSettings[0].port.number=9080",
+			"portLevelSettings[0].tls.mode=ISTIO_MUTUAL",
+		},
+	}, rules[0])
+	assert.Equal(t, internalkiali.DestinationRuleSummary{Namespace: "legacy", Name: "ratings", Host: "ratings.legacy.svc.cluster.local", Subsets: []internalkiali.SubsetSummary{}}, rules[1])
+
+	result, err = kialiClient.DestinationRules(context.Background(), "legacy")
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal([]byte(result), &rules))
+	require.Len(t, rules, 1)
+	assert.Equal(t, "ratings", rules[0].Name)
+}
+
+func TestDanglingSubsets_KialiClient(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"resources": {
+			"networking.istio.io/v1, Kind=DestinationRule": [
+				{"metadata": {"name": "reviews", "namespace": "bookinfo"}, "spec": {"host": "reviews", "subsets": [{"name": "v1"}, {"name": "v2"}]}},
+				{"metadata": {"name": "ratings", "namespace": "bookinfo"}, "spec": {"host": "ratings.bookinfo.svc.cluster.local"}}
+			],
+			"networking.istio.io/v1, Kind=VirtualService": [
+				{"metadata": {"name": "reviews", "namespace": "bookinfo"}, "spec": {"hosts": ["reviews"], "http": [
+					{"route": [{"destination": {"host": "reviews", "subset": "v1"}}, {"destination": {"host": "reviews", "subset": "v3"}}],
+					 "mirror": {"host": "reviews", "subset": "v2"}}
+				]}},
+				{"metadata": {"name": "ratings", "namespace": "bookinfo"}, "spec": {"hosts": ["ratings"], "tcp": [
+					{"route": [{"destination": {"host": "ratings", "subset": "v1"}}]}
+				]}},
+				{"metadata": {"name": "details", "namespace": "legacy"}, "spec": {"hosts": ["details"], "http": [
+					{"route": [{"destination": {"host": "details"}}], "mirrors": [{"destination": {"host": "details", "subset": "v2"}}]}
+				]}}
+			]
+		}}`))
+	}))
+	defer mockServer.Close()
+
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+	result, err := kialiClient.DanglingSubsets(context.Background(), "")
+	require.NoError(t, err)
+
+	var dangling []internalkiali.DanglingSubset
+	require.NoError(t, json.Unmarshal([]byte(result), &dangling))
+	assert.Equal(t, []internalkiali.DanglingSubset{
+		{Namespace: "bookinfo", VirtualService: "ratings", Route: "tcp[0].route[0]", Host: "ratings", Subset: "v1",
+			Reason: "the DestinationRules of the host define no subset"},
+		{Namespace: "bookinfo", VirtualService: "reviews", Route: "http[0].route[1]", Host: "reviews", Subset: "v3",
+			Reason: "subset v3 is not defined by the DestinationRules of the host (available: v1, v2)", AvailableSubsets: []string{"v1", "v2"}},
+		{Namespace: "legacy", VirtualService: "details", Route: "http[0].mirrors[0]", Host: "details", Subset: "v2",
+			Reason: "no DestinationRule targets host details.legacy.svc.cluster.local"},
+	}, dangling)
+
+	result, err = kialiClient.DanglingSubsets(context.Background(), "legacy")
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal([]byte(result), &dangling))
+	require.Len(t, dangling, 1)
+	assert.Equal(t, "details", dangling[0].VirtualService)
+}
+
+func TestIstioObjectClusterName_KialiClient(t *testing.T) {
+	var requests []string
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/clusters" {
+			// central is known to Kiali even though none of its namespaces are accessible
+			_, _ = w.Write([]byte(`[{"name": "central"}, {"name": "east", "isKialiHome": true}, {"name": "west"}]`))
+			return
+		}
+		assert.NotEqual(t, "/api/namespaces", r.URL.Path, "the clusters are not derived from the accessible namespaces")
+		requests = append(requests, r.Method+" "+r.URL.Path+"?"+r.URL.RawQuery)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer mockServer.Close()
+
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+	ctx := context.Background()
+
+	_, err := kialiClient.IstioObjectDetails(ctx, "bookinfo", "networking.istio.io", "v1", "DestinationRule", "reviews", "west")
+	require.NoError(t, err)
+	_, err = kialiClient.IstioObjectPatch(ctx, "bookinfo", "networking.istio.io", "v1", "DestinationRule", "reviews", `{}`, "west")
+	require.NoError(t, err)
+	_, err = kialiClient.IstioObjectCreate(ctx, "bookinfo", "networking.istio.io", "v1", "DestinationRule", `{}`, "west")
+	require.NoError(t, err)
+	_, err = kialiClient.IstioObjectDelete(ctx, "bookinfo", "networking.istio.io", "v1", "DestinationRule", "reviews", "west")
+	require.NoError(t, err)
+	_, err = kialiClient.IstioObjectDelete(ctx, "bookinfo", "networking.istio.io", "v1", "DestinationRule", "reviews", "")
+	require.NoError(t, err)
+	_, err = kialiClient.IstioObjectDetails(ctx, "bookinfo", "networking.istio.io", "v1", "DestinationRule", "reviews", "central")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"GET /api/namespaces/bookinfo/istio/networking.istio.io/v1/DestinationRule/reviews?clusterName=west&help=true&validate=true",
+		"PATCH /api/namespaces/bookinfo/istio/networking.istio.io/v1/DestinationRule/reviews?clusterName=west",
+		"POST /api/namespaces/bookinfo/istio/networking.istio.io/v1/DestinationRule?clusterName=west",
+		"DELETE /api/namespaces/bookinfo/istio/networking.istio.io/v1/DestinationRule/reviews?clusterName=west",
+		"DELETE /api/namespaces/bookinfo/istio/networking.istio.io/v1/DestinationRule/reviews?",
+		"GET /api/namespaces/bookinfo/istio/networking.istio.io/v1/DestinationRule/reviews?clusterName=central&help=true&validate=true",
+	}, requests)
+
+	_, err = kialiClient.IstioObjectDelete(ctx, "bookinfo", "networking.istio.io", "v1", "DestinationRule", "reviews", "north")
+	require.Error(t, err)
+	assert.Equal(t, `unknown cluster "north", known clusters are: central, east, west`, err.Error())
+	assert.Len(t, requests, 6, "no request is sent to an unknown cluster")
+}
+
+func TestIstioObjectPatchDiff_KialiClient(t *testing.T) {
+	var patched bool
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path != "/api/namespaces/bookinfo/istio/networking.istio.io/v1/VirtualService/reviews":
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`{"resource": {"metadata": {"name": "reviews", "namespace": "bookinfo", "labels": {"team": "a"}}, "spec": {
+				"hosts": ["reviews"], "http": [{"timeout": "5s", "retries": {"attempts": 3}, "route": [{"destination": {"host": "reviews"}}]}]
+			}}}`))
+		case r.Method == http.MethodPatch:
+			patched = true
+			_, _ = w.Write([]byte(`{"resource": {"metadata": {"name": "reviews", "namespace": "bookinfo", "labels": {"team": "a", "tier": "backend"}}, "spec": {
+				"hosts": ["reviews"], "http": [{"timeout": "10s", "route": [{"destination": {"host": "reviews", "subset": "v2"}}]}]
+			}}}`))
+		}
+	}))
+	defer mockServer.Close()
+
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+	result, err := kialiClient.IstioObjectPatchDiff(context.Background(), "bookinfo", "networking.istio.io", "v1", "VirtualService", "reviews", `{"spec": {}}`, "")
+	require.NoError(t, err)
+	assert.True(t, patched)
+
+	var diff internalkiali.IstioObjectPatchDiff
+	require.NoError(t, json.Unmarshal([]byte(result), &diff))
+	assert.Equal(t, "reviews", diff.Name)
+	assert.Empty(t, diff.Note)
+	assert.Equal(t, []internalkiali.SpecChange{
+		{Path: "metadata.labels.tier", After: ptr.To("backend")},
+		{Path: "spec.http[0].retries.attempts", Before: ptr.To("3")},
+		{Path: "spec.http[0].route[0].destination.subset", After: ptr.To("v2")},
+		{Path: "spec.http[0].timeout", Before: ptr.To("5s"), After: ptr.To("10s")},
+	}, diff.Changes)
+
+	patched = false
+	_, err = kialiClient.IstioObjectPatchDiff(context.Background(), "bookinfo", "networking.istio.io", "v1", "VirtualService", "missing", `{}`, "")
+	require.Error(t, err)
+	assert.False(t, patched, "the patch is not applied when the object cannot be read")
+}
+
+func TestIstioObjectsDiff_KialiClient(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path + "@" + r.URL.Query().Get("clusterName") {
+		case "/api/clusters@":
+			_, _ = w.Write([]byte(`[{"name": "east", "isKialiHome": true}, {"name": "west"}]`))
+		case "/api/namespaces/staging/istio/networking.istio.io/v1/VirtualService/reviews@":
+			_, _ = w.Write([]byte(`{"resource": {"metadata": {"name": "reviews", "namespace": "staging", "labels": {"env": "staging"}}, "spec": {
+				"hosts": ["reviews"], "http": [{"timeout": "5s", "route": [{"destination": {"host": "reviews", "subset": "v2"}}]}]
+			}}}`))
+		case "/api/namespaces/prod/istio/networking.istio.io/v1/VirtualService/reviews@":
+			_, _ = w.Write([]byte(`{"resource": {"metadata": {"name": "reviews", "namespace": "prod", "labels": {"env": "prod"}}, "spec": {
+				"hosts": ["reviews"], "http": [{"timeout": "10s", "retries": {"attempts": 3}, "route": [{"destination": {"host": "reviews", "subset": "v2"}}]}]
+			}}}`))
+		case "/api/namespaces/staging/istio/networking.istio.io/v1/VirtualService/reviews@west":
+			_, _ = w.Write([]byte(`{"resource": {"metadata": {"name": "reviews", "namespace": "staging", "labels": {"cluster": "west"}}, "spec": {
+				"hosts": ["reviews"], "http": [{"timeout": "5s", "route": [{"destination": {"host": "reviews", "subset": "v2"}}]}]
+			}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockServer.Close()
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+	staging := internalkiali.IstioObjectLocation{Namespace: "staging", Group: "networking.istio.io", Version: "v1", Kind: "VirtualService", Name: "reviews"}
+
+	t.Run("two namespaces", func(t *testing.T) {
+		prod := staging
+		prod.Namespace = "prod"
+		result, err := kialiClient.IstioObjectsDiff(context.Background(), staging, prod)
+		require.NoError(t, err)
+		var diff internalkiali.IstioObjectsDiff
+		require.NoError(t, json.Unmarshal([]byte(result), &diff))
+		assert.False(t, diff.Identical)
+		assert.Equal(t, "prod", diff.Second.Namespace)
+		assert.Equal(t, []internalkiali.SpecChange{
+			{Path: "spec.http[0].retries.attempts", After: ptr.To("3")},
+			{Path: "spec.http[0].timeout", Before: ptr.To("5s"), After: ptr.To("10s")},
+		}, diff.Changes)
+	})
+
+	t.Run("two clusters with identical specs", func(t *testing.T) {
+		west := staging
+		west.Cluster = "west"
+		result, err := kialiClient.IstioObjectsDiff(context.Background(), staging, west)
+		require.NoError(t, err)
+		var diff internalkiali.IstioObjectsDiff
+		require.NoError(t, json.Unmarshal([]byte(result), &diff))
+		assert.True(t, diff.Identical)
+		assert.Empty(t, diff.Changes)
+	})
+
+	t.Run("missing object", func(t *testing.T) {
+		missing := staging
+		missing.Name = "missing"
+		_, err := kialiClient.IstioObjectsDiff(context.Background(), staging, missing)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get staging/VirtualService/missing")
+	})
+
+	t.Run("same object", func(t *testing.T) {
+		_, err := kialiClient.IstioObjectsDiff(context.Background(), staging, staging)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "the two objects are the same object")
+	})
+}
+
+func TestHostReachability_KialiClient(t *testing.T) {
+	istioConfig := `{"resources": {
+		"networking.istio.io/v1, Kind=ServiceEntry": [
+			{"metadata": {"name": "example-api", "namespace": "egress"}, "spec": {
+				"hosts": ["api.example.com"], "location": "MESH_EXTERNAL", "resolution": "DNS",
+				"ports": [{"number": 443, "name": "https", "protocol": "TLS"}], "exportTo": ["."]
+			}},
+			{"metadata": {"name": "wildcard", "namespace": "bookinfo"}, "spec": {"hosts": ["*.example.org"]}}
+		],
+		"networking.istio.io/v1, Kind=VirtualService": [
+			{"metadata": {"name": "example-api", "namespace": "egress"}, "spec": {
+				"hosts": ["api.example.com"],
+				"tls": [{"match": [{"port": 443, "sniHosts": ["api.example.com"]}], "route": [{"destination": {"host": "api.example.com", "port": {"number": 443}}}]}]
+			}},
+			{"metadata": {"name": "reviews", "namespace": "bookinfo"}, "spec": {
+				"hosts": ["reviews"], "http": [{"route": [{"destination": {"host": "reviews", "subset": "v1"}}]}]
+			}},
+			{"metadata": {"name": "reviews-ingress", "namespace": "bookinfo"}, "spec": {
+				"hosts": ["reviews"], "gateways": ["bookinfo-gateway"], "http": [{"route": [{"destination": {"host": "reviews"}}]}]
+			}}
+		],
+		"networking.istio.io/v1, Kind=DestinationRule": [
+			{"metadata": {"name": "example-api", "namespace": "egress"}, "spec": {"host": "api.example.com", "trafficPolicy": {"tls": {"mode": "SIMPLE"}}}},
+			{"metadata": {"name": "reviews", "namespace": "bookinfo"}, "spec": {"host": "reviews.bookinfo.svc.cluster.local"}}
+		]
+	}}`
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(istioConfig))
+	}))
+	defer mockServer.Close()
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+
+	reachability := func(t *testing.T, host, namespace string) internalkiali.HostReachability {
+		result, err := kialiClient.HostReachability(context.Background(), host, namespace)
+		require.NoError(t, err)
+		var ret internalkiali.HostReachability
+		require.NoError(t, json.Unmarshal([]byte(result), &ret))
+		return ret
+	}
+
+	t.Run("external host defined by a ServiceEntry", func(t *testing.T) {
+		r := reachability(t, "API.example.com", "egress")
+		assert.True(t, r.Defined)
+		require.Len(t, r.ServiceEntries, 1)
+		assert.Equal(t, internalkiali.ServiceEntrySummary{
+			Namespace: "egress", Name: "example-api", Hosts: []string{"api.example.com"}, Location: "MESH_EXTERNAL",
+			Resolution: "DNS", Ports: []string{"443/TLS"}, ExportTo: []string{"."}, Visible: true,
+		}, r.ServiceEntries[0])
+		require.Len(t, r.Routes, 1)
+		assert.Equal(t, "tls", r.Routes[0].Protocol)
+		assert.Equal(t, []string{"destination.host=api.example.com, destination.port.number=443"}, r.Routes[0].Destinations)
+		require.Len(t, r.DestinationRules, 1)
+		assert.Equal(t, []string{"tls.mode=SIMPLE"}, r.DestinationRules[0].TrafficPolicy)
+		assert.Empty(t, r.Notes)
+	})
+
+	t.Run("ServiceEntry not exported to the caller", func(t *testing.T) {
+		r := reachability(t, "api.example.com", "bookinfo")
+		require.Len(t, r.ServiceEntries, 1)
+		assert.False(t, r.ServiceEntries[0].Visible)
+		assert.Contains(t, r.Notes, "the ServiceEntries defining the host are not exported to namespace 'bookinfo'")
+	})
+
+	t.Run("wildcard ServiceEntry", func(t *testing.T) {
+		r := reachability(t, "www.example.org", "")
+		assert.True(t, r.Defined)
+		require.Len(t, r.ServiceEntries, 1)
+		assert.Equal(t, "NONE", r.ServiceEntries[0].Resolution)
+		assert.Empty(t, r.Routes)
+	})
+
+	t.Run("undefined external host", func(t *testing.T) {
+		r := reachability(t, "api.unknown.io", "bookinfo")
+		assert.False(t, r.Defined)
+		assert.Empty(t, r.ServiceEntries)
+		require.NotEmpty(t, r.Notes)
+		assert.Contains(t, r.Notes[0], "REGISTRY_ONLY")
+	})
+
+	t.Run("short cluster service name", func(t *testing.T) {
+		r := reachability(t, "reviews", "bookinfo")
+		assert.True(t, r.Defined)
+		require.Len(t, r.Routes, 1, "routes of gateway-only VirtualServices do not apply to mesh callers")
+		assert.Equal(t, "bookinfo/reviews", r.Routes[0].VirtualService)
+		require.Len(t, r.DestinationRules, 1)
+		assert.Contains(t, r.Notes[0], "Kubernetes service")
+	})
+
+	t.Run("host is required", func(t *testing.T) {
+		_, err := kialiClient.HostReachability(context.Background(), " ", "")
+		require.Error(t, err)
+	})
+}
+
+func TestIstioObjectWritableNamespaces_KialiClient(t *testing.T) {
+	var requests []string
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer mockServer.Close()
+
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{
+		KialiServerURL:          mockServer.URL,
+		KialiWritableNamespaces: []string{"bookinfo", " "},
+	})
+	ctx := context.Background()
+
+	t.Run("namespaces outside of the list are rejected before contacting Kiali", func(t *testing.T) {
+		requests = nil
+		for _, call := range []func() error{
+			func() error {
+				_, err := kialiClient.IstioObjectCreate(ctx, "istio-system", "networking.istio.io", "v1", "VirtualService", `{"metadata": {"name": "reviews"}}`, "")
+				return err
+			},
+			func() error {
+				_, err := kialiClient.IstioObjectPatch(ctx, "istio-system", "networking.istio.io", "v1", "VirtualService", "reviews", `{"spec": {}}`, "")
+				return err
+			},
+			func() error {
+				_, err := kialiClient.IstioObjectDelete(ctx, "istio-system", "networking.istio.io", "v1", "VirtualService", "reviews", "")
+				return err
+			},
+		} {
+			err := call()
+			require.Error(t, err)
+			assert.True(t, internalkiali.IsNamespaceNotWritable(err))
+			assert.Equal(t, "namespace 'istio-system' is not writable: write operations are restricted by configuration to the namespaces bookinfo", err.Error())
+		}
+		assert.Empty(t, requests)
+	})
+
+	t.Run("namespaces of the list are allowed", func(t *testing.T) {
+		requests = nil
+		_, err := kialiClient.IstioObjectCreate(ctx, "bookinfo", "networking.istio.io", "v1", "VirtualService", `{"metadata": {"name": "reviews"}}`, "")
+		require.NoError(t, err)
+		_, err = kialiClient.IstioObjectPatch(ctx, "bookinfo", "networking.istio.io", "v1", "VirtualService", "reviews", `{"spec": {}}`, "")
+		require.NoError(t, err)
+		_, err = kialiClient.IstioObjectDelete(ctx, "bookinfo", "networking.istio.io", "v1", "VirtualService", "reviews", "")
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"POST /api/namespaces/bookinfo/istio/networking.istio.io/v1/VirtualService",
+			"PATCH /api/namespaces/bookinfo/istio/networking.istio.io/v1/VirtualService/reviews",
+			"DELETE /api/namespaces/bookinfo/istio/networking.istio.io/v1/VirtualService/reviews",
+		}, requests)
+	})
+
+	t.Run("all namespaces are writable when unset", func(t *testing.T) {
+		unrestricted := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+		_, err := unrestricted.IstioObjectDelete(ctx, "istio-system", "networking.istio.io", "v1", "VirtualService", "reviews", "")
+		require.NoError(t, err)
+	})
+}
+
+func TestVirtualServiceGateways_KialiClient(t *testing.T) {
+	istioConfig := `{"resources": {
+		"networking.istio.io/v1, Kind=VirtualService": [
+			{"metadata": {"name": "bookinfo", "namespace": "bookinfo"}, "spec": {
+				"hosts": ["bookinfo.example.com"], "gateways": ["bookinfo-gateway", "istio-system/shared-gateway", "missing-gateway", "mesh"]
+			}},
+			{"metadata": {"name": "reviews", "namespace": "bookinfo"}, "spec": {"hosts": ["reviews"]}}
+		],
+		"networking.istio.io/v1, Kind=Gateway": [
+			{"metadata": {"name": "bookinfo-gateway", "namespace": "bookinfo"}, "spec": {
+				"selector": {"istio": "ingressgateway"},
+				"servers": [{"port": {"number": 80, "protocol": "HTTP"}, "hosts": ["*.example.com"]}]
+			}},
+			{"metadata": {"name": "shared-gateway", "namespace": "istio-system"}, "spec": {
+				"selector": {"istio": "shared"},
+				"servers": [{"port": {"number": 443, "protocol": "HTTPS"}, "hosts": ["bookinfo/other.example.org"]}]
+			}}
+		],
+		"gateway.networking.k8s.io/v1, Kind=Gateway": [
+			{"metadata": {"name": "missing-gateway", "namespace": "bookinfo"}, "spec": {}}
+		]
+	}}`
+	workloads := `{"workloads": [
+		{"namespace": "istio-system", "name": "istio-ingressgateway", "labels": {"istio": "ingressgateway", "app": "istio-ingressgateway"}},
+		{"namespace": "bookinfo", "name": "reviews-v1", "labels": {"app": "reviews"}}
+	]}`
+	workloadHealth := `{"namespaceWorkloadHealth": {"istio-system": {
+		"istio-ingressgateway": {"workloadStatus": {"name": "istio-ingressgateway", "desiredReplicas": 2, "currentReplicas": 2, "availableReplicas": 1}, "requests": {}}
+	}}}`
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/istio/config":
+			_, _ = w.Write([]byte(istioConfig))
+		case "/api/clusters/workloads":
+			_, _ = w.Write([]byte(workloads))
+		case "/api/clusters/health":
+			assert.Equal(t, "istio-system", r.URL.Query().Get("namespaces"))
+			assert.Equal(t, "workload", r.URL.Query().Get("type"))
+			_, _ = w.Write([]byte(workloadHealth))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockServer.Close()
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+
+	t.Run("bound gateways", func(t *testing.T) {
+		result, err := kialiClient.VirtualServiceGateways(context.Background(), "bookinfo", "bookinfo")
+		require.NoError(t, err)
+		var report internalkiali.VirtualServiceGateways
+		require.NoError(t, json.Unmarshal([]byte(result), &report))
+		assert.Equal(t, []string{"bookinfo.example.com"}, report.Hosts)
+		assert.Len(t, report.Notes, 1)
+		require.Len(t, report.Gateways, 3)
+
+		bound := report.Gateways[0]
+		assert.Equal(t, "bookinfo/bookinfo-gateway", bound.Gateway)
+		assert.True(t, bound.Found)
+		assert.Equal(t, map[string]string{"istio": "ingressgateway"}, bound.Selector)
+		assert.Equal(t, []string{"80/HTTP *.example.com"}, bound.Servers)
+		require.Len(t, bound.Workloads, 1)
+		assert.Equal(t, "istio-ingressgateway", bound.Workloads[0].Name)
+		assert.Equal(t, internalkiali.HealthStatusDegraded, bound.Workloads[0].Status)
+		assert.Equal(t, &internalkiali.ReplicaCounts{Desired: 2, Current: 2, Available: 1}, bound.Workloads[0].Replicas)
+		assert.Equal(t, internalkiali.HealthStatusDegraded, bound.Status)
+		assert.Empty(t, bound.Notes)
+
+		shared := report.Gateways[1]
+		assert.Equal(t, "istio-system/shared-gateway", shared.Gateway)
+		assert.True(t, shared.Found)
+		assert.Empty(t, shared.Workloads)
+		assert.Equal(t, internalkiali.HealthStatusNA, shared.Status)
+		assert.Equal(t, []string{
+			"none of the hosts of the VirtualService are exposed by the servers of the Gateway",
+			"no workload matches the selector of the Gateway: no ingress gateway serves it",
+		}, shared.Notes)
+
+		missing := report.Gateways[2]
+		assert.Equal(t, "bookinfo/missing-gateway", missing.Gateway)
+		assert.False(t, missing.Found)
+		assert.Len(t, missing.Notes, 1)
+	})
+	t.Run("mesh only", func(t *testing.T) {
+		result, err := kialiClient.VirtualServiceGateways(context.Background(), "bookinfo", "reviews")
+		require.NoError(t, err)
+		var report internalkiali.VirtualServiceGateways
+		require.NoError(t, json.Unmarshal([]byte(result), &report))
+		assert.Empty(t, report.Gateways)
+		assert.Equal(t, []string{"the VirtualService sets no gateways: it only applies to the sidecars of the mesh"}, report.Notes)
+	})
+	t.Run("missing virtual service", func(t *testing.T) {
+		_, err := kialiClient.VirtualServiceGateways(context.Background(), "bookinfo", "ratings")
+		require.Error(t, err)
+		assert.True(t, internalkiali.IsNotFound(err))
+	})
+}
+
+func TestPeerAuthentications_KialiClient(t *testing.T) {
+	istioConfig := `{"resources": {
+		"security.istio.io/v1, Kind=PeerAuthentication": [
+			{"metadata": {"name": "default", "namespace": "istio-system"}, "spec": {"mtls": {"mode": "STRICT"}}},
+			{"metadata": {"name": "legacy", "namespace": "legacy"}, "spec": {"mtls": {"mode": "permissive"}}},
+			{"metadata": {"name": "inherit", "namespace": "bookinfo"}, "spec": {}},
+			{"metadata": {"name": "mysql", "namespace": "bookinfo"}, "spec": {
+				"selector": {"matchLabels": {"app": "mysql"}},
+				"portLevelMtls": {"3306": {"mode": "DISABLE"}, "9104": {}}
+			}},
+			{"metadata": {"name": "reviews", "namespace": "legacy"}, "spec": {"selector": {"matchLabels": {"app": "reviews"}}, "mtls": {"mode": "STRICT"}}}
+		]
+	}}`
+	namespaces := `[{"name": "bookinfo"}, {"name": "istio-system"}, {"name": "legacy"}]`
+	workloads := `{"workloads": [
+		{"namespace": "bookinfo", "name": "mysql-v1", "labels": {"app": "mysql"}},
+		{"namespace": "bookinfo", "name": "reviews-v1", "labels": {"app": "reviews"}},
+		{"namespace": "legacy", "name": "reviews-v2", "labels": {"app": "reviews"}}
+	]}`
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/istio/config":
+			_, _ = w.Write([]byte(istioConfig))
+		case "/api/namespaces":
+			_, _ = w.Write([]byte(namespaces))
+		case "/api/clusters/workloads":
+			_, _ = w.Write([]byte(workloads))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer mockServer.Close()
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+
+	t.Run("effective modes", func(t *testing.T) {
+		result, err := kialiClient.PeerAuthentications(context.Background(), "", "")
+		require.NoError(t, err)
+		var report internalkiali.PeerAuthentications
+		require.NoError(t, json.Unmarshal([]byte(result), &report))
+		assert.Equal(t, "istio-system", report.RootNamespace)
+		assert.Equal(t, "STRICT", report.MeshMode)
+		assert.Equal(t, "istio-system/default", report.MeshPolicy)
+		assert.Empty(t, report.Notes)
+		assert.Len(t, report.Policies, 5)
+		assert.Equal(t, []internalkiali.NamespaceMTLSMode{
+			{Namespace: "bookinfo", Mode: "STRICT", Source: "mesh", Policy: "istio-system/default", WorkloadOverrides: []internalkiali.WorkloadMTLSOverride{
+				{Policy: "bookinfo/mysql", Selector: map[string]string{"app": "mysql"}, Mode: "STRICT", PortModes: map[string]string{"3306": "DISABLE", "9104": "STRICT"}, Workloads: []string{"mysql-v1"}},
+			}},
+			{Namespace: "istio-system", Mode: "STRICT", Source: "mesh", Policy: "istio-system/default", WorkloadOverrides: []internalkiali.WorkloadMTLSOverride{}},
+			{Namespace: "legacy", Mode: "PERMISSIVE", Source: "namespace", Policy: "legacy/legacy", WorkloadOverrides: []internalkiali.WorkloadMTLSOverride{
+				{Policy: "legacy/reviews", Selector: map[string]string{"app": "reviews"}, Mode: "STRICT", Workloads: []string{"reviews-v2"}},
+			}},
+		}, report.Namespaces)
+	})
+
+	t.Run("no mesh-wide policy in the root namespace", func(t *testing.T) {
+		result, err := kialiClient.PeerAuthentications(context.Background(), "bookinfo,legacy", "istio-config")
+		require.NoError(t, err)
+		var report internalkiali.PeerAuthentications
+		require.NoError(t, json.Unmarshal([]byte(result), &report))
+		assert.Equal(t, "PERMISSIVE", report.MeshMode)
+		require.Len(t, report.Namespaces, 2)
+		assert.Equal(t, "default", report.Namespaces[0].Source)
+		assert.Equal(t, "PERMISSIVE", report.Namespaces[0].Mode)
+		assert.Equal(t, "namespace", report.Namespaces[1].Source)
+		assert.Equal(t, []string{"no mesh-wide PeerAuthentication in the root namespace 'istio-config': namespaces without policy accept both mTLS and plaintext traffic (PERMISSIVE)"}, report.Notes)
+	})
+}
+
+func TestPeerAuthenticationsConflicts_KialiClient(t *testing.T) {
+	// The oldest policies are not the first ones by name
+	istioConfig := `{"resources": {
+		"security.istio.io/v1, Kind=PeerAuthentication": [
+			{"metadata": {"name": "a-permissive", "namespace": "istio-system", "creationTimestamp": "2024-03-01T10:00:00Z"}, "spec": {"mtls": {"mode": "PERMISSIVE"}}},
+			{"metadata": {"name": "z-strict", "namespace": "istio-system", "creationTimestamp": "2024-01-01T10:00:00Z"}, "spec": {"mtls": {"mode": "STRICT"}}},
+			{"metadata": {"name": "a-disable", "namespace": "bookinfo", "creationTimestamp": "2024-02-02T10:00:00Z"}, "spec": {"mtls": {"mode": "DISABLE"}}},
+			{"metadata": {"name": "b-permissive", "namespace": "bookinfo", "creationTimestamp": "2024-02-01T10:00:00Z"}, "spec": {"mtls": {"mode": "PERMISSIVE"}}}
+		]
+	}}`
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/istio/config":
+			_, _ = w.Write([]byte(istioConfig))
+		case "/api/namespaces":
+			_, _ = w.Write([]byte(`[{"name": "bookinfo"}, {"name": "istio-system"}]`))
+		case "/api/clusters/workloads":
+			_, _ = w.Write([]byte(`{"workloads": []}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer mockServer.Close()
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+
+	result, err := kialiClient.PeerAuthentications(context.Background(), "", "")
+	require.NoError(t, err)
+	var report internalkiali.PeerAuthentications
+	require.NoError(t, json.Unmarshal([]byte(result), &report))
+	assert.Equal(t, "STRICT", report.MeshMode)
+	assert.Equal(t, "istio-system/z-strict", report.MeshPolicy)
+	require.Len(t, report.Namespaces, 2)
+	assert.Equal(t, "PERMISSIVE", report.Namespaces[0].Mode)
+	assert.Equal(t, "bookinfo/b-permissive", report.Namespaces[0].Policy)
+	assert.Equal(t, "STRICT", report.Namespaces[1].Mode)
+	assert.Equal(t, "istio-system/z-strict", report.Namespaces[1].Policy)
+	assert.Equal(t, []string{
+		"several namespace-wide PeerAuthentications in namespace 'bookinfo' (b-permissive, a-disable): only the oldest one, b-permissive, applies",
+		"several mesh-wide PeerAuthentications (istio-system/z-strict, istio-system/a-permissive): only the oldest one, istio-system/z-strict, applies",
+	}, report.Notes)
+}
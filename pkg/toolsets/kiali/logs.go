@@ -1,13 +1,16 @@
 package kiali
 
 import (
-	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/jsonschema-go/jsonschema"
 	"k8s.io/utils/ptr"
 
 	"github.com/kiali/kiali-mcp-server/pkg/api"
+	internalkiali "github.com/kiali/kiali-mcp-server/pkg/kiali"
 )
 
 func initLogs() []api.ServerTool {
@@ -31,17 +34,30 @@ func initLogs() []api.ServerTool {
 					},
 					"container": {
 						Type:        "string",
-						Description: "Optional container name to filter logs. If not provided, automatically detects and uses the main application container (excludes istio-proxy and istio-init)",
+						Description: "Optional container name to filter logs. If not provided, automatically detects and uses the main application container: the first container, in name order, other than istio-proxy and istio-init (or matching the configured preferred container pattern)",
 					},
 					"since": {
 						Type:        "string",
 						Description: "Time duration to fetch logs from (e.g., '5m', '1h', '30s'). If not provided, returns recent logs",
 					},
+					"sinceTime": {
+						Type:        "string",
+						Description: "Optional start of an absolute time window, as an RFC 3339 timestamp (e.g., '2024-01-01T10:00:00Z') or Unix timestamp in seconds. Only logs written at or after this time are returned",
+					},
+					"untilTime": {
+						Type:        "string",
+						Description: "Optional end of an absolute time window, as an RFC 3339 timestamp or Unix timestamp in seconds. Logs written after this time are dropped from the result (based on the log timestamps); note that 'tail' is applied before this trimming",
+					},
 					"tail": {
 						Type:        "integer",
 						Description: "Number of lines to retrieve from the end of logs (default: 100)",
 						Minimum:     ptr.To(float64(1)),
 					},
+					"maxPods": {
+						Type:        "integer",
+						Description: "Maximum number of pods to fetch the logs of, the first ones in name order. The number of omitted pods is noted at the end of the result (default: 5)",
+						Minimum:     ptr.To(float64(1)),
+					},
 					"previous": {
 						Type:        "boolean",
 						Description: "Whether to include logs from previous terminated containers (default: false)",
@@ -76,7 +92,10 @@ func workloadLogsHandler(params api.ToolHandlerParams) (*api.ToolCallResult, err
 
 	// Extract optional parameters
 	container, _ := params.GetArguments()["container"].(string)
-	since, _ := params.GetArguments()["since"].(string)
+	since, err := durationArgument(params, "since")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
 	tail, _ := params.GetArguments()["tail"]
 	previous, _ := params.GetArguments()["previous"]
 
@@ -89,6 +108,26 @@ func workloadLogsHandler(params api.ToolHandlerParams) (*api.ToolCallResult, err
 		duration = since
 	}
 
+	// Convert the absolute time window bounds to Unix timestamps
+	var untilTime string
+	if v, _ := params.GetArguments()["sinceTime"].(string); v != "" {
+		if sinceTime, err = parseTimestamp(v); err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("invalid sinceTime: %v", err)), nil
+		}
+	}
+	if v, _ := params.GetArguments()["untilTime"].(string); v != "" {
+		if untilTime, err = parseTimestamp(v); err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("invalid untilTime: %v", err)), nil
+		}
+	}
+	if sinceTime != "" && untilTime != "" {
+		since, _ := strconv.ParseInt(sinceTime, 10, 64)
+		until, _ := strconv.ParseInt(untilTime, 10, 64)
+		if until < since {
+			return api.NewToolCallResult("", fmt.Errorf("untilTime must not be before sinceTime")), nil
+		}
+	}
+
 	// Convert tail to maxLines
 	if tail != nil {
 		switch v := tail.(type) {
@@ -101,6 +140,15 @@ func workloadLogsHandler(params api.ToolHandlerParams) (*api.ToolCallResult, err
 		}
 	}
 
+	// Limit the number of pods the logs are fetched from
+	maxPods := 0
+	if v, ok := params.GetArguments()["maxPods"].(float64); ok {
+		if v < 1 || v != float64(int(v)) {
+			return api.NewToolCallResult("", fmt.Errorf("maxPods must be a positive integer")), nil
+		}
+		maxPods = int(v)
+	}
+
 	// Convert previous to sinceTime (Unix timestamp)
 	if previous != nil {
 		if prevBool, ok := previous.(bool); ok && prevBool {
@@ -109,59 +157,34 @@ func workloadLogsHandler(params api.ToolHandlerParams) (*api.ToolCallResult, err
 		}
 	}
 
-	// If no container specified, we need to get workload details first to find the main app container
-	if container == "" {
-		workloadDetails, err := params.WorkloadDetails(params.Context, namespace, workload)
-		if err != nil {
-			return api.NewToolCallResult("", fmt.Errorf("failed to get workload details: %v", err)), nil
-		}
-
-		// Parse the workload details JSON to extract container names
-		var workloadData struct {
-			Pods []struct {
-				Name       string `json:"name"`
-				Containers []struct {
-					Name string `json:"name"`
-				} `json:"containers"`
-			} `json:"pods"`
-		}
-
-		if err := json.Unmarshal([]byte(workloadDetails), &workloadData); err != nil {
-			return api.NewToolCallResult("", fmt.Errorf("failed to parse workload details: %v", err)), nil
-		}
-
-		if len(workloadData.Pods) == 0 {
-			return api.NewToolCallResult("", fmt.Errorf("no pods found for workload %s in namespace %s", workload, namespace)), nil
-		}
-
-		// Find the main application container (not istio-proxy or istio-init)
-		for _, pod := range workloadData.Pods {
-			for _, c := range pod.Containers {
-				if c.Name != "istio-proxy" && c.Name != "istio-init" {
-					container = c.Name
-					break
-				}
-			}
-			if container != "" {
-				break
-			}
-		}
-
-		// If no app container found, use the first container
-		if container == "" && len(workloadData.Pods) > 0 && len(workloadData.Pods[0].Containers) > 0 {
-			container = workloadData.Pods[0].Containers[0].Name
-		}
-	}
-
-	if container == "" {
-		return api.NewToolCallResult("", fmt.Errorf("no container found for workload %s in namespace %s", workload, namespace)), nil
-	}
-
-	// Use the WorkloadLogs method with the correct parameters
-	logs, err := params.WorkloadLogs(params.Context, namespace, workload, container, service, duration, logType, sinceTime, maxLines)
+	// Use the WorkloadLogs method with the correct parameters. If no container is specified,
+	// WorkloadLogs selects the main application container of each pod
+	logs, err := params.WorkloadLogs(params.Context, namespace, workload, internalkiali.WorkloadLogsOptions{
+		Container: container,
+		Service:   service,
+		Duration:  duration,
+		LogType:   logType,
+		SinceTime: sinceTime,
+		UntilTime: untilTime,
+		MaxLines:  maxLines,
+		MaxPods:   maxPods,
+	})
 	if err != nil {
 		return api.NewToolCallResult("", fmt.Errorf("failed to get workload logs: %v", err)), nil
 	}
 
 	return api.NewToolCallResult(logs, nil), nil
 }
+
+// parseTimestamp converts an RFC 3339 timestamp or Unix timestamp in seconds to a Unix timestamp in seconds.
+func parseTimestamp(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if _, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return value, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return "", fmt.Errorf("%q must be an RFC 3339 timestamp (e.g. 2024-01-01T10:00:00Z) or a Unix timestamp in seconds", value)
+	}
+	return strconv.FormatInt(t.Unix(), 10), nil
+}
@@ -2,6 +2,7 @@ package kiali
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -198,7 +199,7 @@ func TestWorkloadLogs_KialiClient(t *testing.T) {
 			kialiClient := internalkiali.NewFromConfig(cfg)
 
 			// Test the WorkloadLogs method
-			result, err := kialiClient.WorkloadLogs(context.Background(), tt.namespace, tt.workload, tt.container, "", "", "", "", "")
+			result, err := kialiClient.WorkloadLogs(context.Background(), tt.namespace, tt.workload, internalkiali.WorkloadLogsOptions{Container: tt.container})
 
 			// Check for expected errors
 			if tt.expectedError {
@@ -533,12 +534,12 @@ func TestWorkloadLogsRealWorldScenarios(t *testing.T) {
 				context.Background(),
 				scenario.namespace,
 				scenario.workload,
-				scenario.container,
-				"",                               // service
-				scenario.queryParams["since"],    // duration
-				"",                               // logType
-				scenario.queryParams["previous"], // sinceTime (for previous logs)
-				scenario.queryParams["tail"],     // maxLines
+				internalkiali.WorkloadLogsOptions{
+					Container: scenario.container,
+					Duration:  scenario.queryParams["since"],
+					SinceTime: scenario.queryParams["previous"], // for previous logs
+					MaxLines:  scenario.queryParams["tail"],
+				},
 			)
 
 			if err != nil {
@@ -560,3 +561,278 @@ func TestWorkloadLogsRealWorldScenarios(t *testing.T) {
 		})
 	}
 }
+
+func TestWorkloadLogsContainerSelection_KialiClient(t *testing.T) {
+	tests := []struct {
+		name              string
+		preferred         string
+		containers        string
+		expectedContainer string
+	}{
+		{
+			name:              "first application container in name order",
+			containers:        `[{"name": "zipkin-exporter"}, {"name": "istio-proxy"}, {"name": "reviews-v1"}, {"name": "config-reloader"}]`,
+			expectedContainer: "config-reloader",
+		},
+		{
+			name:              "order of the containers in the pod does not matter",
+			containers:        `[{"name": "reviews-v1"}, {"name": "config-reloader"}, {"name": "istio-proxy"}, {"name": "zipkin-exporter"}]`,
+			expectedContainer: "config-reloader",
+		},
+		{
+			name:              "preferred container matching the workload name",
+			preferred:         "^{workload}$",
+			containers:        `[{"name": "zipkin-exporter"}, {"name": "istio-proxy"}, {"name": "reviews-v1"}, {"name": "config-reloader"}]`,
+			expectedContainer: "reviews-v1",
+		},
+		{
+			name:              "preferred container pattern without match",
+			preferred:         "^app$",
+			containers:        `[{"name": "zipkin-exporter"}, {"name": "reviews-v1"}]`,
+			expectedContainer: "reviews-v1",
+		},
+		{
+			name:              "only istio containers",
+			containers:        `[{"name": "istio-proxy"}, {"name": "istio-init"}]`,
+			expectedContainer: "istio-init",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requestedContainer string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if strings.HasSuffix(r.URL.Path, "/logs") {
+					requestedContainer = r.URL.Query().Get("container")
+					w.Write([]byte(`{"entries": []}`))
+					return
+				}
+				w.Write([]byte(`{"pods": [{"name": "reviews-v1-pod-1", "containers": ` + tt.containers + `}]}`))
+			}))
+			defer server.Close()
+
+			kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{
+				KialiServerURL:              server.URL,
+				KialiLogsPreferredContainer: tt.preferred,
+			})
+			if _, err := kialiClient.WorkloadLogs(context.Background(), "bookinfo", "reviews-v1", internalkiali.WorkloadLogsOptions{}); err != nil {
+				t.Fatalf("Expected no error, but got: %v", err)
+			}
+			if requestedContainer != tt.expectedContainer {
+				t.Errorf("Expected container '%s', got '%s'", tt.expectedContainer, requestedContainer)
+			}
+		})
+	}
+}
+
+func TestWorkloadLogsMaxPods_KialiClient(t *testing.T) {
+	var requestedPods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/logs") {
+			pod := strings.Split(r.URL.Path, "/")[5]
+			requestedPods = append(requestedPods, pod)
+			w.Write([]byte(`{"entries": [{"message": "log of ` + pod + `"}]}`))
+			return
+		}
+		w.Write([]byte(`{"pods": [
+			{"name": "reviews-v1-d", "containers": [{"name": "reviews"}]},
+			{"name": "reviews-v1-b", "containers": [{"name": "reviews"}]},
+			{"name": "reviews-v1-a", "containers": [{"name": "reviews"}]},
+			{"name": "reviews-v1-c", "containers": [{"name": "reviews"}]}
+		]}`))
+	}))
+	defer server.Close()
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: server.URL})
+
+	result, err := kialiClient.WorkloadLogs(context.Background(), "bookinfo", "reviews-v1", internalkiali.WorkloadLogsOptions{MaxPods: 2})
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if strings.Join(requestedPods, ",") != "reviews-v1-a,reviews-v1-b" {
+		t.Errorf("Expected the logs of the first 2 pods by name, got %v", requestedPods)
+	}
+	if !strings.Contains(result, "2 more pods omitted") {
+		t.Errorf("Expected the omitted pods to be noted, got %s", result)
+	}
+
+	requestedPods = nil
+	result, err = kialiClient.WorkloadLogs(context.Background(), "bookinfo", "reviews-v1", internalkiali.WorkloadLogsOptions{})
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if len(requestedPods) != 4 {
+		t.Errorf("Expected the logs of all 4 pods within the default limit, got %v", requestedPods)
+	}
+	if strings.Contains(result, "omitted") {
+		t.Errorf("Expected no omitted pods, got %s", result)
+	}
+}
+
+func TestPodLogsUntilTime_KialiClient(t *testing.T) {
+	// 2024-01-01T10:00:00Z
+	until := "1704103200"
+	tests := []struct {
+		name         string
+		mockResponse string
+		expected     []string
+		unexpected   []string
+	}{
+		{
+			name: "Kiali entries with Unix timestamps",
+			mockResponse: `{"entries": [
+				{"message": "before", "timestampUnix": 1704103140000},
+				{"message": "at end", "timestampUnix": 1704103200000},
+				{"message": "after", "timestampUnix": 1704103260000}
+			], "linesTruncated": false}`,
+			expected:   []string{"before", "at end", "linesTruncated"},
+			unexpected: []string{"after"},
+		},
+		{
+			name: "Kiali entries with RFC 3339 timestamps",
+			mockResponse: `{"entries": [
+				{"message": "before", "timestamp": "2024-01-01T09:59:59.123Z"},
+				{"message": "after", "timestamp": "2024-01-01T10:00:00.001Z"},
+				{"message": "no timestamp"}
+			]}`,
+			expected:   []string{"before", "no timestamp"},
+			unexpected: []string{"after"},
+		},
+		{
+			name:         "plain text lines",
+			mockResponse: "2024-01-01T09:00:00Z INFO before\n  at stacktrace line\n2024-01-01T11:00:00Z INFO after",
+			expected:     []string{"before", "stacktrace line"},
+			unexpected:   []string{"after"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Query().Has("untilTime") {
+					t.Errorf("untilTime must not be sent to Kiali")
+				}
+				w.Write([]byte(tt.mockResponse))
+			}))
+			defer server.Close()
+
+			kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: server.URL})
+			result, err := kialiClient.PodLogs(context.Background(), "bookinfo", "reviews-v1-pod-1", "reviews", "", "", "", "", "1704099600", until, "")
+			if err != nil {
+				t.Fatalf("Expected no error, but got: %v", err)
+			}
+			for _, expected := range tt.expected {
+				if !strings.Contains(result, expected) {
+					t.Errorf("Expected result to contain '%s', got: %s", expected, result)
+				}
+			}
+			for _, unexpected := range tt.unexpected {
+				if strings.Contains(result, unexpected) {
+					t.Errorf("Expected result not to contain '%s', got: %s", unexpected, result)
+				}
+			}
+		})
+	}
+
+	t.Run("invalid untilTime", func(t *testing.T) {
+		kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: "http://localhost"})
+		if _, err := kialiClient.PodLogs(context.Background(), "bookinfo", "pod", "reviews", "", "", "", "", "", "yesterday", ""); err == nil {
+			t.Errorf("Expected an error for an invalid untilTime")
+		}
+	})
+}
+
+func TestParseTimestamp(t *testing.T) {
+	tests := []struct {
+		value       string
+		expected    string
+		expectError bool
+	}{
+		{value: "1704103200", expected: "1704103200"},
+		{value: "2024-01-01T10:00:00Z", expected: "1704103200"},
+		{value: "2024-01-01T11:00:00+01:00", expected: "1704103200"},
+		{value: "yesterday", expectError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			actual, err := parseTimestamp(tt.value)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected an error for '%s'", tt.value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, but got: %v", err)
+			}
+			if actual != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestWorkloadLogsDetailsRetry_KialiClient(t *testing.T) {
+	tests := []struct {
+		name            string
+		detailsFailures int
+		detailsStatus   int
+		logsStatus      int
+		maxRetries      int
+		expectedCalls   int
+		expectedError   string
+	}{
+		{name: "transient details failure is retried", detailsFailures: 2, detailsStatus: http.StatusServiceUnavailable, logsStatus: http.StatusOK, expectedCalls: 3},
+		{name: "details keep failing", detailsFailures: 5, detailsStatus: http.StatusBadGateway, logsStatus: http.StatusOK, expectedCalls: 3,
+			expectedError: "could not discover the pods of workload 'reviews-v1' in namespace 'bookinfo'"},
+		{name: "request retries count towards the details attempts", detailsFailures: 5, detailsStatus: http.StatusServiceUnavailable, logsStatus: http.StatusOK, maxRetries: 2, expectedCalls: 3,
+			expectedError: "could not discover the pods of workload 'reviews-v1' in namespace 'bookinfo'"},
+		{name: "non transient details failure is not retried", detailsFailures: 5, detailsStatus: http.StatusForbidden, logsStatus: http.StatusOK, expectedCalls: 1,
+			expectedError: "could not discover the pods of workload 'reviews-v1' in namespace 'bookinfo'"},
+		{name: "logs of no pod fetched", logsStatus: http.StatusInternalServerError, expectedCalls: 1,
+			expectedError: "could not fetch the logs of the pods of workload reviews-v1 in namespace bookinfo"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detailsCalls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if strings.HasSuffix(r.URL.Path, "/logs") {
+					w.WriteHeader(tt.logsStatus)
+					w.Write([]byte(`{"entries": [{"message": "started"}]}`))
+					return
+				}
+				detailsCalls++
+				if detailsCalls <= tt.detailsFailures {
+					w.WriteHeader(tt.detailsStatus)
+					return
+				}
+				w.Write([]byte(`{"pods": [{"name": "reviews-v1-pod-1", "containers": [{"name": "reviews"}]}]}`))
+			}))
+			defer server.Close()
+			kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: server.URL, KialiMaxRetries: tt.maxRetries})
+
+			result, err := kialiClient.WorkloadLogs(context.Background(), "bookinfo", "reviews-v1", internalkiali.WorkloadLogsOptions{})
+			if detailsCalls != tt.expectedCalls {
+				t.Errorf("Expected %d workload details calls, got %d", tt.expectedCalls, detailsCalls)
+			}
+			if tt.expectedError == "" {
+				if err != nil {
+					t.Fatalf("Expected no error, but got: %v", err)
+				}
+				if !strings.Contains(result, "started") {
+					t.Errorf("Expected the pod logs, got %s", result)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+				t.Fatalf("Expected error containing '%s', got %v", tt.expectedError, err)
+			}
+			var discoveryErr *internalkiali.PodDiscoveryError
+			if errors.As(err, &discoveryErr) != (tt.detailsFailures > 0) {
+				t.Errorf("Expected a PodDiscoveryError only when the pods could not be discovered, got %T", err)
+			}
+		})
+	}
+}
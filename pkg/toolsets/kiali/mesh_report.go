@@ -0,0 +1,59 @@
+package kiali
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/kiali/kiali-mcp-server/pkg/api"
+)
+
+func initMeshReport() []api.ServerTool {
+	ret := make([]api.ServerTool, 0)
+	ret = append(ret, api.ServerTool{
+		Tool: api.Tool{
+			Name:        "mesh_report",
+			Description: "Get a one-shot report of the mesh combining the control plane health, the mesh health summary and the Istio config validation error and warning counts. Sections are retrieved with bounded parallelism and a shared deadline; sections that cannot be retrieved in time are reported under 'errors' while the others are still returned, and the time each section took is reported under 'timings'",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespaces": {
+						Type:        "string",
+						Description: "Comma-separated list of namespaces for the health and validations sections (e.g. 'bookinfo' or 'bookinfo,default'). If not provided, covers all accessible namespaces",
+					},
+					"rateInterval": {
+						Type:        "string",
+						Description: "Rate interval for fetching error rate (e.g., '10m', '5m', '1h'). Default: '10m'",
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Mesh Status: Report",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		},
+		Handler: meshReportHandler,
+		// Aggregates the mesh status, health and validations, which can be slow on large meshes
+		Timeout: 2 * time.Minute,
+	})
+	return ret
+}
+
+func meshReportHandler(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	namespaces, _ := params.GetArguments()["namespaces"].(string)
+	rateInterval, err := durationArgument(params, "rateInterval")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+
+	content, err := params.MeshReport(params.Context, namespaces, rateInterval)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get mesh report: %v", err)), nil
+	}
+	return api.NewToolCallResult(content, nil), nil
+}
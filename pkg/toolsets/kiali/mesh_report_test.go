@@ -0,0 +1,141 @@
+package kiali
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali-mcp-server/pkg/config"
+	internalkiali "github.com/kiali/kiali-mcp-server/pkg/kiali"
+)
+
+func TestMeshReport_KialiClient(t *testing.T) {
+	mockHandler := func(failing string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == failing {
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte("boom"))
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			switch r.URL.Path {
+			case "/api/mesh/graph":
+				_, _ = w.Write([]byte(`{"elements": {"nodes": [{"data": {"infraType": "istiod", "infraName": "istiod", "healthData": "Healthy"}}]}}`))
+			case "/api/clusters/health":
+				_, _ = w.Write([]byte(clustersHealthResponses[r.URL.Query().Get("type")]))
+			case "/api/istio/validations":
+				_, _ = w.Write([]byte(`[
+					{"cluster": "east", "namespace": "bookinfo", "errors": 2, "warnings": 1, "objectCount": 5},
+					{"cluster": "east", "namespace": "default", "errors": 0, "warnings": 3, "objectCount": 1}
+				]`))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}
+	}
+	newMockServer := func(failing string) *httptest.Server {
+		return httptest.NewServer(mockHandler(failing))
+	}
+
+	t.Run("all sections", func(t *testing.T) {
+		mockServer := newMockServer("")
+		defer mockServer.Close()
+		kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+
+		result, err := kialiClient.MeshReport(context.Background(), "", "")
+		require.NoError(t, err)
+		var report internalkiali.MeshReport
+		require.NoError(t, json.Unmarshal([]byte(result), &report))
+
+		require.NotNil(t, report.ControlPlane)
+		assert.True(t, report.ControlPlane.Healthy)
+		require.NotNil(t, report.Health)
+		assert.Equal(t, internalkiali.HealthStatusUnhealthy, report.Health.OverallStatus)
+		require.NotNil(t, report.Validations)
+		assert.Equal(t, 2, report.Validations.Errors)
+		assert.Equal(t, 4, report.Validations.Warnings)
+		assert.Len(t, report.Validations.Namespaces, 2)
+		assert.Empty(t, report.Errors)
+		assert.ElementsMatch(t, []string{"controlPlane", "health", "validations"}, slices.Collect(maps.Keys(report.Timings)))
+		for section, timing := range report.Timings {
+			_, err := time.ParseDuration(timing)
+			assert.NoError(t, err, "timing of section %s", section)
+		}
+	})
+
+	t.Run("partial failure", func(t *testing.T) {
+		mockServer := newMockServer("/api/istio/validations")
+		defer mockServer.Close()
+		kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+
+		result, err := kialiClient.MeshReport(context.Background(), "bookinfo", "")
+		require.NoError(t, err)
+		var report internalkiali.MeshReport
+		require.NoError(t, json.Unmarshal([]byte(result), &report))
+
+		assert.NotNil(t, report.ControlPlane)
+		assert.NotNil(t, report.Health)
+		assert.Nil(t, report.Validations)
+		assert.Contains(t, report.Errors["validations"], "boom")
+		assert.Contains(t, report.Timings, "validations", "failed sections are timed too")
+	})
+
+	t.Run("nested validations summary", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"east": {"bookinfo": {"errors": 1, "warnings": 0, "objectCount": 2}}}`))
+		}))
+		defer mockServer.Close()
+		kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+
+		result, err := kialiClient.MeshReport(context.Background(), "", "")
+		require.NoError(t, err)
+		var report internalkiali.MeshReport
+		require.NoError(t, json.Unmarshal([]byte(result), &report))
+		require.NotNil(t, report.Validations)
+		assert.Equal(t, []internalkiali.NamespaceValidationSummary{{Cluster: "east", Namespace: "bookinfo", Errors: 1, ObjectCount: 2}}, report.Validations.Namespaces)
+	})
+
+	t.Run("slow section cut at the deadline of the call", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/api/istio/validations" {
+				<-r.Context().Done()
+				return
+			}
+			mockHandler("")(w, r)
+		}))
+		defer mockServer.Close()
+		kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		result, err := kialiClient.MeshReport(ctx, "", "")
+		require.NoError(t, err)
+		assert.NoError(t, ctx.Err(), "the report is returned before the deadline of the call")
+		var report internalkiali.MeshReport
+		require.NoError(t, json.Unmarshal([]byte(result), &report))
+		assert.NotNil(t, report.ControlPlane)
+		assert.Nil(t, report.Validations)
+		assert.Contains(t, report.Errors, "validations")
+	})
+
+	t.Run("all sections failing", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer mockServer.Close()
+		kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+
+		_, err := kialiClient.MeshReport(context.Background(), "", "")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "all mesh report sections failed")
+	})
+}
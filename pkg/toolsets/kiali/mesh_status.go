@@ -29,6 +29,42 @@ func initMeshStatus() []api.ServerTool {
 			},
 		}, Handler: meshStatusHandler,
 	})
+	ret = append(ret, api.ServerTool{
+		Tool: api.Tool{
+			Name:        "control_plane_health",
+			Description: "Check whether the mesh control plane components (istiod, Kiali, Prometheus, Grafana, tracing) are healthy. Returns the status of each component and an overall healthy flag. This is the first thing to check when the mesh misbehaves",
+			InputSchema: &jsonschema.Schema{
+				Type:       "object",
+				Properties: map[string]*jsonschema.Schema{},
+				Required:   []string{},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Mesh Status: Control Plane Health",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: controlPlaneHealthHandler,
+	})
+	ret = append(ret, api.ServerTool{
+		Tool: api.Tool{
+			Name:        "kiali_ping",
+			Description: "Check that this server can reach Kiali, before blaming the mesh: issues a lightweight request to the Kiali status API and returns the round-trip latency, the HTTP status, the Kiali version and the TLS details (certificate subject, issuer and expiry, whether it is trusted, whether insecure mode is active). A self-diagnostic distinct from the mesh health",
+			InputSchema: &jsonschema.Schema{
+				Type:       "object",
+				Properties: map[string]*jsonschema.Schema{},
+				Required:   []string{},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Mesh Status: Kiali Ping",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: kialiPingHandler,
+	})
 	return ret
 }
 
@@ -39,3 +75,19 @@ func meshStatusHandler(params api.ToolHandlerParams) (*api.ToolCallResult, error
 	}
 	return api.NewToolCallResult(content, nil), nil
 }
+
+func controlPlaneHealthHandler(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	content, err := params.ControlPlaneHealth(params.Context)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to retrieve control plane health: %v", err)), nil
+	}
+	return api.NewToolCallResult(content, nil), nil
+}
+
+func kialiPingHandler(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	content, err := params.Ping(params.Context)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to ping kiali: %v", err)), nil
+	}
+	return api.NewToolCallResult(content, nil), nil
+}
@@ -0,0 +1,151 @@
+package kiali
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali-mcp-server/pkg/config"
+	internalkiali "github.com/kiali/kiali-mcp-server/pkg/kiali"
+	internalk8s "github.com/kiali/kiali-mcp-server/pkg/kubernetes"
+)
+
+func TestControlPlaneHealth_KialiClient(t *testing.T) {
+	tests := []struct {
+		name               string
+		nodes              string
+		expectedComponents map[string]string
+		expectedHealthy    bool
+	}{
+		{
+			name: "all components healthy, tracing not installed",
+			nodes: `[
+				{"data": {"id": "c1", "nodeType": "box", "infraType": "cluster", "infraName": "Kubernetes"}},
+				{"data": {"id": "n1", "infraType": "istiod", "infraName": "istiod", "healthData": "Healthy"}},
+				{"data": {"id": "n2", "infraType": "kiali", "infraName": "kiali"}},
+				{"data": {"id": "n3", "infraType": "metricStore", "infraName": "Prometheus", "healthData": "Healthy"}},
+				{"data": {"id": "n4", "infraType": "grafana", "infraName": "Grafana", "healthData": "Healthy"}},
+				{"data": {"id": "n5", "infraType": "traceStore", "infraName": "jaeger", "healthData": "NotFound"}},
+				{"data": {"id": "n6", "infraType": "dataplane", "infraName": "Data Plane"}}
+			]`,
+			expectedComponents: map[string]string{"istiod": "Healthy", "kiali": "Healthy", "Prometheus": "Healthy", "Grafana": "Healthy", "jaeger": "NotFound"},
+			expectedHealthy:    true,
+		},
+		{
+			name: "istiod unhealthy in one of two clusters",
+			nodes: `[
+				{"data": {"id": "n1", "cluster": "east", "infraType": "istiod", "infraName": "istiod", "healthData": "Healthy"}},
+				{"data": {"id": "n2", "cluster": "west", "infraType": "istiod", "infraName": "istiod", "healthData": "Unhealthy"}},
+				{"data": {"id": "n3", "infraType": "metricStore", "infraName": "Prometheus", "healthData": "Healthy"}}
+			]`,
+			expectedComponents: map[string]string{"istiod": "Healthy", "istiod@west": "Unhealthy", "Prometheus": "Healthy"},
+			expectedHealthy:    false,
+		},
+		{
+			name: "prometheus unreachable",
+			nodes: `[
+				{"data": {"id": "n1", "infraType": "istiod", "infraName": "istiod", "healthData": "Healthy"}},
+				{"data": {"id": "n3", "infraType": "metricStore", "infraName": "Prometheus", "healthData": "Unreachable"}}
+			]`,
+			expectedComponents: map[string]string{"istiod": "Healthy", "Prometheus": "Unreachable"},
+			expectedHealthy:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/api/mesh/graph", r.URL.Path)
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"elements": {"nodes": ` + tt.nodes + `, "edges": []}}`))
+			}))
+			defer mockServer.Close()
+
+			kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+			result, err := kialiClient.ControlPlaneHealth(context.Background())
+			require.NoError(t, err)
+
+			var health internalkiali.ControlPlaneHealth
+			require.NoError(t, json.Unmarshal([]byte(result), &health))
+			assert.Equal(t, tt.expectedComponents, health.Components)
+			assert.Equal(t, tt.expectedHealthy, health.Healthy)
+		})
+	}
+}
+
+func TestPing_KialiClient(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/status", r.URL.Path)
+		if r.Header.Get("Authorization") == "Bearer expired" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":"Unauthorized"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status": {"Kiali state": "running", "Kiali version": "v2.10.0"}}`))
+	})
+
+	t.Run("plain HTTP", func(t *testing.T) {
+		mockServer := httptest.NewServer(handler)
+		defer mockServer.Close()
+
+		result, err := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL}).Ping(context.Background())
+		require.NoError(t, err)
+		var ping internalkiali.KialiPing
+		require.NoError(t, json.Unmarshal([]byte(result), &ping))
+		assert.Equal(t, mockServer.URL+"/api/status", ping.URL)
+		assert.True(t, ping.Reachable)
+		assert.Equal(t, http.StatusOK, ping.StatusCode)
+		assert.Equal(t, "v2.10.0", ping.KialiVersion)
+		assert.Nil(t, ping.TLS)
+		assert.Empty(t, ping.Error)
+	})
+
+	t.Run("insecure TLS with an untrusted certificate", func(t *testing.T) {
+		mockServer := httptest.NewTLSServer(handler)
+		defer mockServer.Close()
+
+		result, err := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL, KialiInsecure: true}).Ping(context.Background())
+		require.NoError(t, err)
+		var ping internalkiali.KialiPing
+		require.NoError(t, json.Unmarshal([]byte(result), &ping))
+		assert.True(t, ping.Reachable)
+		assert.True(t, ping.Insecure)
+		require.NotNil(t, ping.TLS)
+		assert.Contains(t, ping.TLS.Subject, "Acme Co")
+		assert.False(t, ping.TLS.Verified)
+		assert.NotEmpty(t, ping.TLS.VerifyError)
+		assert.Positive(t, ping.TLS.ExpiresInDays)
+	})
+
+	t.Run("unauthorized", func(t *testing.T) {
+		mockServer := httptest.NewServer(handler)
+		defer mockServer.Close()
+
+		ctx := context.WithValue(context.Background(), internalk8s.OAuthAuthorizationHeader, "Bearer expired")
+		result, err := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL}).Ping(ctx)
+		require.NoError(t, err)
+		var ping internalkiali.KialiPing
+		require.NoError(t, json.Unmarshal([]byte(result), &ping))
+		assert.True(t, ping.Reachable)
+		assert.Equal(t, http.StatusUnauthorized, ping.StatusCode)
+		assert.Contains(t, ping.Error, "Unauthorized")
+	})
+
+	t.Run("unreachable", func(t *testing.T) {
+		mockServer := httptest.NewServer(handler)
+		mockServer.Close()
+
+		result, err := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL}).Ping(context.Background())
+		require.NoError(t, err)
+		var ping internalkiali.KialiPing
+		require.NoError(t, json.Unmarshal([]byte(result), &ping))
+		assert.False(t, ping.Reachable)
+		assert.Zero(t, ping.StatusCode)
+		assert.NotEmpty(t, ping.Error)
+	})
+}
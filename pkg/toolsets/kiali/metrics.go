@@ -0,0 +1,303 @@
+package kiali
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/kiali/kiali-mcp-server/pkg/api"
+	internalkiali "github.com/kiali/kiali-mcp-server/pkg/kiali"
+)
+
+func initMetrics() []api.ServerTool {
+	ret := make([]api.ServerTool, 0)
+	ret = append(ret, api.ServerTool{
+		Tool: api.Tool{
+			Name:        "metrics",
+			Description: "Get the raw Kiali metrics of an app, service or workload for an explicit list of metric names. Use it for metrics that the specialized workload_metrics and service_metrics tools do not cover",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace containing the entity",
+					},
+					"type": {
+						Type:        "string",
+						Description: "Type of the entity: 'app', 'service' or 'workload'",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the entity to get metrics for",
+					},
+					"filters": {
+						Type:        "string",
+						Description: "Comma-separated list of Kiali metric names to fetch (e.g., 'request_count,request_error_count,request_duration_millis,tcp_sent,tcp_received'). If not provided, Kiali returns its default metrics set",
+					},
+					"direction": {
+						Type:        "string",
+						Description: "Traffic direction: 'inbound' or 'outbound'. Optional, defaults to 'outbound'",
+					},
+					"reporter": {
+						Type:        "string",
+						Description: "Metrics reporter: 'source', 'destination', or 'both'. Optional, defaults to 'source'",
+					},
+					"duration": {
+						Type:        "string",
+						Description: "Duration of the query period, in seconds or as a duration (e.g., '1800' or '30m'). Optional, defaults to 1800 seconds",
+					},
+					"step": {
+						Type:        "string",
+						Description: "Step between data points, in seconds or as a duration (e.g., '15' or '1m'). Optional, defaults to 15 seconds",
+					},
+					"rateInterval": {
+						Type:        "string",
+						Description: "Rate interval for metrics (e.g., '1m', '5m'). Optional, defaults to '1m'",
+					},
+					"quantiles": {
+						Type:        "string",
+						Description: "Comma-separated list of quantiles for histogram metrics, either as ratios or percentiles (e.g., '0.5,0.95,0.99' or 'p95,p99'). Optional",
+					},
+					"byLabels": {
+						Type:        "string",
+						Description: "Comma-separated list of labels to group metrics by (e.g., 'source_workload,destination_service'). Optional",
+					},
+					"sparkline": {
+						Type:        "string",
+						Description: "Optional name of a single metric of the response (e.g. 'request_count', or 'request_duration_millis:0.95' for a histogram statistic, 'avg' by default) to return as a compact unicode sparkline with its min, max and last values instead of the full metrics, for an at-a-glance trend. The series of the metric are summed over their labels",
+					},
+					"sparklineWidth": {
+						Type:        "integer",
+						Description: "Number of points of the sparkline, the series being downsampled to it (default: 20)",
+						Minimum:     ptr.To(float64(1)),
+					},
+				},
+				Required: []string{"namespace", "type", "name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Metrics: Raw",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: metricsHandler,
+	})
+	ret = append(ret, api.ServerTool{
+		Tool: api.Tool{
+			Name:        "reporter_error_delta",
+			Description: "Compare the request and error rates of an app, service or workload as reported by the source (caller) and the destination (callee) proxies, to locate where failures originate: errors seen only by the callers point to the network layer (timeouts, connection resets, circuit breaking, unreachable endpoints), errors reported by both point to the application itself",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace containing the entity",
+					},
+					"type": {
+						Type:        "string",
+						Description: "Type of the entity: 'app', 'service' or 'workload'",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the entity to compare the reporters of",
+					},
+					"direction": {
+						Type:        "string",
+						Description: "Traffic direction: 'inbound' (requests to the entity) or 'outbound' (requests from the entity). Optional, defaults to 'inbound'",
+					},
+					"duration": {
+						Type:        "string",
+						Description: "Duration of the compared period, in seconds or as a duration (e.g., '1800' or '30m'). Optional, defaults to 600 seconds",
+					},
+				},
+				Required: []string{"namespace", "type", "name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Metrics: Reporter Error Delta",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: reporterErrorDeltaHandler,
+	})
+	return ret
+}
+
+func metricsHandler(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	// Extract required parameters
+	namespace, _ := params.GetArguments()["namespace"].(string)
+	entityType, _ := params.GetArguments()["type"].(string)
+	name, _ := params.GetArguments()["name"].(string)
+
+	if namespace == "" {
+		return api.NewToolCallResult("", fmt.Errorf("namespace parameter is required")), nil
+	}
+	if !slices.Contains(internalkiali.MetricsEntityTypes, entityType) {
+		return api.NewToolCallResult("", fmt.Errorf("invalid type parameter: must be one of %s", strings.Join(internalkiali.MetricsEntityTypes, ", "))), nil
+	}
+	if name == "" {
+		return api.NewToolCallResult("", fmt.Errorf("name parameter is required")), nil
+	}
+
+	// Extract optional query parameters
+	queryParams := make(map[string]string)
+	for _, key := range []string{"direction", "reporter"} {
+		if value, ok := params.GetArguments()[key].(string); ok && value != "" {
+			queryParams[key] = value
+		}
+	}
+	if err := metricsWindowArguments(params, queryParams); err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	filtersArg, _ := params.GetArguments()["filters"].(string)
+	byLabelsArg, _ := params.GetArguments()["byLabels"].(string)
+	quantilesArg, _ := params.GetArguments()["quantiles"].(string)
+	quantiles, err := parseQuantiles(quantilesArg)
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	sparkline, sparklineWidth, err := sparklineArguments(params)
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+
+	content, err := params.Metrics(params.Context, namespace, entityType, name, splitList(filtersArg), splitList(byLabelsArg), queryParams, quantiles)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get %s metrics: %v", entityType, err)), nil
+	}
+	if sparkline != "" {
+		return metricSparklineResult(content, sparkline, sparklineWidth)
+	}
+	return api.NewToolCallResult(content, nil), nil
+}
+
+func reporterErrorDeltaHandler(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	namespace, _ := params.GetArguments()["namespace"].(string)
+	entityType, _ := params.GetArguments()["type"].(string)
+	name, _ := params.GetArguments()["name"].(string)
+	direction, _ := params.GetArguments()["direction"].(string)
+	duration, err := secondsArgument(params, "duration")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+
+	if namespace == "" {
+		return api.NewToolCallResult("", fmt.Errorf("namespace parameter is required")), nil
+	}
+	if !slices.Contains(internalkiali.MetricsEntityTypes, entityType) {
+		return api.NewToolCallResult("", fmt.Errorf("invalid type parameter: must be one of %s", strings.Join(internalkiali.MetricsEntityTypes, ", "))), nil
+	}
+	if name == "" {
+		return api.NewToolCallResult("", fmt.Errorf("name parameter is required")), nil
+	}
+	if direction != "" && direction != "inbound" && direction != "outbound" {
+		return api.NewToolCallResult("", fmt.Errorf("invalid direction parameter: must be 'inbound' or 'outbound'")), nil
+	}
+
+	content, err := params.ReporterErrorDelta(params.Context, namespace, entityType, name, direction, duration)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get reporter error delta: %v", err)), nil
+	}
+	return api.NewToolCallResult(content, nil), nil
+}
+
+// metricsWindowArguments sets the normalized `duration` and `step` (in seconds) and `rateInterval` arguments of
+// the metrics tools in the query parameters.
+func metricsWindowArguments(params api.ToolHandlerParams, queryParams map[string]string) error {
+	for _, key := range []string{"duration", "step"} {
+		value, err := secondsArgument(params, key)
+		if err != nil {
+			return err
+		}
+		if value != "" {
+			queryParams[key] = value
+		}
+	}
+	rateInterval, err := durationArgument(params, "rateInterval")
+	if err != nil {
+		return err
+	}
+	if rateInterval != "" {
+		queryParams["rateInterval"] = rateInterval
+	}
+	return nil
+}
+
+// sparklineArguments returns the metric to render as a sparkline, empty to return the full metrics, and the
+// width of the sparkline (0 for the default).
+func sparklineArguments(params api.ToolHandlerParams) (string, int, error) {
+	sparkline, _ := params.GetArguments()["sparkline"].(string)
+	width := 0
+	if v, ok := params.GetArguments()["sparklineWidth"].(float64); ok {
+		if v < 1 || v != float64(int(v)) {
+			return "", 0, fmt.Errorf("sparklineWidth must be a positive integer")
+		}
+		width = int(v)
+	}
+	return strings.TrimSpace(sparkline), width, nil
+}
+
+// metricSparklineResult renders a metric of the metrics response as a sparkline.
+func metricSparklineResult(content string, metric string, width int) (*api.ToolCallResult, error) {
+	content, err := internalkiali.MetricSparklineJSON(content, metric, width)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to render metric sparkline: %v", err)), nil
+	}
+	return api.NewToolCallResult(content, nil), nil
+}
+
+// splitList splits a comma-separated list, trimming the entries and dropping the empty ones.
+func splitList(value string) []string {
+	ret := make([]string, 0)
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			ret = append(ret, item)
+		}
+	}
+	return ret
+}
+
+// parseQuantiles parses a comma-separated list of quantiles, given either as ratios ("0.95") or
+// percentiles ("p95", "p99.9"), into the ratio strings expected by the Kiali metrics API.
+func parseQuantiles(value string) ([]string, error) {
+	quantiles := make([]string, 0)
+	for _, raw := range strings.Split(value, ",") {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+		number, percentile := strings.CutPrefix(strings.ToLower(raw), "p")
+		q, err := strconv.ParseFloat(number, 64)
+		if err == nil && percentile {
+			// Round to drop floating point noise such as p99.9 -> 0.9990000000000001
+			q = math.Round(q*1e6) / 1e8
+		}
+		if err != nil || q <= 0 || q >= 1 {
+			return nil, fmt.Errorf("invalid quantile '%s': must be a ratio between 0 and 1 (e.g. '0.95') or a percentile (e.g. 'p95')", raw)
+		}
+		quantiles = append(quantiles, strconv.FormatFloat(q, 'f', -1, 64))
+	}
+	return quantiles, nil
+}
+
+// attachJSONField adds the given raw JSON value as a top-level field of the JSON object in content.
+func attachJSONField(content string, field string, value string) (string, error) {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(content), &object); err != nil {
+		return "", fmt.Errorf("failed to parse response: %v", err)
+	}
+	object[field] = json.RawMessage(value)
+	result, err := json.Marshal(object)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal response: %v", err)
+	}
+	return string(result), nil
+}
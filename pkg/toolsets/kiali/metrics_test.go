@@ -0,0 +1,303 @@
+package kiali
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali-mcp-server/pkg/api"
+	"github.com/kiali/kiali-mcp-server/pkg/config"
+	internalkiali "github.com/kiali/kiali-mcp-server/pkg/kiali"
+)
+
+func TestParseQuantiles(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+		err      bool
+	}{
+		{input: "", expected: []string{}},
+		{input: "0.5, 0.95,0.99", expected: []string{"0.5", "0.95", "0.99"}},
+		{input: "p95,P99,p99.9", expected: []string{"0.95", "0.99", "0.999"}},
+		{input: "0.95,,", expected: []string{"0.95"}},
+		{input: "1", err: true},
+		{input: "p100", err: true},
+		{input: "median", err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			quantiles, err := parseQuantiles(tt.input)
+			if tt.err {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "invalid quantile")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, quantiles)
+		})
+	}
+}
+
+func TestMetricsQuantiles_KialiClient(t *testing.T) {
+	var capturedURL *url.URL
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		capturedURL = r.URL
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer mockServer.Close()
+
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+	quantiles := []string{"0.95", "0.99"}
+
+	t.Run("workload metrics", func(t *testing.T) {
+		_, err := kialiClient.WorkloadMetrics(context.Background(), "bookinfo", "reviews-v1", map[string]string{"direction": "inbound"}, quantiles)
+		require.NoError(t, err)
+		assert.Equal(t, "/api/namespaces/bookinfo/workloads/reviews-v1/metrics", capturedURL.Path)
+		assert.Equal(t, quantiles, capturedURL.Query()["quantiles[]"])
+		assert.Equal(t, "inbound", capturedURL.Query().Get("direction"))
+	})
+
+	t.Run("service metrics", func(t *testing.T) {
+		_, err := kialiClient.ServiceMetrics(context.Background(), "bookinfo", "reviews", nil, quantiles)
+		require.NoError(t, err)
+		assert.Equal(t, "/api/namespaces/bookinfo/services/reviews/metrics", capturedURL.Path)
+		assert.Equal(t, quantiles, capturedURL.Query()["quantiles[]"])
+	})
+
+	t.Run("no quantiles", func(t *testing.T) {
+		_, err := kialiClient.ServiceMetrics(context.Background(), "bookinfo", "reviews", nil, nil)
+		require.NoError(t, err)
+		assert.Empty(t, capturedURL.RawQuery)
+	})
+}
+
+func TestRequestTrend_KialiClient(t *testing.T) {
+	var capturedURL *url.URL
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		capturedURL = r.URL
+		requests := make([]map[string]any, 0)
+		errors := make([]map[string]any, 0)
+		for i := 0; i < 20; i++ {
+			ts := 1700000000 + i*30
+			requests = append(requests, map[string]any{"timestamp": ts, "value": 10})
+			value := any(0)
+			if i >= 15 {
+				value = 2
+			}
+			if i == 0 {
+				value = "NaN"
+			}
+			errors = append(errors, map[string]any{"timestamp": ts, "value": value})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"request_count": []map[string]any{
+				{"name": "request_count", "labels": map[string]string{"request_protocol": "http"}, "datapoints": requests},
+				{"name": "request_count", "labels": map[string]string{"request_protocol": "grpc"}, "datapoints": requests[10:]},
+			},
+			"request_error_count": []map[string]any{{"name": "request_error_count", "datapoints": errors}},
+		})
+	}))
+	defer mockServer.Close()
+
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+	result, err := kialiClient.WorkloadRequestTrend(context.Background(), "bookinfo", "reviews-v1")
+	require.NoError(t, err)
+
+	assert.Equal(t, "/api/namespaces/bookinfo/workloads/reviews-v1/metrics", capturedURL.Path)
+	assert.Equal(t, "inbound", capturedURL.Query().Get("direction"))
+	assert.Equal(t, "600", capturedURL.Query().Get("duration"))
+
+	var trend internalkiali.RequestTrend
+	require.NoError(t, json.Unmarshal([]byte(result), &trend))
+	require.Len(t, trend.Timestamps, internalkiali.RequestTrendPoints)
+	assert.Equal(t, int64(1700000030), trend.Timestamps[0])
+	assert.Equal(t, int64(1700000570), trend.Timestamps[9])
+	assert.Equal(t, []float64{10, 10, 10, 10, 10, 20, 20, 20, 20, 20}, trend.Requests)
+	assert.Equal(t, []float64{0, 0, 0, 0, 0, 0, 0, 1, 2, 2}, trend.Errors)
+}
+
+func TestAttachJSONField(t *testing.T) {
+	result, err := attachJSONField(`{"name":"reviews-v1"}`, "requestTrend", `{"requests": [1, 2]}`)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"reviews-v1","requestTrend":{"requests":[1,2]}}`, result)
+
+	_, err = attachJSONField(`not-json`, "requestTrend", `{}`)
+	require.Error(t, err)
+}
+
+func TestMetrics_KialiClient(t *testing.T) {
+	var capturedPath string
+	var captured url.Values
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		capturedPath = r.URL.Path
+		captured = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"tcp_sent": []}`))
+	}))
+	defer mockServer.Close()
+
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+	ctx := context.Background()
+
+	result, err := kialiClient.Metrics(ctx, "bookinfo", "app", "reviews",
+		[]string{"tcp_sent", "tcp_received"}, []string{"source_workload"}, map[string]string{"direction": "inbound", "reporter": "destination"}, []string{"0.99"})
+	require.NoError(t, err)
+	assert.Equal(t, `{"tcp_sent": []}`, result)
+	assert.Equal(t, "/api/namespaces/bookinfo/apps/reviews/metrics", capturedPath)
+	assert.Equal(t, []string{"tcp_sent", "tcp_received"}, captured["filters[]"])
+	assert.Equal(t, []string{"source_workload"}, captured["byLabels[]"])
+	assert.Equal(t, []string{"0.99"}, captured["quantiles[]"])
+	assert.Equal(t, "inbound", captured.Get("direction"))
+	assert.Equal(t, "destination", captured.Get("reporter"))
+
+	_, err = kialiClient.Metrics(ctx, "bookinfo", "service", "reviews", nil, nil, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "/api/namespaces/bookinfo/services/reviews/metrics", capturedPath)
+	assert.Empty(t, captured)
+
+	_, err = kialiClient.Metrics(ctx, "bookinfo", "pod", "reviews", nil, nil, nil, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid entity type")
+}
+
+func TestSplitList(t *testing.T) {
+	assert.Equal(t, []string{}, splitList(""))
+	assert.Equal(t, []string{"request_count", "tcp_sent"}, splitList(" request_count,, tcp_sent ,"))
+}
+
+func TestReporterErrorDelta_KialiClient(t *testing.T) {
+	series := func(requests, errors float64) string {
+		return fmt.Sprintf(`{"request_count": [{"name": "request_count", "datapoints": [{"timestamp": 1, "value": %[1]g}, {"timestamp": 2, "value": %[1]g}]}],
+			"request_error_count": [{"name": "request_error_count", "datapoints": [{"timestamp": 1, "value": %[2]g}, {"timestamp": 2, "value": %[2]g}]}]}`, requests, errors)
+	}
+	tests := []struct {
+		name          string
+		source        string
+		destination   string
+		expectedLayer string
+		expectedDelta float64
+	}{
+		{name: "network", source: series(10, 1), destination: series(9, 0), expectedLayer: "network", expectedDelta: 10},
+		{name: "application", source: series(10, 1), destination: series(10, 1), expectedLayer: "application", expectedDelta: 0},
+		{name: "unreachable destination", source: series(10, 10), destination: `{}`, expectedLayer: "network", expectedDelta: 100},
+		{name: "healthy", source: series(10, 0), destination: series(10, 0), expectedLayer: "none", expectedDelta: 0},
+		{name: "no traffic", source: `{}`, destination: `{}`, expectedLayer: "no traffic", expectedDelta: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/api/namespaces/bookinfo/services/reviews/metrics", r.URL.Path)
+				assert.Equal(t, []string{"request_count", "request_error_count"}, r.URL.Query()["filters[]"])
+				assert.Equal(t, "inbound", r.URL.Query().Get("direction"))
+				assert.Equal(t, "600", r.URL.Query().Get("duration"))
+				if r.URL.Query().Get("reporter") == "source" {
+					_, _ = w.Write([]byte(tt.source))
+				} else {
+					_, _ = w.Write([]byte(tt.destination))
+				}
+			}))
+			defer mockServer.Close()
+
+			kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+			result, err := kialiClient.ReporterErrorDelta(context.Background(), "bookinfo", "service", "reviews", "", "")
+			require.NoError(t, err)
+
+			var delta internalkiali.ReporterErrorDelta
+			require.NoError(t, json.Unmarshal([]byte(result), &delta))
+			assert.Equal(t, tt.expectedLayer, delta.Layer)
+			assert.Equal(t, tt.expectedDelta, delta.ErrorRateDelta)
+			assert.NotEmpty(t, delta.Explanation)
+		})
+	}
+}
+
+func TestMetricSparkline(t *testing.T) {
+	content := `{
+		"request_count": [
+			{"name": "request_count", "labels": {"source_workload": "productpage-v1"}, "datapoints": [
+				{"timestamp": 100, "value": 1}, {"timestamp": 130, "value": 2}, {"timestamp": 160, "value": 4}, {"timestamp": 190, "value": 8}
+			]},
+			{"name": "request_count", "labels": {"source_workload": "reviews-v1"}, "datapoints": [
+				{"timestamp": 100, "value": 1}, {"timestamp": 130, "value": "NaN"}, {"timestamp": 190, "value": 2}
+			]}
+		],
+		"request_duration_millis": [
+			{"name": "request_duration_millis", "stat": "avg", "datapoints": [{"timestamp": 100, "value": 10}, {"timestamp": 130, "value": 10}]},
+			{"name": "request_duration_millis", "stat": "0.95", "datapoints": [{"timestamp": 100, "value": 50}, {"timestamp": 130, "value": 90}]}
+		],
+		"tcp_sent": []
+	}`
+	sparkline := func(t *testing.T, metric string, width int) internalkiali.MetricSparkline {
+		result, err := internalkiali.MetricSparklineJSON(content, metric, width)
+		require.NoError(t, err)
+		var ret internalkiali.MetricSparkline
+		require.NoError(t, json.Unmarshal([]byte(result), &ret))
+		return ret
+	}
+
+	t.Run("counter summed over its series", func(t *testing.T) {
+		assert.Equal(t, internalkiali.MetricSparkline{
+			Metric: "request_count", Sparkline: "▁▁▃█", Width: 4, From: 100, To: 190, Min: 2, Max: 10, Last: 10,
+		}, sparkline(t, "request_count", 0))
+	})
+	t.Run("downsampled to the width", func(t *testing.T) {
+		s := sparkline(t, "request_count", 2)
+		assert.Equal(t, "▁█", s.Sparkline)
+		assert.Equal(t, 2, s.Width)
+		assert.Equal(t, 10.0, s.Max, "min, max and last are not downsampled")
+	})
+	t.Run("histogram statistics", func(t *testing.T) {
+		avg := sparkline(t, "request_duration_millis", 0)
+		assert.Equal(t, "avg", avg.Stat)
+		assert.Equal(t, "▁▁", avg.Sparkline, "a flat series is rendered with the lowest block")
+		p95 := sparkline(t, "request_duration_millis:0.95", 0)
+		assert.Equal(t, "0.95", p95.Stat)
+		assert.Equal(t, "▁█", p95.Sparkline)
+		assert.Equal(t, 90.0, p95.Last)
+	})
+	t.Run("no datapoints", func(t *testing.T) {
+		s := sparkline(t, "tcp_sent", 0)
+		assert.Empty(t, s.Sparkline)
+		assert.NotEmpty(t, s.Note)
+	})
+	t.Run("errors", func(t *testing.T) {
+		_, err := internalkiali.MetricSparklineJSON(content, "request_size", 0)
+		assert.EqualError(t, err, "metric 'request_size' not found, available metrics are: request_count, request_duration_millis, tcp_sent")
+		_, err = internalkiali.MetricSparklineJSON(content, "request_duration_millis:0.99", 0)
+		assert.EqualError(t, err, "metric 'request_duration_millis': statistic '0.99' not found, available statistics are: 0.95, avg")
+		_, err = internalkiali.MetricSparklineJSON(content, "request_count:avg", 0)
+		assert.EqualError(t, err, "metric 'request_count': statistic 'avg' requested but the metric is not a histogram")
+	})
+}
+
+func TestMetricsSparkline_KialiClient(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"request_count": [{"name": "request_count", "datapoints": [{"timestamp": 100, "value": 1}, {"timestamp": 130, "value": 3}]}]}`))
+	}))
+	defer mockServer.Close()
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+
+	for name, handler := range map[string]api.ToolHandlerFunc{"metrics": metricsHandler, "workload_metrics": workloadMetricsHandler, "service_metrics": serviceMetricsHandler} {
+		t.Run(name, func(t *testing.T) {
+			arguments := argumentsRequest{"namespace": "bookinfo", "type": "workload", "name": "reviews-v1", "workload": "reviews-v1", "service": "reviews", "sparkline": "request_count"}
+			result, err := handler(api.ToolHandlerParams{Context: context.Background(), Kiali: kialiClient, ToolCallRequest: arguments})
+			require.NoError(t, err)
+			require.NoError(t, result.Error)
+			assert.Contains(t, result.Content, `"sparkline": "▁█"`)
+
+			arguments["sparklineWidth"] = float64(0)
+			result, err = handler(api.ToolHandlerParams{Context: context.Background(), Kiali: kialiClient, ToolCallRequest: arguments})
+			require.NoError(t, err)
+			assert.EqualError(t, result.Error, "sparklineWidth must be a positive integer")
+		})
+	}
+}
@@ -27,6 +27,38 @@ func initNamespaces() []api.ServerTool {
 			},
 		}, Handler: namespacesHandler,
 	})
+	ret = append(ret, api.ServerTool{
+		Tool: api.Tool{
+			Name:        "mesh_namespaces",
+			Description: "Get the namespaces that the user has access to, partitioned into the ones that are part of the mesh (sidecar injection enabled, Istio revision label or ambient mode) and the ones that are not. Use it to check whether a namespace is actually in the mesh",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Namespaces: Mesh Membership",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: meshNamespacesHandler,
+	})
+	ret = append(ret, api.ServerTool{
+		Tool: api.Tool{
+			Name:        "token_permissions",
+			Description: "Get the RBAC scope of the current token before running an analysis: the namespaces it can read (per cluster) and whether it can create, update and delete Istio config in each of them. Use it to tell empty results apart from access problems",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Namespaces: Token Permissions",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: tokenPermissionsHandler,
+	})
 	return ret
 }
 
@@ -35,5 +67,24 @@ func namespacesHandler(params api.ToolHandlerParams) (*api.ToolCallResult, error
 	if err != nil {
 		return api.NewToolCallResult("", fmt.Errorf("failed to list namespaces: %v", err)), nil
 	}
+	if isEmptyResult(content, "") {
+		return api.NewToolCallResult("No namespaces found: the credentials used may not have access to any namespace", nil), nil
+	}
+	return api.NewToolCallResult(content, nil), nil
+}
+
+func meshNamespacesHandler(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	content, err := params.MeshNamespaces(params.Context)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get mesh namespaces: %v", err)), nil
+	}
+	return api.NewToolCallResult(content, nil), nil
+}
+
+func tokenPermissionsHandler(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	content, err := params.TokenPermissions(params.Context)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get token permissions: %v", err)), nil
+	}
 	return api.NewToolCallResult(content, nil), nil
 }
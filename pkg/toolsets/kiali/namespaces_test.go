@@ -0,0 +1,326 @@
+package kiali
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+
+	"github.com/kiali/kiali-mcp-server/pkg/config"
+	internalkiali "github.com/kiali/kiali-mcp-server/pkg/kiali"
+)
+
+// TestNamespacesQuery_KialiClient verifies that every list method encodes the namespaces query parameter the same way
+func TestNamespacesQuery_KialiClient(t *testing.T) {
+	var capturedRawQuery string
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		capturedRawQuery = r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer mockServer.Close()
+
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+	ctx := context.Background()
+
+	methods := map[string]func(namespaces string) error{
+		"Health": func(namespaces string) error {
+			_, err := kialiClient.Health(ctx, namespaces, map[string]string{"type": "app"})
+			return err
+		},
+		"WorkloadsList": func(namespaces string) error {
+			_, err := kialiClient.WorkloadsList(ctx, namespaces)
+			return err
+		},
+		"ServicesList": func(namespaces string) error {
+			_, err := kialiClient.ServicesList(ctx, namespaces)
+			return err
+		},
+		"Graph": func(namespaces string) error {
+			_, err := kialiClient.Graph(ctx, strings.Split(namespaces, ","), internalkiali.GraphOptions{IncludeHealth: true})
+			return err
+		},
+		"ValidationsList": func(namespaces string) error {
+			_, err := kialiClient.ValidationsList(ctx, strings.Split(namespaces, ","))
+			return err
+		},
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "single namespace", input: "bookinfo", expected: "namespaces=bookinfo"},
+		{name: "surrounding whitespace is trimmed", input: "  bookinfo , istio-system ", expected: "namespaces=bookinfo,istio-system"},
+		{name: "empty entries are dropped", input: ",bookinfo,,istio-system,", expected: "namespaces=bookinfo,istio-system"},
+		{name: "duplicates are removed", input: "bookinfo,istio-system,bookinfo", expected: "namespaces=bookinfo,istio-system"},
+		{name: "reserved characters are escaped per namespace", input: "a&b,c=d,e f", expected: "namespaces=a%26b,c%3Dd,e+f"},
+		{name: "only separators", input: " , ,", expected: ""},
+		{name: "empty", input: "", expected: ""},
+	}
+
+	for method, call := range methods {
+		for _, tt := range tests {
+			t.Run(method+"/"+tt.name, func(t *testing.T) {
+				capturedRawQuery = ""
+				require.NoError(t, call(tt.input))
+				params := strings.Split(capturedRawQuery, "&")
+				namespacesParams := make([]string, 0)
+				for _, p := range params {
+					if strings.HasPrefix(p, "namespaces=") {
+						namespacesParams = append(namespacesParams, p)
+					}
+				}
+				if tt.expected == "" {
+					assert.Empty(t, namespacesParams)
+					return
+				}
+				assert.Equal(t, []string{tt.expected}, namespacesParams)
+			})
+		}
+	}
+}
+
+func TestListNamespacesCache_KialiClient(t *testing.T) {
+	var requests atomic.Int32
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/namespaces", r.URL.Path)
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"name":"bookinfo"}]`))
+	}))
+	defer mockServer.Close()
+
+	t.Run("cache disabled by default", func(t *testing.T) {
+		requests.Store(0)
+		kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+		for i := 0; i < 2; i++ {
+			_, err := kialiClient.ListNamespaces(context.Background())
+			require.NoError(t, err)
+		}
+		assert.Equal(t, int32(2), requests.Load())
+	})
+
+	t.Run("cached until the ttl expires", func(t *testing.T) {
+		requests.Store(0)
+		kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL, KialiNamespacesCacheTTL: "1h"})
+		for i := 0; i < 3; i++ {
+			content, err := kialiClient.ListNamespaces(context.Background())
+			require.NoError(t, err)
+			assert.Contains(t, content, "bookinfo")
+		}
+		assert.Equal(t, int32(1), requests.Load())
+	})
+}
+
+func TestMeshNamespaces_KialiClient(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/namespaces", r.URL.Path)
+		_, _ = w.Write([]byte(`[
+			{"name": "istio-system", "cluster": "east", "isControlPlane": true},
+			{"name": "bookinfo", "cluster": "east", "labels": {"istio-injection": "enabled"}},
+			{"name": "canary", "cluster": "east", "labels": {"istio.io/rev": "1-22"}},
+			{"name": "opted-out", "cluster": "east", "labels": {"istio.io/rev": "1-22", "istio-injection": "disabled"}},
+			{"name": "ambient", "cluster": "east", "isAmbient": true, "labels": {"istio.io/dataplane-mode": "ambient"}},
+			{"name": "default", "cluster": "east", "labels": {"kubernetes.io/metadata.name": "default"}},
+			{"name": "legacy", "cluster": "east"},
+			{"name": "legacy", "cluster": "west", "labels": {"istio-injection": "enabled"}}
+		]`))
+	}))
+	defer mockServer.Close()
+
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+	result, err := kialiClient.MeshNamespaces(context.Background())
+	require.NoError(t, err)
+
+	var membership internalkiali.NamespacesMeshMembership
+	require.NoError(t, json.Unmarshal([]byte(result), &membership))
+	assert.Equal(t, []string{"ambient", "bookinfo", "canary", "istio-system", "legacy"}, membership.Meshed)
+	assert.Equal(t, []string{"default", "opted-out"}, membership.NotMeshed)
+}
+
+func TestExcludedNamespaces_KialiClient(t *testing.T) {
+	var capturedRawQuery string
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		capturedRawQuery = r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/namespaces":
+			_, _ = w.Write([]byte(`[{"name": "bookinfo"}, {"name": "ci-sandbox"}]`))
+		case "/api/clusters/health":
+			_, _ = w.Write([]byte(`{"namespaceAppHealth": {"bookinfo": {"reviews": {}}, "ci-sandbox": {"load": {}}}}`))
+		case "/api/clusters/services":
+			_, _ = w.Write([]byte(`{"cluster": "east", "services": [{"name": "reviews", "namespace": "bookinfo"}, {"name": "load", "namespace": "ci-sandbox"}]}`))
+		case "/api/namespaces/graph":
+			_, _ = w.Write([]byte(`{"elements": {
+				"nodes": [
+					{"data": {"id": "box-ci", "isBox": "namespace", "namespace": "ci-sandbox"}},
+					{"data": {"id": "load", "parent": "box-ci", "nodeType": "workload", "workload": "load"}},
+					{"data": {"id": "reviews", "nodeType": "app", "namespace": "bookinfo", "app": "reviews"}},
+					{"data": {"id": "ratings", "nodeType": "app", "namespace": "bookinfo", "app": "ratings"}}
+				],
+				"edges": [
+					{"data": {"id": "e1", "source": "load", "target": "reviews"}},
+					{"data": {"id": "e2", "source": "reviews", "target": "ratings"}}
+				]
+			}}`))
+		case "/api/istio/config":
+			_, _ = w.Write([]byte(`{"resources": {"networking.istio.io/v1, Kind=VirtualService": [
+				{"metadata": {"name": "reviews", "namespace": "bookinfo"}},
+				{"metadata": {"name": "load", "namespace": "ci-sandbox"}}
+			]}}`))
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	defer mockServer.Close()
+
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{
+		KialiServerURL:          mockServer.URL,
+		KialiExcludedNamespaces: []string{"ci-sandbox", " "},
+	})
+	ctx := context.Background()
+
+	t.Run("namespaces list", func(t *testing.T) {
+		result, err := kialiClient.ListNamespaces(ctx)
+		require.NoError(t, err)
+		assert.JSONEq(t, `[{"name": "bookinfo"}]`, result)
+	})
+
+	t.Run("health", func(t *testing.T) {
+		result, err := kialiClient.Health(ctx, "", map[string]string{"type": "app"})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"namespaceAppHealth": {"bookinfo": {"reviews": {}}}}`, result)
+	})
+
+	t.Run("services list", func(t *testing.T) {
+		result, err := kialiClient.ServicesList(ctx, "bookinfo,ci-sandbox")
+		require.NoError(t, err)
+		assert.Equal(t, "namespaces=bookinfo", capturedRawQuery[strings.LastIndex(capturedRawQuery, "&")+1:])
+		assert.JSONEq(t, `{"cluster": "east", "services": [{"name": "reviews", "namespace": "bookinfo"}]}`, result)
+	})
+
+	t.Run("graph", func(t *testing.T) {
+		result, err := kialiClient.Graph(ctx, []string{"bookinfo"}, internalkiali.GraphOptions{})
+		require.NoError(t, err)
+		var graph struct {
+			Elements struct {
+				Nodes []struct {
+					Data map[string]any `json:"data"`
+				} `json:"nodes"`
+				Edges []struct {
+					Data map[string]any `json:"data"`
+				} `json:"edges"`
+			} `json:"elements"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(result), &graph))
+		nodes := make([]any, 0)
+		for _, n := range graph.Elements.Nodes {
+			nodes = append(nodes, n.Data["id"])
+		}
+		assert.Equal(t, []any{"reviews", "ratings"}, nodes, "nodes boxed in an excluded namespace are removed too")
+		require.Len(t, graph.Elements.Edges, 1)
+		assert.Equal(t, "e2", graph.Elements.Edges[0].Data["id"])
+	})
+
+	t.Run("istio config", func(t *testing.T) {
+		result, err := kialiClient.IstioConfig(ctx)
+		require.NoError(t, err)
+		assert.NotContains(t, result, "ci-sandbox")
+		assert.Contains(t, result, "bookinfo")
+	})
+
+	t.Run("only excluded namespaces requested", func(t *testing.T) {
+		_, err := kialiClient.WorkloadsList(ctx, "ci-sandbox")
+		require.Error(t, err)
+		assert.True(t, internalkiali.IsNamespaceExcluded(err))
+		assert.Equal(t, "namespace 'ci-sandbox' excluded by configuration", err.Error())
+	})
+
+	t.Run("entity of an excluded namespace", func(t *testing.T) {
+		_, err := kialiClient.ServiceDetails(ctx, "ci-sandbox", "load")
+		require.Error(t, err)
+		assert.True(t, internalkiali.IsNamespaceExcluded(err))
+		_, err = kialiClient.IstioObjectDelete(ctx, "ci-sandbox", "networking.istio.io", "v1", "VirtualService", "load", "")
+		assert.True(t, internalkiali.IsNamespaceExcluded(err))
+	})
+}
+
+func TestTokenPermissions_KialiClient(t *testing.T) {
+	tests := []struct {
+		name        string
+		permissions string
+		status      int
+		expected    []internalkiali.NamespacePermissions
+		summary     string
+	}{
+		{
+			name:        "permissions by cluster",
+			permissions: `{"bookinfo": {"east": {"create": true, "update": true, "delete": false}, "west": {"create": false, "update": false, "delete": false}}, "legacy": {"east": {"create": false, "update": false, "delete": false}}}`,
+			expected: []internalkiali.NamespacePermissions{
+				{Namespace: "bookinfo", Cluster: "east", Read: true, Write: ptr.To(true), Create: ptr.To(true), Update: ptr.To(true), Delete: ptr.To(false)},
+				{Namespace: "bookinfo", Cluster: "west", Read: true, Write: ptr.To(false), Create: ptr.To(false), Update: ptr.To(false), Delete: ptr.To(false)},
+				{Namespace: "legacy", Cluster: "east", Read: true, Write: ptr.To(false), Create: ptr.To(false), Update: ptr.To(false), Delete: ptr.To(false)},
+			},
+			summary: "the token can read 3 namespaces and write Istio config in 1 of them",
+		},
+		{
+			name:        "permissions not keyed by cluster",
+			permissions: `{"bookinfo": {"create": false, "update": true, "delete": false}}`,
+			expected: []internalkiali.NamespacePermissions{
+				{Namespace: "bookinfo", Cluster: "east", Read: true, Write: ptr.To(true), Create: ptr.To(false), Update: ptr.To(true), Delete: ptr.To(false)},
+				{Namespace: "bookinfo", Cluster: "west", Read: true, Write: ptr.To(true), Create: ptr.To(false), Update: ptr.To(true), Delete: ptr.To(false)},
+				{Namespace: "legacy", Cluster: "east", Read: true},
+			},
+			summary: "the token can read 3 namespaces and write Istio config in 2 of them",
+		},
+		{
+			name:   "permissions unavailable",
+			status: http.StatusNotFound,
+			expected: []internalkiali.NamespacePermissions{
+				{Namespace: "bookinfo", Cluster: "east", Read: true},
+				{Namespace: "bookinfo", Cluster: "west", Read: true},
+				{Namespace: "legacy", Cluster: "east", Read: true},
+			},
+			summary: "the token can read 3 namespaces",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/api/namespaces":
+					_, _ = w.Write([]byte(`[{"name": "legacy", "cluster": "east"}, {"name": "bookinfo", "cluster": "west"}, {"name": "bookinfo", "cluster": "east"}]`))
+				case "/api/istio/permissions":
+					assert.Equal(t, "legacy,bookinfo", r.URL.Query().Get("namespaces"))
+					if tt.status != 0 {
+						w.WriteHeader(tt.status)
+						return
+					}
+					_, _ = w.Write([]byte(tt.permissions))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer mockServer.Close()
+
+			kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+			result, err := kialiClient.TokenPermissions(context.Background())
+			require.NoError(t, err)
+
+			var permissions internalkiali.TokenPermissions
+			require.NoError(t, json.Unmarshal([]byte(result), &permissions))
+			assert.Equal(t, tt.expected, permissions.Namespaces)
+			assert.Equal(t, tt.summary, permissions.Summary)
+			assert.Equal(t, tt.status != 0, permissions.Note != "")
+		})
+	}
+}
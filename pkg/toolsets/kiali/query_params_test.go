@@ -0,0 +1,70 @@
+package kiali
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali-mcp-server/pkg/config"
+	internalkiali "github.com/kiali/kiali-mcp-server/pkg/kiali"
+)
+
+// TestQueryParams_KialiClient verifies that the rate, interval and array query parameter names can be overridden
+func TestQueryParams_KialiClient(t *testing.T) {
+	var captured url.Values
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		captured = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer mockServer.Close()
+	ctx := context.Background()
+
+	t.Run("default names", func(t *testing.T) {
+		kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+
+		_, err := kialiClient.WorkloadMetrics(ctx, "bookinfo", "reviews-v1", map[string]string{"rateInterval": "1m", "step": "15"}, []string{"0.99"})
+		require.NoError(t, err)
+		assert.Equal(t, "1m", captured.Get("rateInterval"))
+		assert.Equal(t, "15", captured.Get("step"))
+		assert.Equal(t, []string{"0.99"}, captured["quantiles[]"])
+
+		_, err = kialiClient.ServicesList(ctx, "bookinfo")
+		require.NoError(t, err)
+		assert.Equal(t, "60s", captured.Get("rateInterval"))
+		assert.Equal(t, "bookinfo", captured.Get("namespaces"))
+	})
+
+	t.Run("overridden names", func(t *testing.T) {
+		kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{
+			KialiServerURL: mockServer.URL,
+			KialiQueryParams: map[string]string{
+				"rateInterval": "rate_interval",
+				"quantiles":    "quantiles",
+				"namespaces":   "ns",
+			},
+		})
+
+		_, err := kialiClient.ServiceMetrics(ctx, "bookinfo", "reviews", map[string]string{"rateInterval": "1m", "step": "15"}, []string{"0.5", "0.99"})
+		require.NoError(t, err)
+		assert.Equal(t, "1m", captured.Get("rate_interval"))
+		assert.False(t, captured.Has("rateInterval"))
+		assert.Equal(t, "15", captured.Get("step"), "parameters without an override keep their default name")
+		assert.Equal(t, []string{"0.5", "0.99"}, captured["quantiles"])
+
+		_, err = kialiClient.WorkloadDetails(ctx, "bookinfo", "reviews-v1")
+		require.NoError(t, err)
+		assert.Equal(t, "60s", captured.Get("rate_interval"))
+
+		_, err = kialiClient.Health(ctx, "bookinfo", map[string]string{"type": "app", "rateInterval": "5m"})
+		require.NoError(t, err)
+		assert.Equal(t, "5m", captured.Get("rate_interval"))
+		assert.Equal(t, "bookinfo", captured.Get("ns"))
+		assert.False(t, captured.Has("namespaces"))
+	})
+}
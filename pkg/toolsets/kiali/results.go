@@ -0,0 +1,54 @@
+package kiali
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// isEmptyResult reports whether the JSON value at key of a Kiali response, or the whole response when key is
+// empty, is semantically empty: missing, null, an empty list, or an object whose values are all empty, such as a
+// validations map of clusters and namespaces without any validation. Responses that are not JSON are not empty.
+func isEmptyResult(content string, key string) bool {
+	if strings.TrimSpace(content) == "" {
+		return true
+	}
+	var value any
+	if err := json.Unmarshal([]byte(content), &value); err != nil {
+		return false
+	}
+	if key != "" {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return false
+		}
+		value = object[key]
+	}
+	return isEmptyValue(value)
+}
+
+func isEmptyValue(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case []any:
+		return len(v) == 0
+	case map[string]any:
+		for _, field := range v {
+			if !isEmptyValue(field) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// emptyListResult returns the message of a list tool that found nothing, so that an empty list is not mistaken
+// for an error or a partial answer.
+func emptyListResult(what string, namespaces string) string {
+	if namespaces = strings.TrimSpace(namespaces); namespaces == "" {
+		return fmt.Sprintf("No %s found in any accessible namespace", what)
+	}
+	return fmt.Sprintf("No %s found in the specified namespaces (%s)", what, namespaces)
+}
@@ -0,0 +1,39 @@
+package kiali
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsEmptyResult(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		key      string
+		expected bool
+	}{
+		{name: "empty response", content: "", expected: true},
+		{name: "null", content: "null", expected: true},
+		{name: "empty list", content: "[]", expected: true},
+		{name: "list", content: `[{"name":"reviews"}]`, expected: false},
+		{name: "empty object", content: "{}", expected: true},
+		{name: "nested empty maps", content: `{"east": {"bookinfo": {}}, "west": {}}`, expected: true},
+		{name: "nested validation", content: `{"east": {"bookinfo": {"vs": {"valid": true}}}}`, expected: false},
+		{name: "empty list at key", content: `{"cluster": "east", "workloads": []}`, key: "workloads", expected: true},
+		{name: "missing key", content: `{"cluster": "east"}`, key: "workloads", expected: true},
+		{name: "list at key", content: `{"workloads": [{"name": "reviews-v1"}]}`, key: "workloads", expected: false},
+		{name: "key of a list", content: `[]`, key: "workloads", expected: false},
+		{name: "not JSON", content: "No workloads", expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isEmptyResult(tt.content, tt.key))
+		})
+	}
+}
+
+func TestEmptyListResult(t *testing.T) {
+	assert.Equal(t, "No workloads found in any accessible namespace", emptyListResult("workloads", ""))
+	assert.Equal(t, "No services found in the specified namespaces (bookinfo,travels)", emptyListResult("services", " bookinfo,travels "))
+}
@@ -2,6 +2,7 @@ package kiali
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/google/jsonschema-go/jsonschema"
 	"k8s.io/utils/ptr"
@@ -52,6 +53,10 @@ func initServices() []api.ServerTool {
 						Type:        "string",
 						Description: "Name of the service to get details for",
 					},
+					"includeRequestTrend": {
+						Type:        "boolean",
+						Description: "Whether to attach the inbound request and error rates of the last 10 minutes, downsampled to 10 points, to help judge when a problem started (default: false)",
+					},
 				},
 				Required: []string{"namespace", "service"},
 			},
@@ -65,6 +70,155 @@ func initServices() []api.ServerTool {
 		}, Handler: serviceDetailsHandler,
 	})
 
+	// Orphaned services tool
+	ret = append(ret, api.ServerTool{
+		Tool: api.Tool{
+			Name:        "orphaned_services",
+			Description: "Find the services without any backing workload, often leftovers, returning their namespace, name and selector. A service is orphaned when its selector matches the labels of no workload in its namespace; services without a selector (ExternalName or manually managed endpoints) and ServiceEntries are never reported",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespaces": {
+						Type:        "string",
+						Description: "Comma-separated list of namespaces to check (e.g. 'bookinfo' or 'bookinfo,default'). If not provided, checks all accessible namespaces",
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Services: Orphaned",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: orphanedServicesHandler,
+	})
+
+	// Slowest services tool
+	ret = append(ret, api.ServerTool{
+		Tool: api.Tool{
+			Name:        "slowest_services",
+			Description: "Rank the services by p99 inbound request latency to find the slowest ones for performance triage. Returns the top N services with their p99 latency in milliseconds (averaged over the period) and their request rate, the slowest first. Services without inbound requests over the period are not ranked, only counted under noTraffic; services whose metrics cannot be retrieved are reported under failedServices",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespaces": {
+						Type:        "string",
+						Description: "Comma-separated list of namespaces to rank the services of (e.g. 'bookinfo' or 'bookinfo,default'). If not provided, ranks the services of all accessible namespaces",
+					},
+					"topN": {
+						Type:        "integer",
+						Description: "Number of services to return (default: 10)",
+						Minimum:     ptr.To(float64(1)),
+					},
+					"duration": {
+						Type:        "string",
+						Description: "Duration of the period the latencies are computed over, in seconds or as a duration (e.g., '1800' or '30m'). Optional, defaults to 600 seconds",
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Services: Slowest",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		},
+		Handler: slowestServicesHandler,
+		// Fetches the metrics of every service, which can be slow on large meshes
+		Timeout: 2 * time.Minute,
+	})
+
+	// Service health tool
+	ret = append(ret, api.ServerTool{
+		Tool: api.Tool{
+			Name:        "service_health",
+			Description: "Get the health of a service combining its request error rates with the replica availability of the workloads backing it, so a service without available backends is not reported as healthy just because it has no traffic. Also returns the health of each backing workload",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace containing the service",
+					},
+					"service": {
+						Type:        "string",
+						Description: "Name of the service to get the health for",
+					},
+					"rateInterval": {
+						Type:        "string",
+						Description: "Rate interval for fetching error rate (e.g., '10m', '5m', '1h'). Default: '10m'",
+					},
+				},
+				Required: []string{"namespace", "service"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Service: Health",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: serviceHealthHandler,
+	})
+
+	// Service traffic policy tool
+	ret = append(ret, api.ServerTool{
+		Tool: api.Tool{
+			Name:        "service_traffic_policy",
+			Description: "Get the effective timeout, retry and fault injection policy a mesh caller experiences for a service, computed from the VirtualService HTTP routes of its host (in evaluation order, with the Istio defaults when not set) and the DestinationRules targeting it (connection pool, outlier detection, subsets). Answers questions like 'why is this call timing out at 5s'",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace containing the service",
+					},
+					"service": {
+						Type:        "string",
+						Description: "Name of the service to get the traffic policy for",
+					},
+				},
+				Required: []string{"namespace", "service"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Service: Traffic Policy",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: serviceTrafficPolicyHandler,
+	})
+	ret = append(ret, api.ServerTool{
+		Tool: api.Tool{
+			Name:        "service_resilience",
+			Description: "Get the outlier detection (circuit breaking) and connection pool limits configured for a service, or for every service of a namespace, from the traffic policies (host, port and subset level) of the DestinationRules targeting them. Highlights the services lacking such protection, for resilience reviews",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace containing the services",
+					},
+					"service": {
+						Type:        "string",
+						Description: "Optional name of the service. If not provided, all the services of the namespace are reviewed",
+					},
+				},
+				Required: []string{"namespace"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Service: Resilience",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: serviceResilienceHandler,
+	})
+
 	// Service metrics tool
 	ret = append(ret, api.ServerTool{
 		Tool: api.Tool{
@@ -83,11 +237,11 @@ func initServices() []api.ServerTool {
 					},
 					"duration": {
 						Type:        "string",
-						Description: "Duration of the query period in seconds (e.g., '1800' for 30 minutes). Optional, defaults to 1800 seconds",
+						Description: "Duration of the query period, in seconds or as a duration (e.g., '1800' or '30m'). Optional, defaults to 1800 seconds",
 					},
 					"step": {
 						Type:        "string",
-						Description: "Step between data points in seconds (e.g., '15'). Optional, defaults to 15 seconds",
+						Description: "Step between data points, in seconds or as a duration (e.g., '15' or '1m'). Optional, defaults to 15 seconds",
 					},
 					"rateInterval": {
 						Type:        "string",
@@ -107,12 +261,21 @@ func initServices() []api.ServerTool {
 					},
 					"quantiles": {
 						Type:        "string",
-						Description: "Comma-separated list of quantiles for histogram metrics (e.g., '0.5,0.95,0.99'). Optional",
+						Description: "Comma-separated list of quantiles for histogram metrics such as request duration, either as ratios or percentiles (e.g., '0.5,0.95,0.99' or 'p95,p99'). Optional",
 					},
 					"byLabels": {
 						Type:        "string",
 						Description: "Comma-separated list of labels to group metrics by (e.g., 'source_workload,destination_service'). Optional",
 					},
+					"sparkline": {
+						Type:        "string",
+						Description: "Optional name of a single metric of the response (e.g. 'request_count', or 'request_duration_millis:0.95' for a histogram statistic, 'avg' by default) to return as a compact unicode sparkline with its min, max and last values instead of the full metrics, for an at-a-glance trend. The series of the metric are summed over their labels",
+					},
+					"sparklineWidth": {
+						Type:        "integer",
+						Description: "Number of points of the sparkline, the series being downsampled to it (default: 20)",
+						Minimum:     ptr.To(float64(1)),
+					},
 				},
 				Required: []string{"namespace", "service"},
 			},
@@ -137,6 +300,96 @@ func servicesListHandler(params api.ToolHandlerParams) (*api.ToolCallResult, err
 	if err != nil {
 		return api.NewToolCallResult("", fmt.Errorf("failed to list services: %v", err)), nil
 	}
+	if isEmptyResult(content, "services") {
+		return api.NewToolCallResult(emptyListResult("services", namespaces), nil), nil
+	}
+	return api.NewToolCallResult(content, nil), nil
+}
+
+func orphanedServicesHandler(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	namespaces, _ := params.GetArguments()["namespaces"].(string)
+
+	content, err := params.OrphanedServices(params.Context, namespaces)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to find orphaned services: %v", err)), nil
+	}
+	return api.NewToolCallResult(content, nil), nil
+}
+
+func slowestServicesHandler(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	namespaces, _ := params.GetArguments()["namespaces"].(string)
+	duration, err := secondsArgument(params, "duration")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	topN := 0
+	if v, ok := params.GetArguments()["topN"].(float64); ok {
+		if v < 1 || v != float64(int(v)) {
+			return api.NewToolCallResult("", fmt.Errorf("topN must be a positive integer")), nil
+		}
+		topN = int(v)
+	}
+
+	content, err := params.SlowestServices(params.Context, namespaces, topN, duration)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get slowest services: %v", err)), nil
+	}
+	return api.NewToolCallResult(content, nil), nil
+}
+
+func serviceHealthHandler(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	// Extract parameters
+	namespace, _ := params.GetArguments()["namespace"].(string)
+	service, _ := params.GetArguments()["service"].(string)
+	rateInterval, err := durationArgument(params, "rateInterval")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+
+	if namespace == "" {
+		return api.NewToolCallResult("", fmt.Errorf("namespace parameter is required")), nil
+	}
+	if service == "" {
+		return api.NewToolCallResult("", fmt.Errorf("service parameter is required")), nil
+	}
+
+	content, err := params.ServiceHealthWithWorkloads(params.Context, namespace, service, rateInterval)
+	if err != nil {
+		return api.NewToolCallResult("", detailsError(err, "service health", "services_list")), nil
+	}
+	return api.NewToolCallResult(content, nil), nil
+}
+
+func serviceTrafficPolicyHandler(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	namespace, _ := params.GetArguments()["namespace"].(string)
+	service, _ := params.GetArguments()["service"].(string)
+
+	if namespace == "" {
+		return api.NewToolCallResult("", fmt.Errorf("namespace parameter is required")), nil
+	}
+	if service == "" {
+		return api.NewToolCallResult("", fmt.Errorf("service parameter is required")), nil
+	}
+
+	content, err := params.ServiceTrafficPolicy(params.Context, namespace, service)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get service traffic policy: %v", err)), nil
+	}
+	return api.NewToolCallResult(content, nil), nil
+}
+
+func serviceResilienceHandler(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	namespace, _ := params.GetArguments()["namespace"].(string)
+	service, _ := params.GetArguments()["service"].(string)
+
+	if namespace == "" {
+		return api.NewToolCallResult("", fmt.Errorf("namespace parameter is required")), nil
+	}
+
+	content, err := params.ServiceResilience(params.Context, namespace, service)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get service resilience: %v", err)), nil
+	}
 	return api.NewToolCallResult(content, nil), nil
 }
 
@@ -153,7 +406,16 @@ func serviceDetailsHandler(params api.ToolHandlerParams) (*api.ToolCallResult, e
 	}
 	content, err := params.ServiceDetails(params.Context, namespace, service)
 	if err != nil {
-		return api.NewToolCallResult("", fmt.Errorf("failed to get service details: %v", err)), nil
+		return api.NewToolCallResult("", detailsError(err, "service details", "services_list")), nil
+	}
+	if includeTrend, _ := params.GetArguments()["includeRequestTrend"].(bool); includeTrend {
+		trend, err := params.ServiceRequestTrend(params.Context, namespace, service)
+		if err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to get service request trend: %v", err)), nil
+		}
+		if content, err = attachJSONField(content, "requestTrend", trend); err != nil {
+			return api.NewToolCallResult("", err), nil
+		}
 	}
 	return api.NewToolCallResult(content, nil), nil
 }
@@ -172,14 +434,8 @@ func serviceMetricsHandler(params api.ToolHandlerParams) (*api.ToolCallResult, e
 
 	// Extract optional query parameters
 	queryParams := make(map[string]string)
-	if duration, ok := params.GetArguments()["duration"].(string); ok && duration != "" {
-		queryParams["duration"] = duration
-	}
-	if step, ok := params.GetArguments()["step"].(string); ok && step != "" {
-		queryParams["step"] = step
-	}
-	if rateInterval, ok := params.GetArguments()["rateInterval"].(string); ok && rateInterval != "" {
-		queryParams["rateInterval"] = rateInterval
+	if err := metricsWindowArguments(params, queryParams); err != nil {
+		return api.NewToolCallResult("", err), nil
 	}
 	if direction, ok := params.GetArguments()["direction"].(string); ok && direction != "" {
 		queryParams["direction"] = direction
@@ -190,16 +446,26 @@ func serviceMetricsHandler(params api.ToolHandlerParams) (*api.ToolCallResult, e
 	if requestProtocol, ok := params.GetArguments()["requestProtocol"].(string); ok && requestProtocol != "" {
 		queryParams["requestProtocol"] = requestProtocol
 	}
-	if quantiles, ok := params.GetArguments()["quantiles"].(string); ok && quantiles != "" {
-		queryParams["quantiles"] = quantiles
-	}
 	if byLabels, ok := params.GetArguments()["byLabels"].(string); ok && byLabels != "" {
 		queryParams["byLabels"] = byLabels
 	}
 
-	content, err := params.ServiceMetrics(params.Context, namespace, service, queryParams)
+	quantilesArg, _ := params.GetArguments()["quantiles"].(string)
+	quantiles, err := parseQuantiles(quantilesArg)
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	sparkline, sparklineWidth, err := sparklineArguments(params)
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+
+	content, err := params.ServiceMetrics(params.Context, namespace, service, queryParams, quantiles)
 	if err != nil {
 		return api.NewToolCallResult("", fmt.Errorf("failed to get service metrics: %v", err)), nil
 	}
+	if sparkline != "" {
+		return metricSparklineResult(content, sparkline, sparklineWidth)
+	}
 	return api.NewToolCallResult(content, nil), nil
 }
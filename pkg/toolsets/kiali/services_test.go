@@ -0,0 +1,344 @@
+package kiali
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali-mcp-server/pkg/config"
+	internalkiali "github.com/kiali/kiali-mcp-server/pkg/kiali"
+)
+
+func TestServiceHealthWithWorkloads_KialiClient(t *testing.T) {
+	workloadHealth := `{"namespaceWorkloadHealth": {"bookinfo": {
+		"reviews-v1": {"workloadStatus": {"name": "reviews-v1", "desiredReplicas": 1, "currentReplicas": 1, "availableReplicas": 0}, "requests": {}},
+		"reviews-v2": {"workloadStatus": {"name": "reviews-v2", "desiredReplicas": 2, "currentReplicas": 2, "availableReplicas": 2}, "requests": {}},
+		"ratings-v1": {"workloadStatus": {"name": "ratings-v1", "desiredReplicas": 0, "currentReplicas": 0, "availableReplicas": 0}, "requests": {}}
+	}}}`
+	serviceHealth := `{"namespaceServiceHealth": {"bookinfo": {
+		"reviews": {"requests": {}},
+		"ratings": {"requests": {}},
+		"details": {"requests": {"inbound": {"http": {"200": 10}}}}
+	}}}`
+	serviceWorkloads := map[string]string{
+		"reviews": `{"workloads": [{"name": "reviews-v1"}, {"name": "reviews-v2"}]}`,
+		"ratings": `{"workloads": [{"name": "ratings-v1"}]}`,
+		"details": `{"workloads": [{"name": "reviews-v1"}]}`,
+	}
+
+	tests := []struct {
+		service          string
+		expectedStatus   internalkiali.HealthStatus
+		expectedIssue    string
+		expectedBackends int
+	}{
+		{service: "details", expectedStatus: internalkiali.HealthStatusUnhealthy, expectedIssue: "no backing workload has available replicas (0/1)", expectedBackends: 1},
+		{service: "reviews", expectedStatus: internalkiali.HealthStatusDegraded, expectedIssue: "1/2 backing workloads have no available replicas", expectedBackends: 2},
+		{service: "ratings", expectedStatus: internalkiali.HealthStatusNotReady, expectedIssue: "backing workloads are scaled down to 0 replicas (1/1)", expectedBackends: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.service, func(t *testing.T) {
+			mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch r.URL.Path {
+				case "/api/clusters/health":
+					assert.Equal(t, "bookinfo", r.URL.Query().Get("namespaces"))
+					if r.URL.Query().Get("type") == "workload" {
+						_, _ = w.Write([]byte(workloadHealth))
+					} else {
+						_, _ = w.Write([]byte(serviceHealth))
+					}
+				case "/api/namespaces/bookinfo/services/" + tt.service:
+					_, _ = w.Write([]byte(serviceWorkloads[tt.service]))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer mockServer.Close()
+
+			kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+			result, err := kialiClient.ServiceHealthWithWorkloads(context.Background(), "bookinfo", tt.service, "")
+			require.NoError(t, err)
+
+			var health internalkiali.CombinedServiceHealth
+			require.NoError(t, json.Unmarshal([]byte(result), &health))
+			assert.Equal(t, tt.service, health.Name)
+			assert.Equal(t, tt.expectedStatus, health.Status)
+			assert.Contains(t, health.Issues, tt.expectedIssue)
+			assert.Len(t, health.Workloads, tt.expectedBackends)
+		})
+	}
+}
+
+func TestServiceHealthWithWorkloads_KialiClient_NotFound(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/clusters/health" {
+			_, _ = w.Write([]byte(`{}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer mockServer.Close()
+
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+	_, err := kialiClient.ServiceHealthWithWorkloads(context.Background(), "bookinfo", "missing", "")
+	require.Error(t, err)
+	assert.True(t, internalkiali.IsNotFound(err))
+}
+
+func TestServiceTrafficPolicy_KialiClient(t *testing.T) {
+	istioConfig := `{"resources": {
+		"networking.istio.io/v1, Kind=VirtualService": [
+			{"metadata": {"name": "reviews", "namespace": "bookinfo"}, "spec": {
+				"hosts": ["reviews"],
+				"http": [
+					{"name": "jason", "match": [{"headers": {"end-user": {"exact": "jason"}}}], "route": [{"destination": {"host": "reviews", "subset": "v2"}}],
+					 "timeout": "5s", "retries": {"attempts": 3, "perTryTimeout": "2s"}, "fault": {"delay": {"fixedDelay": "7s", "percentage": {"value": 100}}}},
+					{"route": [{"destination": {"host": "reviews", "subset": "v1"}, "weight": 90}, {"destination": {"host": "reviews", "subset": "v3"}, "weight": 10}],
+					 "retries": {"attempts": 0}}
+				]
+			}},
+			{"metadata": {"name": "reviews-ingress", "namespace": "bookinfo"}, "spec": {
+				"hosts": ["reviews.example.com", "reviews"], "gateways": ["bookinfo-gateway"],
+				"http": [{"route": [{"destination": {"host": "reviews"}}], "timeout": "1s"}]
+			}},
+			{"metadata": {"name": "ratings", "namespace": "bookinfo"}, "spec": {
+				"hosts": ["ratings"], "http": [{"route": [{"destination": {"host": "ratings"}}]}]
+			}}
+		],
+		"networking.istio.io/v1, Kind=DestinationRule": [
+			{"metadata": {"name": "reviews", "namespace": "bookinfo"}, "spec": {
+				"host": "reviews.bookinfo.svc.cluster.local",
+				"trafficPolicy": {"connectionPool": {"tcp": {"connectTimeout": "1s"}}},
+				"subsets": [{"name": "v1", "labels": {"version": "v1"}}]
+			}}
+		]
+	}}`
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(istioConfig))
+	}))
+	defer mockServer.Close()
+
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+
+	t.Run("routed service", func(t *testing.T) {
+		result, err := kialiClient.ServiceTrafficPolicy(context.Background(), "bookinfo", "reviews")
+		require.NoError(t, err)
+
+		var policy internalkiali.ServiceTrafficPolicy
+		require.NoError(t, json.Unmarshal([]byte(result), &policy))
+		assert.Equal(t, "reviews.bookinfo.svc.cluster.local", policy.Host)
+		assert.Empty(t, policy.Notes)
+		require.Len(t, policy.Routes, 2, "routes of gateway-only VirtualServices do not apply to mesh callers")
+		assert.Equal(t, internalkiali.RoutePolicy{
+			VirtualService: "bookinfo/reviews",
+			Name:           "jason",
+			Match:          []string{"headers.end-user.exact=jason"},
+			Destinations:   []string{"destination.host=reviews, destination.subset=v2"},
+			Timeout:        "5s",
+			Retries:        internalkiali.RetryPolicy{Attempts: 3, PerTryTimeout: "2s", RetryOn: "connect-failure,refused-stream,unavailable,cancelled,retriable-status-codes"},
+			Fault:          []string{"delay.fixedDelay=7s", "delay.percentage.value=100"},
+		}, policy.Routes[0])
+		assert.Equal(t, "disabled", policy.Routes[1].Timeout)
+		assert.True(t, policy.Routes[1].TimeoutDefault)
+		assert.Equal(t, internalkiali.RetryPolicy{}, policy.Routes[1].Retries)
+		require.Len(t, policy.DestinationRules, 1)
+		assert.Equal(t, []string{"connectionPool.tcp.connectTimeout=1s"}, policy.DestinationRules[0].TrafficPolicy)
+	})
+
+	t.Run("service without VirtualService", func(t *testing.T) {
+		result, err := kialiClient.ServiceTrafficPolicy(context.Background(), "bookinfo", "details")
+		require.NoError(t, err)
+
+		var policy internalkiali.ServiceTrafficPolicy
+		require.NoError(t, json.Unmarshal([]byte(result), &policy))
+		require.Len(t, policy.Routes, 1)
+		assert.Equal(t, internalkiali.RoutePolicy{
+			Timeout:        "disabled",
+			TimeoutDefault: true,
+			Retries:        internalkiali.RetryPolicy{Attempts: 2, RetryOn: "connect-failure,refused-stream,unavailable,cancelled,retriable-status-codes", Default: true},
+		}, policy.Routes[0])
+		assert.Empty(t, policy.DestinationRules)
+		require.Len(t, policy.Notes, 1)
+		assert.Contains(t, policy.Notes[0], "Istio default")
+	})
+}
+
+func TestServiceResilience_KialiClient(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/clusters/services":
+			assert.Equal(t, "bookinfo", r.URL.Query().Get("namespaces"))
+			_, _ = w.Write([]byte(`{"services": [
+				{"name": "reviews", "namespace": "bookinfo"},
+				{"name": "details", "namespace": "bookinfo"},
+				{"name": "ratings", "namespace": "bookinfo"}
+			]}`))
+		case "/api/istio/config":
+			_, _ = w.Write([]byte(`{"resources": {
+				"networking.istio.io/v1, Kind=DestinationRule": [
+					{"metadata": {"name": "reviews", "namespace": "bookinfo"}, "spec": {
+						"host": "reviews",
+						"trafficPolicy": {
+							"connectionPool": {"http": {"http1MaxPendingRequests": 10}},
+							"portLevelSettings": [{"port": {"number": 9080}, "outlierDetection": {"consecutive5xxErrors": 3}}]
+						},
+						"subsets": [{"name": "v2", "trafficPolicy": {"outlierDetection": {}}}, {"name": "v1"}]
+					}},
+					{"metadata": {"name": "ratings", "namespace": "bookinfo"}, "spec": {
+						"host": "ratings.bookinfo.svc.cluster.local",
+						"trafficPolicy": {"loadBalancer": {"simple": "ROUND_ROBIN"}}
+					}}
+				]
+			}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockServer.Close()
+
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+
+	t.Run("namespace", func(t *testing.T) {
+		result, err := kialiClient.ServiceResilience(context.Background(), "bookinfo", "")
+		require.NoError(t, err)
+
+		var report internalkiali.ServiceResilienceReport
+		require.NoError(t, json.Unmarshal([]byte(result), &report))
+		assert.Equal(t, []string{"details", "ratings"}, report.Unprotected)
+		require.Len(t, report.Services, 3)
+
+		details := report.Services[0]
+		assert.Equal(t, "details", details.Service)
+		assert.Empty(t, details.DestinationRules)
+		assert.Equal(t, []string{"outlierDetection", "connectionPool"}, details.Missing)
+		require.Len(t, details.Notes, 1)
+		assert.Contains(t, details.Notes[0], "no DestinationRule")
+
+		ratings := report.Services[1]
+		assert.Equal(t, []string{"bookinfo/ratings"}, ratings.DestinationRules)
+		assert.Equal(t, []string{"outlierDetection", "connectionPool"}, ratings.Missing)
+
+		reviews := report.Services[2]
+		assert.Equal(t, "reviews.bookinfo.svc.cluster.local", reviews.Host)
+		assert.Empty(t, reviews.Missing)
+		assert.Equal(t, []internalkiali.ResilienceSetting{
+			{DestinationRule: "bookinfo/reviews", Scope: "host", Settings: []string{"http.http1MaxPendingRequests=10"}},
+		}, reviews.ConnectionPool)
+		assert.Equal(t, []internalkiali.ResilienceSetting{
+			{DestinationRule: "bookinfo/reviews", Scope: "port 9080", Settings: []string{"consecutive5xxErrors=3"}},
+			{DestinationRule: "bookinfo/reviews", Scope: "subset v2"},
+		}, reviews.OutlierDetection)
+	})
+
+	t.Run("single service", func(t *testing.T) {
+		result, err := kialiClient.ServiceResilience(context.Background(), "bookinfo", "reviews")
+		require.NoError(t, err)
+
+		var report internalkiali.ServiceResilienceReport
+		require.NoError(t, json.Unmarshal([]byte(result), &report))
+		require.Len(t, report.Services, 1)
+		assert.Equal(t, "reviews", report.Services[0].Service)
+		assert.Empty(t, report.Unprotected)
+	})
+
+	t.Run("missing namespace", func(t *testing.T) {
+		_, err := kialiClient.ServiceResilience(context.Background(), "", "reviews")
+		require.Error(t, err)
+		assert.Equal(t, "namespace is required", err.Error())
+	})
+}
+
+func TestOrphanedServices_KialiClient(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "bookinfo,legacy", r.URL.Query().Get("namespaces"))
+		switch r.URL.Path {
+		case "/api/clusters/services":
+			_, _ = w.Write([]byte(`{"services": [
+				{"name": "reviews", "namespace": "bookinfo", "selector": {"app": "reviews"}},
+				{"name": "ratings", "namespace": "bookinfo", "selector": {"app": "ratings", "tier": "backend"}},
+				{"name": "details", "namespace": "legacy", "selector": {"app": "details"}},
+				{"name": "external", "namespace": "bookinfo"},
+				{"name": "api.example.com", "namespace": "bookinfo", "selector": {"app": "api"}, "serviceRegistry": "External"}
+			]}`))
+		case "/api/clusters/workloads":
+			_, _ = w.Write([]byte(`{"workloads": [
+				{"name": "reviews-v1", "namespace": "bookinfo", "labels": {"app": "reviews", "version": "v1"}},
+				{"name": "ratings-v1", "namespace": "bookinfo", "labels": {"app": "ratings", "version": "v1"}},
+				{"name": "details-v1", "namespace": "bookinfo", "labels": {"app": "details"}}
+			]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockServer.Close()
+
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+	result, err := kialiClient.OrphanedServices(context.Background(), "bookinfo,legacy")
+	require.NoError(t, err)
+
+	var orphaned []internalkiali.OrphanedService
+	require.NoError(t, json.Unmarshal([]byte(result), &orphaned))
+	assert.Equal(t, []internalkiali.OrphanedService{
+		{Namespace: "bookinfo", Name: "ratings", Selector: map[string]string{"app": "ratings", "tier": "backend"}},
+		{Namespace: "legacy", Name: "details", Selector: map[string]string{"app": "details"}},
+	}, orphaned)
+}
+
+func TestSlowestServices_KialiClient(t *testing.T) {
+	metrics := map[string]string{
+		"productpage": `{"request_count": [{"datapoints": [{"timestamp": 1, "value": 10}, {"timestamp": 2, "value": 20}]}],
+			"request_duration_millis": [{"stat": "0.99", "datapoints": [{"timestamp": 1, "value": 120}, {"timestamp": 2, "value": "NaN"}]}]}`,
+		"reviews": `{"request_count": [{"datapoints": [{"timestamp": 1, "value": 5}]}],
+			"request_duration_millis": [{"stat": "0.99", "datapoints": [{"timestamp": 1, "value": 300}, {"timestamp": 2, "value": 500}]}]}`,
+		"ratings": `{"request_count": [{"datapoints": [{"timestamp": 1, "value": 2}]}],
+			"request_duration_millis": [{"stat": "0.99", "datapoints": [{"timestamp": 1, "value": 40}]}]}`,
+		"details": `{"request_count": [], "request_duration_millis": []}`,
+	}
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/clusters/services" {
+			assert.Equal(t, "bookinfo", r.URL.Query().Get("namespaces"))
+			_, _ = w.Write([]byte(`{"services": [
+				{"namespace": "bookinfo", "name": "reviews", "cluster": "east"},
+				{"namespace": "bookinfo", "name": "reviews", "cluster": "west"},
+				{"namespace": "bookinfo", "name": "productpage"},
+				{"namespace": "bookinfo", "name": "ratings"},
+				{"namespace": "bookinfo", "name": "details"},
+				{"namespace": "bookinfo", "name": "mongodb"},
+				{"namespace": "bookinfo", "name": "httpbin.org", "serviceRegistry": "External"}
+			]}`))
+			return
+		}
+		assert.Equal(t, []string{"0.99"}, r.URL.Query()["quantiles[]"])
+		assert.Equal(t, "1800", r.URL.Query().Get("duration"))
+		for name, content := range metrics {
+			if r.URL.Path == "/api/namespaces/bookinfo/services/"+name+"/metrics" {
+				_, _ = w.Write([]byte(content))
+				return
+			}
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer mockServer.Close()
+
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+	result, err := kialiClient.SlowestServices(context.Background(), "bookinfo", 2, "1800")
+	require.NoError(t, err)
+
+	var slowest internalkiali.SlowestServices
+	require.NoError(t, json.Unmarshal([]byte(result), &slowest))
+	assert.Equal(t, []internalkiali.ServiceLatency{
+		{Namespace: "bookinfo", Name: "reviews", P99Millis: 400, RequestRate: 5},
+		{Namespace: "bookinfo", Name: "productpage", P99Millis: 120, RequestRate: 15},
+	}, slowest.Services)
+	assert.Equal(t, 3, slowest.Ranked)
+	assert.Equal(t, 1, slowest.NoTraffic)
+	assert.Equal(t, "0.99", slowest.Quantile)
+	require.Len(t, slowest.FailedServices, 1)
+	assert.Contains(t, slowest.FailedServices, "bookinfo/mongodb")
+}
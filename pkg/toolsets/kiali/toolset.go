@@ -24,16 +24,28 @@ func (t *Toolset) GetTools(_ internalk8s.Openshift) []api.ServerTool {
 	return slices.Concat(
 		initGraph(),
 		initMeshStatus(),
+		initMeshReport(),
 		initIstioConfig(),
 		initIstioObjectDetails(),
 		initIstioObjectPatch(),
 		initIstioObjectCreate(),
+		initIstioObjectConflicts(),
+		initIstioObjectValidate(),
+		initIstioObjectsDiff(),
 		initIstioObjectDelete(),
+		initAuthorizationPolicies(),
+		initPeerAuthentications(),
+		initDestinationRules(),
+		initHostReachability(),
+		initVirtualServiceGateways(),
 		initValidations(),
 		initNamespaces(),
 		initServices(),
+		initApps(),
 		initWorkloads(),
+		initMetrics(),
 		initHealth(),
+		initHealthSummary(),
 		initLogs(),
 		initTraces(),
 	)
@@ -0,0 +1,53 @@
+package kiali
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali-mcp-server/pkg/api"
+	"github.com/kiali/kiali-mcp-server/pkg/config"
+	internalkiali "github.com/kiali/kiali-mcp-server/pkg/kiali"
+)
+
+func TestToolsetTools(t *testing.T) {
+	tools := map[string]api.ServerTool{}
+	for _, tool := range (&Toolset{}).GetTools(nil) {
+		_, duplicate := tools[tool.Tool.Name]
+		assert.False(t, duplicate, "tool %s is registered twice", tool.Tool.Name)
+		tools[tool.Tool.Name] = tool
+	}
+	for _, name := range []string{"health", "mesh_health_summary", "mesh_health_metrics", "mesh_health_table", "app_traces", "service_traces", "workload_traces"} {
+		assert.Contains(t, tools, name, "tool %s is not registered", name)
+	}
+
+	t.Run("mesh_health_summary handler", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Query().Get("type") {
+			case "app":
+				_, _ = w.Write([]byte(`{"namespaceAppHealth": {"bookinfo": {"reviews": {"workloadStatuses": [{"name": "reviews-v1", "desiredReplicas": 1, "currentReplicas": 1, "availableReplicas": 1}], "requests": {}}}}}`))
+			default:
+				_, _ = w.Write([]byte(`{}`))
+			}
+		}))
+		defer mockServer.Close()
+
+		tool, ok := tools["mesh_health_summary"]
+		require.True(t, ok)
+		result, err := tool.Handler(api.ToolHandlerParams{
+			Context:         context.Background(),
+			Kiali:           internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL}),
+			ToolCallRequest: argumentsRequest{"namespaces": "bookinfo"},
+		})
+		require.NoError(t, err)
+		require.NoError(t, result.Error)
+		var summary internalkiali.MeshHealthSummary
+		require.NoError(t, json.Unmarshal([]byte(result.Content), &summary))
+		assert.Equal(t, 1, summary.Entities["app"].Healthy)
+	})
+}
@@ -2,6 +2,8 @@ package kiali
 
 import (
 	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/google/jsonschema-go/jsonschema"
 	"k8s.io/utils/ptr"
@@ -30,7 +32,7 @@ func initTraces() []api.ServerTool {
 					},
 					"startMicros": {
 						Type:        "string",
-						Description: "Start time for traces in microseconds since epoch (optional)",
+						Description: "Start time for traces in microseconds since epoch (optional, defaults to the configured lookback before the end time)",
 					},
 					"endMicros": {
 						Type:        "string",
@@ -38,7 +40,7 @@ func initTraces() []api.ServerTool {
 					},
 					"limit": {
 						Type:        "integer",
-						Description: "Maximum number of traces to return (default: 100)",
+						Description: "Maximum number of traces to return (default: the configured traces limit, or 100)",
 						Minimum:     ptr.To(float64(1)),
 					},
 					"minDuration": {
@@ -86,7 +88,7 @@ func initTraces() []api.ServerTool {
 					},
 					"startMicros": {
 						Type:        "string",
-						Description: "Start time for traces in microseconds since epoch (optional)",
+						Description: "Start time for traces in microseconds since epoch (optional, defaults to the configured lookback before the end time)",
 					},
 					"endMicros": {
 						Type:        "string",
@@ -94,7 +96,7 @@ func initTraces() []api.ServerTool {
 					},
 					"limit": {
 						Type:        "integer",
-						Description: "Maximum number of traces to return (default: 100)",
+						Description: "Maximum number of traces to return (default: the configured traces limit, or 100)",
 						Minimum:     ptr.To(float64(1)),
 					},
 					"minDuration": {
@@ -142,7 +144,7 @@ func initTraces() []api.ServerTool {
 					},
 					"startMicros": {
 						Type:        "string",
-						Description: "Start time for traces in microseconds since epoch (optional)",
+						Description: "Start time for traces in microseconds since epoch (optional, defaults to the configured lookback before the end time)",
 					},
 					"endMicros": {
 						Type:        "string",
@@ -150,7 +152,7 @@ func initTraces() []api.ServerTool {
 					},
 					"limit": {
 						Type:        "integer",
-						Description: "Maximum number of traces to return (default: 100)",
+						Description: "Maximum number of traces to return (default: the configured traces limit, or 100)",
 						Minimum:     ptr.To(float64(1)),
 					},
 					"minDuration": {
@@ -180,33 +182,97 @@ func initTraces() []api.ServerTool {
 		Handler: workloadTracesHandler,
 	})
 
+	// Namespace traces tool
+	ret = append(ret, api.ServerTool{
+		Tool: api.Tool{
+			Name:        "namespace_traces",
+			Description: "Get distributed tracing data for all the apps in a namespace. The traces of every app are fetched in parallel and deduplicated by trace ID; apps whose traces could not be retrieved in time are reported under 'failedApps' while the available traces are still returned.",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace to get traces for",
+					},
+					"startMicros": {
+						Type:        "string",
+						Description: "Start time for traces in microseconds since epoch (optional, defaults to the configured lookback before the end time)",
+					},
+					"endMicros": {
+						Type:        "string",
+						Description: "End time for traces in microseconds since epoch (optional)",
+					},
+					"limit": {
+						Type:        "integer",
+						Description: "Maximum number of traces to return across all apps (default: the configured traces limit, or 100)",
+						Minimum:     ptr.To(float64(1)),
+					},
+					"minDuration": {
+						Type:        "integer",
+						Description: "Minimum trace duration in microseconds (optional)",
+						Minimum:     ptr.To(float64(0)),
+					},
+					"tags": {
+						Type:        "string",
+						Description: "JSON string of tags to filter traces (optional)",
+					},
+				},
+				Required: []string{"namespace"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Namespace: Traces",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		},
+		Handler: namespaceTracesHandler,
+		// Fans out one traces request per app in the namespace
+		Timeout: time.Minute,
+	})
+
+	// Trace waterfall tool
+	ret = append(ret, api.ServerTool{
+		Tool: api.Tool{
+			Name:        "trace_waterfall",
+			Description: "Get the request flow of a single trace as a readable timeline: its spans ordered by start time, each with its service, operation, start offset from the beginning of the trace and duration in milliseconds, and whether it failed. Use it on a trace ID returned by the other trace tools to follow a request across the services",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"traceID": {
+						Type:        "string",
+						Description: "ID of the trace (e.g. '4bf92f3577b34da6a3ce929d0e0e4736')",
+					},
+				},
+				Required: []string{"traceID"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Trace: Waterfall",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		},
+		Handler: traceWaterfallHandler,
+	})
+
 	return ret
 }
 
 func appTracesHandler(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
-	// Extract parameters
-	namespace := params.GetArguments()["namespace"].(string)
-	app := params.GetArguments()["app"].(string)
-
-	// Build query parameters from optional arguments
-	queryParams := make(map[string]string)
-	if startMicros, ok := params.GetArguments()["startMicros"].(string); ok && startMicros != "" {
-		queryParams["startMicros"] = startMicros
-	}
-	if endMicros, ok := params.GetArguments()["endMicros"].(string); ok && endMicros != "" {
-		queryParams["endMicros"] = endMicros
+	namespace, _ := params.GetArguments()["namespace"].(string)
+	app, _ := params.GetArguments()["app"].(string)
+	if namespace == "" {
+		return api.NewToolCallResult("", fmt.Errorf("namespace parameter is required")), nil
 	}
-	if limit, ok := params.GetArguments()["limit"].(string); ok && limit != "" {
-		queryParams["limit"] = limit
+	if app == "" {
+		return api.NewToolCallResult("", fmt.Errorf("app parameter is required")), nil
 	}
-	if minDuration, ok := params.GetArguments()["minDuration"].(string); ok && minDuration != "" {
-		queryParams["minDuration"] = minDuration
-	}
-	if tags, ok := params.GetArguments()["tags"].(string); ok && tags != "" {
-		queryParams["tags"] = tags
-	}
-	if clusterName, ok := params.GetArguments()["clusterName"].(string); ok && clusterName != "" {
-		queryParams["clusterName"] = clusterName
+	queryParams, err := tracesQueryParams(params)
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
 	}
 
 	content, err := params.AppTraces(params.Context, namespace, app, queryParams)
@@ -217,29 +283,17 @@ func appTracesHandler(params api.ToolHandlerParams) (*api.ToolCallResult, error)
 }
 
 func serviceTracesHandler(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
-	// Extract parameters
-	namespace := params.GetArguments()["namespace"].(string)
-	service := params.GetArguments()["service"].(string)
-
-	// Build query parameters from optional arguments
-	queryParams := make(map[string]string)
-	if startMicros, ok := params.GetArguments()["startMicros"].(string); ok && startMicros != "" {
-		queryParams["startMicros"] = startMicros
+	namespace, _ := params.GetArguments()["namespace"].(string)
+	service, _ := params.GetArguments()["service"].(string)
+	if namespace == "" {
+		return api.NewToolCallResult("", fmt.Errorf("namespace parameter is required")), nil
 	}
-	if endMicros, ok := params.GetArguments()["endMicros"].(string); ok && endMicros != "" {
-		queryParams["endMicros"] = endMicros
+	if service == "" {
+		return api.NewToolCallResult("", fmt.Errorf("service parameter is required")), nil
 	}
-	if limit, ok := params.GetArguments()["limit"].(string); ok && limit != "" {
-		queryParams["limit"] = limit
-	}
-	if minDuration, ok := params.GetArguments()["minDuration"].(string); ok && minDuration != "" {
-		queryParams["minDuration"] = minDuration
-	}
-	if tags, ok := params.GetArguments()["tags"].(string); ok && tags != "" {
-		queryParams["tags"] = tags
-	}
-	if clusterName, ok := params.GetArguments()["clusterName"].(string); ok && clusterName != "" {
-		queryParams["clusterName"] = clusterName
+	queryParams, err := tracesQueryParams(params)
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
 	}
 
 	content, err := params.ServiceTraces(params.Context, namespace, service, queryParams)
@@ -250,34 +304,84 @@ func serviceTracesHandler(params api.ToolHandlerParams) (*api.ToolCallResult, er
 }
 
 func workloadTracesHandler(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
-	// Extract parameters
-	namespace := params.GetArguments()["namespace"].(string)
-	workload := params.GetArguments()["workload"].(string)
+	namespace, _ := params.GetArguments()["namespace"].(string)
+	workload, _ := params.GetArguments()["workload"].(string)
+	if namespace == "" {
+		return api.NewToolCallResult("", fmt.Errorf("namespace parameter is required")), nil
+	}
+	if workload == "" {
+		return api.NewToolCallResult("", fmt.Errorf("workload parameter is required")), nil
+	}
+	queryParams, err := tracesQueryParams(params)
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+
+	content, err := params.WorkloadTraces(params.Context, namespace, workload, queryParams)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get workload traces: %v", err)), nil
+	}
+	return api.NewToolCallResult(content, nil), nil
+}
 
-	// Build query parameters from optional arguments
+// tracesQueryParams builds the query parameters of the traces tools from their optional arguments. The integer
+// arguments (limit, minDuration) are received as JSON numbers.
+func tracesQueryParams(params api.ToolHandlerParams) (map[string]string, error) {
 	queryParams := make(map[string]string)
-	if startMicros, ok := params.GetArguments()["startMicros"].(string); ok && startMicros != "" {
-		queryParams["startMicros"] = startMicros
+	for _, name := range []string{"startMicros", "endMicros", "tags", "clusterName"} {
+		if value, ok := params.GetArguments()[name].(string); ok && value != "" {
+			queryParams[name] = value
+		}
+	}
+	if v, ok := params.GetArguments()["limit"].(float64); ok {
+		if v < 1 || v != float64(int(v)) {
+			return nil, fmt.Errorf("limit must be a positive integer")
+		}
+		queryParams["limit"] = strconv.Itoa(int(v))
+	}
+	if v, ok := params.GetArguments()["minDuration"].(float64); ok {
+		if v < 0 || v != float64(int64(v)) {
+			return nil, fmt.Errorf("minDuration must be a non-negative integer")
+		}
+		queryParams["minDuration"] = strconv.FormatInt(int64(v), 10)
 	}
-	if endMicros, ok := params.GetArguments()["endMicros"].(string); ok && endMicros != "" {
-		queryParams["endMicros"] = endMicros
+	return queryParams, nil
+}
+
+func namespaceTracesHandler(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	// Extract parameters
+	namespace, _ := params.GetArguments()["namespace"].(string)
+	if namespace == "" {
+		return api.NewToolCallResult("", fmt.Errorf("namespace parameter is required")), nil
 	}
-	if limit, ok := params.GetArguments()["limit"].(string); ok && limit != "" {
-		queryParams["limit"] = limit
+
+	queryParams, err := tracesQueryParams(params)
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
 	}
-	if minDuration, ok := params.GetArguments()["minDuration"].(string); ok && minDuration != "" {
-		queryParams["minDuration"] = minDuration
+	// The limit applies across all apps; 0 applies the configured default limit, or DefaultNamespaceTracesLimit
+	limit := 0
+	if value, ok := queryParams["limit"]; ok {
+		limit, _ = strconv.Atoi(value)
+		delete(queryParams, "limit")
 	}
-	if tags, ok := params.GetArguments()["tags"].(string); ok && tags != "" {
-		queryParams["tags"] = tags
+
+	content, err := params.NamespaceTraces(params.Context, namespace, queryParams, limit)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get namespace traces: %v", err)), nil
 	}
-	if clusterName, ok := params.GetArguments()["clusterName"].(string); ok && clusterName != "" {
-		queryParams["clusterName"] = clusterName
+	return api.NewToolCallResult(content, nil), nil
+}
+
+func traceWaterfallHandler(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	traceID, _ := params.GetArguments()["traceID"].(string)
+	if traceID == "" {
+		return api.NewToolCallResult("", fmt.Errorf("traceID parameter is required")), nil
 	}
 
-	content, err := params.WorkloadTraces(params.Context, namespace, workload, queryParams)
+	content, err := params.TraceWaterfall(params.Context, traceID)
 	if err != nil {
-		return api.NewToolCallResult("", fmt.Errorf("failed to get workload traces: %v", err)), nil
+		return api.NewToolCallResult("", fmt.Errorf("failed to get trace waterfall: %v", err)), nil
 	}
 	return api.NewToolCallResult(content, nil), nil
 }
@@ -2,11 +2,16 @@ package kiali
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/kiali/kiali-mcp-server/pkg/api"
 	"github.com/kiali/kiali-mcp-server/pkg/config"
 	internalkiali "github.com/kiali/kiali-mcp-server/pkg/kiali"
 )
@@ -466,3 +471,336 @@ func TestWorkloadTraces_KialiClient(t *testing.T) {
 		})
 	}
 }
+
+func TestNamespaceTraces_KialiClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/clusters/apps":
+			if r.URL.Query().Get("namespaces") != "bookinfo" {
+				t.Errorf("Expected namespaces=bookinfo, got %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`{"applications": [
+				{"name": "reviews", "namespace": "bookinfo"},
+				{"name": "productpage", "namespace": "bookinfo"},
+				{"name": "ratings", "namespace": "bookinfo"}
+			]}`))
+		case "/api/namespaces/bookinfo/apps/productpage/traces":
+			if r.URL.Query().Get("limit") != "3" {
+				t.Errorf("Expected per-app limit 3, got %s", r.URL.Query().Get("limit"))
+			}
+			_, _ = w.Write([]byte(`{"data": [{"traceID": "t1"}, {"traceID": "t2"}]}`))
+		case "/api/namespaces/bookinfo/apps/reviews/traces":
+			_, _ = w.Write([]byte(`{"data": [{"traceID": "t2"}, {"traceID": "t3"}, {"traceID": "t4"}]}`))
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte("tracing backend unavailable"))
+		}
+	}))
+	defer server.Close()
+
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: server.URL})
+	result, err := kialiClient.NamespaceTraces(context.Background(), "bookinfo", nil, 3)
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+
+	var traces internalkiali.NamespaceTraces
+	if err := json.Unmarshal([]byte(result), &traces); err != nil {
+		t.Fatalf("Failed to parse result: %v", err)
+	}
+	ids := make([]string, 0, len(traces.Traces))
+	for _, trace := range traces.Traces {
+		var tr struct {
+			TraceID string `json:"traceID"`
+		}
+		_ = json.Unmarshal(trace, &tr)
+		ids = append(ids, tr.TraceID)
+	}
+	if strings.Join(ids, ",") != "t1,t2,t3" {
+		t.Errorf("Expected deduplicated traces t1,t2,t3, got %v", ids)
+	}
+	if !traces.Truncated {
+		t.Errorf("Expected result to be truncated")
+	}
+	if strings.Join(traces.Apps, ",") != "productpage,ratings,reviews" {
+		t.Errorf("Expected sorted apps, got %v", traces.Apps)
+	}
+	if len(traces.FailedApps) != 1 || !strings.Contains(traces.FailedApps["ratings"], "tracing backend unavailable") {
+		t.Errorf("Expected ratings to be reported as failed, got %v", traces.FailedApps)
+	}
+}
+
+func TestNamespaceTraces_KialiClient_AllAppsFail(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/clusters/apps" {
+			_, _ = w.Write([]byte(`{"applications": [{"name": "reviews", "namespace": "bookinfo"}]}`))
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: server.URL})
+	if _, err := kialiClient.NamespaceTraces(context.Background(), "bookinfo", nil, 0); err == nil {
+		t.Errorf("Expected error when every app fails")
+	}
+}
+
+func TestNamespaceTraces_KialiClient_SlowApp(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/clusters/apps":
+			_, _ = w.Write([]byte(`{"applications": [{"name": "reviews", "namespace": "bookinfo"}, {"name": "ratings", "namespace": "bookinfo"}]}`))
+		case "/api/namespaces/bookinfo/apps/ratings/traces":
+			select {
+			case <-r.Context().Done():
+			case <-time.After(5 * time.Second):
+			}
+		default:
+			_, _ = w.Write([]byte(`{"data": [{"traceID": "t1"}]}`))
+		}
+	}))
+	defer server.Close()
+
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: server.URL})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	result, err := kialiClient.NamespaceTraces(ctx, "bookinfo", nil, 0)
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if ctx.Err() != nil {
+		t.Errorf("Expected the slow app to be reported before the deadline of the call")
+	}
+	var traces internalkiali.NamespaceTraces
+	if err := json.Unmarshal([]byte(result), &traces); err != nil {
+		t.Fatalf("Failed to parse result: %v", err)
+	}
+	if len(traces.Traces) != 1 {
+		t.Errorf("Expected the traces of the other app, got %d traces", len(traces.Traces))
+	}
+	if _, ok := traces.FailedApps["ratings"]; !ok || len(traces.FailedApps) != 1 {
+		t.Errorf("Expected ratings to be reported as failed, got %v", traces.FailedApps)
+	}
+}
+
+func TestNamespaceTracesHandler(t *testing.T) {
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/clusters/apps" {
+			_, _ = w.Write([]byte(`{"applications": [{"name": "reviews", "namespace": "bookinfo"}]}`))
+			return
+		}
+		query = r.URL.Query()
+		_, _ = w.Write([]byte(`{"data": []}`))
+	}))
+	defer server.Close()
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: server.URL})
+	call := func(arguments argumentsRequest) *api.ToolCallResult {
+		result, err := namespaceTracesHandler(api.ToolHandlerParams{Context: context.Background(), Kiali: kialiClient, ToolCallRequest: arguments})
+		if err != nil {
+			t.Fatalf("Expected no error, but got: %v", err)
+		}
+		return result
+	}
+
+	result := call(argumentsRequest{"namespace": "bookinfo", "limit": float64(7), "minDuration": float64(1500)})
+	if result.Error != nil {
+		t.Fatalf("Expected no error, but got: %v", result.Error)
+	}
+	if query.Get("limit") != "7" || query.Get("minDuration") != "1500" {
+		t.Errorf("Expected limit 7 and minDuration 1500, got %s", query.Encode())
+	}
+
+	invalid := []struct {
+		arguments argumentsRequest
+		expected  string
+	}{
+		{argumentsRequest{"namespace": "bookinfo", "limit": float64(0)}, "limit must be a positive integer"},
+		{argumentsRequest{"namespace": "bookinfo", "limit": 2.5}, "limit must be a positive integer"},
+		{argumentsRequest{"namespace": "bookinfo", "minDuration": float64(-1)}, "minDuration must be a non-negative integer"},
+		{argumentsRequest{"namespace": "bookinfo", "minDuration": 0.5}, "minDuration must be a non-negative integer"},
+	}
+	for _, tt := range invalid {
+		result := call(tt.arguments)
+		if result.Error == nil || result.Error.Error() != tt.expected {
+			t.Errorf("Expected error %q for %v, got %v", tt.expected, tt.arguments, result.Error)
+		}
+	}
+}
+
+func TestTracesDefaults_KialiClient(t *testing.T) {
+	var queries []url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/clusters/apps":
+			_, _ = w.Write([]byte(`{"applications": [{"name": "reviews", "namespace": "bookinfo"}]}`))
+		default:
+			queries = append(queries, r.URL.Query())
+			_, _ = w.Write([]byte(`{"data": []}`))
+		}
+	}))
+	defer server.Close()
+
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: server.URL, KialiTracesLimit: 20, KialiTracesLookback: "15m"})
+	lookback := (15 * time.Minute).Microseconds()
+
+	t.Run("defaults applied", func(t *testing.T) {
+		queries = nil
+		before := time.Now().UnixMicro()
+		if _, err := kialiClient.ServiceTraces(context.Background(), "bookinfo", "reviews", nil); err != nil {
+			t.Fatalf("Expected no error, but got: %v", err)
+		}
+		if queries[0].Get("limit") != "20" {
+			t.Errorf("Expected default limit 20, got %s", queries[0].Get("limit"))
+		}
+		start, err := strconv.ParseInt(queries[0].Get("startMicros"), 10, 64)
+		if err != nil || start < before-lookback || start > time.Now().UnixMicro()-lookback {
+			t.Errorf("Expected startMicros 15m before now, got %s", queries[0].Get("startMicros"))
+		}
+	})
+
+	t.Run("lookback counted from endMicros", func(t *testing.T) {
+		queries = nil
+		if _, err := kialiClient.WorkloadTraces(context.Background(), "bookinfo", "reviews-v1", map[string]string{"endMicros": "1000000000000"}); err != nil {
+			t.Fatalf("Expected no error, but got: %v", err)
+		}
+		if expected := strconv.FormatInt(1000000000000-lookback, 10); queries[0].Get("startMicros") != expected {
+			t.Errorf("Expected startMicros %s, got %s", expected, queries[0].Get("startMicros"))
+		}
+	})
+
+	t.Run("per-call parameters override the defaults", func(t *testing.T) {
+		queries = nil
+		if _, err := kialiClient.AppTraces(context.Background(), "bookinfo", "reviews", map[string]string{"limit": "5", "startMicros": "42"}); err != nil {
+			t.Fatalf("Expected no error, but got: %v", err)
+		}
+		if queries[0].Get("limit") != "5" || queries[0].Get("startMicros") != "42" {
+			t.Errorf("Expected limit 5 and startMicros 42, got %s", queries[0].Encode())
+		}
+	})
+
+	t.Run("namespace traces default limit", func(t *testing.T) {
+		queries = nil
+		if _, err := kialiClient.NamespaceTraces(context.Background(), "bookinfo", nil, 0); err != nil {
+			t.Fatalf("Expected no error, but got: %v", err)
+		}
+		if queries[0].Get("limit") != "20" || queries[0].Get("startMicros") == "" {
+			t.Errorf("Expected default limit 20 and lookback, got %s", queries[0].Encode())
+		}
+	})
+}
+
+func TestTraceWaterfall_KialiClient(t *testing.T) {
+	trace := `{"data": [{"traceID": "abc123", "spans": [
+		{"spanID": "2", "operationName": "reviews.bookinfo.svc.cluster.local:9080/*", "startTime": 1000500, "duration": 2000, "processID": "p2",
+		 "tags": [{"key": "error", "value": true}]},
+		{"spanID": "1", "operationName": "productpage.bookinfo.svc.cluster.local:9080/productpage", "startTime": 1000000, "duration": 4250, "processID": "p1"},
+		{"spanID": "3", "operationName": "ratings.bookinfo.svc.cluster.local:9080/*", "startTime": 1000500, "duration": 750, "processID": "p3",
+		 "tags": [{"key": "otel.status_code", "value": "OK"}]}
+	], "processes": {"p1": {"serviceName": "productpage.bookinfo"}, "p2": {"serviceName": "reviews.bookinfo"}, "p3": {"serviceName": "ratings.bookinfo"}}}]}`
+
+	var requestedPath string
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedPath = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/missing") {
+			_, _ = w.Write([]byte(`{"data": []}`))
+			return
+		}
+		_, _ = w.Write([]byte(trace))
+	}))
+	defer mockServer.Close()
+
+	client := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+
+	result, err := client.TraceWaterfall(context.Background(), "abc123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requestedPath != "/api/traces/abc123" {
+		t.Errorf("expected path /api/traces/abc123, got %s", requestedPath)
+	}
+	var waterfall internalkiali.TraceWaterfall
+	if err := json.Unmarshal([]byte(result), &waterfall); err != nil {
+		t.Fatalf("failed to parse result: %v", err)
+	}
+	if waterfall.TraceID != "abc123" || waterfall.DurationMs != 4.25 {
+		t.Errorf("unexpected trace: %s, %v ms", waterfall.TraceID, waterfall.DurationMs)
+	}
+	expected := []internalkiali.WaterfallSpan{
+		{Service: "productpage.bookinfo", Operation: "productpage.bookinfo.svc.cluster.local:9080/productpage", StartOffsetMs: 0, DurationMs: 4.25},
+		{Service: "reviews.bookinfo", Operation: "reviews.bookinfo.svc.cluster.local:9080/*", StartOffsetMs: 0.5, DurationMs: 2, Error: true},
+		{Service: "ratings.bookinfo", Operation: "ratings.bookinfo.svc.cluster.local:9080/*", StartOffsetMs: 0.5, DurationMs: 0.75},
+	}
+	if len(waterfall.Spans) != len(expected) {
+		t.Fatalf("expected %d spans, got %d", len(expected), len(waterfall.Spans))
+	}
+	for i, span := range waterfall.Spans {
+		if span != expected[i] {
+			t.Errorf("span %d: expected %+v, got %+v", i, expected[i], span)
+		}
+	}
+
+	if _, err := client.TraceWaterfall(context.Background(), "missing"); err == nil || !strings.Contains(err.Error(), "trace 'missing' not found") {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
+
+func TestTracesHandlers(t *testing.T) {
+	var requested url.Values
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = r.URL.Query()
+		_, _ = w.Write([]byte(`{"data": []}`))
+	}))
+	defer mockServer.Close()
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+
+	handlers := map[string]struct {
+		handler api.ToolHandlerFunc
+		entity  string
+	}{
+		"app_traces":      {handler: appTracesHandler, entity: "app"},
+		"service_traces":  {handler: serviceTracesHandler, entity: "service"},
+		"workload_traces": {handler: workloadTracesHandler, entity: "workload"},
+	}
+	for name, h := range handlers {
+		t.Run(name, func(t *testing.T) {
+			call := func(arguments argumentsRequest) *api.ToolCallResult {
+				result, err := h.handler(api.ToolHandlerParams{Context: context.Background(), Kiali: kialiClient, ToolCallRequest: arguments})
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				return result
+			}
+
+			requested = nil
+			result := call(argumentsRequest{"namespace": "bookinfo", h.entity: "reviews", "limit": float64(5), "minDuration": float64(1000), "clusterName": "east"})
+			if result.Error != nil {
+				t.Fatalf("Unexpected error: %v", result.Error)
+			}
+			if requested.Get("limit") != "5" || requested.Get("minDuration") != "1000" || requested.Get("clusterName") != "east" {
+				t.Errorf("Expected limit 5, minDuration 1000 and clusterName east, got %v", requested)
+			}
+
+			for _, tt := range []struct {
+				arguments argumentsRequest
+				expected  string
+			}{
+				{arguments: argumentsRequest{h.entity: "reviews"}, expected: "namespace parameter is required"},
+				{arguments: argumentsRequest{"namespace": "bookinfo"}, expected: h.entity + " parameter is required"},
+				{arguments: argumentsRequest{"namespace": "bookinfo", h.entity: "reviews", "limit": float64(2.5)}, expected: "limit must be a positive integer"},
+				{arguments: argumentsRequest{"namespace": "bookinfo", h.entity: "reviews", "minDuration": float64(-1)}, expected: "minDuration must be a non-negative integer"},
+			} {
+				requested = nil
+				result := call(tt.arguments)
+				if result.Error == nil || result.Error.Error() != tt.expected {
+					t.Errorf("Expected error %q, got %v", tt.expected, result.Error)
+				}
+				if requested != nil {
+					t.Errorf("Expected no request to Kiali, got %v", requested)
+				}
+			}
+		})
+	}
+}
@@ -39,6 +39,42 @@ func initValidations() []api.ServerTool {
 			},
 		}, Handler: validationsList,
 	})
+	ret = append(ret, api.ServerTool{
+		Tool: api.Tool{
+			Name:        "validation_errors",
+			Description: "List every Istio object of the mesh that currently fails a validation, across all namespaces: the triage entry point for config correctness. Returns one entry per failed check with the object namespace, type and name, the severity and the message, the errors first, then the warnings",
+			InputSchema: &jsonschema.Schema{
+				Type:       "object",
+				Properties: map[string]*jsonschema.Schema{},
+				Required:   []string{},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Validations: Errors",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: validationErrors,
+	})
+	ret = append(ret, api.ServerTool{
+		Tool: api.Tool{
+			Name:        "validation_changes_since",
+			Description: "Track Istio config drift: report the validation errors and warnings that appeared or were resolved across all namespaces since the previous call of this tool, along with the time of that previous call and the current error and warning counts. Kiali does not keep the validations history, so each call snapshots the current validations in the memory of this MCP server: the snapshots are scoped to the caller's credentials and MCP session, so each connected client tracks its own changes, only the last one is kept and they are lost when the server restarts. The first call only records the baseline and reports no change",
+			InputSchema: &jsonschema.Schema{
+				Type:       "object",
+				Properties: map[string]*jsonschema.Schema{},
+				Required:   []string{},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Validations: Changes Since Last Call",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: validationChangesSince,
+	})
 	return ret
 }
 
@@ -81,5 +117,24 @@ func validationsList(params api.ToolHandlerParams) (*api.ToolCallResult, error)
 	if err != nil {
 		return api.NewToolCallResult("", fmt.Errorf("failed to list validations: %v", err)), nil
 	}
+	if isEmptyResult(content, "") {
+		return api.NewToolCallResult(emptyListResult("Istio config validations", strings.Join(namespaces, ",")), nil), nil
+	}
+	return api.NewToolCallResult(content, nil), nil
+}
+
+func validationErrors(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	content, err := params.ValidationErrors(params.Context)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list validation errors: %v", err)), nil
+	}
+	return api.NewToolCallResult(content, nil), nil
+}
+
+func validationChangesSince(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	content, err := params.ValidationChangesSince(params.Context)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get validation changes: %v", err)), nil
+	}
 	return api.NewToolCallResult(content, nil), nil
 }
@@ -0,0 +1,148 @@
+package kiali
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali-mcp-server/pkg/config"
+	internalkiali "github.com/kiali/kiali-mcp-server/pkg/kiali"
+	internalk8s "github.com/kiali/kiali-mcp-server/pkg/kubernetes"
+)
+
+func TestValidationErrors_KialiClient(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/istio/config", r.URL.Path)
+		assert.Equal(t, "true", r.URL.Query().Get("validate"))
+		_, _ = w.Write([]byte(`{"resources": {}, "validations": {
+			"networking.istio.io/v1, Kind=VirtualService": {
+				"reviews.bookinfo": {"name": "reviews", "namespace": "bookinfo", "objectGVK": {"Group": "networking.istio.io", "Version": "v1", "Kind": "VirtualService"}, "valid": false, "checks": [
+					{"code": "KIA1107", "message": "Subset not found", "severity": "warning", "path": "spec/http[0]/route[0]/destination"},
+					{"code": "KIA1101", "message": "DestinationWeight on route doesn't have a valid service (host not found)", "severity": "error", "path": "spec/http[0]/route[0]/destination/host"}
+				]},
+				"ratings.bookinfo": {"name": "ratings", "namespace": "bookinfo", "valid": true, "checks": []}
+			},
+			"destinationrule": {
+				"details.legacy": {"valid": false, "checks": [
+					{"message": "More than one DestinationRules for the same host subset combination", "severity": "warning"},
+					{"message": "Informative check", "severity": "info"}
+				]}
+			},
+			"networking.istio.io/v1, Kind=Gateway": {
+				"ingress.istio-system": {"name": "ingress", "namespace": "istio-system", "cluster": "east", "valid": false, "checks": [
+					{"code": "KIA0301", "message": "More than one Gateway for the same host port combination", "severity": "error"}
+				]}
+			}
+		}}`))
+	}))
+	defer mockServer.Close()
+
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+	result, err := kialiClient.ValidationErrors(context.Background())
+	require.NoError(t, err)
+
+	var validationErrors []internalkiali.ValidationError
+	require.NoError(t, json.Unmarshal([]byte(result), &validationErrors))
+	assert.Equal(t, []internalkiali.ValidationError{
+		{Namespace: "bookinfo", ObjectType: "VirtualService", Name: "reviews", Severity: "error",
+			Message: "DestinationWeight on route doesn't have a valid service (host not found)", Code: "KIA1101", Path: "spec/http[0]/route[0]/destination/host"},
+		{Cluster: "east", Namespace: "istio-system", ObjectType: "Gateway", Name: "ingress", Severity: "error",
+			Message: "More than one Gateway for the same host port combination", Code: "KIA0301"},
+		{Namespace: "bookinfo", ObjectType: "VirtualService", Name: "reviews", Severity: "warning",
+			Message: "Subset not found", Code: "KIA1107", Path: "spec/http[0]/route[0]/destination"},
+		{Namespace: "legacy", ObjectType: "destinationrule", Name: "details", Severity: "warning",
+			Message: "More than one DestinationRules for the same host subset combination"},
+	}, validationErrors)
+
+	t.Run("excluded namespaces", func(t *testing.T) {
+		kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL, KialiExcludedNamespaces: []string{"bookinfo"}})
+		result, err := kialiClient.ValidationErrors(context.Background())
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal([]byte(result), &validationErrors))
+		require.Len(t, validationErrors, 2)
+		assert.Equal(t, "istio-system", validationErrors[0].Namespace)
+		assert.Equal(t, "legacy", validationErrors[1].Namespace)
+	})
+}
+
+func TestValidationChangesSince_KialiClient(t *testing.T) {
+	const subsetNotFound = `{"code": "KIA1107", "message": "Subset not found", "severity": "warning"}`
+	const hostNotFound = `{"code": "KIA1101", "message": "DestinationWeight on route doesn't have a valid service (host not found)", "severity": "error"}`
+	var checks atomic.Value
+	checks.Store(subsetNotFound)
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"resources": {}, "validations": {"networking.istio.io/v1, Kind=VirtualService": {
+			"reviews.bookinfo": {"name": "reviews", "namespace": "bookinfo", "objectGVK": {"Kind": "VirtualService"}, "checks": [` + checks.Load().(string) + `]}
+		}}}`))
+	}))
+	defer mockServer.Close()
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+
+	changesSince := func(t *testing.T, ctx context.Context) internalkiali.ValidationChanges {
+		result, err := kialiClient.ValidationChangesSince(ctx)
+		require.NoError(t, err)
+		var changes internalkiali.ValidationChanges
+		require.NoError(t, json.Unmarshal([]byte(result), &changes))
+		return changes
+	}
+	subset := internalkiali.ValidationError{Namespace: "bookinfo", ObjectType: "VirtualService", Name: "reviews", Severity: "warning", Message: "Subset not found", Code: "KIA1107"}
+	host := internalkiali.ValidationError{Namespace: "bookinfo", ObjectType: "VirtualService", Name: "reviews", Severity: "error",
+		Message: "DestinationWeight on route doesn't have a valid service (host not found)", Code: "KIA1101"}
+
+	baseline := changesSince(t, context.Background())
+	assert.True(t, baseline.Baseline)
+	assert.Nil(t, baseline.Since)
+	assert.Empty(t, baseline.Appeared, "the first call only records the baseline")
+	assert.Empty(t, baseline.Resolved)
+	assert.Equal(t, 1, baseline.Warnings)
+
+	checks.Store(hostNotFound)
+	changes := changesSince(t, context.Background())
+	assert.False(t, changes.Baseline)
+	require.NotNil(t, changes.Since)
+	assert.True(t, changes.Since.Equal(baseline.Now))
+	assert.Equal(t, []internalkiali.ValidationError{host}, changes.Appeared)
+	assert.Equal(t, []internalkiali.ValidationError{subset}, changes.Resolved)
+	assert.Equal(t, 1, changes.Errors)
+	assert.Equal(t, 0, changes.Warnings)
+
+	unchanged := changesSince(t, context.Background())
+	assert.Empty(t, unchanged.Appeared)
+	assert.Empty(t, unchanged.Resolved)
+	assert.True(t, unchanged.Since.Equal(changes.Now))
+
+	t.Run("snapshots are scoped to the caller credentials", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), internalk8s.OAuthAuthorizationHeader, "Bearer other-user")
+		assert.True(t, changesSince(t, ctx).Baseline)
+	})
+
+	t.Run("snapshots are scoped to the MCP session", func(t *testing.T) {
+		checks.Store(subsetNotFound)
+		first := internalkiali.WithSessionID(context.Background(), "session-1")
+		second := internalkiali.WithSessionID(context.Background(), "session-2")
+		assert.True(t, changesSince(t, first).Baseline)
+		assert.True(t, changesSince(t, second).Baseline, "sessions sharing the credentials of the server have their own snapshot")
+
+		checks.Store(hostNotFound)
+		assert.Equal(t, []internalkiali.ValidationError{host}, changesSince(t, first).Appeared)
+		assert.Equal(t, []internalkiali.ValidationError{host}, changesSince(t, second).Appeared, "a session does not consume the changes of another one")
+	})
+
+	t.Run("snapshots of a session ID are scoped to the caller credentials", func(t *testing.T) {
+		checks.Store(subsetNotFound)
+		first := internalkiali.WithSessionID(context.WithValue(context.Background(), internalk8s.OAuthAuthorizationHeader, "Bearer first-user"), "shared")
+		second := internalkiali.WithSessionID(context.WithValue(context.Background(), internalk8s.OAuthAuthorizationHeader, "Bearer second-user"), "shared")
+		assert.True(t, changesSince(t, first).Baseline)
+		assert.True(t, changesSince(t, second).Baseline, "callers with different credentials do not share the snapshot of a session ID")
+
+		checks.Store(hostNotFound)
+		assert.Equal(t, []internalkiali.ValidationError{subset}, changesSince(t, first).Resolved)
+		assert.Equal(t, []internalkiali.ValidationError{subset}, changesSince(t, second).Resolved, "a caller does not consume the changes of another one")
+	})
+}
@@ -1,12 +1,19 @@
 package kiali
 
 import (
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/google/jsonschema-go/jsonschema"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/utils/ptr"
 
 	"github.com/kiali/kiali-mcp-server/pkg/api"
+	internalkiali "github.com/kiali/kiali-mcp-server/pkg/kiali"
+	"github.com/kiali/kiali-mcp-server/pkg/kubernetes"
 )
 
 func initWorkloads() []api.ServerTool {
@@ -24,6 +31,19 @@ func initWorkloads() []api.ServerTool {
 						Type:        "string",
 						Description: "Comma-separated list of namespaces to get workloads from (e.g. 'bookinfo' or 'bookinfo,default'). If not provided, will list workloads from all accessible namespaces",
 					},
+					"workloadType": {
+						Type:        "string",
+						Description: "Optional controller kind to only list the workloads of: " + strings.Join(internalkiali.WorkloadTypes, ", ") + ". If not provided, workloads of all types are listed",
+					},
+					"perNamespace": {
+						Type:        "boolean",
+						Description: "Fetch the workloads namespace by namespace, a few namespaces at a time in name order, instead of in a single call, and stop once maxWorkloads workloads are listed. Use it on clusters with many namespaces to get a bounded listing: the result reports the workloads, the number of namespaces scanned out of the total, whether the cap was reached and the namespaces that failed. Default: false",
+					},
+					"maxWorkloads": {
+						Type:        "integer",
+						Description: "Maximum number of workloads listed namespace by namespace. Implies perNamespace (default: 500)",
+						Minimum:     ptr.To(float64(1)),
+					},
 				},
 			},
 			Annotations: api.ToolAnnotations{
@@ -36,6 +56,54 @@ func initWorkloads() []api.ServerTool {
 		}, Handler: workloadsListHandler,
 	})
 
+	// Workloads without sidecar tool
+	ret = append(ret, api.ServerTool{
+		Tool: api.Tool{
+			Name:        "workloads_without_sidecar",
+			Description: "List the workloads that have no injected Istio sidecar proxy and are therefore not part of the mesh, across specified namespaces. Returns the namespace and name of each workload, and whether injection is explicitly disabled by annotation. Ambient workloads are not reported",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespaces": {
+						Type:        "string",
+						Description: "Comma-separated list of namespaces to check (e.g. 'bookinfo' or 'bookinfo,default'). If not provided, will check workloads from all accessible namespaces",
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Workloads: Without Sidecar",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: workloadsWithoutSidecarHandler,
+	})
+
+	// Missing labels tool
+	ret = append(ret, api.ServerTool{
+		Tool: api.Tool{
+			Name:        "missing_labels",
+			Description: "List the workloads and services missing the recommended 'app' and 'version' labels that Kiali relies on for its graph, across specified namespaces. Workloads without an app label show as 'unknown' and degrade observability. Returns the kind (workload or service), namespace and name of each, and which labels are missing. Only the app label is expected on services",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespaces": {
+						Type:        "string",
+						Description: "Comma-separated list of namespaces to check (e.g. 'bookinfo' or 'bookinfo,default'). If not provided, will check workloads and services from all accessible namespaces",
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Workloads: Missing Labels",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: missingLabelsHandler,
+	})
+
 	// Workload details tool
 	ret = append(ret, api.ServerTool{
 		Tool: api.Tool{
@@ -52,6 +120,10 @@ func initWorkloads() []api.ServerTool {
 						Type:        "string",
 						Description: "Name of the workload to get details for",
 					},
+					"includeRequestTrend": {
+						Type:        "boolean",
+						Description: "Whether to attach the inbound request and error rates of the last 10 minutes, downsampled to 10 points, to help judge when a problem started (default: false)",
+					},
 				},
 				Required: []string{"namespace", "workload"},
 			},
@@ -65,6 +137,160 @@ func initWorkloads() []api.ServerTool {
 		}, Handler: workloadDetailsHandler,
 	})
 
+	// Workload resources tool
+	ret = append(ret, api.ServerTool{
+		Tool: api.Tool{
+			Name:        "workload_resources",
+			Description: "Get the recent CPU and memory usage of each container of a workload's pods, to correlate latency or errors with resource pressure. Requires the Kubernetes metrics API (metrics-server) in the cluster",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace containing the workload",
+					},
+					"workload": {
+						Type:        "string",
+						Description: "Name of the workload to get the resource usage for",
+					},
+				},
+				Required: []string{"namespace", "workload"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Workload: Resources",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: workloadResourcesHandler,
+	})
+
+	// Workload events tool
+	ret = append(ret, api.ServerTool{
+		Tool: api.Tool{
+			Name:        "workload_events",
+			Description: "Get the recent Kubernetes Warning events of a workload's pods (e.g. BackOff, Unhealthy, FailedScheduling, OOMKilling), most recent first, to explain why a workload is unhealthy",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace containing the workload",
+					},
+					"workload": {
+						Type:        "string",
+						Description: "Name of the workload to get the pod events for",
+					},
+					"limit": {
+						Type:        "integer",
+						Description: "Maximum number of events to return (default: 20)",
+						Minimum:     ptr.To(float64(1)),
+					},
+				},
+				Required: []string{"namespace", "workload"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Workload: Events",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: workloadEventsHandler,
+	})
+
+	// Workload Istio config tool
+	ret = append(ret, api.ServerTool{
+		Tool: api.Tool{
+			Name:        "workload_istio_config",
+			Description: "List the Istio objects associated with a specific workload in a namespace (e.g. Sidecars, DestinationRules through their subsets, AuthorizationPolicies through their selectors), with their kinds, namespaces and names",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace containing the workload",
+					},
+					"workload": {
+						Type:        "string",
+						Description: "Name of the workload to list the Istio objects for",
+					},
+				},
+				Required: []string{"namespace", "workload"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Workload: Istio Config",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: workloadIstioConfigHandler,
+	})
+
+	// Workload proxy sync status tool
+	ret = append(ret, api.ServerTool{
+		Tool: api.Tool{
+			Name:        "workload_proxy_sync",
+			Description: "Get the proxy sync status of each pod of a workload, to find out whether its sidecars are in sync with istiod. Each pod reports SYNCED, STALE or NOT_SENT (the worst of its CDS, EDS, LDS and RDS statuses), or UNKNOWN without a proxy status. Out-of-sync pods are listed in 'outOfSync' and come first, so a replica with a stale configuration is easy to pinpoint",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace containing the workload",
+					},
+					"workload": {
+						Type:        "string",
+						Description: "Name of the workload to get the proxy sync status for",
+					},
+				},
+				Required: []string{"namespace", "workload"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Workload: Proxy Sync Status",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: workloadProxySyncHandler,
+	})
+
+	// Workload Envoy clusters health tool
+	ret = append(ret, api.ServerTool{
+		Tool: api.Tool{
+			Name:        "workload_envoy_clusters",
+			Description: "Get the health of the upstream clusters of a workload's sidecar: fetches the Envoy config dump of one of its pods and returns, per outbound cluster of a Kubernetes service (e.g. 'outbound|9080|v1|reviews.bookinfo.svc.cluster.local'), the available ('healthy') and desired ('total') replicas of the workloads backing it, restricted to the DestinationRule subset of the cluster. Clusters with unavailable replicas or no replica are listed in 'unhealthy' and come first. Kiali does not expose the endpoint health seen by the sidecar, so a sidecar unable to reach an upstream whose workloads are healthy is not detected: use the workload_proxy_sync and workload_logs tools for that",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace containing the workload",
+					},
+					"workload": {
+						Type:        "string",
+						Description: "Name of the workload to get the Envoy clusters health for",
+					},
+					"pod": {
+						Type:        "string",
+						Description: "Optional pod of the workload to inspect. If not provided, the first pod by name is used",
+					},
+				},
+				Required: []string{"namespace", "workload"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Workload: Envoy Clusters Health",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: workloadEnvoyClustersHandler,
+	})
+
 	// Workload metrics tool
 	ret = append(ret, api.ServerTool{
 		Tool: api.Tool{
@@ -83,11 +309,11 @@ func initWorkloads() []api.ServerTool {
 					},
 					"duration": {
 						Type:        "string",
-						Description: "Duration of the query period in seconds (e.g., '1800' for 30 minutes). Optional, defaults to 1800 seconds",
+						Description: "Duration of the query period, in seconds or as a duration (e.g., '1800' or '30m'). Optional, defaults to 1800 seconds",
 					},
 					"step": {
 						Type:        "string",
-						Description: "Step between data points in seconds (e.g., '15'). Optional, defaults to 15 seconds",
+						Description: "Step between data points, in seconds or as a duration (e.g., '15' or '1m'). Optional, defaults to 15 seconds",
 					},
 					"rateInterval": {
 						Type:        "string",
@@ -107,12 +333,25 @@ func initWorkloads() []api.ServerTool {
 					},
 					"quantiles": {
 						Type:        "string",
-						Description: "Comma-separated list of quantiles for histogram metrics (e.g., '0.5,0.95,0.99'). Optional",
+						Description: "Comma-separated list of quantiles for histogram metrics such as request duration, either as ratios or percentiles (e.g., '0.5,0.95,0.99' or 'p95,p99'). Optional",
 					},
 					"byLabels": {
 						Type:        "string",
 						Description: "Comma-separated list of labels to group metrics by (e.g., 'source_workload,destination_service'). Optional",
 					},
+					"sparkline": {
+						Type:        "string",
+						Description: "Optional name of a single metric of the response (e.g. 'request_count', or 'request_duration_millis:0.95' for a histogram statistic, 'avg' by default) to return as a compact unicode sparkline with its min, max and last values instead of the full metrics, for an at-a-glance trend. The series of the metric are summed over their labels",
+					},
+					"sparklineWidth": {
+						Type:        "integer",
+						Description: "Number of points of the sparkline, the series being downsampled to it (default: 20)",
+						Minimum:     ptr.To(float64(1)),
+					},
+					"includeSizes": {
+						Type:        "boolean",
+						Description: "Also return, under 'sizes', a compact summary of the average request and response sizes in bytes (request_size and response_size metrics) and of the average TCP sent and received bytes per second (tcp_sent and tcp_received metrics), to investigate bandwidth issues. Optional, defaults to false",
+					},
 				},
 				Required: []string{"namespace", "workload"},
 			},
@@ -132,11 +371,57 @@ func initWorkloads() []api.ServerTool {
 func workloadsListHandler(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
 	// Extract parameters
 	namespaces, _ := params.GetArguments()["namespaces"].(string)
+	workloadType, _ := params.GetArguments()["workloadType"].(string)
+	perNamespace, _ := params.GetArguments()["perNamespace"].(bool)
+	maxWorkloads := 0
+	if v, ok := params.GetArguments()["maxWorkloads"].(float64); ok {
+		if v < 1 || v != float64(int(v)) {
+			return api.NewToolCallResult("", fmt.Errorf("maxWorkloads must be a positive integer")), nil
+		}
+		maxWorkloads = int(v)
+		perNamespace = true
+	}
 
-	content, err := params.WorkloadsList(params.Context, namespaces)
+	if perNamespace {
+		content, err := params.WorkloadsListPerNamespace(params.Context, namespaces, workloadType, maxWorkloads)
+		if err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to list workloads: %v", err)), nil
+		}
+		// Namespaces that failed are still reported, as they may hold workloads
+		if isEmptyResult(content, "workloads") && isEmptyResult(content, "failedNamespaces") {
+			return api.NewToolCallResult(emptyListResult("workloads", namespaces), nil), nil
+		}
+		return api.NewToolCallResult(content, nil), nil
+	}
+	content, err := params.WorkloadsListOfType(params.Context, namespaces, workloadType)
 	if err != nil {
 		return api.NewToolCallResult("", fmt.Errorf("failed to list workloads: %v", err)), nil
 	}
+	if isEmptyResult(content, "workloads") {
+		return api.NewToolCallResult(emptyListResult("workloads", namespaces), nil), nil
+	}
+	return api.NewToolCallResult(content, nil), nil
+}
+
+func workloadsWithoutSidecarHandler(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	// Extract parameters
+	namespaces, _ := params.GetArguments()["namespaces"].(string)
+
+	content, err := params.WorkloadsWithoutSidecar(params.Context, namespaces)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list workloads without sidecar: %v", err)), nil
+	}
+	return api.NewToolCallResult(content, nil), nil
+}
+
+func missingLabelsHandler(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	// Extract parameters
+	namespaces, _ := params.GetArguments()["namespaces"].(string)
+
+	content, err := params.MissingLabels(params.Context, namespaces)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list missing labels: %v", err)), nil
+	}
 	return api.NewToolCallResult(content, nil), nil
 }
 
@@ -154,7 +439,94 @@ func workloadDetailsHandler(params api.ToolHandlerParams) (*api.ToolCallResult,
 
 	content, err := params.WorkloadDetails(params.Context, namespace, workload)
 	if err != nil {
-		return api.NewToolCallResult("", fmt.Errorf("failed to get workload details: %v", err)), nil
+		return api.NewToolCallResult("", detailsError(err, "workload details", "workloads_list")), nil
+	}
+	if includeTrend, _ := params.GetArguments()["includeRequestTrend"].(bool); includeTrend {
+		trend, err := params.WorkloadRequestTrend(params.Context, namespace, workload)
+		if err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to get workload request trend: %v", err)), nil
+		}
+		if content, err = attachJSONField(content, "requestTrend", trend); err != nil {
+			return api.NewToolCallResult("", err), nil
+		}
+	}
+	return api.NewToolCallResult(content, nil), nil
+}
+
+func workloadResourcesHandler(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	namespace, _ := params.GetArguments()["namespace"].(string)
+	workload, _ := params.GetArguments()["workload"].(string)
+
+	if namespace == "" {
+		return api.NewToolCallResult("", fmt.Errorf("namespace parameter is required")), nil
+	}
+	if workload == "" {
+		return api.NewToolCallResult("", fmt.Errorf("workload parameter is required")), nil
+	}
+
+	podMetrics, err := params.PodsTop(params.Context, kubernetes.PodsTopOptions{Namespace: namespace})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get workload resources: %v", err)), nil
+	}
+	content, err := params.WorkloadResources(params.Context, namespace, workload, podMetrics.Items)
+	if err != nil {
+		return api.NewToolCallResult("", detailsError(err, "workload resources", "workloads_list")), nil
+	}
+	return api.NewToolCallResult(content, nil), nil
+}
+
+func workloadIstioConfigHandler(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	// Extract parameters
+	namespace, _ := params.GetArguments()["namespace"].(string)
+	workload, _ := params.GetArguments()["workload"].(string)
+
+	if namespace == "" {
+		return api.NewToolCallResult("", fmt.Errorf("namespace parameter is required")), nil
+	}
+	if workload == "" {
+		return api.NewToolCallResult("", fmt.Errorf("workload parameter is required")), nil
+	}
+
+	content, err := params.WorkloadIstioConfig(params.Context, namespace, workload)
+	if err != nil {
+		return api.NewToolCallResult("", detailsError(err, "workload Istio config", "workloads_list")), nil
+	}
+	return api.NewToolCallResult(content, nil), nil
+}
+
+func workloadProxySyncHandler(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	namespace, _ := params.GetArguments()["namespace"].(string)
+	workload, _ := params.GetArguments()["workload"].(string)
+
+	if namespace == "" {
+		return api.NewToolCallResult("", fmt.Errorf("namespace parameter is required")), nil
+	}
+	if workload == "" {
+		return api.NewToolCallResult("", fmt.Errorf("workload parameter is required")), nil
+	}
+
+	content, err := params.WorkloadProxySyncStatus(params.Context, namespace, workload)
+	if err != nil {
+		return api.NewToolCallResult("", detailsError(err, "workload proxy sync status", "workloads_list")), nil
+	}
+	return api.NewToolCallResult(content, nil), nil
+}
+
+func workloadEnvoyClustersHandler(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	namespace, _ := params.GetArguments()["namespace"].(string)
+	workload, _ := params.GetArguments()["workload"].(string)
+	pod, _ := params.GetArguments()["pod"].(string)
+
+	if namespace == "" {
+		return api.NewToolCallResult("", fmt.Errorf("namespace parameter is required")), nil
+	}
+	if workload == "" {
+		return api.NewToolCallResult("", fmt.Errorf("workload parameter is required")), nil
+	}
+
+	content, err := params.WorkloadEnvoyClusters(params.Context, namespace, workload, pod)
+	if err != nil {
+		return api.NewToolCallResult("", detailsError(err, "workload envoy clusters health", "workloads_list")), nil
 	}
 	return api.NewToolCallResult(content, nil), nil
 }
@@ -173,14 +545,8 @@ func workloadMetricsHandler(params api.ToolHandlerParams) (*api.ToolCallResult,
 
 	// Extract optional query parameters
 	queryParams := make(map[string]string)
-	if duration, ok := params.GetArguments()["duration"].(string); ok && duration != "" {
-		queryParams["duration"] = duration
-	}
-	if step, ok := params.GetArguments()["step"].(string); ok && step != "" {
-		queryParams["step"] = step
-	}
-	if rateInterval, ok := params.GetArguments()["rateInterval"].(string); ok && rateInterval != "" {
-		queryParams["rateInterval"] = rateInterval
+	if err := metricsWindowArguments(params, queryParams); err != nil {
+		return api.NewToolCallResult("", err), nil
 	}
 	if direction, ok := params.GetArguments()["direction"].(string); ok && direction != "" {
 		queryParams["direction"] = direction
@@ -191,16 +557,132 @@ func workloadMetricsHandler(params api.ToolHandlerParams) (*api.ToolCallResult,
 	if requestProtocol, ok := params.GetArguments()["requestProtocol"].(string); ok && requestProtocol != "" {
 		queryParams["requestProtocol"] = requestProtocol
 	}
-	if quantiles, ok := params.GetArguments()["quantiles"].(string); ok && quantiles != "" {
-		queryParams["quantiles"] = quantiles
-	}
 	if byLabels, ok := params.GetArguments()["byLabels"].(string); ok && byLabels != "" {
 		queryParams["byLabels"] = byLabels
 	}
 
-	content, err := params.WorkloadMetrics(params.Context, namespace, workload, queryParams)
+	quantilesArg, _ := params.GetArguments()["quantiles"].(string)
+	quantiles, err := parseQuantiles(quantilesArg)
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	sparkline, sparklineWidth, err := sparklineArguments(params)
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+
+	content, err := params.WorkloadMetrics(params.Context, namespace, workload, queryParams, quantiles)
 	if err != nil {
 		return api.NewToolCallResult("", fmt.Errorf("failed to get workload metrics: %v", err)), nil
 	}
-	return api.NewToolCallResult(content, nil), nil
+	if sparkline != "" {
+		return metricSparklineResult(content, sparkline, sparklineWidth)
+	}
+	if includeSizes, _ := params.GetArguments()["includeSizes"].(bool); !includeSizes {
+		return api.NewToolCallResult(content, nil), nil
+	}
+
+	// The sizes are summed over all the series, grouping them is pointless
+	delete(queryParams, "byLabels")
+	sizes, err := params.WorkloadSizeSummary(params.Context, namespace, workload, queryParams)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get workload size metrics: %v", err)), nil
+	}
+	result, err := json.MarshalIndent(workloadMetricsWithSizes{Metrics: json.RawMessage(content), Sizes: json.RawMessage(sizes)}, "", "  ")
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to marshal workload metrics: %v", err)), nil
+	}
+	return api.NewToolCallResult(string(result), nil), nil
+}
+
+// workloadMetricsWithSizes are the workload metrics along with their size summary.
+type workloadMetricsWithSizes struct {
+	Metrics json.RawMessage `json:"metrics"`
+	Sizes   json.RawMessage `json:"sizes"`
+}
+
+// defaultWorkloadEventsLimit is the default maximum number of events returned by workload_events.
+const defaultWorkloadEventsLimit = 20
+
+// workloadEvents are the Warning events of the pods of a workload.
+type workloadEvents struct {
+	Namespace string          `json:"namespace"`
+	Workload  string          `json:"workload"`
+	Pods      []string        `json:"pods"`
+	Events    []workloadEvent `json:"events"`
+}
+
+// workloadEvent is a Warning event of a pod of a workload.
+type workloadEvent struct {
+	Timestamp time.Time `json:"timestamp"`
+	Pod       string    `json:"pod"`
+	Reason    string    `json:"reason"`
+	Message   string    `json:"message"`
+	Count     int32     `json:"count,omitempty"`
+}
+
+func workloadEventsHandler(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	namespace, _ := params.GetArguments()["namespace"].(string)
+	workload, _ := params.GetArguments()["workload"].(string)
+
+	if namespace == "" {
+		return api.NewToolCallResult("", fmt.Errorf("namespace parameter is required")), nil
+	}
+	if workload == "" {
+		return api.NewToolCallResult("", fmt.Errorf("workload parameter is required")), nil
+	}
+	limit := defaultWorkloadEventsLimit
+	if v, ok := params.GetArguments()["limit"].(float64); ok {
+		if v < 1 || v != float64(int(v)) {
+			return api.NewToolCallResult("", fmt.Errorf("limit must be a positive integer")), nil
+		}
+		limit = int(v)
+	}
+
+	pods, err := params.WorkloadPods(params.Context, namespace, workload)
+	if err != nil {
+		return api.NewToolCallResult("", detailsError(err, "workload events", "workloads_list")), nil
+	}
+	events, err := params.Events(params.Context, namespace)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get workload events: %v", err)), nil
+	}
+	result, err := json.MarshalIndent(&workloadEvents{
+		Namespace: namespace,
+		Workload:  workload,
+		Pods:      pods,
+		Events:    podWarningEvents(events, pods, limit),
+	}, "", "  ")
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to marshal workload events: %v", err)), nil
+	}
+	return api.NewToolCallResult(string(result), nil), nil
+}
+
+// podWarningEvents returns the Warning events involving the given pods, most recent first, up to limit events.
+func podWarningEvents(events []corev1.Event, pods []string, limit int) []workloadEvent {
+	podNames := make(map[string]bool, len(pods))
+	for _, pod := range pods {
+		podNames[pod] = true
+	}
+	ret := make([]workloadEvent, 0)
+	for _, event := range events {
+		if event.Type != corev1.EventTypeWarning || event.InvolvedObject.Kind != "Pod" || !podNames[event.InvolvedObject.Name] {
+			continue
+		}
+		ret = append(ret, workloadEvent{
+			Timestamp: kubernetes.EventTimestamp(&event).UTC(),
+			Pod:       event.InvolvedObject.Name,
+			Reason:    event.Reason,
+			Message:   strings.TrimSpace(event.Message),
+			Count:     event.Count,
+		})
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].Timestamp.After(ret[j].Timestamp)
+	})
+	if len(ret) > limit {
+		ret = ret[:limit]
+	}
+	return ret
 }
@@ -0,0 +1,567 @@
+package kiali
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/metrics/pkg/apis/metrics"
+	"k8s.io/utils/ptr"
+
+	"github.com/kiali/kiali-mcp-server/pkg/config"
+	internalkiali "github.com/kiali/kiali-mcp-server/pkg/kiali"
+)
+
+func TestWorkloadsListOfType_KialiClient(t *testing.T) {
+	response := `{"cluster": "east", "workloads": [
+		{"namespace": "bookinfo", "name": "reviews-v1", "type": "Deployment"},
+		{"namespace": "istio-system", "name": "ztunnel", "type": "DaemonSet"},
+		{"namespace": "bookinfo", "name": "mysql", "gvk": {"Group": "apps", "Version": "v1", "Kind": "StatefulSet"}}
+	]}`
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/clusters/workloads", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(response))
+	}))
+	defer mockServer.Close()
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+
+	names := func(t *testing.T, result string) []string {
+		var list struct {
+			Cluster   string `json:"cluster"`
+			Workloads []struct {
+				Name string `json:"name"`
+			} `json:"workloads"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(result), &list))
+		assert.Equal(t, "east", list.Cluster)
+		ret := make([]string, 0)
+		for _, w := range list.Workloads {
+			ret = append(ret, w.Name)
+		}
+		return ret
+	}
+
+	t.Run("no type returns the response unchanged", func(t *testing.T) {
+		result, err := kialiClient.WorkloadsListOfType(context.Background(), "", "")
+		require.NoError(t, err)
+		assert.Equal(t, response, result)
+	})
+
+	t.Run("type is matched regardless of case", func(t *testing.T) {
+		result, err := kialiClient.WorkloadsListOfType(context.Background(), "", "daemonset")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ztunnel"}, names(t, result))
+	})
+
+	t.Run("gvk kind", func(t *testing.T) {
+		result, err := kialiClient.WorkloadsListOfType(context.Background(), "", "StatefulSet")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"mysql"}, names(t, result))
+	})
+
+	t.Run("no workload of the type", func(t *testing.T) {
+		result, err := kialiClient.WorkloadsListOfType(context.Background(), "", "CronJob")
+		require.NoError(t, err)
+		assert.Empty(t, names(t, result))
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		_, err := kialiClient.WorkloadsListOfType(context.Background(), "", "Rollout")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid workload type 'Rollout': must be one of")
+	})
+}
+
+func TestWorkloadsListPerNamespace_KialiClient(t *testing.T) {
+	namespaces := []string{"ns-g", "ns-a", "ns-b", "ns-c", "ns-d", "ns-e", "ns-f"}
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/namespaces" {
+			list := make([]map[string]string, 0)
+			for _, ns := range namespaces {
+				list = append(list, map[string]string{"name": ns, "cluster": "east"})
+			}
+			_ = json.NewEncoder(w).Encode(list)
+			return
+		}
+		assert.Equal(t, "/api/clusters/workloads", r.URL.Path)
+		ns := r.URL.Query().Get("namespaces")
+		if ns == "ns-b" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte(`{"workloads": [
+			{"namespace": "` + ns + `", "name": "web", "type": "Deployment"},
+			{"namespace": "` + ns + `", "name": "db", "type": "StatefulSet"}
+		]}`))
+	}))
+	defer mockServer.Close()
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+
+	list := func(t *testing.T, namespaces, workloadType string, maxWorkloads int) (internalkiali.NamespacedWorkloads, []string) {
+		result, err := kialiClient.WorkloadsListPerNamespace(context.Background(), namespaces, workloadType, maxWorkloads)
+		require.NoError(t, err)
+		var ret internalkiali.NamespacedWorkloads
+		require.NoError(t, json.Unmarshal([]byte(result), &ret))
+		workloads := make([]string, 0)
+		for _, raw := range ret.Workloads {
+			var w struct{ Namespace, Name string }
+			require.NoError(t, json.Unmarshal(raw, &w))
+			workloads = append(workloads, w.Namespace+"/"+w.Name)
+		}
+		return ret, workloads
+	}
+
+	t.Run("cap reached", func(t *testing.T) {
+		result, workloads := list(t, "", "", 5)
+		assert.Equal(t, []string{"ns-a/web", "ns-a/db", "ns-c/web", "ns-c/db", "ns-d/web"}, workloads)
+		assert.Equal(t, 5, result.NamespacesScanned, "the scan stops after the batch reaching the cap")
+		assert.Equal(t, 7, result.NamespacesTotal)
+		assert.Equal(t, 5, result.MaxWorkloads)
+		assert.True(t, result.CapReached)
+		assert.Contains(t, result.FailedNamespaces, "ns-b")
+	})
+
+	t.Run("all namespaces scanned", func(t *testing.T) {
+		result, workloads := list(t, "", "StatefulSet", 0)
+		assert.Len(t, workloads, 6)
+		assert.Equal(t, 7, result.NamespacesScanned)
+		assert.Equal(t, internalkiali.DefaultWorkloadsListMax, result.MaxWorkloads)
+		assert.False(t, result.CapReached)
+	})
+
+	t.Run("cap reached exactly by the last namespace", func(t *testing.T) {
+		result, workloads := list(t, "ns-a,ns-c", "", 4)
+		assert.Len(t, workloads, 4)
+		assert.Equal(t, 2, result.NamespacesTotal)
+		assert.False(t, result.CapReached)
+		assert.Empty(t, result.FailedNamespaces)
+	})
+
+	t.Run("every namespace failed", func(t *testing.T) {
+		_, err := kialiClient.WorkloadsListPerNamespace(context.Background(), "ns-b", "", 0)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to list the workloads of all namespaces")
+	})
+}
+
+func TestWorkloadsWithoutSidecar_KialiClient(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/clusters/workloads", r.URL.Path)
+		assert.Equal(t, "bookinfo,legacy", r.URL.Query().Get("namespaces"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"workloads": [
+			{"namespace": "bookinfo", "name": "reviews-v1", "cluster": "east", "istioSidecar": true},
+			{"namespace": "legacy", "name": "mysql", "cluster": "east", "istioSidecar": false, "istioInjectionAnnotation": false},
+			{"namespace": "bookinfo", "name": "ratings-v1", "cluster": "east", "istioSidecar": false},
+			{"namespace": "bookinfo", "name": "details-v1", "cluster": "east", "istioSidecar": false, "isAmbient": true},
+			{"namespace": "bookinfo", "name": "batch", "cluster": "east", "istioSidecar": false, "istioInjectionAnnotation": true}
+		]}`))
+	}))
+	defer mockServer.Close()
+
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+	result, err := kialiClient.WorkloadsWithoutSidecar(context.Background(), "bookinfo,legacy")
+	require.NoError(t, err)
+
+	var workloads []internalkiali.WorkloadWithoutSidecar
+	require.NoError(t, json.Unmarshal([]byte(result), &workloads))
+	assert.Equal(t, []internalkiali.WorkloadWithoutSidecar{
+		{Namespace: "bookinfo", Name: "batch", Cluster: "east"},
+		{Namespace: "bookinfo", Name: "ratings-v1", Cluster: "east"},
+		{Namespace: "legacy", Name: "mysql", Cluster: "east", InjectionDisabled: true},
+	}, workloads)
+}
+
+func TestWorkloadsWithoutSidecar_KialiClient_NoWorkloads(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"workloads": [{"namespace": "bookinfo", "name": "reviews-v1", "istioSidecar": true}]}`))
+	}))
+	defer mockServer.Close()
+
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+	result, err := kialiClient.WorkloadsWithoutSidecar(context.Background(), "")
+	require.NoError(t, err)
+	assert.Equal(t, "[]", result)
+}
+
+func TestMissingLabels_KialiClient(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "bookinfo,legacy", r.URL.Query().Get("namespaces"))
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/clusters/workloads":
+			_, _ = w.Write([]byte(`{"workloads": [
+				{"namespace": "bookinfo", "name": "reviews-v1", "appLabel": true, "versionLabel": true},
+				{"namespace": "bookinfo", "name": "ratings-v1", "appLabel": true, "versionLabel": false},
+				{"namespace": "legacy", "name": "mysql", "appLabel": false, "versionLabel": false},
+				{"namespace": "bookinfo", "name": "details-v1", "labels": {"app.kubernetes.io/name": "details", "app.kubernetes.io/version": "v1"}},
+				{"namespace": "bookinfo", "name": "batch", "labels": {"app": "batch"}}
+			]}`))
+		case "/api/clusters/services":
+			_, _ = w.Write([]byte(`{"services": [
+				{"namespace": "bookinfo", "name": "reviews", "appLabel": true},
+				{"namespace": "legacy", "name": "mysql", "appLabel": false},
+				{"namespace": "bookinfo", "name": "api.example.com", "serviceRegistry": "External"}
+			]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockServer.Close()
+
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+	result, err := kialiClient.MissingLabels(context.Background(), "bookinfo,legacy")
+	require.NoError(t, err)
+
+	var missing []internalkiali.MissingLabels
+	require.NoError(t, json.Unmarshal([]byte(result), &missing))
+	assert.Equal(t, []internalkiali.MissingLabels{
+		{Kind: "workload", Namespace: "bookinfo", Name: "batch", Missing: []string{"version"}},
+		{Kind: "workload", Namespace: "bookinfo", Name: "ratings-v1", Missing: []string{"version"}},
+		{Kind: "service", Namespace: "legacy", Name: "mysql", Missing: []string{"app"}},
+		{Kind: "workload", Namespace: "legacy", Name: "mysql", Missing: []string{"app", "version"}},
+	}, missing)
+}
+
+func TestWorkloadIstioConfig_KialiClient(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/namespaces/bookinfo/workloads/reviews-v1" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"name": "reviews-v1",
+			"destinationRules": [{"apiVersion": "networking.istio.io/v1", "kind": "DestinationRule", "metadata": {"name": "reviews", "namespace": "bookinfo"}}],
+			"sidecars": [{"apiVersion": "networking.istio.io/v1", "kind": "Sidecar", "metadata": {"name": "default", "namespace": "bookinfo"}}],
+			"istioReferences": [
+				{"objectGVK": {"Group": "security.istio.io", "Version": "v1", "Kind": "AuthorizationPolicy"}, "name": "allow-reviews", "namespace": "bookinfo"},
+				{"objectGVK": {"Group": "networking.istio.io", "Version": "v1", "Kind": "DestinationRule"}, "name": "reviews", "namespace": "bookinfo"}
+			]
+		}`))
+	}))
+	defer mockServer.Close()
+
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+	result, err := kialiClient.WorkloadIstioConfig(context.Background(), "bookinfo", "reviews-v1")
+	require.NoError(t, err)
+
+	var refs []internalkiali.IstioObjectRef
+	require.NoError(t, json.Unmarshal([]byte(result), &refs))
+	assert.Equal(t, []internalkiali.IstioObjectRef{
+		{Kind: "AuthorizationPolicy", APIVersion: "security.istio.io/v1", Namespace: "bookinfo", Name: "allow-reviews"},
+		{Kind: "DestinationRule", APIVersion: "networking.istio.io/v1", Namespace: "bookinfo", Name: "reviews"},
+		{Kind: "Sidecar", APIVersion: "networking.istio.io/v1", Namespace: "bookinfo", Name: "default"},
+	}, refs)
+
+	_, err = kialiClient.WorkloadIstioConfig(context.Background(), "bookinfo", "missing")
+	require.Error(t, err)
+	assert.True(t, internalkiali.IsNotFound(err))
+}
+
+func TestWorkloadProxySyncStatus_KialiClient(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/namespaces/bookinfo/workloads/reviews-v1":
+			_, _ = w.Write([]byte(`{"name": "reviews-v1", "pods": [
+				{"name": "reviews-v1-a", "proxyStatus": {"CDS": "Synced", "EDS": "Synced", "LDS": "Synced", "RDS": "Synced"}},
+				{"name": "reviews-v1-b", "proxyStatus": {"CDS": "Synced", "EDS": "STALE", "LDS": "NOT_SENT", "RDS": "Synced"}},
+				{"name": "reviews-v1-c", "proxyStatus": {"CDS": "NOT_SENT", "EDS": "Synced", "LDS": "Synced", "RDS": "Synced"}},
+				{"name": "reviews-v1-d"}
+			]}`))
+		case "/api/namespaces/bookinfo/workloads/legacy":
+			_, _ = w.Write([]byte(`{"name": "legacy", "pods": [{"name": "legacy-a"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockServer.Close()
+
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+
+	t.Run("out of sync pods", func(t *testing.T) {
+		result, err := kialiClient.WorkloadProxySyncStatus(context.Background(), "bookinfo", "reviews-v1")
+		require.NoError(t, err)
+
+		var status internalkiali.WorkloadProxySync
+		require.NoError(t, json.Unmarshal([]byte(result), &status))
+		assert.Equal(t, "reviews-v1", status.Workload)
+		assert.Equal(t, []string{"reviews-v1-b", "reviews-v1-c"}, status.OutOfSync)
+		assert.Equal(t, "2/4 pods have a proxy out of sync with istiod: reviews-v1-b, reviews-v1-c", status.Summary)
+		require.Len(t, status.Pods, 4)
+		assert.Equal(t, internalkiali.PodProxySync{
+			Pod:        "reviews-v1-b",
+			Status:     internalkiali.ProxySyncStale,
+			Components: map[string]string{"CDS": "SYNCED", "EDS": "STALE", "LDS": "NOT_SENT", "RDS": "SYNCED"},
+		}, status.Pods[0])
+		assert.Equal(t, internalkiali.ProxySyncNotSent, status.Pods[1].Status)
+		assert.Equal(t, internalkiali.ProxySyncSynced, status.Pods[2].Status)
+		assert.Equal(t, internalkiali.PodProxySync{Pod: "reviews-v1-d", Status: internalkiali.ProxySyncUnknown}, status.Pods[3])
+	})
+
+	t.Run("no proxy status", func(t *testing.T) {
+		result, err := kialiClient.WorkloadProxySyncStatus(context.Background(), "bookinfo", "legacy")
+		require.NoError(t, err)
+
+		var status internalkiali.WorkloadProxySync
+		require.NoError(t, json.Unmarshal([]byte(result), &status))
+		assert.Empty(t, status.OutOfSync)
+		assert.Contains(t, status.Summary, "no proxy status reported")
+	})
+
+	t.Run("missing workload", func(t *testing.T) {
+		_, err := kialiClient.WorkloadProxySyncStatus(context.Background(), "bookinfo", "missing")
+		require.Error(t, err)
+		assert.True(t, internalkiali.IsNotFound(err))
+	})
+}
+
+func TestWorkloadEnvoyClusters_KialiClient(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/namespaces/bookinfo/workloads/productpage-v1":
+			_, _ = w.Write([]byte(`{"name": "productpage-v1", "pods": [{"name": "productpage-v1-b"}, {"name": "productpage-v1-a"}]}`))
+		case "/api/namespaces/bookinfo/pods/productpage-v1-a/config_dump":
+			_, _ = w.Write([]byte(`{"configs": [{"@type": "type.googleapis.com/envoy.admin.v3.ListenersConfigDump"}]}`))
+		case "/api/namespaces/bookinfo/pods/productpage-v1-b/config_dump":
+			// Kiali proxies the Envoy /config_dump without include_eds: there is no EDS section
+			_, _ = w.Write([]byte(`{"configs": [
+				{"@type": "type.googleapis.com/envoy.admin.v3.BootstrapConfigDump", "bootstrap": {"node": {"id": "sidecar~10.244.0.12~productpage-v1-b.bookinfo~bookinfo.svc.cluster.local"}}},
+				{"@type": "type.googleapis.com/envoy.admin.v3.ClustersConfigDump",
+					"version_info": "2024-05-02T09:15:11Z/21",
+					"static_clusters": [
+						{"cluster": {"@type": "type.googleapis.com/envoy.config.cluster.v3.Cluster", "name": "prometheus_stats", "type": "STATIC"}, "last_updated": "2024-05-02T09:14:58.392Z"},
+						{"cluster": {"@type": "type.googleapis.com/envoy.config.cluster.v3.Cluster", "name": "xds-grpc", "type": "STATIC"}, "last_updated": "2024-05-02T09:14:58.394Z"}
+					],
+					"dynamic_active_clusters": [
+						{"version_info": "2024-05-02T09:15:11Z/21", "cluster": {"@type": "type.googleapis.com/envoy.config.cluster.v3.Cluster", "name": "BlackHoleCluster", "type": "STATIC"}, "last_updated": "2024-05-02T09:15:00.001Z"},
+						{"version_info": "2024-05-02T09:15:11Z/21", "cluster": {"@type": "type.googleapis.com/envoy.config.cluster.v3.Cluster", "name": "PassthroughCluster", "type": "ORIGINAL_DST"}, "last_updated": "2024-05-02T09:15:00.001Z"},
+						{"version_info": "2024-05-02T09:15:11Z/21", "cluster": {"@type": "type.googleapis.com/envoy.config.cluster.v3.Cluster", "name": "inbound|9080||", "type": "ORIGINAL_DST"}, "last_updated": "2024-05-02T09:15:00.002Z"},
+						{"version_info": "2024-05-02T09:15:11Z/21", "cluster": {"@type": "type.googleapis.com/envoy.config.cluster.v3.Cluster", "name": "outbound|9080||details.bookinfo.svc.cluster.local", "type": "EDS", "eds_cluster_config": {"eds_config": {"ads": {}, "initial_fetch_timeout": "0s", "resource_api_version": "V3"}, "service_name": "outbound|9080||details.bookinfo.svc.cluster.local"}}, "last_updated": "2024-05-02T09:15:00.004Z"},
+						{"version_info": "2024-05-02T09:15:11Z/21", "cluster": {"@type": "type.googleapis.com/envoy.config.cluster.v3.Cluster", "name": "outbound|9080|v1|reviews.bookinfo.svc.cluster.local", "type": "EDS", "eds_cluster_config": {"eds_config": {"ads": {}, "initial_fetch_timeout": "0s", "resource_api_version": "V3"}, "service_name": "outbound|9080|v1|reviews.bookinfo.svc.cluster.local"}}, "last_updated": "2024-05-02T09:15:00.005Z"},
+						{"version_info": "2024-05-02T09:15:11Z/21", "cluster": {"@type": "type.googleapis.com/envoy.config.cluster.v3.Cluster", "name": "outbound|9080|v2|reviews.bookinfo.svc.cluster.local", "type": "EDS", "eds_cluster_config": {"eds_config": {"ads": {}, "initial_fetch_timeout": "0s", "resource_api_version": "V3"}, "service_name": "outbound|9080|v2|reviews.bookinfo.svc.cluster.local"}}, "last_updated": "2024-05-02T09:15:00.005Z"},
+						{"version_info": "2024-05-02T09:15:11Z/21", "cluster": {"@type": "type.googleapis.com/envoy.config.cluster.v3.Cluster", "name": "outbound|443||api.example.com", "type": "STRICT_DNS"}, "last_updated": "2024-05-02T09:15:00.006Z"}
+					]
+				},
+				{"@type": "type.googleapis.com/envoy.admin.v3.ListenersConfigDump", "version_info": "2024-05-02T09:15:11Z/21"},
+				{"@type": "type.googleapis.com/envoy.admin.v3.RoutesConfigDump"}
+			]}`))
+		case "/api/clusters/services":
+			assert.Equal(t, "bookinfo", r.URL.Query().Get("namespaces"))
+			_, _ = w.Write([]byte(`{"services": [
+				{"namespace": "bookinfo", "name": "details", "selector": {"app": "details"}},
+				{"namespace": "bookinfo", "name": "reviews", "selector": {"app": "reviews"}}
+			]}`))
+		case "/api/clusters/workloads":
+			_, _ = w.Write([]byte(`{"workloads": [
+				{"namespace": "bookinfo", "name": "details-v1", "labels": {"app": "details", "version": "v1"}},
+				{"namespace": "bookinfo", "name": "reviews-v1", "labels": {"app": "reviews", "version": "v1"}},
+				{"namespace": "bookinfo", "name": "reviews-v2", "labels": {"app": "reviews", "version": "v2"}}
+			]}`))
+		case "/api/clusters/health":
+			assert.Equal(t, "workload", r.URL.Query().Get("type"))
+			_, _ = w.Write([]byte(`{"namespaceWorkloadHealth": {"bookinfo": {
+				"details-v1": {"workloadStatus": {"name": "details-v1", "desiredReplicas": 1, "currentReplicas": 1, "availableReplicas": 1}, "requests": {}},
+				"reviews-v1": {"workloadStatus": {"name": "reviews-v1", "desiredReplicas": 2, "currentReplicas": 2, "availableReplicas": 2}, "requests": {}},
+				"reviews-v2": {"workloadStatus": {"name": "reviews-v2", "desiredReplicas": 2, "currentReplicas": 2, "availableReplicas": 0}, "requests": {}}
+			}}}`))
+		case "/api/istio/config":
+			_, _ = w.Write([]byte(`{"resources": {"networking.istio.io/v1, Kind=DestinationRule": [
+				{"metadata": {"name": "reviews", "namespace": "bookinfo"}, "spec": {"host": "reviews", "subsets": [
+					{"name": "v1", "labels": {"version": "v1"}},
+					{"name": "v2", "labels": {"version": "v2"}}
+				]}}
+			]}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockServer.Close()
+
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+
+	t.Run("config dump proxied by Kiali", func(t *testing.T) {
+		result, err := kialiClient.WorkloadEnvoyClusters(context.Background(), "bookinfo", "productpage-v1", "productpage-v1-b")
+		require.NoError(t, err)
+
+		var health internalkiali.EnvoyClustersHealth
+		require.NoError(t, json.Unmarshal([]byte(result), &health))
+		assert.Equal(t, "productpage-v1-b", health.Pod)
+		assert.Equal(t, []string{"outbound|9080|v2|reviews.bookinfo.svc.cluster.local"}, health.Unhealthy)
+		assert.Equal(t, []internalkiali.EnvoyClusterHealth{
+			{Name: "outbound|9080|v2|reviews.bookinfo.svc.cluster.local", Healthy: 0, Total: 2},
+			{Name: "outbound|9080|v1|reviews.bookinfo.svc.cluster.local", Healthy: 2, Total: 2},
+			{Name: "outbound|9080||details.bookinfo.svc.cluster.local", Healthy: 1, Total: 1},
+		}, health.Clusters, "subset clusters only count the workloads of their subset")
+		assert.Contains(t, health.Summary, "1/3 upstream clusters")
+		assert.Contains(t, health.Note, "is not detected")
+	})
+
+	t.Run("first pod without clusters", func(t *testing.T) {
+		result, err := kialiClient.WorkloadEnvoyClusters(context.Background(), "bookinfo", "productpage-v1", "")
+		require.NoError(t, err)
+
+		var health internalkiali.EnvoyClustersHealth
+		require.NoError(t, json.Unmarshal([]byte(result), &health))
+		assert.Equal(t, "productpage-v1-a", health.Pod)
+		assert.Empty(t, health.Clusters)
+		assert.Contains(t, health.Summary, "no clusters in the config dump")
+	})
+
+	t.Run("pod of another workload", func(t *testing.T) {
+		_, err := kialiClient.WorkloadEnvoyClusters(context.Background(), "bookinfo", "productpage-v1", "reviews-v1-a")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "productpage-v1-a, productpage-v1-b")
+	})
+
+	t.Run("missing workload", func(t *testing.T) {
+		_, err := kialiClient.WorkloadEnvoyClusters(context.Background(), "bookinfo", "missing", "")
+		require.Error(t, err)
+		assert.True(t, internalkiali.IsNotFound(err))
+	})
+}
+
+func TestWorkloadSizeSummary_KialiClient(t *testing.T) {
+	var query url.Values
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/namespaces/bookinfo/workloads/reviews-v1/metrics", r.URL.Path)
+		query = r.URL.Query()
+		_, _ = w.Write([]byte(`{
+			"request_size": [
+				{"name": "request_size", "stat": "avg", "datapoints": [{"timestamp": 1, "value": 100}, {"timestamp": 2, "value": "NaN"}, {"timestamp": 3, "value": 200}]},
+				{"name": "request_size", "stat": "0.99", "datapoints": [{"timestamp": 1, "value": 5000}]}
+			],
+			"response_size": [{"name": "response_size", "stat": "avg", "datapoints": [{"timestamp": 1, "value": 1024.5}]}],
+			"tcp_sent": [
+				{"name": "tcp_sent", "datapoints": [{"timestamp": 1, "value": 10}, {"timestamp": 2, "value": 30}]},
+				{"name": "tcp_sent", "datapoints": [{"timestamp": 1, "value": 20}]}
+			]
+		}`))
+	}))
+	defer mockServer.Close()
+
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+	result, err := kialiClient.WorkloadSizeSummary(context.Background(), "bookinfo", "reviews-v1", map[string]string{"direction": "inbound"})
+	require.NoError(t, err)
+	assert.Equal(t, internalkiali.SizeMetrics, query["filters[]"])
+	assert.Equal(t, []string{"avg"}, query["quantiles[]"])
+	assert.Equal(t, "inbound", query.Get("direction"))
+
+	var summary internalkiali.SizeSummary
+	require.NoError(t, json.Unmarshal([]byte(result), &summary))
+	assert.Equal(t, internalkiali.SizeSummary{
+		Metrics:         internalkiali.SizeMetrics,
+		AvgRequestSize:  ptr.To(150.0),
+		AvgResponseSize: ptr.To(1024.5),
+		TCPSentRate:     ptr.To(30.0),
+	}, summary)
+}
+
+func TestWorkloadResources_KialiClient(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/namespaces/bookinfo/workloads/reviews-v1", r.URL.Path)
+		_, _ = w.Write([]byte(`{"name": "reviews-v1", "pods": [{"name": "reviews-v1-b"}, {"name": "reviews-v1-a"}, {"name": "reviews-v1-c"}]}`))
+	}))
+	defer mockServer.Close()
+
+	timestamp := metav1.NewTime(time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC))
+	podMetrics := []metrics.PodMetrics{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "reviews-v1-a", Namespace: "bookinfo"},
+			Timestamp:  timestamp,
+			Window:     metav1.Duration{Duration: 30 * time.Second},
+			Containers: []metrics.ContainerMetrics{
+				{Name: "reviews", Usage: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("250m"), corev1.ResourceMemory: resource.MustParse("128Mi")}},
+				{Name: "istio-proxy", Usage: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("10m"), corev1.ResourceMemory: resource.MustParse("32Mi")}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "reviews-v1-b", Namespace: "bookinfo"},
+			Timestamp:  timestamp,
+			Window:     metav1.Duration{Duration: 30 * time.Second},
+			Containers: []metrics.ContainerMetrics{
+				{Name: "reviews", Usage: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1"), corev1.ResourceMemory: resource.MustParse("256Mi")}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "ratings-v1-a", Namespace: "bookinfo"},
+			Containers: []metrics.ContainerMetrics{
+				{Name: "ratings", Usage: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("5")}},
+			},
+		},
+	}
+
+	kialiClient := internalkiali.NewFromConfig(&config.StaticConfig{KialiServerURL: mockServer.URL})
+	result, err := kialiClient.WorkloadResources(context.Background(), "bookinfo", "reviews-v1", podMetrics)
+	require.NoError(t, err)
+
+	var resources internalkiali.WorkloadResources
+	require.NoError(t, json.Unmarshal([]byte(result), &resources))
+	assert.Equal(t, int64(1260), resources.CPUMillicores)
+	assert.Equal(t, int64(416*1024*1024), resources.MemoryBytes)
+	assert.Equal(t, []string{"reviews-v1-c"}, resources.MissingPods)
+	require.Len(t, resources.Pods, 2)
+	assert.Equal(t, []string{"reviews-v1-a", "reviews-v1-b"}, []string{resources.Pods[0].Name, resources.Pods[1].Name})
+	assert.Equal(t, "2024-01-01T10:00:00Z", resources.Pods[0].Timestamp)
+	assert.Equal(t, "30s", resources.Pods[0].Window)
+	assert.Equal(t, []internalkiali.ContainerResources{
+		{Name: "istio-proxy", CPU: "10m", CPUMillicores: 10, Memory: "32Mi", MemoryBytes: 32 * 1024 * 1024},
+		{Name: "reviews", CPU: "250m", CPUMillicores: 250, Memory: "128Mi", MemoryBytes: 128 * 1024 * 1024},
+	}, resources.Pods[0].Containers)
+}
+
+func TestPodWarningEvents(t *testing.T) {
+	event := func(eventType, kind, name string, timestamp time.Time, reason string) corev1.Event {
+		return corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Namespace: "bookinfo"},
+			InvolvedObject: corev1.ObjectReference{APIVersion: "v1", Kind: kind, Name: name},
+			Type:           eventType,
+			Reason:         reason,
+			Message:        reason + " message\n",
+			FirstTimestamp: metav1.NewTime(timestamp),
+		}
+	}
+	at := func(minute int) time.Time {
+		return time.Date(2024, 1, 1, 10, minute, 0, 0, time.UTC)
+	}
+	events := []corev1.Event{
+		event(corev1.EventTypeWarning, "Pod", "reviews-v1-a", at(1), "Unhealthy"),
+		event(corev1.EventTypeNormal, "Pod", "reviews-v1-a", at(2), "Pulled"),
+		event(corev1.EventTypeWarning, "Pod", "reviews-v1-b", at(3), "BackOff"),
+		event(corev1.EventTypeWarning, "Pod", "ratings-v1-a", at(4), "BackOff"),
+		event(corev1.EventTypeWarning, "ReplicaSet", "reviews-v1-a", at(5), "FailedCreate"),
+		event(corev1.EventTypeWarning, "Pod", "reviews-v1-a", at(6), "OOMKilling"),
+	}
+	// repeated events are timed by their last occurrence
+	events[0].Count = 3
+	events[0].LastTimestamp = metav1.NewTime(at(7))
+	pods := []string{"reviews-v1-a", "reviews-v1-b"}
+
+	reasons := func(events []workloadEvent) []string {
+		ret := make([]string, 0, len(events))
+		for _, e := range events {
+			ret = append(ret, e.Reason)
+		}
+		return ret
+	}
+	assert.Equal(t, []string{"Unhealthy", "OOMKilling", "BackOff"}, reasons(podWarningEvents(events, pods, 20)))
+	assert.Equal(t, []string{"Unhealthy", "OOMKilling"}, reasons(podWarningEvents(events, pods, 2)))
+	assert.Empty(t, podWarningEvents(events, nil, 20))
+
+	result, err := json.Marshal(podWarningEvents(events, pods, 1))
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"timestamp": "2024-01-01T10:07:00Z", "pod": "reviews-v1-a", "reason": "Unhealthy", "message": "Unhealthy message", "count": 3}]`, string(result))
+}
@@ -41,6 +41,21 @@ func ToolsetFromString(name string) api.Toolset {
 	return nil
 }
 
+// Enabled returns the toolsets with the given names, or all the registered toolsets, sorted by name,
+// if no names are given.
+func Enabled(names []string) []api.Toolset {
+	if len(names) == 0 {
+		names = ToolsetNames()
+	}
+	enabled := make([]api.Toolset, 0, len(names))
+	for _, name := range names {
+		if toolset := ToolsetFromString(name); toolset != nil {
+			enabled = append(enabled, toolset)
+		}
+	}
+	return enabled
+}
+
 func Validate(toolsets []string) error {
 	for _, toolset := range toolsets {
 		if ToolsetFromString(toolset) == nil {
@@ -69,6 +69,32 @@ func (s *ToolsetsSuite) TestToolsetFromString() {
 	})
 }
 
+func (s *ToolsetsSuite) TestEnabled() {
+	s.Run("Returns empty list if no toolsets registered", func() {
+		s.Empty(Enabled(nil), "Expected empty list of enabled toolsets")
+	})
+
+	Register(&TestToolset{name: "kiali"})
+	Register(&TestToolset{name: "core"})
+	names := func(toolsets []api.Toolset) []string {
+		ret := make([]string, 0, len(toolsets))
+		for _, toolset := range toolsets {
+			ret = append(ret, toolset.GetName())
+		}
+		return ret
+	}
+	s.Run("Returns all registered toolsets sorted by name if no names given", func() {
+		s.Equal([]string{"core", "kiali"}, names(Enabled(nil)))
+		s.Equal([]string{"core", "kiali"}, names(Enabled([]string{})))
+	})
+	s.Run("Returns only the named toolsets", func() {
+		s.Equal([]string{"kiali"}, names(Enabled([]string{" kiali "})))
+	})
+	s.Run("Ignores unknown toolsets", func() {
+		s.Equal([]string{"core"}, names(Enabled([]string{"core", "unknown"})))
+	})
+}
+
 func (s *ToolsetsSuite) TestValidate() {
 	s.Run("Returns nil for empty toolset list", func() {
 		s.Nil(Validate([]string{}), "Expected nil for empty toolset list")